@@ -0,0 +1,214 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// -------------------- Command-line reports --------------------
+
+// modpackListing is one modpack as reported by --list-modpacks
+type modpackListing struct {
+	ID            string `json:"id"`
+	Name          string `json:"name"`
+	InstanceName  string `json:"instanceName"`
+	Installed     bool   `json:"installed"`
+	LocalVersion  string `json:"localVersion,omitempty"`
+	RemoteVersion string `json:"remoteVersion,omitempty"`
+	// RemoteError is set when the pack server couldn't be reached
+	RemoteError     string `json:"remoteError,omitempty"`
+	UpdateAvailable bool   `json:"updateAvailable"`
+}
+
+// listModpacks collects the install state and versions of every modpack
+func listModpacks(root string, modpacks []Modpack) []modpackListing {
+	listings := make([]modpackListing, 0, len(modpacks))
+	for _, mp := range modpacks {
+		instDir := filepath.Join(instancesDirFor(root), mp.InstanceName)
+		listing := modpackListing{
+			ID:           mp.ID,
+			Name:         modpackLabel(mp),
+			InstanceName: mp.InstanceName,
+			Installed:    exists(filepath.Join(instDir, "instance.cfg")) && exists(filepath.Join(instDir, "mmc-pack.json")),
+		}
+		if listing.Installed {
+			if local, err := getLocalPackVersion(mp, instDir); err == nil {
+				listing.LocalVersion = local
+			}
+		}
+		if remote, err := fetchRemotePackVersion(mp.PackURL, mp.Headers); err != nil {
+			listing.RemoteError = err.Error()
+		} else {
+			listing.RemoteVersion = remote
+			listing.UpdateAvailable = listing.Installed && !sameVersion(listing.LocalVersion, remote)
+		}
+		listings = append(listings, listing)
+	}
+	return listings
+}
+
+// runListModpacks prints the modpack list for --list-modpacks
+func runListModpacks(w io.Writer, root string, modpacks []Modpack, jsonOutput bool) error {
+	listings := listModpacks(root, modpacks)
+	if jsonOutput {
+		return writeJSON(w, listings)
+	}
+
+	for _, l := range listings {
+		state := "not installed"
+		if l.Installed {
+			state = "installed " + l.LocalVersion
+			if l.UpdateAvailable {
+				state += fmt.Sprintf(" (update to %s available)", l.RemoteVersion)
+			}
+		} else if l.RemoteVersion != "" {
+			state = fmt.Sprintf("not installed (latest %s)", l.RemoteVersion)
+		}
+		if l.RemoteError != "" {
+			state += " - couldn't reach pack server"
+		}
+		fmt.Fprintf(w, "%-20s %-32s %s\n", l.ID, l.Name, strings.TrimSpace(state))
+	}
+	return nil
+}
+
+// runSettings prints the current launcher settings for --print-settings
+func runSettings(w io.Writer, jsonOutput bool) error {
+	if jsonOutput {
+		return writeJSON(w, settings)
+	}
+
+	// Reuse the settings.json field names so the text output matches the file
+	data, err := json.Marshal(settings)
+	if err != nil {
+		return err
+	}
+	var values map[string]interface{}
+	if err := json.Unmarshal(data, &values); err != nil {
+		return err
+	}
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		fmt.Fprintf(w, "%s: %v\n", key, values[key])
+	}
+	return nil
+}
+
+// exitUpdateAvailable is the exit status of --check-update when a newer launcher exists
+const exitUpdateAvailable = 10
+
+// updateCheckReport is the result of --check-update
+type updateCheckReport struct {
+	Current         string `json:"current"`
+	Latest          string `json:"latest"`
+	Channel         string `json:"channel"`
+	UpdateAvailable bool   `json:"updateAvailable"`
+}
+
+// runCheckUpdate prints whether a launcher update is available for --check-update,
+// without downloading it
+func runCheckUpdate(w io.Writer, jsonOutput bool) (bool, error) {
+	if offlineMode() {
+		return false, fmt.Errorf("cannot check for launcher updates in offline mode")
+	}
+	channel := updateChannelSetting()
+	report := updateCheckReport{Channel: string(channel)}
+	available, current, latest, err := checkLauncherUpdate(channel)
+	if err != nil {
+		return false, fmt.Errorf("update check failed: %w", err)
+	}
+	report.Current, report.Latest, report.UpdateAvailable = current, latest, available
+	if jsonOutput {
+		return available, writeJSON(w, report)
+	}
+
+	if available {
+		fmt.Fprintf(w, "Update available: %s -> %s (%s channel)\n", current, latest, report.Channel)
+	} else {
+		fmt.Fprintf(w, "%s %s is up to date (latest %s release is %s)\n", launcherShortName, current, report.Channel, latest)
+	}
+	return available, nil
+}
+
+// runRollback restores the launcher version the last update replaced for --rollback
+// and starts it
+func runRollback(w io.Writer, root, exePath string) error {
+	previous, err := rollbackLauncher(root, exePath)
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(w, "Rolled back %s from %s to %s; restarting\n", launcherShortName, version, previous)
+	return relaunchAfterRollback(exePath)
+}
+
+// textProgress returns a progress callback that prints each stage to w, such as
+// "[3/8] Ensuring Java runtime", for headless runs
+func textProgress(w io.Writer) func(stage InstallStage, step, total int) {
+	return func(stage InstallStage, step, total int) {
+		fmt.Fprintf(w, "[%d/%d] %s\n", step, total, stage)
+	}
+}
+
+// runHeadless installs or updates the modpack with the given ID without the GUI, for
+// --install and --no-gui, and then launches it unless installOnly is set. The error
+// says which stage failed; main turns it into a non-zero exit code.
+func runHeadless(ctx context.Context, w io.Writer, root string, modpacks []Modpack, id string, installOnly bool, prismProcess **os.Process) error {
+	var mod Modpack
+	found := false
+	for _, mp := range modpacks {
+		if strings.EqualFold(mp.ID, id) {
+			mod, found = mp, true
+			break
+		}
+	}
+	if !found {
+		return fmt.Errorf("unknown modpack %q; --list-modpacks shows the available IDs", id)
+	}
+
+	var (
+		result launchResult
+		err    error
+	)
+	if installOnly {
+		fmt.Fprintf(w, "Installing %s\n", modpackLabel(mod))
+		result, err = installModpack(ctx, root, mod, launchOptions{}, textProgress(w))
+	} else {
+		fmt.Fprintf(w, "Launching %s\n", modpackLabel(mod))
+		result, err = runLauncherLogicSafe(ctx, root, "", mod, prismProcess, launchOptions{}, textProgress(w))
+	}
+	if err != nil {
+		if result.Stage != StageNone {
+			return fmt.Errorf("%s failed while %s: %w", modpackLabel(mod), strings.ToLower(result.Stage.String()), err)
+		}
+		return fmt.Errorf("%s failed: %w", modpackLabel(mod), err)
+	}
+
+	switch {
+	case result.Outcome == outcomeInstalled:
+		fmt.Fprintf(w, "%s %s is installed\n", modpackLabel(mod), result.Version)
+	case result.CrashReport != "":
+		return fmt.Errorf("%s crashed; crash report: %s", modpackLabel(mod), result.CrashReport)
+	case result.FallbackErr != nil:
+		fmt.Fprintf(w, "%s closed; it could not be launched directly: %v\n", modpackLabel(mod), result.FallbackErr)
+	default:
+		fmt.Fprintf(w, "%s closed\n", modpackLabel(mod))
+	}
+	return nil
+}
+
+// writeJSON writes v as indented JSON followed by a newline
+func writeJSON(w io.Writer, v interface{}) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(v)
+}
@@ -0,0 +1,86 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// TestRunListModpacksJSON tests that --list-modpacks --json reports install state and versions
+func TestRunListModpacksJSON(t *testing.T) {
+	saved := settings
+	defer func() { settings = saved }()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/missing/pack.toml" {
+			http.NotFound(w, r)
+			return
+		}
+		w.Write([]byte("name = \"Pack\"\nversion = \"1.1.0\"\n"))
+	}))
+	defer server.Close()
+
+	root := t.TempDir()
+	settings.InstancesDir = filepath.Join(root, "instances")
+	installed := Modpack{ID: "alpha", DisplayName: "Alpha", PackURL: server.URL + "/alpha/pack.toml", InstanceName: "Alpha"}
+	instDir := filepath.Join(settings.InstancesDir, "Alpha")
+	if err := os.MkdirAll(instDir, 0755); err != nil {
+		t.Fatalf("Failed to create instance directory: %v", err)
+	}
+	for name, content := range map[string]string{
+		"instance.cfg":                "name=Alpha\n",
+		"mmc-pack.json":               "{}",
+		versionFileNameFor(installed): "1.0.0\n",
+	} {
+		if err := os.WriteFile(filepath.Join(instDir, name), []byte(content), 0644); err != nil {
+			t.Fatalf("Failed to write %s: %v", name, err)
+		}
+	}
+	unreachable := Modpack{ID: "beta", DisplayName: "Beta", PackURL: server.URL + "/missing/pack.toml", InstanceName: "Beta"}
+
+	var buf bytes.Buffer
+	if err := runListModpacks(&buf, root, []Modpack{installed, unreachable}, true); err != nil {
+		t.Fatalf("runListModpacks failed: %v", err)
+	}
+	var listings []modpackListing
+	if err := json.Unmarshal(buf.Bytes(), &listings); err != nil {
+		t.Fatalf("Expected JSON output, got %q: %v", buf.String(), err)
+	}
+	if len(listings) != 2 {
+		t.Fatalf("Expected 2 modpacks, got %d", len(listings))
+	}
+	alpha := listings[0]
+	if !alpha.Installed || alpha.LocalVersion != "1.0.0" || alpha.RemoteVersion != "1.1.0" || !alpha.UpdateAvailable {
+		t.Errorf("Unexpected listing for installed pack: %+v", alpha)
+	}
+	beta := listings[1]
+	if beta.Installed || beta.RemoteError == "" || beta.UpdateAvailable {
+		t.Errorf("Unexpected listing for unreachable pack: %+v", beta)
+	}
+}
+
+// TestRunHeadless tests that a headless install prints its progress and reports the stage that failed
+func TestRunHeadless(t *testing.T) {
+	server := httptest.NewServer(http.NotFoundHandler())
+	defer server.Close()
+	mods := []Modpack{{ID: "missing", DisplayName: "Missing", PackURL: server.URL + "/pack.toml", InstanceName: "Missing"}}
+
+	var buf bytes.Buffer
+	if err := runHeadless(context.Background(), &buf, t.TempDir(), mods, "nope", true, new(*os.Process)); err == nil || !strings.Contains(err.Error(), "--list-modpacks") {
+		t.Errorf("Expected an unknown modpack to be rejected, got %v", err)
+	}
+
+	err := runHeadless(context.Background(), &buf, t.TempDir(), mods, "MISSING", true, new(*os.Process))
+	if err == nil || !strings.Contains(err.Error(), "reading modpack configuration") {
+		t.Errorf("Expected the failed stage in the error, got %v", err)
+	}
+	if !strings.Contains(buf.String(), "[1/8] Reading modpack configuration") {
+		t.Errorf("Expected progress on stdout, got %q", buf.String())
+	}
+}
@@ -6,6 +6,8 @@ import (
 	"os"
 	"path/filepath"
 	"strings"
+	"sync"
+	"time"
 )
 
 // -------------------- CONFIG: EDIT THESE --------------------
@@ -22,6 +24,8 @@ const (
 
 	envCacheBust = "THEBOYS_CACHEBUST"
 	envNoPause   = "THEBOYS_NOPAUSE"
+	envNoUpdate  = "THEBOYS_NO_UPDATE"
+	envHome      = "THEBOYS_HOME"
 )
 
 type Modpack struct {
@@ -37,6 +41,24 @@ type Modpack struct {
 	MinRam         int      `json:"minRam"`
 	RecommendedRam int      `json:"recommendedRam"`
 	Changelog      string   `json:"changelog"`
+	// Extra environment variables for Prism and Minecraft; these win over the launcher's defaults
+	EnvVars map[string]string `json:"envVars,omitempty"`
+	// Platforms the pack runs on, as GOOS/GOARCH names; empty means any
+	SupportedOS   []string `json:"supportedOS,omitempty"`
+	SupportedArch []string `json:"supportedArch,omitempty"`
+	// HTTP headers sent with requests for the pack's own files, for packs hosted behind
+	// auth; values may reference environment variables the user allows as ${NAME},
+	// see LauncherSettings.HeaderEnvVars
+	Headers map[string]string `json:"headers,omitempty"`
+	// Approximate download size in bytes as published by the pack host; 0 means it is
+	// estimated from the pack index before installing
+	SizeBytes int64 `json:"sizeBytes,omitempty"`
+	// Catalog URL the pack was loaded from; set by the launcher, not the catalog
+	Source string `json:"source,omitempty"`
+	// Path of an imported pack's pack.toml under custom-packs; PackURL is served from it
+	LocalPack string `json:"localPack,omitempty"`
+	// User-set memory for this pack in MB, copied from settings.MemoryOverrides; 0 follows the global setting
+	OverrideMemoryMB int `json:"-"`
 	// Legacy support
 	Default bool `json:"default,omitempty"`
 }
@@ -45,15 +67,95 @@ type Modpack struct {
 type LauncherSettings struct {
 	MemoryMB int  `json:"memoryMB"` // Memory allocation in MB (2-16GB range)
 	AutoRAM  bool `json:"autoRam"`  // Whether to auto-manage RAM per modpack
-	// If true, the launcher will check and install prerelease/dev builds from releases
+	// If true, the launcher will check and install prerelease/dev builds from releases.
+	// Kept in step with UpdateChannel for launchers that predate channels.
 	DevBuildsEnabled bool `json:"devBuildsEnabled,omitempty"`
+	// Release channel launcher updates come from: stable, rc, beta or dev
+	UpdateChannel string `json:"updateChannel,omitempty"`
 	// If true, enables debug logging for troubleshooting
 	DebugEnabled bool `json:"debugEnabled,omitempty"`
+	// User-chosen instance directory names, keyed by lowercased modpack ID
+	InstanceNames map[string]string `json:"instanceNames,omitempty"`
+	// Set once the first-run setup wizard has been completed or skipped
+	SetupComplete bool `json:"setupComplete"`
+	// Memory Auto RAM always leaves free for the OS; 0 uses the default
+	RAMHeadroomMB int `json:"ramHeadroomMB,omitempty"`
+	// settings.json format version, see migrateSettings
+	SchemaVersion int `json:"schemaVersion"`
+	// If true, pack.toml is always fetched with a cache-busting query parameter
+	CacheBust bool `json:"cacheBust,omitempty"`
+	// When each modpack was last launched, keyed by lowercased modpack ID
+	LastPlayed map[string]time.Time `json:"lastPlayed,omitempty"`
+	// Lowercased IDs of the modpacks the user starred, shown under Favorites
+	FavoriteModpackIDs []string `json:"favoriteModpackIds,omitempty"`
+	// Total seconds played per modpack, keyed by lowercased modpack ID
+	PlaytimeSeconds map[string]int64 `json:"playtimeSeconds,omitempty"`
+	// If true, the launcher never checks for or installs new versions of itself
+	DisableSelfUpdate bool `json:"disableSelfUpdate,omitempty"`
+	// Where modpack instances are stored; empty uses prism/instances under the launcher home
+	InstancesDir string `json:"instancesDir,omitempty"`
+	// UI language code matching a file in locales/; empty uses English
+	Language string `json:"language,omitempty"`
+	// If true, Prism and the default modpack's Java runtime are downloaded in the background after startup
+	Prefetch bool `json:"prefetch,omitempty"`
+	// Prism profile name to launch with; empty uses whichever account is active in Prism
+	PrismAccount string `json:"prismAccount,omitempty"`
+	// Seconds to wait for a download server to respond; 0 uses the default
+	DownloadTimeoutSec int `json:"downloadTimeoutSec,omitempty"`
+	// How many prerequisites (Prism, Java, packwiz) are downloaded at once; 0 uses the default
+	DownloadConcurrency int `json:"downloadConcurrency,omitempty"`
+	// How many modpacks may install, update or run at once; later ones wait in a queue. 0 uses the default
+	InstallConcurrency int `json:"installConcurrency,omitempty"`
+	// How many update backups are kept per modpack; older ones are deleted. 0 uses the default
+	BackupRetention int `json:"backupRetention,omitempty"`
+	// How many times GitHub and Adoptium requests are tried before giving up; 0 uses the default
+	HTTPRetries int `json:"httpRetries,omitempty"`
+	// Milliseconds before the first retry, doubling after each one; 0 uses the default
+	HTTPRetryDelayMs int `json:"httpRetryDelayMs,omitempty"`
+	// If true, installed modpacks launch without checking for updates or syncing
+	OfflineMode bool `json:"offlineMode,omitempty"`
+	// Prism archive to download instead of the latest release, e.g. a fork's build
+	PrismDownloadURL string `json:"prismDownloadUrl,omitempty"`
+	// Prism release tag to download instead of the latest; ignored when PrismDownloadURL is set
+	PrismVersion string `json:"prismVersion,omitempty"`
+	// If true, the console tab is brought forward when the game exits and console windows aren't hidden
+	KeepConsoleOpen bool `json:"keepConsoleOpen,omitempty"`
+	// If true, launching opens Prism's own window rather than starting the instance directly
+	LaunchPrismGUI bool `json:"launchPrismGui,omitempty"`
+	// Tab index and sidebar category selected when the launcher was last closed
+	LastTab      int    `json:"lastTab,omitempty"`
+	LastCategory string `json:"lastCategory,omitempty"`
+	// Paste service used by Upload Log: "dylan" (the default) or "microbin"
+	LogUploadProvider string `json:"logUploadProvider,omitempty"`
+	// Upload endpoint for LogUploadProvider; empty uses https://i.dylan.lol/logs/
+	LogUploadURL string `json:"logUploadUrl,omitempty"`
+	// If true (the default), the home folder and username are removed from logs before upload
+	RedactLogs bool `json:"redactLogs"`
+	// "json" writes latest.log as newline-delimited JSON; empty or "pretty" keeps the
+	// readable format. Read at startup, see logFormatSetting.
+	LogFormat string `json:"logFormat,omitempty"`
+	// Extra catalogs merged after the official one; later catalogs override packs with the same ID
+	CatalogURLs []string `json:"catalogUrls,omitempty"`
+	// Per-modpack memory in MB that replaces Auto/Manual RAM, keyed by lowercased modpack ID
+	MemoryOverrides map[string]int `json:"memoryOverrides,omitempty"`
+	// Qt platform Prism runs on under Linux: "xcb" (X11), "wayland", or empty to detect it
+	QtPlatform string `json:"qtPlatform,omitempty"`
+	// Environment variables modpack headers may reference as ${NAME}; catalogs can't read any others
+	HeaderEnvVars []string `json:"headerEnvVars,omitempty"`
 }
 
+// settingsSchemaVersion is the current settings.json format version
+const settingsSchemaVersion = 1
+
 var defaultModpackID string
 var settings LauncherSettings
 
+// Set by the --no-update command-line flag
+var noUpdateFlag bool
+
+// Set by the --offline command-line flag
+var offlineFlag bool
+
 // Use TUI interface by default
 var interactive = false
 
@@ -68,6 +170,35 @@ func getUserAgent(component string) string {
 	return fmt.Sprintf("TheBoys-%s/%s", component, version)
 }
 
+// keepConsoleOpenSetting reads KeepConsoleOpen straight from settings.json, for
+// startup code that runs before loadSettings
+func keepConsoleOpenSetting(root string) bool {
+	data, err := os.ReadFile(filepath.Join(root, "settings.json"))
+	if err != nil {
+		return false
+	}
+	var stored struct {
+		KeepConsoleOpen bool `json:"keepConsoleOpen"`
+	}
+	return json.Unmarshal(data, &stored) == nil && stored.KeepConsoleOpen
+}
+
+// logFormatSetting reads LogFormat straight from settings.json, since the log is
+// opened before the rest of the settings are loaded
+func logFormatSetting(root string) string {
+	data, err := os.ReadFile(filepath.Join(root, "settings.json"))
+	if err != nil {
+		return ""
+	}
+	var stored struct {
+		LogFormat string `json:"logFormat"`
+	}
+	if json.Unmarshal(data, &stored) != nil {
+		return ""
+	}
+	return stored.LogFormat
+}
+
 // loadSettings loads launcher settings from settings.json, creates defaults if needed
 func loadSettings(root string) error {
 	settingsPath := filepath.Join(root, "settings.json")
@@ -77,15 +208,52 @@ func loadSettings(root string) error {
 		AutoRAM:          true,
 		DevBuildsEnabled: isDevBuild(),
 		DebugEnabled:     false, // Debug disabled by default for better user experience
+		RedactLogs:       true,
+		SchemaVersion:    settingsSchemaVersion,
 	}
 
 	// Try to load existing settings
 	if data, err := os.ReadFile(settingsPath); err == nil {
 		type storedSettings struct {
-			MemoryMB         int   `json:"memoryMB"`
-			AutoRAM          *bool `json:"autoRam"`
-			DevBuildsEnabled *bool `json:"devBuildsEnabled"`
-			DebugEnabled     *bool `json:"debugEnabled,omitempty"`
+			MemoryMB            int                  `json:"memoryMB"`
+			AutoRAM             *bool                `json:"autoRam"`
+			DevBuildsEnabled    *bool                `json:"devBuildsEnabled"`
+			UpdateChannel       string               `json:"updateChannel,omitempty"`
+			DebugEnabled        *bool                `json:"debugEnabled,omitempty"`
+			InstanceNames       map[string]string    `json:"instanceNames,omitempty"`
+			SetupComplete       *bool                `json:"setupComplete"`
+			RAMHeadroomMB       int                  `json:"ramHeadroomMB,omitempty"`
+			SchemaVersion       int                  `json:"schemaVersion"`
+			CacheBust           bool                 `json:"cacheBust,omitempty"`
+			LastPlayed          map[string]time.Time `json:"lastPlayed,omitempty"`
+			FavoriteModpackIDs  []string             `json:"favoriteModpackIds,omitempty"`
+			PlaytimeSeconds     map[string]int64     `json:"playtimeSeconds,omitempty"`
+			DisableSelfUpdate   bool                 `json:"disableSelfUpdate,omitempty"`
+			InstancesDir        string               `json:"instancesDir,omitempty"`
+			Language            string               `json:"language,omitempty"`
+			Prefetch            bool                 `json:"prefetch,omitempty"`
+			PrismAccount        string               `json:"prismAccount,omitempty"`
+			DownloadTimeoutSec  int                  `json:"downloadTimeoutSec,omitempty"`
+			DownloadConcurrency int                  `json:"downloadConcurrency,omitempty"`
+			InstallConcurrency  int                  `json:"installConcurrency,omitempty"`
+			BackupRetention     int                  `json:"backupRetention,omitempty"`
+			HTTPRetries         int                  `json:"httpRetries,omitempty"`
+			HTTPRetryDelayMs    int                  `json:"httpRetryDelayMs,omitempty"`
+			OfflineMode         bool                 `json:"offlineMode,omitempty"`
+			PrismDownloadURL    string               `json:"prismDownloadUrl,omitempty"`
+			PrismVersion        string               `json:"prismVersion,omitempty"`
+			KeepConsoleOpen     bool                 `json:"keepConsoleOpen,omitempty"`
+			LaunchPrismGUI      bool                 `json:"launchPrismGui,omitempty"`
+			LastTab             int                  `json:"lastTab,omitempty"`
+			LastCategory        string               `json:"lastCategory,omitempty"`
+			CatalogURLs         []string             `json:"catalogUrls,omitempty"`
+			LogUploadProvider   string               `json:"logUploadProvider,omitempty"`
+			LogUploadURL        string               `json:"logUploadUrl,omitempty"`
+			RedactLogs          *bool                `json:"redactLogs"`
+			LogFormat           string               `json:"logFormat,omitempty"`
+			MemoryOverrides     map[string]int       `json:"memoryOverrides,omitempty"`
+			QtPlatform          string               `json:"qtPlatform,omitempty"`
+			HeaderEnvVars       []string             `json:"headerEnvVars,omitempty"`
 		}
 		var stored storedSettings
 		if err := json.Unmarshal(data, &stored); err == nil {
@@ -103,11 +271,50 @@ func loadSettings(root string) error {
 			} else {
 				settings.DevBuildsEnabled = *stored.DevBuildsEnabled
 			}
+			// Settings from before channels only have the dev builds switch
+			settings.UpdateChannel = stored.UpdateChannel
+			setUpdateChannel(updateChannelSetting())
 			if stored.DebugEnabled == nil {
 				settings.DebugEnabled = defaultSettings.DebugEnabled
 			} else {
 				settings.DebugEnabled = *stored.DebugEnabled
 			}
+			settings.InstanceNames = stored.InstanceNames
+			settings.RAMHeadroomMB = stored.RAMHeadroomMB
+			if stored.SetupComplete != nil {
+				settings.SetupComplete = *stored.SetupComplete
+			}
+			settings.SchemaVersion = stored.SchemaVersion
+			settings.CacheBust = stored.CacheBust
+			settings.LastPlayed = stored.LastPlayed
+			settings.FavoriteModpackIDs = stored.FavoriteModpackIDs
+			settings.PlaytimeSeconds = stored.PlaytimeSeconds
+			settings.DisableSelfUpdate = stored.DisableSelfUpdate
+			settings.InstancesDir = stored.InstancesDir
+			settings.Language = stored.Language
+			settings.Prefetch = stored.Prefetch
+			settings.PrismAccount = stored.PrismAccount
+			settings.DownloadTimeoutSec = stored.DownloadTimeoutSec
+			settings.DownloadConcurrency = stored.DownloadConcurrency
+			settings.InstallConcurrency = stored.InstallConcurrency
+			settings.BackupRetention = stored.BackupRetention
+			settings.HTTPRetries = stored.HTTPRetries
+			settings.HTTPRetryDelayMs = stored.HTTPRetryDelayMs
+			settings.OfflineMode = stored.OfflineMode
+			settings.PrismDownloadURL = stored.PrismDownloadURL
+			settings.PrismVersion = stored.PrismVersion
+			settings.KeepConsoleOpen = stored.KeepConsoleOpen
+			settings.LaunchPrismGUI = stored.LaunchPrismGUI
+			settings.LastTab = stored.LastTab
+			settings.LastCategory = stored.LastCategory
+			settings.CatalogURLs = stored.CatalogURLs
+			settings.LogUploadProvider = stored.LogUploadProvider
+			settings.LogUploadURL = stored.LogUploadURL
+			settings.RedactLogs = stored.RedactLogs == nil || *stored.RedactLogs
+			settings.LogFormat = stored.LogFormat
+			settings.MemoryOverrides = stored.MemoryOverrides
+			settings.QtPlatform = stored.QtPlatform
+			settings.HeaderEnvVars = stored.HeaderEnvVars
 			if !settings.AutoRAM {
 				settings.MemoryMB = clampMemoryMB(settings.MemoryMB)
 			}
@@ -119,12 +326,17 @@ func loadSettings(root string) error {
 					logf("%s", infoLine(fmt.Sprintf("Dev build detected (version: %s), dev builds disabled by user preference", version)))
 				}
 			}
+			if stored.SchemaVersion < settingsSchemaVersion {
+				migrateSettings(stored.SchemaVersion, stored.SetupComplete != nil)
+				return saveSettings(root)
+			}
 			return nil
 		}
 	}
 
 	// Use defaults if loading failed
 	settings = defaultSettings
+	setUpdateChannel(updateChannelSetting())
 	// Log when using default dev builds setting
 	if isDevBuild() && settings.DevBuildsEnabled {
 		logf("%s", infoLine(fmt.Sprintf("New installation detected with dev build (version: %s), dev builds enabled by default", version)))
@@ -132,12 +344,191 @@ func loadSettings(root string) error {
 	return saveSettings(root)
 }
 
+// updateChannelSetting returns the update channel chosen in Settings, or for
+// settings without one, Dev if dev builds are enabled and Stable otherwise
+func updateChannelSetting() updateChannel {
+	if channel, ok := parseUpdateChannel(settings.UpdateChannel); ok {
+		return channel
+	}
+	if settings.DevBuildsEnabled {
+		return channelDev
+	}
+	return channelStable
+}
+
+// setUpdateChannel chooses the update channel, keeping DevBuildsEnabled on for
+// every channel with prereleases so older launchers reading the file still get them
+func setUpdateChannel(channel updateChannel) {
+	settings.UpdateChannel = string(channel)
+	settings.DevBuildsEnabled = channel != channelStable
+}
+
+// cacheBustEnabled reports whether pack.toml requests should bypass CDN caches,
+// either from settings or the legacy THEBOYS_CACHEBUST=1 environment variable
+func cacheBustEnabled() bool {
+	return settings.CacheBust || os.Getenv(envCacheBust) == "1"
+}
+
+// selfUpdateDisabled reports whether the launcher must not replace its own binary,
+// either from settings, the --no-update flag or THEBOYS_NO_UPDATE=1. Offline mode
+// implies it, since checking for an update needs the network.
+func selfUpdateDisabled() bool {
+	return settings.DisableSelfUpdate || noUpdateFlag || os.Getenv(envNoUpdate) == "1" || offlineMode()
+}
+
+// offlineMode reports whether installed modpacks launch straight from disk, with no
+// catalog refresh, version checks or packwiz sync, from settings or the --offline flag
+func offlineMode() bool {
+	return settings.OfflineMode || offlineFlag
+}
+
+// instancesDirFor returns the folder modpack instances live in, either the
+// user-chosen InstancesDir or the default prism/instances under root
+func instancesDirFor(root string) string {
+	if settings.InstancesDir != "" {
+		return settings.InstancesDir
+	}
+	return defaultInstancesDir(root)
+}
+
+// defaultInstancesDir returns Prism's own instances folder under root
+func defaultInstancesDir(root string) string {
+	return filepath.Join(root, "prism", "instances")
+}
+
+// validateInstancesDir checks that dir can be used as the instances folder.
+// It must be an absolute path that is not nested inside the current instances
+// folder (or the other way round), and it must be writable.
+func validateInstancesDir(root, dir string) error {
+	if !filepath.IsAbs(dir) {
+		return fmt.Errorf("instances folder must be an absolute path: %s", dir)
+	}
+	dir = filepath.Clean(dir)
+	if info, err := os.Stat(dir); err == nil && !info.IsDir() {
+		return fmt.Errorf("%s is a file, not a folder", dir)
+	}
+
+	current := filepath.Clean(instancesDirFor(root))
+	if dir != current && (isSubPath(current, dir) || isSubPath(dir, current)) {
+		return fmt.Errorf("instances folder cannot be inside %s or contain it", current)
+	}
+
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return fmt.Errorf("cannot create %s: %w", dir, err)
+	}
+	return probeWritable(dir)
+}
+
+// playStatsMu guards settings.LastPlayed, settings.PlaytimeSeconds and
+// settings.InstanceNames, which worker goroutines update while the UI reads them
+var playStatsMu sync.RWMutex
+
+// customInstanceName returns the instance name the user chose for a modpack, or ""
+func customInstanceName(id string) string {
+	playStatsMu.RLock()
+	defer playStatsMu.RUnlock()
+	return strings.TrimSpace(settings.InstanceNames[strings.ToLower(id)])
+}
+
+// setInstanceName stores the instance name the user chose for a modpack and persists settings
+func setInstanceName(root, id, name string) error {
+	playStatsMu.Lock()
+	if settings.InstanceNames == nil {
+		settings.InstanceNames = make(map[string]string)
+	}
+	settings.InstanceNames[strings.ToLower(id)] = name
+	playStatsMu.Unlock()
+	return saveSettings(root)
+}
+
+// lastPlayedAt returns when the modpack was last launched, or the zero time if never
+func lastPlayedAt(id string) time.Time {
+	playStatsMu.RLock()
+	defer playStatsMu.RUnlock()
+	return settings.LastPlayed[strings.ToLower(id)]
+}
+
+// recordLastPlayed stores the launch time for a modpack and persists settings
+func recordLastPlayed(root, id string, at time.Time) error {
+	playStatsMu.Lock()
+	if settings.LastPlayed == nil {
+		settings.LastPlayed = make(map[string]time.Time)
+	}
+	settings.LastPlayed[strings.ToLower(id)] = at
+	playStatsMu.Unlock()
+	return saveSettings(root)
+}
+
+// isFavorite reports whether the user starred the modpack
+func isFavorite(id string) bool {
+	id = strings.ToLower(id)
+	for _, fav := range settings.FavoriteModpackIDs {
+		if fav == id {
+			return true
+		}
+	}
+	return false
+}
+
+// setFavorite stars or unstars a modpack and persists settings
+func setFavorite(root, id string, favorite bool) error {
+	id = strings.ToLower(id)
+	kept := settings.FavoriteModpackIDs[:0]
+	for _, fav := range settings.FavoriteModpackIDs {
+		if fav != id {
+			kept = append(kept, fav)
+		}
+	}
+	if favorite {
+		kept = append(kept, id)
+	}
+	settings.FavoriteModpackIDs = kept
+	return saveSettings(root)
+}
+
+// playtimeFor returns the total time played for a modpack
+func playtimeFor(id string) time.Duration {
+	playStatsMu.RLock()
+	defer playStatsMu.RUnlock()
+	return time.Duration(settings.PlaytimeSeconds[strings.ToLower(id)]) * time.Second
+}
+
+// addPlaytime adds a finished session to a modpack's total, persists settings and returns the new total
+func addPlaytime(root, id string, session time.Duration) (time.Duration, error) {
+	playStatsMu.Lock()
+	if settings.PlaytimeSeconds == nil {
+		settings.PlaytimeSeconds = make(map[string]int64)
+	}
+	if session > 0 {
+		settings.PlaytimeSeconds[strings.ToLower(id)] += int64(session / time.Second)
+	}
+	playStatsMu.Unlock()
+	return playtimeFor(id), saveSettings(root)
+}
+
+// migrateSettings upgrades settings loaded from an older settings.json in place.
+// Steps run in order so a file several versions behind is upgraded one step at a time.
+func migrateSettings(fromVersion int, hasSetupFlag bool) {
+	logf("%s", infoLine(fmt.Sprintf("Migrating settings.json from schema %d to %d", fromVersion, settingsSchemaVersion)))
+
+	if fromVersion < 1 {
+		// Version 0 predates the setup wizard; people who already have settings don't need it
+		if !hasSetupFlag {
+			settings.SetupComplete = true
+		}
+	}
+
+	settings.SchemaVersion = settingsSchemaVersion
+}
+
 // saveSettings saves current settings to settings.json
 func saveSettings(root string) error {
 	settingsPath := filepath.Join(root, "settings.json")
-	logf("%s", infoLine(fmt.Sprintf("Saving settings: DevBuildsEnabled=%t, AutoRAM=%t, MemoryMB=%d, DebugEnabled=%t",
-		settings.DevBuildsEnabled, settings.AutoRAM, settings.MemoryMB, settings.DebugEnabled)))
+	logf("%s", infoLine(fmt.Sprintf("Saving settings: UpdateChannel=%s, AutoRAM=%t, MemoryMB=%d, DebugEnabled=%t",
+		updateChannelSetting(), settings.AutoRAM, settings.MemoryMB, settings.DebugEnabled)))
+	playStatsMu.RLock()
 	data, err := json.MarshalIndent(settings, "", "  ")
+	playStatsMu.RUnlock()
 	if err != nil {
 		return err
 	}
@@ -172,7 +563,105 @@ func clampMemoryMB(mb int) int {
 	return mb
 }
 
-// DefaultAutoMemoryMB returns the baseline auto RAM target (half total RAM capped 2-16GB)
+// defaultRAMHeadroomMB is the memory Auto RAM leaves for the OS and other apps by default
+const defaultRAMHeadroomMB = 3072
+
+// ramHeadroomMB returns the configured system RAM headroom for Auto RAM
+func ramHeadroomMB() int {
+	if settings.RAMHeadroomMB > 0 {
+		return settings.RAMHeadroomMB
+	}
+	return defaultRAMHeadroomMB
+}
+
+// Download tuning defaults and the ranges accepted from settings.json
+const (
+	defaultDownloadTimeoutSec  = 30
+	minDownloadTimeoutSec      = 5
+	maxDownloadTimeoutSec      = 600
+	defaultDownloadConcurrency = 2
+	maxDownloadConcurrency     = 4
+	defaultInstallConcurrency  = 1
+	maxInstallConcurrency      = 3
+	defaultBackupRetention     = 3
+	maxBackupRetention         = 20
+	defaultHTTPRetries         = 3
+	maxHTTPRetries             = 10
+	defaultHTTPRetryDelayMs    = 1000
+	maxHTTPRetryDelayMs        = 30000
+)
+
+// downloadTimeout returns how long to wait for a download server to respond.
+// Values outside the accepted range fall back to the default.
+func downloadTimeout() time.Duration {
+	sec := settings.DownloadTimeoutSec
+	if sec < minDownloadTimeoutSec || sec > maxDownloadTimeoutSec {
+		sec = defaultDownloadTimeoutSec
+	}
+	return time.Duration(sec) * time.Second
+}
+
+// downloadConcurrency returns how many prerequisite downloads may run at once.
+// Values outside the accepted range fall back to the default.
+func downloadConcurrency() int {
+	n := settings.DownloadConcurrency
+	if n < 1 || n > maxDownloadConcurrency {
+		n = defaultDownloadConcurrency
+	}
+	return n
+}
+
+// installConcurrency returns how many modpack operations may run before the rest are queued.
+// Values outside the accepted range fall back to the default.
+func installConcurrency() int {
+	n := settings.InstallConcurrency
+	if n < 1 || n > maxInstallConcurrency {
+		n = defaultInstallConcurrency
+	}
+	return n
+}
+
+// backupRetention returns how many update backups to keep for each modpack.
+// Values outside the accepted range fall back to the default.
+func backupRetention() int {
+	n := settings.BackupRetention
+	if n < 1 || n > maxBackupRetention {
+		n = defaultBackupRetention
+	}
+	return n
+}
+
+// httpRetryAttempts returns how many times a GitHub or Adoptium request is tried.
+// Values outside the accepted range fall back to the default.
+func httpRetryAttempts() int {
+	n := settings.HTTPRetries
+	if n < 1 || n > maxHTTPRetries {
+		n = defaultHTTPRetries
+	}
+	return n
+}
+
+// httpRetryDelay returns the backoff before the first retry of a failed request.
+// Values outside the accepted range fall back to the default.
+func httpRetryDelay() time.Duration {
+	ms := settings.HTTPRetryDelayMs
+	if ms < 1 || ms > maxHTTPRetryDelayMs {
+		ms = defaultHTTPRetryDelayMs
+	}
+	return time.Duration(ms) * time.Millisecond
+}
+
+// autoMemoryForTotal computes the auto RAM target for a machine with totalMB of RAM:
+// half of total RAM, but never eating into the headroom, clamped to 2-16GB
+func autoMemoryForTotal(totalMB, headroomMB int) int {
+	auto := totalMB / 2
+	if spare := totalMB - headroomMB; spare < auto {
+		auto = spare
+	}
+	return clampMemoryMB(auto)
+}
+
+// DefaultAutoMemoryMB returns the baseline auto RAM target (half total RAM minus headroom, capped 2-16GB)
 func DefaultAutoMemoryMB() int {
 	total := totalRAMMB()
 	
@@ -181,9 +670,17 @@ func DefaultAutoMemoryMB() int {
 		total = 32768 // fallback 32GB
 	}
 	
-	// Calculate half of total memory and clamp to 2-16GB range
-	auto := clampMemoryMB(total / 2)
-	return auto
+	return autoMemoryForTotal(total, ramHeadroomMB())
+}
+
+// autoRAMExplanation describes how the current Auto RAM baseline was derived
+func autoRAMExplanation() string {
+	total := totalRAMMB()
+	if total <= 0 {
+		return fmt.Sprintf("System RAM could not be detected; assuming 32 GB. Auto RAM uses half, keeps %d GB free for the system, and never exceeds a pack's recommended RAM.", ramHeadroomMB()/1024)
+	}
+	return fmt.Sprintf("Half of %d GB system RAM, keeping %d GB free for the system (2-16 GB range). Each pack is capped at its recommended RAM.",
+		roundToNearestGB(total), ramHeadroomMB()/1024)
 }
 
 func computeAutoRAMForModpack(modpack Modpack) int {
@@ -203,13 +700,29 @@ func computeAutoRAMForModpack(modpack Modpack) int {
 			recommended = clampMemoryMB(total)
 		}
 		// Return the minimum of auto-calculated RAM and recommended RAM
-		return min(auto, recommended)
+		auto = min(auto, recommended)
+	}
+
+	// Raise to the pack's minimum when the system has room for it
+	if modpack.MinRam > auto && modpack.MinRam <= 16384 && total > 0 && modpack.MinRam <= total-ramHeadroomMB() {
+		auto = clampMemoryMB(modpack.MinRam)
 	}
 	return auto
 }
 
-// MemoryForModpack returns the memory allocation that should be applied for the given modpack
+// memoryBelowMinimum reports the memory that would be applied to the modpack and
+// whether it falls short of the pack's advertised minimum
+func memoryBelowMinimum(modpack Modpack) (int, bool) {
+	mem := MemoryForModpack(modpack)
+	return mem, modpack.MinRam > 0 && mem < modpack.MinRam
+}
+
+// MemoryForModpack returns the memory allocation that should be applied for the given modpack.
+// A per-pack override wins over Auto/Manual RAM and leaves the global value alone.
 func MemoryForModpack(modpack Modpack) int {
+	if modpack.OverrideMemoryMB > 0 {
+		return clampMemoryMB(modpack.OverrideMemoryMB)
+	}
 	if settings.AutoRAM {
 		mem := clampMemoryMB(computeAutoRAMForModpack(modpack))
 		settings.MemoryMB = mem
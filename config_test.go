@@ -0,0 +1,255 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+)
+
+// TestMemoryBelowMinimum tests detection of RAM allocations below a pack's minimum
+func TestMemoryBelowMinimum(t *testing.T) {
+	saved := settings
+	defer func() { settings = saved }()
+
+	settings.AutoRAM = false
+	settings.MemoryMB = 4096
+
+	tests := []struct {
+		name   string
+		minRam int
+		below  bool
+	}{
+		{"pack minimum above allocation", 6144, true},
+		{"pack minimum equal to allocation", 4096, false},
+		{"pack minimum below allocation", 2048, false},
+		{"no pack minimum", 0, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mem, below := memoryBelowMinimum(Modpack{ID: "test", MinRam: tt.minRam})
+			if mem != 4096 {
+				t.Errorf("Expected manual allocation of 4096 MB, got %d", mem)
+			}
+			if below != tt.below {
+				t.Errorf("Expected below=%t for MinRam %d, got %t", tt.below, tt.minRam, below)
+			}
+		})
+	}
+}
+
+// TestMemoryOverride tests that a per-pack override wins, is clamped and clearing it restores the global setting
+func TestMemoryOverride(t *testing.T) {
+	saved := settings
+	defer func() { settings = saved }()
+
+	settings.AutoRAM = false
+	settings.MemoryMB = 4096
+	settings.MemoryOverrides = map[string]int{"heavy": 10240, "huge": 65536}
+
+	mods := normalizeModpacks([]Modpack{
+		{ID: "Heavy", PackURL: "https://example.com/a/pack.toml", InstanceName: "Heavy"},
+		{ID: "huge", PackURL: "https://example.com/b/pack.toml", InstanceName: "Huge"},
+		{ID: "light", PackURL: "https://example.com/c/pack.toml", InstanceName: "Light"},
+	})
+	if len(mods) != 3 {
+		t.Fatalf("Expected 3 modpacks, got %d", len(mods))
+	}
+	if got := MemoryForModpack(mods[0]); got != 10240 {
+		t.Errorf("Expected the 10240 MB override, got %d", got)
+	}
+	if got := MemoryForModpack(mods[1]); got != 16384 {
+		t.Errorf("Expected the override to be clamped to 16384 MB, got %d", got)
+	}
+	if got := MemoryForModpack(mods[2]); got != 4096 {
+		t.Errorf("Expected a pack without an override to use the manual 4096 MB, got %d", got)
+	}
+	if settings.MemoryMB != 4096 {
+		t.Errorf("Expected overrides to leave the global allocation alone, got %d", settings.MemoryMB)
+	}
+
+	delete(settings.MemoryOverrides, "heavy")
+	mods = normalizeModpacks(mods)
+	if got := MemoryForModpack(mods[0]); got != 4096 {
+		t.Errorf("Expected a cleared override to follow the global setting, got %d", got)
+	}
+}
+
+// TestAutoMemoryForTotal tests that Auto RAM leaves headroom for the system
+func TestAutoMemoryForTotal(t *testing.T) {
+	tests := []struct {
+		totalMB    int
+		headroomMB int
+		expected   int
+	}{
+		{4096, 3072, 2048},   // tiny machine still gets the 2GB floor
+		{8192, 3072, 4096},   // half of total fits within headroom
+		{7168, 4096, 3072},   // headroom limits below half
+		{6144, 4096, 2048},   // large headroom falls back to the floor
+		{16384, 3072, 8192},  // half of total
+		{65536, 3072, 16384}, // capped at 16GB
+	}
+
+	for _, tt := range tests {
+		result := autoMemoryForTotal(tt.totalMB, tt.headroomMB)
+		if result != tt.expected {
+			t.Errorf("autoMemoryForTotal(%d, %d) = %d, expected %d", tt.totalMB, tt.headroomMB, result, tt.expected)
+		}
+	}
+}
+
+// TestLoadSettingsMigratesLegacyFile tests that a pre-versioning settings.json is upgraded and written back
+func TestLoadSettingsMigratesLegacyFile(t *testing.T) {
+	saved := settings
+	defer func() { settings = saved }()
+
+	root := t.TempDir()
+	legacy := `{"memoryMB": 6144, "autoRam": false, "devBuildsEnabled": false}`
+	if err := os.WriteFile(filepath.Join(root, "settings.json"), []byte(legacy), 0644); err != nil {
+		t.Fatalf("Failed to write settings.json: %v", err)
+	}
+
+	if err := loadSettings(root); err != nil {
+		t.Fatalf("loadSettings failed: %v", err)
+	}
+	if settings.SchemaVersion != settingsSchemaVersion {
+		t.Errorf("Expected schema version %d, got %d", settingsSchemaVersion, settings.SchemaVersion)
+	}
+	if !settings.SetupComplete {
+		t.Error("Expected existing users to skip the setup wizard after migration")
+	}
+	if settings.MemoryMB != 6144 || settings.AutoRAM {
+		t.Errorf("Expected saved memory settings to survive migration, got %d MB auto=%t", settings.MemoryMB, settings.AutoRAM)
+	}
+
+	data, err := os.ReadFile(filepath.Join(root, "settings.json"))
+	if err != nil {
+		t.Fatalf("Failed to read settings.json: %v", err)
+	}
+	var written LauncherSettings
+	if err := json.Unmarshal(data, &written); err != nil {
+		t.Fatalf("Failed to parse migrated settings.json: %v", err)
+	}
+	if written.SchemaVersion != settingsSchemaVersion {
+		t.Errorf("Expected migrated file to be written back with schema %d, got %d", settingsSchemaVersion, written.SchemaVersion)
+	}
+}
+
+// TestAddPlaytime tests that sessions accumulate per modpack and survive a reload
+func TestAddPlaytime(t *testing.T) {
+	saved := settings
+	defer func() { settings = saved }()
+
+	root := t.TempDir()
+	settings = LauncherSettings{MemoryMB: 4096, AutoRAM: true, SchemaVersion: settingsSchemaVersion, SetupComplete: true}
+
+	if _, err := addPlaytime(root, "Alpha", 90*time.Minute); err != nil {
+		t.Fatalf("addPlaytime failed: %v", err)
+	}
+	total, err := addPlaytime(root, "alpha", 30*time.Minute)
+	if err != nil {
+		t.Fatalf("addPlaytime failed: %v", err)
+	}
+	if total != 2*time.Hour {
+		t.Errorf("Expected 2h total playtime, got %s", total)
+	}
+
+	settings = LauncherSettings{}
+	if err := loadSettings(root); err != nil {
+		t.Fatalf("loadSettings failed: %v", err)
+	}
+	if got := playtimeFor("ALPHA"); got != 2*time.Hour {
+		t.Errorf("Expected playtime to persist as 2h, got %s", got)
+	}
+	if got := playtimeFor("beta"); got != 0 {
+		t.Errorf("Expected no playtime for an unplayed modpack, got %s", got)
+	}
+
+	// Sessions end on worker goroutines while the UI reads the totals
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			id := fmt.Sprintf("pack-%d", i)
+			recordLastPlayed(root, id, time.Now())
+			addPlaytime(root, id, time.Minute)
+		}(i)
+	}
+	for i := 0; i < 100; i++ {
+		lastPlayedAt("pack-0")
+		playtimeFor("pack-1")
+	}
+	wg.Wait()
+	if got := playtimeFor("pack-7"); got != time.Minute {
+		t.Errorf("Expected 1m of playtime from a concurrent session, got %s", got)
+	}
+}
+
+// TestDownloadSettings tests that out-of-range download tuning falls back to the defaults
+func TestDownloadSettings(t *testing.T) {
+	saved := settings
+	defer func() { settings = saved }()
+
+	tests := []struct {
+		timeoutSec  int
+		concurrency int
+		wantTimeout time.Duration
+		wantWorkers int
+	}{
+		{0, 0, defaultDownloadTimeoutSec * time.Second, defaultDownloadConcurrency},
+		{120, 4, 120 * time.Second, 4},
+		{1, -1, defaultDownloadTimeoutSec * time.Second, defaultDownloadConcurrency},
+		{100000, 64, defaultDownloadTimeoutSec * time.Second, defaultDownloadConcurrency},
+	}
+	for _, tt := range tests {
+		settings.DownloadTimeoutSec = tt.timeoutSec
+		settings.DownloadConcurrency = tt.concurrency
+		if got := downloadTimeout(); got != tt.wantTimeout {
+			t.Errorf("DownloadTimeoutSec=%d: expected %v, got %v", tt.timeoutSec, tt.wantTimeout, got)
+		}
+		if got := downloadConcurrency(); got != tt.wantWorkers {
+			t.Errorf("DownloadConcurrency=%d: expected %d, got %d", tt.concurrency, tt.wantWorkers, got)
+		}
+	}
+}
+
+// TestFavorites tests that favorites are keyed by ID, persist, and select the Favorites category
+func TestFavorites(t *testing.T) {
+	saved := settings
+	defer func() { settings = saved }()
+
+	root := t.TempDir()
+	settings = LauncherSettings{MemoryMB: 4096, AutoRAM: true, SchemaVersion: settingsSchemaVersion, SetupComplete: true}
+
+	if err := setFavorite(root, "Alpha", true); err != nil {
+		t.Fatalf("setFavorite failed: %v", err)
+	}
+	if err := setFavorite(root, "beta", true); err != nil {
+		t.Fatalf("setFavorite failed: %v", err)
+	}
+	if err := setFavorite(root, "BETA", false); err != nil {
+		t.Fatalf("setFavorite failed: %v", err)
+	}
+
+	settings = LauncherSettings{}
+	if err := loadSettings(root); err != nil {
+		t.Fatalf("loadSettings failed: %v", err)
+	}
+	if !isFavorite("alpha") || isFavorite("beta") {
+		t.Errorf("Expected only alpha to be a favorite after reloading, got %v", settings.FavoriteModpackIDs)
+	}
+
+	// A refreshed catalog is new Modpack values with the same IDs
+	refreshed := []Modpack{{ID: "ALPHA", DisplayName: "Alpha v2"}, {ID: "beta"}}
+	if !modMatchesCategory(refreshed[0], categoryFavorites) || modMatchesCategory(refreshed[1], categoryFavorites) {
+		t.Error("Expected the Favorites category to match only the starred modpack")
+	}
+	if !categoryHasModpacks(refreshed, categoryFavorites) || categoryHasModpacks(refreshed[1:], categoryFavorites) {
+		t.Error("Expected the Favorites category to be restored only when a favorite is in the catalog")
+	}
+}
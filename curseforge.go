@@ -2,14 +2,17 @@ package main
 
 import (
 	"compress/gzip"
+	"context"
+	"encoding/json"
 	"fmt"
 	"io"
 	"net/http"
 	"os"
-	"os/exec"
+	"path"
 	"path/filepath"
 	"regexp"
 	"strings"
+	"sync"
 	"time"
 )
 
@@ -23,7 +26,7 @@ func downloadFromCurseForge(url, destPath string) error {
 	}
 
 	// For non-CurseForge URLs, fall back to regular download
-	return downloadTo(url, destPath, 0644)
+	return downloadTo(context.Background(), url, destPath, 0644)
 }
 
 // downloadCurseForgeFileWithRetry attempts to download from CurseForge with multiple retry attempts
@@ -251,7 +254,7 @@ func downloadCurseForgeFromPage(pageURL, destPath string) error {
 	}
 	req.Header.Set("User-Agent", getUserAgent("General"))
 
-	resp, err := http.DefaultClient.Do(req)
+	resp, err := apiClient().Do(req)
 	if err != nil {
 		return fmt.Errorf("failed to fetch CurseForge page: %w", err)
 	}
@@ -281,7 +284,7 @@ func downloadCurseForgeFromPage(pageURL, destPath string) error {
 	// Look for download link patterns in the HTML
 	downloadURL := extractCurseForgeDownloadLink(string(body))
 	if downloadURL != "" {
-		return downloadTo(downloadURL, destPath, 0644)
+		return downloadTo(context.Background(), downloadURL, destPath, 0644)
 	}
 
 	return fmt.Errorf("could not extract direct download link from CurseForge page")
@@ -377,9 +380,13 @@ func parsePackwizManuals(s string) []manualItem {
 	return items
 }
 
-func assistManualFromPackwiz(items []manualItem) {
+// assistManualFromPackwiz downloads manual mods directly from CurseForge. Anything that
+// still fails is handed to prompt as one batch, which blocks until the user has saved
+// the files; prompt is asked again with whatever is still missing until it returns
+// false. It returns the items that are still missing.
+func assistManualFromPackwiz(items []manualItem, prompt func(items []manualItem) bool) []manualItem {
 	if len(items) == 0 {
-		return
+		return nil
 	}
 
 	logf("Downloading %d manual mod(s) directly from CurseForge...", len(items))
@@ -404,47 +411,175 @@ func assistManualFromPackwiz(items []manualItem) {
 		}
 	}
 
-	// Handle any failed downloads
-	if len(failedItems) > 0 {
-		logf("\n%d download(s) failed. These may require manual download:", len(failedItems))
-		for _, it := range failedItems {
-			logf(" - %s\n   %s\n   Save as: %s", it.Name, it.URL, it.Path)
-		}
+	if len(failedItems) == 0 {
+		logf("All manual downloads completed successfully!")
+		return nil
+	}
+
+	logf("\n%d download(s) failed. These may require manual download:", len(failedItems))
+	for _, it := range failedItems {
+		logf(" - %s\n   %s\n   Save as: %s", it.Name, it.URL, it.Path)
+	}
 
+	if prompt == nil {
 		if yesNoBox("Some downloads failed. Open remaining pages in browser?", launcherName+" - Download Failed") {
 			for _, it := range failedItems {
-				_ = exec.Command("rundll32", "url.dll,FileProtocolHandler", it.URL).Start()
+				_ = openPath(it.URL)
 			}
+		}
+		return failedItems
+	}
 
-			// Wait for manual downloads
-			for {
-				logf("\nPress Enter after saving the files to re-check…")
-				waitEnter()
+	for len(failedItems) > 0 && prompt(failedItems) {
+		failedItems = missingManualItems(failedItems)
+		if len(failedItems) > 0 {
+			logf("Still missing:")
+			for _, it := range failedItems {
+				logf(" - %s -> %s", it.Name, it.Path)
+			}
+		}
+	}
 
-				still := failedItems[:0]
-				for _, it := range failedItems {
-					if !exists(it.Path) {
-						still = append(still, it)
-					}
-				}
-				failedItems = still
-				if len(failedItems) == 0 {
-					logf("All manual items found. Continuing…")
-					return
-				}
+	if len(failedItems) == 0 {
+		logf("All manual items found. Continuing…")
+	}
+	return failedItems
+}
 
-				logf("Still missing:")
-				for _, it := range failedItems {
-					logf(" - %s -> %s", it.Name, it.Path)
-				}
-				if yesNoBox("Open the pages again?", launcherName) {
-					for _, it := range failedItems {
-						_ = exec.Command("rundll32", "url.dll,FileProtocolHandler", it.URL).Start()
-					}
-				}
+// missingManualItems returns the items whose files are not on disk yet
+func missingManualItems(items []manualItem) []manualItem {
+	var missing []manualItem
+	for _, it := range items {
+		if !exists(it.Path) {
+			missing = append(missing, it)
+		}
+	}
+	return missing
+}
+
+// -------------------- Manual download pre-scan --------------------
+
+// manualModsFile records CurseForge projects that packwiz has asked us to download by
+// hand, with the category of their file page
+const manualModsFile = "manual-mods.json"
+
+// loadKnownManualMods returns the remembered manual-download projects (slug -> category)
+func loadKnownManualMods(utilDir string) map[string]string {
+	known := make(map[string]string)
+	data, err := os.ReadFile(filepath.Join(utilDir, manualModsFile))
+	if err != nil {
+		return known
+	}
+	if err := json.Unmarshal(data, &known); err != nil {
+		debugf("Ignoring unreadable %s: %v", manualModsFile, err)
+		return make(map[string]string)
+	}
+	return known
+}
+
+// rememberManualMods records the projects behind the given items so the next
+// install or update can fetch them before packwiz runs
+func rememberManualMods(utilDir string, items []manualItem) {
+	known := loadKnownManualMods(utilDir)
+	changed := false
+	for _, it := range items {
+		_, category, slug, _, err := parseCurseForgeFileURL(it.URL)
+		if err != nil || known[slug] == category {
+			continue
+		}
+		known[slug] = category
+		changed = true
+	}
+	if !changed {
+		return
+	}
+
+	data, err := json.MarshalIndent(known, "", "  ")
+	if err != nil {
+		return
+	}
+	if err := os.WriteFile(filepath.Join(utilDir, manualModsFile), data, 0644); err != nil {
+		logf("%s", warnLine(fmt.Sprintf("Failed to save %s: %v", manualModsFile, err)))
+	}
+}
+
+// manualScanConcurrency is how many metafiles scanManualMods fetches at once
+const manualScanConcurrency = 8
+
+// scanManualMods checks the pack index for the CurseForge projects packwiz asked for
+// by hand on an earlier run, and returns the files of theirs that are not in mcDir
+// yet. Other CurseForge mods are left to packwiz, which downloads and hash-checks them
+// itself; a metafile alone doesn't say whether CurseForge allows that.
+func scanManualMods(info *PackInfo, mcDir, utilDir string) []manualItem {
+	if info == nil || info.IndexURL == "" {
+		return nil
+	}
+	known := loadKnownManualMods(utilDir)
+	if len(known) == 0 {
+		return nil
+	}
+
+	index, err := fetchPackIndex(info.IndexURL, info.Headers)
+	if err != nil {
+		debugf("Skipping manual mod pre-scan: %v", err)
+		return nil
+	}
+
+	var files []string
+	for _, f := range index.Files {
+		if !f.Metafile || !strings.HasSuffix(f.File, ".pw.toml") {
+			continue
+		}
+		rel := path.Clean(f.File)
+		if path.IsAbs(rel) || rel == ".." || strings.HasPrefix(rel, "../") {
+			continue
+		}
+		if _, ok := known[strings.TrimSuffix(path.Base(rel), ".pw.toml")]; !ok {
+			continue
+		}
+		files = append(files, f.File)
+	}
+
+	// Metafiles are small but a pack has hundreds, so they are fetched side by side
+	found := make([]*manualItem, len(files))
+	var wg sync.WaitGroup
+	sem := make(chan struct{}, manualScanConcurrency)
+	for i, file := range files {
+		wg.Add(1)
+		go func(i int, file string) {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+
+			meta, err := fetchPackMetafile(info.IndexURL, file, info.Headers)
+			if err != nil {
+				debugf("Skipping %s in manual mod pre-scan: %v", file, err)
+				return
+			}
+			if meta.Download.Mode != "metadata:curseforge" || meta.Filename == "" || meta.Update.CurseForge.FileID == 0 {
+				return
 			}
+
+			rel := path.Clean(file)
+			dest := filepath.Join(mcDir, filepath.FromSlash(path.Dir(rel)), filepath.Base(meta.Filename))
+			if exists(dest) {
+				return
+			}
+			slug := strings.TrimSuffix(path.Base(rel), ".pw.toml")
+			found[i] = &manualItem{
+				Name: meta.Name,
+				URL:  fmt.Sprintf("https://www.curseforge.com/minecraft/%s/%s/files/%d", known[slug], slug, meta.Update.CurseForge.FileID),
+				Path: dest,
+			}
+		}(i, file)
+	}
+	wg.Wait()
+
+	var items []manualItem
+	for _, it := range found {
+		if it != nil {
+			items = append(items, *it)
 		}
-	} else {
-		logf("All manual downloads completed successfully!")
 	}
+	return items
 }
@@ -0,0 +1,326 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+)
+
+// -------------------- Custom modpacks --------------------
+
+// customPackSource marks packs the user added themselves, in place of a catalog URL.
+// It doubles as their category in the sidebar.
+const customPackSource = "custom"
+
+// customPacksFile lists the user's own packs. It is kept apart from modpacks.json so
+// refreshing the catalogs never drops them.
+const customPacksFile = "custom-modpacks.json"
+
+// customPacksDir holds imported pack zips, one folder per pack
+const customPacksDir = "custom-packs"
+
+// customPacksMu serializes changes to custom-modpacks.json, as several imports can
+// finish at once
+var customPacksMu sync.Mutex
+
+// isCustomModpack reports whether the user added mod rather than a catalog
+func isCustomModpack(mod Modpack) bool {
+	return mod.Source == customPackSource
+}
+
+// loadCustomModpacks reads the user's custom packs. A missing file means there are none.
+// Packs imported from a zip get a PackURL on the loopback server that serves them.
+func loadCustomModpacks(root string) ([]Modpack, error) {
+	data, err := os.ReadFile(filepath.Join(root, customPacksFile))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var mods []Modpack
+	if err := json.Unmarshal(data, &mods); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", customPacksFile, err)
+	}
+
+	for i := range mods {
+		mods[i].Source = customPackSource
+		if mods[i].LocalPack == "" {
+			continue
+		}
+		base, err := customPackServerURL(root)
+		if err != nil {
+			logf("%s", warnLine(fmt.Sprintf("Cannot serve imported pack %s: %v", mods[i].DisplayName, err)))
+			continue
+		}
+		mods[i].PackURL = base + "/" + mods[i].LocalPack
+	}
+	return mods, nil
+}
+
+// saveCustomModpacks writes the custom pack list. Loopback URLs change every run, so
+// imported packs are saved by their folder alone.
+func saveCustomModpacks(root string, mods []Modpack) error {
+	entries := make([]Modpack, len(mods))
+	for i, mod := range mods {
+		if mod.LocalPack != "" {
+			mod.PackURL = ""
+		}
+		entries[i] = mod
+	}
+	data, err := json.MarshalIndent(entries, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(filepath.Join(root, customPacksFile), data, 0644)
+}
+
+// withCustomModpacks replaces any custom packs in mods with the ones saved on disk,
+// listed after the catalog packs
+func withCustomModpacks(root string, mods []Modpack) []Modpack {
+	custom, err := loadCustomModpacks(root)
+	if err != nil {
+		logf("%s", warnLine(fmt.Sprintf("Failed to load custom modpacks: %v", err)))
+	}
+	combined := make([]Modpack, 0, len(mods)+len(custom))
+	for _, mod := range mods {
+		if !isCustomModpack(mod) {
+			combined = append(combined, mod)
+		}
+	}
+	return normalizeModpacks(append(combined, custom...))
+}
+
+// saveCustomModpack adds mod to the custom pack list, replacing a pack with the same ID
+func saveCustomModpack(root string, mod Modpack) error {
+	customPacksMu.Lock()
+	defer customPacksMu.Unlock()
+
+	mods, err := loadCustomModpacks(root)
+	if err != nil {
+		return err
+	}
+	replaced := false
+	for i := range mods {
+		if strings.EqualFold(mods[i].ID, mod.ID) {
+			mods[i] = mod
+			replaced = true
+		}
+	}
+	if !replaced {
+		mods = append(mods, mod)
+	}
+	return saveCustomModpacks(root, mods)
+}
+
+// removeCustomModpack drops a custom pack from the list along with its imported files.
+// The instance itself is left to the caller.
+func removeCustomModpack(root, id string) error {
+	customPacksMu.Lock()
+	defer customPacksMu.Unlock()
+
+	mods, err := loadCustomModpacks(root)
+	if err != nil {
+		return err
+	}
+	kept := mods[:0]
+	for _, mod := range mods {
+		if !strings.EqualFold(mod.ID, id) {
+			kept = append(kept, mod)
+			continue
+		}
+		if mod.LocalPack != "" {
+			if err := os.RemoveAll(filepath.Join(root, customPacksDir, mod.ID)); err != nil {
+				return err
+			}
+		}
+	}
+	return saveCustomModpacks(root, kept)
+}
+
+// parseCustomPackURL checks that raw looks like a link to a packwiz pack.toml
+func parseCustomPackURL(raw string) (string, error) {
+	raw = strings.TrimSpace(raw)
+	if raw == "" {
+		return "", errors.New("enter the URL of the pack's pack.toml")
+	}
+	u, err := url.Parse(raw)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") {
+		return "", fmt.Errorf("%q is not a web address; pack URLs start with http:// or https://", raw)
+	}
+	if u.Host == "" {
+		return "", fmt.Errorf("%q has no host name", raw)
+	}
+	if !strings.HasSuffix(strings.ToLower(u.Path), ".toml") {
+		return "", fmt.Errorf("%q does not point at a pack.toml file", raw)
+	}
+	return u.String(), nil
+}
+
+// addCustomModpackURL reads the pack.toml at raw and saves it as a custom pack
+func addCustomModpackURL(root, raw string) (Modpack, error) {
+	packURL, err := parseCustomPackURL(raw)
+	if err != nil {
+		return Modpack{}, err
+	}
+	info, err := fetchPackInfo(packURL, nil)
+	if err != nil {
+		return Modpack{}, fmt.Errorf("could not read a packwiz pack at %s: %w", redactURL(packURL), err)
+	}
+
+	u, _ := url.Parse(packURL)
+	fallback := path.Base(path.Dir(u.Path))
+	if fallback == "." || fallback == "/" {
+		fallback = u.Hostname()
+	}
+	mod := newCustomModpack(info, fallback)
+	mod.PackURL = packURL
+	if err := saveCustomModpack(root, mod); err != nil {
+		return Modpack{}, fmt.Errorf("failed to save custom pack: %w", err)
+	}
+	return mod, nil
+}
+
+// importCustomPackZip unpacks an exported packwiz pack into the launcher home and saves
+// it as a custom pack. Importing a newer zip of the same pack replaces the old files,
+// which shows up as an update for the installed instance.
+func importCustomPackZip(root, zipPath string) (Modpack, error) {
+	name := filepath.Base(zipPath)
+	data, err := os.ReadFile(zipPath)
+	if err != nil {
+		return Modpack{}, err
+	}
+
+	packsDir := filepath.Join(root, customPacksDir)
+	if err := os.MkdirAll(packsDir, 0755); err != nil {
+		return Modpack{}, err
+	}
+	tmp, err := os.MkdirTemp(packsDir, ".import-")
+	if err != nil {
+		return Modpack{}, err
+	}
+	defer os.RemoveAll(tmp)
+
+	if err := unzipBytesTo(data, tmp); err != nil {
+		return Modpack{}, fmt.Errorf("%s is not a readable zip: %w", name, err)
+	}
+	rel, err := findPackToml(tmp)
+	if err != nil {
+		return Modpack{}, fmt.Errorf("%s does not contain a packwiz pack.toml", name)
+	}
+
+	base, err := customPackServerURL(root)
+	if err != nil {
+		return Modpack{}, fmt.Errorf("cannot serve the imported pack: %w", err)
+	}
+	info, err := fetchPackInfo(base+"/"+filepath.Base(tmp)+"/"+rel, nil)
+	if err != nil {
+		return Modpack{}, fmt.Errorf("%s is not a valid packwiz pack: %w", name, err)
+	}
+
+	mod := newCustomModpack(info, strings.TrimSuffix(name, filepath.Ext(name)))
+	dest := filepath.Join(packsDir, mod.ID)
+	if err := os.RemoveAll(dest); err != nil {
+		return Modpack{}, err
+	}
+	if err := os.Rename(tmp, dest); err != nil {
+		return Modpack{}, err
+	}
+	mod.LocalPack = mod.ID + "/" + rel
+	mod.PackURL = base + "/" + mod.LocalPack
+	if err := saveCustomModpack(root, mod); err != nil {
+		return Modpack{}, fmt.Errorf("failed to save custom pack: %w", err)
+	}
+	return mod, nil
+}
+
+// findPackToml returns the slash-separated path of the outermost pack.toml under dir,
+// as exported zips may wrap the pack in a folder
+func findPackToml(dir string) (string, error) {
+	found := ""
+	err := filepath.WalkDir(dir, func(p string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() || !strings.EqualFold(d.Name(), "pack.toml") {
+			return err
+		}
+		rel, err := filepath.Rel(dir, p)
+		if err != nil {
+			return err
+		}
+		rel = filepath.ToSlash(rel)
+		if found == "" || strings.Count(rel, "/") < strings.Count(found, "/") {
+			found = rel
+		}
+		return nil
+	})
+	if err != nil {
+		return "", err
+	}
+	if found == "" {
+		return "", errors.New("no pack.toml found")
+	}
+	return found, nil
+}
+
+// newCustomModpack describes a pack from its pack.toml, named after fallback when the
+// pack has no name of its own
+func newCustomModpack(info *PackInfo, fallback string) Modpack {
+	name := strings.TrimSpace(info.Name)
+	if name == "" {
+		name = strings.TrimSpace(fallback)
+	}
+	id := "custom-" + slugifyID(name)
+	instance := name
+	if validateInstanceName(instance) != nil {
+		instance = id
+	}
+	return Modpack{
+		ID:           id,
+		DisplayName:  name,
+		InstanceName: instance,
+		Description:  fmt.Sprintf("Custom pack for Minecraft %s with %s.", info.Minecraft, info.ModLoader),
+		Author:       strings.TrimSpace(info.Author),
+		Tags:         []string{customPackSource},
+		LastUpdated:  time.Now().Format(time.RFC3339),
+		Category:     customPackSource,
+		Source:       customPackSource,
+	}
+}
+
+// customPackServers maps each launcher home to the loopback server for its imported
+// packs. packwiz-installer only reads packs over HTTP, so the servers run until exit.
+var (
+	customPackServersMu sync.Mutex
+	customPackServers   = map[string]string{}
+)
+
+// customPackServerURL returns the base URL serving root's custom-packs folder,
+// starting the server on first use
+func customPackServerURL(root string) (string, error) {
+	customPackServersMu.Lock()
+	defer customPackServersMu.Unlock()
+	if base, ok := customPackServers[root]; ok {
+		return base, nil
+	}
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return "", err
+	}
+	server := &http.Server{Handler: http.FileServer(http.Dir(filepath.Join(root, customPacksDir)))}
+	go server.Serve(listener)
+
+	base := "http://" + listener.Addr().String()
+	customPackServers[root] = base
+	debugf("Serving imported packs from %s at %s", filepath.Join(root, customPacksDir), base)
+	return base, nil
+}
@@ -0,0 +1,136 @@
+package main
+
+import (
+	"archive/zip"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const testCustomPackToml = `name = "Friday Night Pack"
+author = "Sam"
+version = "1.2.0"
+
+[index]
+file = "index.toml"
+
+[versions]
+minecraft = "1.20.1"
+fabric = "0.15.0"
+`
+
+// TestParseCustomPackURL tests that only http(s) links to a .toml file are accepted
+func TestParseCustomPackURL(t *testing.T) {
+	if got, err := parseCustomPackURL("  https://example.com/pack/pack.toml "); err != nil || got != "https://example.com/pack/pack.toml" {
+		t.Errorf("Expected a valid pack URL to be accepted, got %q, %v", got, err)
+	}
+	for _, raw := range []string{
+		"",
+		"example.com/pack.toml",
+		"ftp://example.com/pack.toml",
+		"https:///pack.toml",
+		"https://example.com/pack/",
+		"https://example.com/modpacks.json",
+	} {
+		if _, err := parseCustomPackURL(raw); err == nil {
+			t.Errorf("Expected %q to be rejected", raw)
+		}
+	}
+}
+
+// TestAddCustomModpackURL tests that a pack added by URL is saved and survives a catalog refresh
+func TestAddCustomModpackURL(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/friday/pack.toml" {
+			http.NotFound(w, r)
+			return
+		}
+		fmt.Fprint(w, testCustomPackToml)
+	}))
+	defer server.Close()
+	root := t.TempDir()
+
+	mod, err := addCustomModpackURL(root, server.URL+"/friday/pack.toml")
+	if err != nil {
+		t.Fatalf("addCustomModpackURL failed: %v", err)
+	}
+	if mod.ID != "custom-friday-night-pack" || mod.DisplayName != "Friday Night Pack" || mod.Author != "Sam" {
+		t.Errorf("Expected the pack to be named from pack.toml, got %+v", mod)
+	}
+	if _, err := addCustomModpackURL(root, server.URL+"/missing/pack.toml"); err == nil {
+		t.Error("Expected an error for a URL with no pack behind it")
+	}
+
+	catalog := normalizeModpacks([]Modpack{{ID: "official", PackURL: "https://example.com/pack.toml", InstanceName: "Official"}})
+	merged := withCustomModpacks(root, catalog)
+	if len(merged) != 2 || merged[0].ID != "official" || merged[1].ID != mod.ID || !isCustomModpack(merged[1]) {
+		t.Fatalf("Expected the custom pack after the catalog, got %+v", merged)
+	}
+	// Merging again, as a refresh does, must not duplicate the pack
+	if again := withCustomModpacks(root, merged); len(again) != 2 {
+		t.Errorf("Expected 2 modpacks after a second merge, got %d", len(again))
+	}
+	if _, err := os.Stat(filepath.Join(root, "modpacks.json")); !os.IsNotExist(err) {
+		t.Error("Expected custom packs to be kept out of modpacks.json")
+	}
+
+	if err := removeCustomModpack(root, mod.ID); err != nil {
+		t.Fatalf("removeCustomModpack failed: %v", err)
+	}
+	if merged := withCustomModpacks(root, catalog); len(merged) != 1 {
+		t.Errorf("Expected the removed pack to be gone, got %+v", merged)
+	}
+}
+
+// TestImportCustomPackZip tests that an exported zip is unpacked and served to packwiz over loopback
+func TestImportCustomPackZip(t *testing.T) {
+	root := t.TempDir()
+	zipPath := filepath.Join(t.TempDir(), "friday.zip")
+	f, err := os.Create(zipPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	zw := zip.NewWriter(f)
+	for name, content := range map[string]string{
+		"friday/pack.toml":           testCustomPackToml,
+		"friday/index.toml":          "hash-format = \"sha256\"\n",
+		"friday/mods/thing.pw.toml":  "name = \"Thing\"\n",
+		"friday/extra/old/pack.toml": "not the pack",
+	} {
+		w, _ := zw.Create(name)
+		w.Write([]byte(content))
+	}
+	zw.Close()
+	f.Close()
+
+	mod, err := importCustomPackZip(root, zipPath)
+	if err != nil {
+		t.Fatalf("importCustomPackZip failed: %v", err)
+	}
+	if mod.LocalPack != mod.ID+"/friday/pack.toml" {
+		t.Errorf("Expected the outermost pack.toml to be used, got %q", mod.LocalPack)
+	}
+	info, err := fetchPackInfo(mod.PackURL, nil)
+	if err != nil || info.Version != "1.2.0" {
+		t.Fatalf("Expected the imported pack to be served at %s, got %v, %v", mod.PackURL, info, err)
+	}
+
+	data, err := os.ReadFile(filepath.Join(root, customPacksFile))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(string(data), "127.0.0.1") {
+		t.Errorf("Expected the loopback URL not to be saved, got %s", data)
+	}
+	if entries, _ := os.ReadDir(filepath.Join(root, customPacksDir)); len(entries) != 1 {
+		t.Errorf("Expected only the pack folder to be left behind, got %d entries", len(entries))
+	}
+
+	if _, err := importCustomPackZip(root, writeTestLog(t, "not a zip")); err == nil {
+		t.Error("Expected an error for a file that isn't a zip")
+	}
+}
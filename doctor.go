@@ -0,0 +1,207 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"strings"
+	"time"
+)
+
+// doctorCheck is one line of the --doctor health report
+type doctorCheck struct {
+	Name   string   `json:"name"`
+	OK     bool     `json:"ok"`
+	Detail string   `json:"detail"`
+	Hints  []string `json:"hints,omitempty"`
+}
+
+// runDoctor checks the launcher installation under root without changing anything
+func runDoctor(root string, modpacks []Modpack) []doctorCheck {
+	var checks []doctorCheck
+
+	if err := checkHomeWritable(root); err != nil {
+		checks = append(checks, doctorCheck{
+			Name:   "Launcher folder writable",
+			Detail: err.Error(),
+			Hints: []string{
+				"Make sure the folder is not read-only or locked by a sync or backup tool",
+				"Check that your user account owns " + root,
+			},
+		})
+	} else {
+		checks = append(checks, doctorCheck{Name: "Launcher folder writable", OK: true, Detail: root})
+	}
+
+	checks = append(checks, checkPrismInstall(filepath.Join(root, "prism")))
+	checks = append(checks, checkJREs(filepath.Join(root, "prism", "java"))...)
+	checks = append(checks, checkInstances(instancesDirFor(root), modpacks)...)
+
+	if runtime.GOOS == "linux" {
+		if path, err := exec.LookPath("patchelf"); err != nil {
+			checks = append(checks, doctorCheck{
+				Name:   "patchelf available",
+				Detail: "patchelf was not found on PATH, so Qt plugin RPATHs can't be fixed",
+				Hints:  []string{"Install patchelf: sudo apt install patchelf"},
+			})
+		} else {
+			checks = append(checks, doctorCheck{Name: "patchelf available", OK: true, Detail: path})
+		}
+	}
+
+	checks = append(checks, checkProcessRegistry(getRegistryPath(root)))
+	return checks
+}
+
+// checkPrismInstall verifies the Prism executable exists once Prism has been downloaded
+func checkPrismInstall(prismDir string) doctorCheck {
+	check := doctorCheck{Name: "Prism Launcher installed"}
+	if !exists(prismDir) {
+		check.OK = true
+		check.Detail = "not downloaded yet; it is fetched on the first install"
+		return check
+	}
+	exe := GetPrismExecutablePath(prismDir)
+	if !exists(exe) {
+		check.Detail = fmt.Sprintf("%s is missing", exe)
+		check.Hints = []string{"Install or launch a modpack to download Prism Launcher again"}
+		return check
+	}
+	check.OK = true
+	check.Detail = exe
+	return check
+}
+
+// checkJREs runs java -version for every runtime under javaDir
+func checkJREs(javaDir string) []doctorCheck {
+	entries, err := os.ReadDir(javaDir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return []doctorCheck{{Name: "Java runtimes", OK: true, Detail: "none downloaded yet"}}
+		}
+		return []doctorCheck{{Name: "Java runtimes", Detail: err.Error()}}
+	}
+
+	var checks []doctorCheck
+	for _, entry := range entries {
+		if !entry.IsDir() || !strings.HasPrefix(entry.Name(), "jre") {
+			continue
+		}
+		check := doctorCheck{Name: fmt.Sprintf("Java runtime %s", strings.TrimPrefix(entry.Name(), "jre"))}
+		javaBin := filepath.Join(javaDir, entry.Name(), "bin", JavaBinName)
+		if !exists(javaBin) {
+			check.Detail = fmt.Sprintf("%s is missing", javaBin)
+			check.Hints = []string{fmt.Sprintf("Delete %s; it is downloaded again on the next launch", filepath.Join(javaDir, entry.Name()))}
+		} else if version, err := javaVersionLine(javaBin); err != nil {
+			check.Detail = fmt.Sprintf("%s does not run: %v", javaBin, err)
+			check.Hints = append(issueSolutions("Java configuration"), fmt.Sprintf("Delete %s to download it again", filepath.Join(javaDir, entry.Name())))
+		} else {
+			check.OK = true
+			check.Detail = version
+		}
+		checks = append(checks, check)
+	}
+	if len(checks) == 0 {
+		return []doctorCheck{{Name: "Java runtimes", OK: true, Detail: "none downloaded yet"}}
+	}
+	return checks
+}
+
+// javaVersionLine returns the first line java -version prints
+func javaVersionLine(javaBin string) (string, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 15*time.Second)
+	defer cancel()
+	cmd := exec.CommandContext(ctx, javaBin, "-version")
+	setProcessAttributes(cmd)
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		return "", err
+	}
+	line, _, _ := strings.Cut(strings.TrimSpace(string(output)), "\n")
+	return strings.TrimSpace(line), nil
+}
+
+// checkInstances validates instance.cfg and mmc-pack.json for every installed modpack
+func checkInstances(instancesDir string, modpacks []Modpack) []doctorCheck {
+	var checks []doctorCheck
+	for _, mp := range modpacks {
+		instDir := filepath.Join(instancesDir, mp.InstanceName)
+		if !exists(instDir) {
+			continue
+		}
+		check := doctorCheck{Name: fmt.Sprintf("Instance %s", modpackLabel(mp))}
+		hints := []string{"Use Force re-sync on the modpack card, or Reinstall if that doesn't help"}
+		if _, err := readInstanceConfig(instDir); err != nil {
+			check.Detail = fmt.Sprintf("instance.cfg unreadable: %v", err)
+			check.Hints = hints
+		} else if info, err := readInstancePackInfo(instDir); err != nil {
+			check.Detail = fmt.Sprintf("mmc-pack.json unreadable: %v", err)
+			check.Hints = hints
+		} else if info.Minecraft == "" {
+			check.Detail = "mmc-pack.json has no Minecraft component"
+			check.Hints = hints
+		} else {
+			check.OK = true
+			check.Detail = fmt.Sprintf("Minecraft %s", info.Minecraft)
+			if info.ModLoader != "" {
+				check.Detail += fmt.Sprintf(", %s %s", info.ModLoader, info.LoaderVersion)
+			}
+		}
+		checks = append(checks, check)
+	}
+	sort.SliceStable(checks, func(i, j int) bool { return checks[i].Name < checks[j].Name })
+	return checks
+}
+
+// checkProcessRegistry makes sure the saved process registry can be parsed
+func checkProcessRegistry(path string) doctorCheck {
+	check := doctorCheck{Name: "Process registry"}
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		check.OK = true
+		check.Detail = "no registry yet"
+		return check
+	}
+	if err != nil {
+		check.Detail = err.Error()
+		return check
+	}
+	var records map[string]*PersistentProcessRecord
+	if err := json.Unmarshal(data, &records); err != nil {
+		check.Detail = fmt.Sprintf("%s is corrupt: %v", path, err)
+		check.Hints = []string{fmt.Sprintf("Close the launcher and delete %s; it is recreated on the next launch", path)}
+		return check
+	}
+	check.OK = true
+	check.Detail = fmt.Sprintf("%d record(s) in %s", len(records), path)
+	return check
+}
+
+// formatDoctorReport renders the checks as a pass/fail report and reports whether all passed
+func formatDoctorReport(checks []doctorCheck) (string, bool) {
+	var b strings.Builder
+	failed := 0
+	for _, check := range checks {
+		if check.OK {
+			b.WriteString(successLine(fmt.Sprintf("%s: %s", check.Name, check.Detail)))
+		} else {
+			failed++
+			b.WriteString(warnLine(fmt.Sprintf("%s: %s", check.Name, check.Detail)))
+			for _, hint := range check.Hints {
+				b.WriteString("\n" + infoLine("• "+hint))
+			}
+		}
+		b.WriteString("\n")
+	}
+	if failed == 0 {
+		b.WriteString(fmt.Sprintf("\nAll %d checks passed", len(checks)))
+	} else {
+		b.WriteString(fmt.Sprintf("\n%d of %d checks failed", failed, len(checks)))
+	}
+	return b.String(), failed == 0
+}
@@ -0,0 +1,65 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// TestCheckInstances tests that broken instance files fail the health check with a hint
+func TestCheckInstances(t *testing.T) {
+	instancesDir := t.TempDir()
+	good := filepath.Join(instancesDir, "Good")
+	writeTestMMCPack(t, good, "1.20.1", "net.minecraftforge", "47.2.0")
+	if err := os.WriteFile(filepath.Join(good, "instance.cfg"), []byte("name=Good\n"), 0644); err != nil {
+		t.Fatalf("Failed to create instance.cfg: %v", err)
+	}
+	broken := filepath.Join(instancesDir, "Broken")
+	if err := os.MkdirAll(broken, 0755); err != nil {
+		t.Fatalf("Failed to create instance directory: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(broken, "instance.cfg"), []byte("name=Broken\n"), 0644); err != nil {
+		t.Fatalf("Failed to create instance.cfg: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(broken, "mmc-pack.json"), []byte("{not json"), 0644); err != nil {
+		t.Fatalf("Failed to create mmc-pack.json: %v", err)
+	}
+
+	checks := checkInstances(instancesDir, []Modpack{
+		{ID: "good", DisplayName: "Good", InstanceName: "Good"},
+		{ID: "broken", DisplayName: "Broken", InstanceName: "Broken"},
+		{ID: "missing", DisplayName: "Missing", InstanceName: "Missing"},
+	})
+	if len(checks) != 2 {
+		t.Fatalf("Expected checks for the 2 installed instances only, got %+v", checks)
+	}
+	if checks[0].Name != "Instance Broken" || checks[0].OK || len(checks[0].Hints) == 0 {
+		t.Errorf("Expected the broken instance to fail with a hint, got %+v", checks[0])
+	}
+	if checks[1].Name != "Instance Good" || !checks[1].OK || !strings.Contains(checks[1].Detail, "1.20.1") {
+		t.Errorf("Expected the good instance to pass, got %+v", checks[1])
+	}
+
+	report, ok := formatDoctorReport(checks)
+	if ok {
+		t.Error("Expected the report to fail when a check fails")
+	}
+	if !strings.Contains(report, "1 of 2 checks failed") {
+		t.Errorf("Expected a failure summary, got:\n%s", report)
+	}
+}
+
+// TestCheckProcessRegistry tests that a missing registry passes and a corrupt one fails
+func TestCheckProcessRegistry(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "processes.json")
+	if check := checkProcessRegistry(path); !check.OK {
+		t.Errorf("Expected a missing registry to pass, got %+v", check)
+	}
+	if err := os.WriteFile(path, []byte("{\"a\":"), 0644); err != nil {
+		t.Fatalf("Failed to write registry: %v", err)
+	}
+	if check := checkProcessRegistry(path); check.OK || len(check.Hints) == 0 {
+		t.Errorf("Expected a corrupt registry to fail with a hint, got %+v", check)
+	}
+}
@@ -5,61 +5,99 @@ import (
 	"archive/zip"
 	"bytes"
 	"compress/gzip"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
 	"fmt"
 	"io"
+	"math/rand"
+	"net"
 	"net/http"
 	"os"
 	"path/filepath"
+	"regexp"
 	"runtime"
+	"strconv"
 	"strings"
+	"sync"
+	"sync/atomic"
 	"time"
 )
 
 // -------------------- Downloads / Unzip --------------------
 
-type progressWriter struct {
-	total      int64
-	downloaded int64
-	filename   string
-	startTime  time.Time
-}
+// progressInterval is how often a download reports its progress
+const progressInterval = 500 * time.Millisecond
 
-func (pw *progressWriter) Write(p []byte) (int, error) {
-	n := len(p)
-	err := error(nil)
-	pw.downloaded += int64(n)
+// downloadProgress is how far one download has got. Total is 0 when the server
+// didn't send a Content-Length.
+type downloadProgress struct {
+	Name        string
+	Downloaded  int64
+	Total       int64
+	BytesPerSec float64
+}
 
-	// Update progress every 1MB or every second
-	if pw.downloaded%1048576 == 0 || time.Since(pw.startTime) > time.Second {
-		pw.updateProgress()
-		pw.startTime = time.Now()
+// String describes the download like "Downloading Java (42 MB/s, ~12s left)", or just
+// the bytes downloaded so far when the size isn't known
+func (p downloadProgress) String() string {
+	if p.Total <= 0 {
+		return fmt.Sprintf("Downloading %s (%s)", p.Name, formatSize(p.Downloaded))
+	}
+	if p.BytesPerSec <= 0 {
+		return fmt.Sprintf("Downloading %s (%d%%)", p.Name, int(float64(p.Downloaded)/float64(p.Total)*100))
 	}
+	left := time.Duration(float64(p.Total-p.Downloaded) / p.BytesPerSec * float64(time.Second)).Round(time.Second)
+	return fmt.Sprintf("Downloading %s (%s/s, ~%s left)", p.Name, formatSize(int64(p.BytesPerSec)), left)
+}
 
-	return n, err
+type downloadReporterKey struct{}
+type downloadNameKey struct{}
+
+// withDownloadReporter makes every download started with the returned context pass
+// its progress to report
+func withDownloadReporter(ctx context.Context, report func(downloadProgress)) context.Context {
+	return context.WithValue(ctx, downloadReporterKey{}, report)
+}
+
+// withDownloadName names the downloads started with the returned context in their
+// progress, such as "Java 21", instead of by the file they fetch
+func withDownloadName(ctx context.Context, name string) context.Context {
+	return context.WithValue(ctx, downloadNameKey{}, name)
 }
 
-func (pw *progressWriter) updateProgress() {
-	if pw.total > 0 {
-		percent := float64(pw.downloaded) / float64(pw.total) * 100
+// progressReader counts the bytes read through it and reports the average rate since
+// the download started, at most once per progressInterval
+type progressReader struct {
+	r          io.Reader
+	progress   downloadProgress
+	startTime  time.Time
+	lastReport time.Time
+	report     func(downloadProgress)
+}
 
-		// Calculate download speed
-		elapsed := time.Since(pw.startTime).Seconds()
-		if elapsed > 0 {
-			speedMBps := (float64(pw.downloaded) / (1024 * 1024)) / elapsed
-			fmt.Fprintf(out, "\rDownloading %s (%.1f MB/s, %d%%)", pw.filename, speedMBps, int(percent))
-		} else {
-			fmt.Fprintf(out, "\rDownloading %s (%d%%)", pw.filename, int(percent))
+func (pr *progressReader) Read(p []byte) (int, error) {
+	n, err := pr.r.Read(p)
+	pr.progress.Downloaded += int64(n)
+	if now := time.Now(); now.Sub(pr.lastReport) >= progressInterval {
+		if elapsed := now.Sub(pr.startTime).Seconds(); elapsed > 0 {
+			pr.progress.BytesPerSec = float64(pr.progress.Downloaded) / elapsed
 		}
+		pr.lastReport = now
+		pr.report(pr.progress)
 	}
+	return n, err
+}
+
+func downloadTo(ctx context.Context, url, path string, mode os.FileMode) error {
+	return downloadVerifiedTo(ctx, url, "", path, mode)
 }
 
-func downloadTo(url, path string, mode os.FileMode) error {
+// downloadVerifiedTo is downloadTo for a file with a published SHA-256; nothing is
+// written if the download doesn't match. An empty wantSHA256 skips the check.
+func downloadVerifiedTo(ctx context.Context, url, wantSHA256, path string, mode os.FileMode) error {
 	debugf("Starting download from %s to %s", url, path)
-	b, err := downloadWithProgress(url)
-	if err != nil {
-		debugf("Download failed for %s: %v", url, err)
-		return err
-	}
 
 	// Verify the directory exists before writing
 	if dir := filepath.Dir(path); !exists(dir) {
@@ -70,23 +108,70 @@ func downloadTo(url, path string, mode os.FileMode) error {
 		}
 	}
 
-	err = os.WriteFile(path, b, mode)
-	if err != nil {
-		debugf("Failed to write file %s: %v", path, err)
+	part := path + partSuffix
+	if err := downloadPart(ctx, url, part); err != nil {
+		debugf("Download failed for %s: %v", url, err)
+		return err
+	}
+	if wantSHA256 != "" {
+		b, err := os.ReadFile(part)
+		if err != nil {
+			return err
+		}
+		if err := verifySHA256(b, wantSHA256); err != nil {
+			_ = os.Remove(part)
+			return fmt.Errorf("download from %s is corrupt or was tampered with: %w", url, err)
+		}
+	}
+	if err := os.Chmod(part, mode); err != nil {
+		return err
+	}
+	if err := os.Rename(part, path); err != nil {
+		debugf("Failed to move %s into place: %v", path, err)
 		return err
 	}
 
-	debugf("Successfully downloaded and wrote %s (%d bytes)", path, len(b))
+	debugf("Successfully downloaded and wrote %s", path)
 	return nil
 }
 
-func downloadAndUnzipTo(url, dest string) error {
+// archiveAttempts is how many times downloadAndUnzipTo downloads an archive that fails validation
+const archiveAttempts = 2
+
+// downloadAndUnzipTo downloads an archive and extracts it into dest. The archive is
+// checked against wantSHA256 first, when one is known, and downloaded again once if
+// it doesn't match or can't be read.
+func downloadAndUnzipTo(ctx context.Context, url, wantSHA256, dest string) error {
 	debugf("Starting download and extract from %s to %s", url, dest)
-	b, err := download(url)
-	if err != nil {
-		debugf("Download failed for %s: %v", url, err)
+	// The archive is kept next to dest until it has been extracted, so a download
+	// that breaks off resumes on the next attempt
+	part := filepath.Clean(dest) + partSuffix
+	if err := os.MkdirAll(filepath.Dir(part), 0755); err != nil {
 		return err
 	}
+	var b []byte
+	for attempt := 1; ; attempt++ {
+		if err := downloadPart(ctx, url, part); err != nil {
+			debugf("Download failed for %s: %v", url, err)
+			return err
+		}
+		var err error
+		b, err = os.ReadFile(part)
+		if err == nil {
+			err = verifySHA256(b, wantSHA256)
+		}
+		if err == nil {
+			err = validateArchive(b)
+		}
+		if err == nil {
+			break
+		}
+		_ = os.Remove(part)
+		if attempt == archiveAttempts {
+			return fmt.Errorf("downloaded archive from %s is not usable: %w", url, err)
+		}
+		logf("%s", warnLine(fmt.Sprintf("Downloaded archive failed validation (%v); downloading again", err)))
+	}
 
 	// Ensure destination directory exists
 	if !exists(dest) {
@@ -97,16 +182,82 @@ func downloadAndUnzipTo(url, dest string) error {
 		}
 	}
 
-	err = extractBytesTo(b, dest, url)
-	if err != nil {
+	if err := extractBytesTo(b, dest, url); err != nil {
 		debugf("Extraction failed for %s: %v", url, err)
 		return err
 	}
+	_ = os.Remove(part)
 
 	debugf("Successfully downloaded and extracted %s to %s", url, dest)
 	return nil
 }
 
+// validateArchive checks that b is a complete zip or tar.gz with at least one entry and
+// no entry escaping the destination, so nothing is extracted from a truncated download
+// or an error page
+func validateArchive(b []byte) error {
+	switch {
+	case bytes.HasPrefix(b, []byte("PK\x03\x04")), bytes.HasPrefix(b, []byte("PK\x05\x06")):
+		r, err := zip.NewReader(bytes.NewReader(b), int64(len(b)))
+		if err != nil {
+			return fmt.Errorf("zip archive is damaged or incomplete: %w", err)
+		}
+		if len(r.File) == 0 {
+			return errors.New("zip archive is empty")
+		}
+		for _, f := range r.File {
+			if _, err := archiveEntryPath(".", f.Name); err != nil {
+				return err
+			}
+		}
+		return nil
+
+	case bytes.HasPrefix(b, []byte{0x1f, 0x8b}):
+		gzReader, err := gzip.NewReader(bytes.NewReader(b))
+		if err != nil {
+			return fmt.Errorf("tar.gz archive is damaged: %w", err)
+		}
+		defer gzReader.Close()
+		tarReader := tar.NewReader(gzReader)
+		entries := 0
+		for {
+			header, err := tarReader.Next()
+			if err == io.EOF {
+				break
+			}
+			if err != nil {
+				return fmt.Errorf("tar.gz archive is damaged or incomplete: %w", err)
+			}
+			if _, err := archiveEntryPath(".", header.Name); err != nil {
+				return err
+			}
+			if _, err := io.Copy(io.Discard, tarReader); err != nil {
+				return fmt.Errorf("tar.gz archive is damaged or incomplete: %w", err)
+			}
+			entries++
+		}
+		if entries == 0 {
+			return errors.New("tar.gz archive is empty")
+		}
+		return nil
+	}
+
+	if start := bytes.ToLower(bytes.TrimSpace(b[:min(len(b), 512)])); bytes.HasPrefix(start, []byte("<!doctype html")) || bytes.HasPrefix(start, []byte("<html")) {
+		return errors.New("the server returned a web page instead of an archive")
+	}
+	return errors.New("download is not a zip or tar.gz archive")
+}
+
+// archiveEntryPath returns where an archive entry is extracted under dest, refusing
+// absolute names and names that climb out of dest ("zip slip")
+func archiveEntryPath(dest, name string) (string, error) {
+	target := filepath.Join(dest, name)
+	if filepath.IsAbs(name) || strings.HasPrefix(name, "/") || (target != filepath.Clean(dest) && !isSubPath(dest, target)) {
+		return "", fmt.Errorf("archive entry %q points outside the extraction folder", name)
+	}
+	return target, nil
+}
+
 // extractBytesTo extracts archive bytes to destination, detecting format
 func extractBytesTo(b []byte, dest, url string) error {
 	// Determine format based on file extension and platform
@@ -147,7 +298,10 @@ func untarBytesTo(b []byte, dest string) error {
 			return fmt.Errorf("failed to read tar header: %w", err)
 		}
 
-		targetPath := filepath.Join(dest, header.Name)
+		targetPath, err := archiveEntryPath(dest, header.Name)
+		if err != nil {
+			return err
+		}
 
 		switch header.Typeflag {
 		case tar.TypeDir:
@@ -182,59 +336,542 @@ func untarBytesTo(b []byte, dest string) error {
 	return nil
 }
 
-func download(url string) ([]byte, error) {
-	return downloadWithProgress(url)
+var (
+	downloadClientMu      sync.Mutex
+	downloadClientTimeout time.Duration
+	sharedDownloadClient  *http.Client
+)
+
+// downloadClient returns the HTTP client shared by launcher downloads. The
+// configured timeout bounds connecting and waiting for a response, not the
+// transfer itself, so large files on slow links still complete; fetchPart gives
+// up on a body that stops sending instead.
+func downloadClient() *http.Client {
+	timeout := downloadTimeout()
+	downloadClientMu.Lock()
+	defer downloadClientMu.Unlock()
+	if sharedDownloadClient == nil || downloadClientTimeout != timeout {
+		transport := http.DefaultTransport.(*http.Transport).Clone()
+		transport.TLSHandshakeTimeout = timeout
+		transport.ResponseHeaderTimeout = timeout
+		sharedDownloadClient = &http.Client{Transport: transport}
+		downloadClientTimeout = timeout
+	}
+	return sharedDownloadClient
+}
+
+// apiClient returns a client for metadata and API requests. Their responses are
+// small, so unlike downloadClient the configured timeout bounds the whole request
+// including reading the body, and a server that stalls mid-response can't hang it.
+func apiClient() *http.Client {
+	return &http.Client{Transport: downloadClient().Transport, Timeout: downloadTimeout()}
+}
+
+// stallReader cancels a transfer once no data has arrived for timeout
+type stallReader struct {
+	r       io.Reader
+	timer   *time.Timer
+	timeout time.Duration
+}
+
+func (s *stallReader) Read(p []byte) (int, error) {
+	n, err := s.r.Read(p)
+	if n > 0 {
+		s.timer.Reset(s.timeout)
+	}
+	return n, err
+}
+
+// -------------------- Checksums --------------------
+
+// errChecksumMismatch is wrapped by every failed SHA-256 check
+var errChecksumMismatch = errors.New("SHA-256 checksum mismatch")
+
+// checksumManifestFile lists the SHA-256 of every file extracted into a runtime folder
+const checksumManifestFile = ".launcher-checksums"
+
+// sha256Pattern finds a hex SHA-256 digest, e.g. in a .sha256 sidecar
+var sha256Pattern = regexp.MustCompile(`\b[0-9a-fA-F]{64}\b`)
+
+// verifySHA256 checks data against a hex SHA-256 digest; an empty want skips the check
+func verifySHA256(data []byte, want string) error {
+	want = strings.ToLower(strings.TrimSpace(want))
+	if want == "" {
+		return nil
+	}
+	sum := sha256.Sum256(data)
+	if got := hex.EncodeToString(sum[:]); got != want {
+		return fmt.Errorf("%w: expected %s, got %s", errChecksumMismatch, want, got)
+	}
+	return nil
+}
+
+// fileSHA256 returns the hex SHA-256 of the file at path
+func fileSHA256(path string) (string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+	h := sha256.New()
+	if _, err := io.Copy(h, f); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// fetchSHA256Sidecar looks for the checksum GitHub releases often publish next to an
+// asset as <asset>.sha256.txt or <asset>.sha256, returning "" if there is none
+func fetchSHA256Sidecar(ctx context.Context, assetURL string) string {
+	for _, suffix := range []string{".sha256.txt", ".sha256"} {
+		req, err := http.NewRequestWithContext(ctx, "GET", assetURL+suffix, nil)
+		if err != nil {
+			return ""
+		}
+		req.Header.Set("User-Agent", getUserAgent("General"))
+		resp, err := doWithRetry(req, httpRetryAttempts(), downloadTimeout())
+		if err != nil {
+			continue
+		}
+		body, err := io.ReadAll(io.LimitReader(resp.Body, 4096))
+		resp.Body.Close()
+		if err != nil || resp.StatusCode != http.StatusOK {
+			continue
+		}
+		if sum := sha256Pattern.FindString(string(body)); sum != "" {
+			debugf("Found SHA-256 sidecar %s%s", assetURL, suffix)
+			return strings.ToLower(sum)
+		}
+	}
+	return ""
+}
+
+// saveFileChecksum records the SHA-256 of a downloaded file next to it as path.sha256
+func saveFileChecksum(path string) error {
+	sum, err := fileSHA256(path)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path+".sha256", []byte(sum+"  "+filepath.Base(path)+"\n"), 0644)
+}
+
+// verifyFileChecksum compares a file with the digest saveFileChecksum stored for it.
+// A file without a stored digest passes.
+func verifyFileChecksum(path string) error {
+	data, err := os.ReadFile(path + ".sha256")
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	got, err := fileSHA256(path)
+	if err != nil {
+		return err
+	}
+	if want := sha256Pattern.FindString(string(data)); !strings.EqualFold(got, want) {
+		return fmt.Errorf("%w: expected %s, got %s", errChecksumMismatch, want, got)
+	}
+	return nil
+}
+
+// writeChecksumManifest records the SHA-256 of every file under dir so a later
+// verifyChecksumManifest can tell whether the folder has been damaged
+func writeChecksumManifest(dir string) error {
+	var b strings.Builder
+	err := filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
+		if err != nil || !info.Mode().IsRegular() {
+			return err
+		}
+		rel, err := filepath.Rel(dir, path)
+		if err != nil || rel == checksumManifestFile {
+			return err
+		}
+		sum, err := fileSHA256(path)
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(&b, "%s  %s\n", sum, filepath.ToSlash(rel))
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(filepath.Join(dir, checksumManifestFile), []byte(b.String()), 0644)
+}
+
+// verifyChecksumManifest re-hashes the files listed in dir's manifest. The error
+// wraps os.ErrNotExist when dir has no manifest and errChecksumMismatch when a
+// file is missing or changed.
+func verifyChecksumManifest(dir string) error {
+	data, err := os.ReadFile(filepath.Join(dir, checksumManifestFile))
+	if err != nil {
+		return err
+	}
+	for _, line := range strings.Split(string(data), "\n") {
+		want, rel, ok := strings.Cut(line, "  ")
+		if !ok {
+			continue
+		}
+		got, err := fileSHA256(filepath.Join(dir, filepath.FromSlash(rel)))
+		if err != nil {
+			return fmt.Errorf("%w: %s is unreadable: %v", errChecksumMismatch, rel, err)
+		}
+		if got != want {
+			return fmt.Errorf("%w: %s has changed", errChecksumMismatch, rel)
+		}
+	}
+	return nil
+}
+
+// -------------------- Retrying requests --------------------
+
+// maxRetryAfter caps how long a 429 response's Retry-After can make us wait
+const maxRetryAfter = time.Minute
+
+// httpGetWithRetry GETs url with the launcher's User-Agent, see doWithRetry
+func httpGetWithRetry(url string, attempts int, timeout time.Duration) (*http.Response, error) {
+	req, err := http.NewRequest("GET", url, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("User-Agent", getUserAgent("General"))
+	return doWithRetry(req, attempts, timeout)
+}
+
+// doWithRetry sends a body-less request up to attempts times, retrying network
+// errors and 5xx responses with exponential backoff and jitter, and waiting out
+// Retry-After on a 429. timeout bounds each attempt including reading the body;
+// 0 leaves that to the request's context. The last response is returned as is,
+// so callers keep reporting non-200 statuses themselves.
+func doWithRetry(req *http.Request, attempts int, timeout time.Duration) (*http.Response, error) {
+	if attempts < 1 {
+		attempts = 1
+	}
+	ctx := req.Context()
+	for attempt := 1; ; attempt++ {
+		resp, err := doAttempt(req, timeout)
+		if ctx.Err() != nil {
+			if err == nil {
+				resp.Body.Close()
+			}
+			return nil, ctx.Err()
+		}
+
+		var reason string
+		var wait time.Duration
+		switch {
+		case err != nil && !retryableError(err):
+			return nil, err
+		case err != nil:
+			reason = err.Error()
+		case resp.StatusCode == http.StatusTooManyRequests:
+			reason = resp.Status
+			wait = retryAfter(resp.Header.Get("Retry-After"))
+		case resp.StatusCode >= 500:
+			reason = resp.Status
+		default:
+			return resp, nil
+		}
+		if attempt >= attempts {
+			return resp, err
+		}
+		if resp != nil {
+			resp.Body.Close()
+		}
+		if wait == 0 {
+			wait = backoffDelay(httpRetryDelay(), attempt)
+		}
+
+		logf("%s", warnLine(fmt.Sprintf("Request to %s failed (%s), retrying in %s (attempt %d/%d)",
+			redactURL(req.URL.String()), reason, wait.Round(time.Millisecond), attempt+1, attempts)))
+		select {
+		case <-time.After(wait):
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+}
+
+// doAttempt sends one copy of req, cancelling it after timeout unless the body is
+// still being read, in which case closing the body releases it
+func doAttempt(req *http.Request, timeout time.Duration) (*http.Response, error) {
+	if timeout <= 0 {
+		return apiClient().Do(req.Clone(req.Context()))
+	}
+	ctx, cancel := context.WithTimeout(req.Context(), timeout)
+	resp, err := downloadClient().Do(req.Clone(ctx))
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+	resp.Body = cancelOnClose{ReadCloser: resp.Body, cancel: cancel}
+	return resp, nil
+}
+
+// cancelOnClose releases a per-attempt context once the caller is done with the body
+type cancelOnClose struct {
+	io.ReadCloser
+	cancel context.CancelFunc
+}
+
+func (c cancelOnClose) Close() error {
+	err := c.ReadCloser.Close()
+	c.cancel()
+	return err
 }
 
-func downloadWithProgress(url string) ([]byte, error) {
+// retryableError reports whether a failed request might succeed if sent again. A
+// host that DNS says doesn't exist won't appear a second later, so it fails fast.
+func retryableError(err error) bool {
+	var dnsErr *net.DNSError
+	if errors.As(err, &dnsErr) && dnsErr.IsNotFound {
+		return false
+	}
+	return true
+}
+
+// backoffDelay doubles base for every attempt already made and adds up to 50% jitter
+// so parallel downloads don't retry in lockstep
+func backoffDelay(base time.Duration, attempt int) time.Duration {
+	delay := base << (attempt - 1)
+	return delay + time.Duration(rand.Int63n(int64(delay)/2+1))
+}
+
+// retryAfter parses a Retry-After header given in seconds or as an HTTP date,
+// returning 0 when it is missing or unusable
+func retryAfter(value string) time.Duration {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return 0
+	}
+	var wait time.Duration
+	if sec, err := strconv.Atoi(value); err == nil {
+		wait = time.Duration(sec) * time.Second
+	} else if at, err := http.ParseTime(value); err == nil {
+		wait = time.Until(at)
+	}
+	if wait <= 0 {
+		return 0
+	}
+	if wait > maxRetryAfter {
+		wait = maxRetryAfter
+	}
+	return wait
+}
+
+const (
+	// partSuffix marks a download that hasn't finished yet
+	partSuffix = ".part"
+	// partMaxAge is how long a partial download is resumed from; an older one may be
+	// of a file that has since been replaced on the server
+	partMaxAge = 24 * time.Hour
+	// partValidatorSuffix is appended to a .part to name the file holding the ETag or
+	// Last-Modified of the response it was started from
+	partValidatorSuffix = ".validator"
+)
+
+// partValidator returns what a resume of resp can be checked against with If-Range:
+// a strong ETag, or else Last-Modified. Weak ETags can't be used for ranges.
+func partValidator(resp *http.Response) string {
+	if etag := resp.Header.Get("ETag"); etag != "" && !strings.HasPrefix(etag, "W/") {
+		return etag
+	}
+	return resp.Header.Get("Last-Modified")
+}
+
+// errRangeNotSatisfiable is returned when the server can't serve the rest of a .part,
+// which then has to be downloaded again from the start
+var errRangeNotSatisfiable = errors.New("server cannot resume the partial download")
+
+// downloadPart downloads url into part, resuming from what part already holds with a
+// Range request. A transfer that breaks off is resumed up to httpRetryAttempts times;
+// the .part is left in place when it still fails, or ctx is cancelled, so a later
+// call picks up where this one stopped. Resumes send If-Range with the validator
+// saved next to the .part, so a file that changed on the server comes back whole.
+// Servers that answer a Range request with the whole file, and .part files older
+// than partMaxAge, start the download over.
+func downloadPart(ctx context.Context, url, part string) error {
+	validator := part + partValidatorSuffix
+	if info, err := os.Stat(part); err == nil && time.Since(info.ModTime()) > partMaxAge {
+		debugf("Discarding stale partial download %s", part)
+		_ = os.Remove(part)
+		_ = os.Remove(validator)
+	}
+
+	attempts := httpRetryAttempts()
+	for attempt := 1; ; attempt++ {
+		resumable, err := fetchPart(ctx, url, part)
+		if err == nil {
+			_ = os.Remove(validator)
+			return nil
+		}
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		if errors.Is(err, errRangeNotSatisfiable) {
+			_ = os.Remove(part)
+			_ = os.Remove(validator)
+			resumable = true
+		}
+		if !resumable || attempt >= attempts {
+			return err
+		}
+
+		wait := backoffDelay(httpRetryDelay(), attempt)
+		logf("%s", warnLine(fmt.Sprintf("Download of %s broke off (%v), resuming in %s (attempt %d/%d)",
+			redactURL(url), err, wait.Round(time.Millisecond), attempt+1, attempts)))
+		select {
+		case <-time.After(wait):
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+// fetchPart makes one request for the rest of part. resumable reports whether the
+// error happened in transit, so that sending the request again could get further.
+func fetchPart(ctx context.Context, url, part string) (resumable bool, err error) {
+	var offset int64
+	if info, err := os.Stat(part); err == nil {
+		offset = info.Size()
+	}
+
+	// A server that stops sending part way cancels the request, which leaves the
+	// transfer to be resumed like any other that broke off
+	timeout := downloadTimeout()
+	reqCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	var stalled atomic.Bool
+	stall := time.AfterFunc(timeout, func() {
+		stalled.Store(true)
+		cancel()
+	})
+	defer stall.Stop()
+
 	debugf("Initiating HTTP GET request to %s", url)
-	req, _ := http.NewRequest("GET", url, nil)
+	req, err := http.NewRequestWithContext(reqCtx, "GET", url, nil)
+	if err != nil {
+		return false, err
+	}
 	req.Header.Set("User-Agent", getUserAgent("General"))
 	req.Header.Set("Cache-Control", "no-cache")
 	req.Header.Set("Pragma", "no-cache")
+	validatorPath := part + partValidatorSuffix
+	if offset > 0 {
+		debugf("Resuming %s from byte %d", url, offset)
+		req.Header.Set("Range", fmt.Sprintf("bytes=%d-", offset))
+		if validator, err := os.ReadFile(validatorPath); err == nil && len(validator) > 0 {
+			req.Header.Set("If-Range", strings.TrimSpace(string(validator)))
+		}
+	}
 
 	debugf("Sending request with User-Agent: %s", getUserAgent("General"))
-	resp, err := http.DefaultClient.Do(req)
+	resp, err := downloadClient().Do(req)
 	if err != nil {
 		debugf("HTTP request failed for %s: %v", url, err)
-		return nil, err
+		if stalled.Load() && ctx.Err() == nil {
+			return true, fmt.Errorf("no response received for %s", timeout)
+		}
+		return retryableError(err), err
 	}
 	defer resp.Body.Close()
 
 	debugf("Received response: HTTP %d for %s", resp.StatusCode, url)
-	if resp.StatusCode != 200 {
+	flags := os.O_CREATE | os.O_WRONLY | os.O_TRUNC
+	switch {
+	case resp.StatusCode == http.StatusPartialContent && offset > 0 && contentRangeStart(resp.Header.Get("Content-Range")) == offset:
+		flags = os.O_CREATE | os.O_WRONLY | os.O_APPEND
+	case resp.StatusCode == http.StatusOK:
+		if offset > 0 {
+			debugf("Server ignored the Range request for %s or the file changed; downloading it again", url)
+		}
+		offset = 0
+		if validator := partValidator(resp); validator != "" {
+			if err := os.WriteFile(validatorPath, []byte(validator), 0644); err != nil {
+				debugf("Failed to save the download validator for %s: %v", url, err)
+			}
+		} else {
+			_ = os.Remove(validatorPath)
+		}
+	case resp.StatusCode == http.StatusPartialContent && offset > 0:
+		// The range doesn't continue the .part, so it is started over
+		debugf("Server sent Content-Range %q for %s, expected it to start at %d", resp.Header.Get("Content-Range"), url, offset)
+		return false, errRangeNotSatisfiable
+	case resp.StatusCode == http.StatusRequestedRangeNotSatisfiable && offset > 0:
+		return false, errRangeNotSatisfiable
+	default:
 		debugf("HTTP error details for %s - Status: %d, Content-Type: %s, Content-Length: %s",
 			url, resp.StatusCode, resp.Header.Get("Content-Type"), resp.Header.Get("Content-Length"))
-		return nil, fmt.Errorf("HTTP %d: %s", resp.StatusCode, url)
+		return resp.StatusCode >= 500, fmt.Errorf("HTTP %d: %s", resp.StatusCode, url)
 	}
 
-	// Get file info for progress
-	contentLength := resp.ContentLength
-	filename := filepath.Base(url)
-
-	// Create progress writer
-	pw := &progressWriter{
-		total:     contentLength,
-		filename:  filename,
-		startTime: time.Now(),
+	f, err := os.OpenFile(part, flags, 0644)
+	if err != nil {
+		return false, err
 	}
 
-	// If we don't know the content length, show indefinite progress
-	if contentLength <= 0 {
-		fmt.Fprintf(out, "Downloading %s...", filename)
-		return io.ReadAll(resp.Body)
+	filename := filepath.Base(url)
+	name := filename
+	if n, ok := ctx.Value(downloadNameKey{}).(string); ok && n != "" {
+		name = n
+	}
+	reporter, _ := ctx.Value(downloadReporterKey{}).(func(downloadProgress))
+	var total int64
+	if resp.ContentLength > 0 {
+		total = offset + resp.ContentLength
 	}
 
-	// Read with progress tracking
-	body, err := io.ReadAll(io.TeeReader(resp.Body, pw))
+	now := time.Now()
+	pr := &progressReader{
+		r:          &stallReader{r: resp.Body, timer: stall, timeout: timeout},
+		progress:   downloadProgress{Name: name, Total: total},
+		startTime:  now,
+		lastReport: now,
+		report: func(p downloadProgress) {
+			p.Downloaded += offset
+			fmt.Fprintf(out, "\r%s", p)
+			if reporter != nil {
+				reporter(p)
+			}
+		},
+	}
+	written, err := io.Copy(f, pr)
+	closeErr := f.Close()
+	if err == nil && resp.ContentLength >= 0 && written < resp.ContentLength {
+		err = io.ErrUnexpectedEOF
+	}
+	if err != nil && stalled.Load() && ctx.Err() == nil {
+		err = fmt.Errorf("no data received for %s", timeout)
+	}
 	if err != nil {
-		return nil, err
+		return true, err
+	}
+	if closeErr != nil {
+		return false, closeErr
 	}
 
 	// Show completion
-	fmt.Fprintf(out, "\nDownloaded %s (%.1f MB)\n", filename, float64(contentLength)/(1024*1024))
+	fmt.Fprintf(out, "\nDownloaded %s (%.1f MB)\n", filename, float64(offset+written)/(1024*1024))
+	return false, nil
+}
 
-	return body, nil
+// contentRangeStart returns the first byte of a "bytes first-last/size" Content-Range,
+// or -1 if it can't be parsed
+func contentRangeStart(value string) int64 {
+	rest, ok := strings.CutPrefix(value, "bytes ")
+	if !ok {
+		return -1
+	}
+	first, _, ok := strings.Cut(rest, "-")
+	if !ok {
+		return -1
+	}
+	n, err := strconv.ParseInt(strings.TrimSpace(first), 10, 64)
+	if err != nil {
+		return -1
+	}
+	return n
 }
 
 func unzipBytesTo(b []byte, dest string) error {
@@ -248,7 +885,10 @@ func unzipBytesTo(b []byte, dest string) error {
 	fileCount := 0
 	dirCount := 0
 	for _, f := range r.File {
-		p := filepath.Join(dest, f.Name)
+		p, err := archiveEntryPath(dest, f.Name)
+		if err != nil {
+			return err
+		}
 		debugf("Processing ZIP entry: %s (size: %d, compressed: %d)", f.Name, f.UncompressedSize64, f.CompressedSize64)
 
 		if f.FileInfo().IsDir() {
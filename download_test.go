@@ -0,0 +1,432 @@
+package main
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+// buildZip returns a zip archive holding the named entries
+func buildZip(t *testing.T, names ...string) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	w := zip.NewWriter(&buf)
+	for _, name := range names {
+		f, err := w.Create(name)
+		if err != nil {
+			t.Fatalf("Failed to add %s: %v", name, err)
+		}
+		f.Write([]byte("data"))
+	}
+	if err := w.Close(); err != nil {
+		t.Fatalf("Failed to finish zip: %v", err)
+	}
+	return buf.Bytes()
+}
+
+// TestValidateArchive tests that truncated downloads, error pages and zip slip entries are rejected
+func TestValidateArchive(t *testing.T) {
+	good := buildZip(t, "jdk/bin/java", "jdk/release")
+	if err := validateArchive(good); err != nil {
+		t.Errorf("Expected a valid zip to pass, got %v", err)
+	}
+
+	var tgz bytes.Buffer
+	gz := gzip.NewWriter(&tgz)
+	tw := tar.NewWriter(gz)
+	tw.WriteHeader(&tar.Header{Name: "PrismLauncher/prismlauncher", Mode: 0755, Size: 4, Typeflag: tar.TypeReg})
+	tw.Write([]byte("data"))
+	tw.Close()
+	gz.Close()
+	if err := validateArchive(tgz.Bytes()); err != nil {
+		t.Errorf("Expected a valid tar.gz to pass, got %v", err)
+	}
+
+	cases := map[string][]byte{
+		"truncated zip":    good[:len(good)/2],
+		"truncated tar.gz": tgz.Bytes()[:tgz.Len()/2],
+		"html error page":  []byte("\n<!DOCTYPE html><html><body>502 Bad Gateway</body></html>"),
+		"empty zip":        buildZip(t),
+		"zip slip":         buildZip(t, "jdk/bin/java", "../../evil.sh"),
+	}
+	for name, data := range cases {
+		if err := validateArchive(data); err == nil {
+			t.Errorf("%s: expected validation to fail", name)
+		}
+	}
+
+	dest := t.TempDir()
+	if err := unzipBytesTo(buildZip(t, "ok.txt", "../escaped.txt"), dest); err == nil {
+		t.Error("Expected extraction to refuse an entry outside the destination")
+	}
+	if exists(filepath.Join(filepath.Dir(dest), "escaped.txt")) {
+		os.Remove(filepath.Join(filepath.Dir(dest), "escaped.txt"))
+		t.Error("Entry was written outside the destination")
+	}
+}
+
+// TestDownloadToCancelled tests that cancelling a download returns context.Canceled and writes nothing
+func TestDownloadToCancelled(t *testing.T) {
+	started := make(chan struct{})
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Length", "1048576")
+		w.Write([]byte("partial"))
+		w.(http.Flusher).Flush()
+		close(started)
+		<-r.Context().Done()
+	}))
+	defer srv.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	go func() {
+		<-started
+		cancel()
+	}()
+
+	target := filepath.Join(t.TempDir(), "file.jar")
+	err := downloadTo(ctx, srv.URL, target, 0644)
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("downloadTo() error = %v, want context.Canceled", err)
+	}
+	if exists(target) {
+		t.Error("cancelled download left a file behind")
+	}
+}
+
+// TestDownloadProgress tests the speed and time left reported to a download's context
+func TestDownloadProgress(t *testing.T) {
+	const mb = 1024 * 1024
+	if got := (downloadProgress{Name: "Java 21", Downloaded: 100 * mb, Total: 604 * mb, BytesPerSec: 42 * mb}).String(); got != "Downloading Java 21 (42 MB/s, ~12s left)" {
+		t.Errorf("Unexpected progress with a known size: %q", got)
+	}
+	if got := (downloadProgress{Name: "Java 21", Downloaded: 120 * mb, BytesPerSec: 42 * mb}).String(); got != "Downloading Java 21 (120 MB)" {
+		t.Errorf("Expected only the bytes downloaded without a Content-Length, got %q", got)
+	}
+
+	payload := bytes.Repeat([]byte("x"), 64*1024)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Length", strconv.Itoa(len(payload)))
+		w.Write(payload[:len(payload)/2])
+		w.(http.Flusher).Flush()
+		time.Sleep(progressInterval + 100*time.Millisecond)
+		w.Write(payload[len(payload)/2:])
+	}))
+	defer srv.Close()
+
+	var reports []downloadProgress
+	ctx := withDownloadName(withDownloadReporter(context.Background(), func(p downloadProgress) {
+		reports = append(reports, p)
+	}), "Java 21")
+	part := filepath.Join(t.TempDir(), "jre.zip.part")
+	if err := downloadPart(ctx, srv.URL+"/jre.zip", part); err != nil {
+		t.Fatalf("downloadPart failed: %v", err)
+	}
+	if len(reports) == 0 {
+		t.Fatal("Expected a slow download to report its progress")
+	}
+	last := reports[len(reports)-1]
+	if last.Name != "Java 21" || last.Total != int64(len(payload)) || last.Downloaded <= 0 || last.BytesPerSec <= 0 {
+		t.Errorf("Unexpected progress report: %+v", last)
+	}
+}
+
+// TestDownloadResume tests that a .part file is resumed with a Range request, and
+// downloaded again when it is stale or the server sends the whole file
+func TestDownloadResume(t *testing.T) {
+	saved := settings
+	defer func() { settings = saved }()
+	settings.HTTPRetryDelayMs = 1
+
+	payload := bytes.Repeat([]byte("0123456789"), 10000)
+	modified := time.Now().Add(-time.Hour)
+	var ranges []string
+	honorRange, breakOff, stallOff, wrongRange := true, false, false, false
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ranges = append(ranges, r.Header.Get("Range"))
+		if wrongRange && r.Header.Get("Range") != "" {
+			// Answer with a range that doesn't continue the .part
+			wrongRange = false
+			w.Header().Set("Content-Range", fmt.Sprintf("bytes 0-99/%d", len(payload)))
+			w.WriteHeader(http.StatusPartialContent)
+			w.Write(payload[:100])
+			return
+		}
+		if stallOff {
+			// Send half the file and then nothing until the client gives up
+			stallOff = false
+			w.Header().Set("Content-Length", strconv.Itoa(len(payload)))
+			w.Write(payload[:len(payload)/2])
+			w.(http.Flusher).Flush()
+			<-r.Context().Done()
+			return
+		}
+		if breakOff {
+			// Send half the file and drop the connection
+			breakOff = false
+			w.Header().Set("Content-Length", strconv.Itoa(len(payload)))
+			w.Write(payload[:len(payload)/2])
+			w.(http.Flusher).Flush()
+			conn, _, _ := w.(http.Hijacker).Hijack()
+			conn.Close()
+			return
+		}
+		if !honorRange {
+			r.Header.Del("Range")
+		}
+		http.ServeContent(w, r, "jre.zip", modified, bytes.NewReader(payload))
+	}))
+	defer srv.Close()
+
+	dir := t.TempDir()
+	target := filepath.Join(dir, "jre.zip")
+	check := func(name string, wantRanges ...string) {
+		t.Helper()
+		got, err := os.ReadFile(target)
+		if err != nil || !bytes.Equal(got, payload) {
+			t.Errorf("%s: expected the complete file, got %d bytes, %v", name, len(got), err)
+		}
+		if exists(target + partSuffix) {
+			t.Errorf("%s: expected the .part file to be renamed", name)
+		}
+		if strings.Join(ranges, ",") != strings.Join(wantRanges, ",") {
+			t.Errorf("%s: expected Range headers %q, got %q", name, wantRanges, ranges)
+		}
+		ranges = nil
+		os.Remove(target)
+	}
+
+	// A .part left behind by an earlier launch is resumed
+	if err := os.WriteFile(target+partSuffix, payload[:40000], 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := downloadTo(context.Background(), srv.URL+"/jre.zip", target, 0644); err != nil {
+		t.Fatalf("downloadTo failed: %v", err)
+	}
+	check("resumed", "bytes=40000-")
+
+	// A .part whose file hasn't changed since is resumed, and one that has changed
+	// comes back whole instead of being appended to
+	for _, tc := range []struct {
+		name     string
+		modified time.Time
+	}{{"unchanged", modified}, {"changed", modified.Add(-time.Hour)}} {
+		os.WriteFile(target+partSuffix, payload[:40000], 0644)
+		os.WriteFile(target+partSuffix+partValidatorSuffix, []byte(tc.modified.UTC().Format(http.TimeFormat)), 0644)
+		if tc.name == "changed" {
+			// The old version's bytes differ from the new one
+			os.WriteFile(target+partSuffix, bytes.Repeat([]byte("x"), 40000), 0644)
+		}
+		if err := downloadTo(context.Background(), srv.URL+"/jre.zip", target, 0644); err != nil {
+			t.Fatalf("downloadTo failed: %v", err)
+		}
+		check(tc.name, "bytes=40000-")
+		if exists(target + partSuffix + partValidatorSuffix) {
+			t.Errorf("%s: expected the validator to be removed after the download", tc.name)
+		}
+	}
+
+	// A range that doesn't continue the .part starts the download over
+	wrongRange = true
+	os.WriteFile(target+partSuffix, payload[:40000], 0644)
+	if err := downloadTo(context.Background(), srv.URL+"/jre.zip", target, 0644); err != nil {
+		t.Fatalf("downloadTo failed: %v", err)
+	}
+	check("wrong range", "bytes=40000-", "")
+
+	// A transfer that breaks off is resumed from where it stopped
+	breakOff = true
+	if err := downloadTo(context.Background(), srv.URL+"/jre.zip", target, 0644); err != nil {
+		t.Fatalf("downloadTo failed: %v", err)
+	}
+	check("broke off", "", fmt.Sprintf("bytes=%d-", len(payload)/2))
+
+	// A transfer that stalls is given up after the download timeout and resumed
+	settings.DownloadTimeoutSec = minDownloadTimeoutSec
+	stallOff = true
+	if err := downloadTo(context.Background(), srv.URL+"/jre.zip", target, 0644); err != nil {
+		t.Fatalf("downloadTo failed: %v", err)
+	}
+	check("stalled", "", fmt.Sprintf("bytes=%d-", len(payload)/2))
+
+	// A server without Range support sends the whole file, which replaces the .part
+	honorRange = false
+	os.WriteFile(target+partSuffix, []byte("garbage"), 0644)
+	if err := downloadTo(context.Background(), srv.URL+"/jre.zip", target, 0644); err != nil {
+		t.Fatalf("downloadTo failed: %v", err)
+	}
+	check("no range support", "bytes=7-")
+	honorRange = true
+
+	// A stale .part is not resumed from
+	os.WriteFile(target+partSuffix, []byte("garbage"), 0644)
+	old := time.Now().Add(-2 * partMaxAge)
+	os.Chtimes(target+partSuffix, old, old)
+	if err := downloadTo(context.Background(), srv.URL+"/jre.zip", target, 0644); err != nil {
+		t.Fatalf("downloadTo failed: %v", err)
+	}
+	check("stale", "")
+
+	// A .part that already holds everything can't be resumed and is downloaded again
+	os.WriteFile(target+partSuffix, append(append([]byte(nil), payload...), "extra"...), 0644)
+	if err := downloadTo(context.Background(), srv.URL+"/jre.zip", target, 0644); err != nil {
+		t.Fatalf("downloadTo failed: %v", err)
+	}
+	check("too long", fmt.Sprintf("bytes=%d-", len(payload)+5), "")
+}
+
+// TestHTTPGetWithRetry tests that 5xx and 429 responses are retried until the server recovers
+func TestHTTPGetWithRetry(t *testing.T) {
+	saved := settings
+	defer func() { settings = saved }()
+	settings.HTTPRetryDelayMs = 1
+
+	statuses := []int{http.StatusServiceUnavailable, http.StatusTooManyRequests, http.StatusOK}
+	var calls int
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		status := statuses[calls]
+		calls++
+		if status == http.StatusTooManyRequests {
+			w.Header().Set("Retry-After", "0")
+		}
+		w.WriteHeader(status)
+		w.Write([]byte("body"))
+	}))
+	defer srv.Close()
+
+	resp, err := httpGetWithRetry(srv.URL, 3, time.Second)
+	if err != nil {
+		t.Fatalf("httpGetWithRetry() error = %v", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK || calls != 3 {
+		t.Errorf("got status %d after %d calls, want 200 after 3", resp.StatusCode, calls)
+	}
+	if body, _ := io.ReadAll(resp.Body); string(body) != "body" {
+		t.Errorf("body = %q, want %q", body, "body")
+	}
+
+	// Running out of attempts hands back the last failure; 4xx is never retried
+	calls = 0
+	statuses = []int{http.StatusBadGateway, http.StatusBadGateway}
+	resp, err = httpGetWithRetry(srv.URL, 2, time.Second)
+	if err != nil || resp.StatusCode != http.StatusBadGateway || calls != 2 {
+		t.Errorf("exhausted retries: status %v, err %v, calls %d", resp.StatusCode, err, calls)
+	}
+	resp.Body.Close()
+
+	calls = 0
+	statuses = []int{http.StatusNotFound}
+	resp, err = httpGetWithRetry(srv.URL, 3, time.Second)
+	if err != nil || resp.StatusCode != http.StatusNotFound || calls != 1 {
+		t.Errorf("404: status %v, err %v, calls %d", resp.StatusCode, err, calls)
+	}
+	resp.Body.Close()
+}
+
+// TestRetryAfter tests parsing Retry-After as seconds and as an HTTP date
+func TestRetryAfter(t *testing.T) {
+	if got := retryAfter("5"); got != 5*time.Second {
+		t.Errorf("retryAfter(5) = %s", got)
+	}
+	if got := retryAfter("3600"); got != maxRetryAfter {
+		t.Errorf("retryAfter(3600) = %s, want the %s cap", got, maxRetryAfter)
+	}
+	date := time.Now().Add(10 * time.Second).UTC().Format(http.TimeFormat)
+	if got := retryAfter(date); got <= 0 || got > 10*time.Second {
+		t.Errorf("retryAfter(%q) = %s", date, got)
+	}
+	for _, value := range []string{"", "soon", "-1"} {
+		if got := retryAfter(value); got != 0 {
+			t.Errorf("retryAfter(%q) = %s, want 0", value, got)
+		}
+	}
+}
+
+// TestVerifySHA256 tests checking a known blob against matching and mismatching digests
+func TestVerifySHA256(t *testing.T) {
+	blob := []byte("hello world")
+	const sum = "b94d27b9934d3e08a52e52d7da7dabfac484efe37a5380ee9088f7ace2efcde9"
+
+	if err := verifySHA256(blob, sum); err != nil {
+		t.Errorf("matching digest: %v", err)
+	}
+	if err := verifySHA256(blob, " "+strings.ToUpper(sum)+"\n"); err != nil {
+		t.Errorf("matching digest in upper case: %v", err)
+	}
+	if err := verifySHA256(blob, ""); err != nil {
+		t.Errorf("empty digest should skip the check, got %v", err)
+	}
+	err := verifySHA256(blob, strings.Repeat("0", 64))
+	if !errors.Is(err, errChecksumMismatch) || !strings.Contains(err.Error(), sum) {
+		t.Errorf("mismatching digest: got %v", err)
+	}
+}
+
+// TestDownloadAndUnzipChecksum tests that an archive not matching its published digest is never extracted
+func TestDownloadAndUnzipChecksum(t *testing.T) {
+	archive := buildZip(t, "jre/bin/java", "jre/release")
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write(archive)
+	}))
+	defer srv.Close()
+	sum := sha256.Sum256(archive)
+
+	dest := filepath.Join(t.TempDir(), "good")
+	if err := downloadAndUnzipTo(context.Background(), srv.URL+"/jre.zip", hex.EncodeToString(sum[:]), dest); err != nil {
+		t.Fatalf("matching digest: %v", err)
+	}
+	if !exists(filepath.Join(dest, "jre", "bin", "java")) {
+		t.Error("matching archive was not extracted")
+	}
+
+	dest = filepath.Join(t.TempDir(), "bad")
+	err := downloadAndUnzipTo(context.Background(), srv.URL+"/jre.zip", strings.Repeat("ab", 32), dest)
+	if !errors.Is(err, errChecksumMismatch) {
+		t.Fatalf("mismatching digest: got %v", err)
+	}
+	if exists(dest) {
+		t.Error("mismatching archive was extracted")
+	}
+}
+
+// TestChecksumManifest tests that a damaged runtime folder fails verification
+func TestChecksumManifest(t *testing.T) {
+	dir := t.TempDir()
+	if err := verifyChecksumManifest(dir); !errors.Is(err, os.ErrNotExist) {
+		t.Fatalf("folder without a manifest: got %v", err)
+	}
+
+	os.MkdirAll(filepath.Join(dir, "bin"), 0755)
+	os.WriteFile(filepath.Join(dir, "bin", "java"), []byte("java"), 0755)
+	os.WriteFile(filepath.Join(dir, "release"), []byte("JAVA_VERSION=21"), 0644)
+	if err := writeChecksumManifest(dir); err != nil {
+		t.Fatal(err)
+	}
+	if err := verifyChecksumManifest(dir); err != nil {
+		t.Fatalf("untouched folder: %v", err)
+	}
+
+	os.WriteFile(filepath.Join(dir, "bin", "java"), []byte("jav"), 0755)
+	if err := verifyChecksumManifest(dir); !errors.Is(err, errChecksumMismatch) {
+		t.Errorf("changed file: got %v", err)
+	}
+	os.Remove(filepath.Join(dir, "bin", "java"))
+	if err := verifyChecksumManifest(dir); !errors.Is(err, errChecksumMismatch) {
+		t.Errorf("missing file: got %v", err)
+	}
+}
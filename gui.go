@@ -1,32 +1,41 @@
 package main
 
 import (
-	"bytes"
+	"context"
 	"crypto/rand"
-	"crypto/tls"
 	"errors"
 	"fmt"
 	"image/color"
 	"io"
-	"mime/multipart"
-	"net/http"
+	"net/url"
 	"os"
 	"path/filepath"
 	"regexp"
+	"runtime"
+	"sort"
+	"strconv"
 	"strings"
 	"sync"
 	"time"
+	"unicode/utf8"
 
 	"fyne.io/fyne/v2"
 	"fyne.io/fyne/v2/app"
 	"fyne.io/fyne/v2/canvas"
 	"fyne.io/fyne/v2/container"
 	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/driver/desktop"
 	"fyne.io/fyne/v2/layout"
+	"fyne.io/fyne/v2/storage"
 	"fyne.io/fyne/v2/theme"
 	"fyne.io/fyne/v2/widget"
 )
 
+// channelDisplayName returns the translated name of an update channel
+func channelDisplayName(channel updateChannel) string {
+	return T("settings.channel." + string(channel))
+}
+
 // createInfoButton creates a styled info button with improved appearance
 func createInfoButton(title, content string, window fyne.Window) fyne.CanvasObject {
 	btn := widget.NewButtonWithIcon("", theme.InfoIcon(), func() {
@@ -50,40 +59,86 @@ type GUI struct {
 	filtered       []Modpack
 	searchQuery    string
 	activeCategory string
+	activeTags     map[string]bool // Lowercased tags the grid is narrowed to
+	matchAllTags   bool            // Require every active tag instead of any of them
+	sortMode       string
 	root           string
 	exePath        string
 	prismProcess   **os.Process
 
+	// Catalog preview (--preview-catalog): actions are disabled and nothing is installed
+	previewSource   string
+	previewWarnings []string
+
 	// UI elements we mutate
 	searchEntry   *widget.Entry
 	statusLabel   *widget.Label
+	stageIcon     *widget.Icon
 	progressBar   *widget.ProgressBar
 	consoleOutput *widget.Entry
 	tabs          *container.AppTabs
-	browseGrid    *fyne.Container
+	browseGrid    *widget.GridWrap
+	browseEmpty   fyne.CanvasObject
 	featuredGrid  *fyne.Container
 
 	// Log file monitoring
 	logWatcherActive   bool
 	logStopChan        chan struct{}
+	logPoke            chan struct{} // Wakes the log watcher early when streamed output arrives
 	logMutex           sync.RWMutex
 	logLastPosition    int64    // Track last read position for incremental reading
 	logFileHandle      *os.File // Keep file handle open for better performance
 	loadingOverlay     fyne.CanvasObject
 	loadingLabel       *widget.Label
 	memorySummaryLabel *widget.Label
+	accountLabel       *widget.Label
+	tagChips           *fyne.Container
+	// Console output waiting for the next coalesced update, and the lines shown so far
+	consolePending     strings.Builder
+	consoleFlushQueued bool
+	consoleMu          sync.Mutex
+	consoleLines       int
+	// Console search; only used on the UI goroutine. consoleMatchIndex is -1 while no
+	// match is selected.
+	consoleSearch     *widget.Entry
+	consoleMatchLabel *widget.Label
+	consoleMatches    []consoleMatch
+	consoleMatchIndex int
 
 	// Modpack status tracking
-	modpackStates    map[string]*ModpackState
-	cardBindings     map[string][]*modpackCardBinding
-	stateMu          sync.RWMutex
-	bindingsMu       sync.RWMutex
-	runningModpackID string
-	runningMu        sync.RWMutex
-	processMu        sync.Mutex
+	modpackStates map[string]*ModpackState
+	cardBindings  map[string][]*modpackCardBinding
+	stateMu       sync.RWMutex
+	bindingsMu    sync.RWMutex
+	// Operations in progress and games that were launched or reattached to, keyed by
+	// modpack ID; guarded by runningMu
+	operations map[string]*modpackOperation
+	runningMu  sync.RWMutex
+	processMu  sync.Mutex
 
 	// Process registry for reattachment
 	processRegistry *ProcessRegistry
+	registryBanner  *fyne.Container // Shown while the registry is unavailable
+	registryLabel   *widget.Label
+	registryRetry   *widget.Button
+
+	// Status-bar action that stops every running instance
+	stopAllBtn *widget.Button
+	// Sidebar action that checks for a launcher update, badged when one is available
+	updateBtn *widget.Button
+	// Status-bar action that cancels every install or update in progress
+	cancelBtn *widget.Button
+	// installQueue limits how many modpack operations run at once
+	installQueue *installQueue
+}
+
+// modpackOperation is one modpack's install, update, verify or launch, kept until it
+// returns so several can run at once without sharing a process or modpack ID
+type modpackOperation struct {
+	// process is the Prism process the operation launched, once it has one
+	process *os.Process
+	// cancel stops the install or update; nil once the game is up
+	cancel context.CancelFunc
 }
 
 // modernTheme tweaks the default Fyne look.
@@ -116,6 +171,10 @@ const (
 	ActionLaunch
 	ActionUpdate
 	ActionKill
+	// ActionQueued is waiting in the install queue; clicking it leaves the queue
+	ActionQueued
+	// ActionVerify is never a card's primary action; it runs from the Verify button
+	ActionVerify
 )
 
 type ModpackState struct {
@@ -135,6 +194,21 @@ type ModpackState struct {
 	ProcessID        string
 	ProcessStatus    ProcessStatus
 	ProcessStartTime time.Time
+	// When the modpack was last launched (persisted in settings)
+	LastPlayed time.Time
+	// Total time played and the start of the session in progress, if any
+	Playtime     time.Duration
+	SessionStart time.Time
+	// The pack's SupportedOS/SupportedArch exclude this machine
+	Unsupported bool
+	// Set when the last version check couldn't reach the pack server
+	RemoteError error
+	// Download size estimated from the pack index before installing
+	SizeEstimate packSizeEstimate
+	// Waiting in the install queue; Busy is only set once the operation starts
+	Queued bool
+	// The operation in progress can still be cancelled from the card
+	Cancellable bool
 }
 
 func (s *ModpackState) PrimaryAction() PrimaryAction {
@@ -147,6 +221,9 @@ func (s *ModpackState) PrimaryAction() PrimaryAction {
 	if s.Reattachable && s.ProcessID != "" && s.ProcessStatus == ProcessStatusRunning {
 		return ActionKill // Kill action for reattached processes
 	}
+	if s.Queued {
+		return ActionQueued
+	}
 	if s.Busy {
 		switch s.CurrentAction {
 		case ActionInstall, ActionUpdate, ActionLaunch:
@@ -159,6 +236,9 @@ func (s *ModpackState) PrimaryAction() PrimaryAction {
 		return ActionLaunch // Reattach action
 	}
 	if !s.Installed {
+		if s.Unsupported {
+			return ActionNone
+		}
 		return ActionInstall
 	}
 	if s.UpdateAvailable {
@@ -169,36 +249,44 @@ func (s *ModpackState) PrimaryAction() PrimaryAction {
 
 func (s *ModpackState) PrimaryLabel() string {
 	if s == nil {
-		return "Checking..."
+		return T("action.checking")
 	}
 	if s.Running {
-		return "Kill"
+		return T("action.kill")
 	}
 	if s.Reattachable && s.ProcessID != "" && s.ProcessStatus == ProcessStatusRunning {
-		return "Kill"
+		return T("action.kill")
+	}
+	if s.Queued {
+		return T("status.queued")
 	}
 	if s.Busy {
 		switch s.CurrentAction {
 		case ActionInstall:
-			return "Installing..."
+			return T("status.installing")
 		case ActionUpdate:
-			return "Updating..."
+			return T("status.updating")
 		case ActionLaunch:
-			return "Launching..."
+			return T("status.launching")
+		case ActionVerify:
+			return T("status.verifying")
 		default:
-			return "Working..."
+			return T("status.working")
 		}
 	}
 	if s.Reattachable && s.ProcessID != "" {
-		return "Reattach"
+		return T("action.reattach")
 	}
 	if !s.Installed {
-		return "Install"
+		if s.Unsupported {
+			return T("action.unsupported")
+		}
+		return T("action.install")
 	}
 	if s.UpdateAvailable {
-		return "Update"
+		return T("action.update")
 	}
-	return "Launch"
+	return T("action.launch")
 }
 
 func (s *ModpackState) PrimaryIcon() fyne.Resource {
@@ -208,10 +296,16 @@ func (s *ModpackState) PrimaryIcon() fyne.Resource {
 	if s.Running {
 		return theme.CancelIcon()
 	}
+	if s.Queued {
+		return theme.HistoryIcon()
+	}
 	if s.Busy {
 		return theme.ViewRefreshIcon()
 	}
 	if !s.Installed {
+		if s.Unsupported {
+			return theme.ErrorIcon()
+		}
 		return theme.DownloadIcon()
 	}
 	if s.UpdateAvailable {
@@ -222,65 +316,159 @@ func (s *ModpackState) PrimaryIcon() fyne.Resource {
 
 func (s *ModpackState) StatusSummary() string {
 	if s == nil {
-		return "Determining status..."
+		return T("status.determining")
 	}
 	if s.Error != nil {
-		return fmt.Sprintf("Status error: %v", s.Error)
+		return Tf("status.error", s.Error)
 	}
 	if s.Running {
 		if s.RunningPID > 0 {
-			return fmt.Sprintf("Running (PID %d)", s.RunningPID)
+			return Tf("status.runningPID", s.RunningPID)
 		}
-		return "Running"
+		return T("status.running")
 	}
 	if s.Reattachable && s.ProcessID != "" {
 		if s.ProcessStatus == ProcessStatusRunning {
-			return fmt.Sprintf("Running in background (PID %d)", s.RunningPID)
+			return Tf("status.background", s.RunningPID)
 		}
-		return fmt.Sprintf("Available for reattachment (%s)", s.ProcessStatus)
+		return Tf("status.reattachable", s.ProcessStatus)
+	}
+	if s.Queued {
+		return T("status.waitingInQueue")
 	}
 	if s.Busy {
 		switch s.CurrentAction {
 		case ActionInstall:
-			return "Installing..."
+			return T("status.installing")
 		case ActionUpdate:
-			return "Updating..."
+			return T("status.updating")
 		case ActionLaunch:
-			return "Launching..."
+			return T("status.launching")
+		case ActionVerify:
+			return T("status.verifying")
 		default:
-			return "Working..."
+			return T("status.working")
+		}
+	}
+	if s.RemoteError != nil && !s.Unsupported {
+		if s.Installed && s.LocalVersion != "" {
+			return Tf("status.unreachableInstalled", s.LocalVersion)
 		}
+		return T("status.unreachable")
 	}
 	if !s.Installed {
+		if s.Unsupported {
+			return T("status.unsupported")
+		}
 		if s.RemoteVersion != "" {
-			return fmt.Sprintf("Not installed (latest %s)", s.RemoteVersion)
+			return Tf("status.notInstalledLatest", s.RemoteVersion)
 		}
-		return "Not installed"
+		return T("status.notInstalled")
 	}
 	if s.UpdateAvailable && s.LocalVersion != "" && s.RemoteVersion != "" {
-		return fmt.Sprintf("Update available: %s -> %s", s.LocalVersion, s.RemoteVersion)
+		return Tf("status.updateAvailable", s.LocalVersion, s.RemoteVersion)
 	}
 	if s.LocalVersion != "" {
-		return fmt.Sprintf("Up to date (%s)", s.LocalVersion)
+		return Tf("status.upToDateVersion", s.LocalVersion)
 	}
-	return "Up to date"
+	return T("status.upToDate")
 }
 
 type modpackCardBinding struct {
 	modpack      Modpack
 	view         string
 	card         *widget.Card
+	title        *widget.Label
+	meta         *widget.Label
+	description  *widget.Label
+	ram          *widget.Label
+	ramBtn       *widget.Button
+	source       *widget.Label
+	tagGrid      *fyne.Container
+	noTags       *widget.Label
 	statusLabel  *widget.Label
+	retryBtn     *widget.Button
 	primaryBtn   *widget.Button
+	cancelBtn    *widget.Button
 	deleteBtn    *widget.Button
 	reinstallBtn *widget.Button
+	moreBtn      *widget.Button
+	favoriteBtn  *widget.Button
+	lastPlayed   *widget.Label
+	sizeLabel    *widget.Label
 }
 
+const (
+	// categoryRecent is the pseudo-category listing played modpacks by recency
+	categoryRecent = "recent"
+	// categoryFavorites is the pseudo-category listing the modpacks the user starred
+	categoryFavorites = "favorites"
+
+	sortCatalog = "Catalog order"
+	sortRecent  = "Recently played"
+	sortName    = "Name"
+)
+
+// Fyne's theme has no star, so the favorite toggle brings its own outline and filled icons
+var (
+	starIcon = theme.NewThemedResource(fyne.NewStaticResource("star.svg", []byte(
+		`<svg xmlns="http://www.w3.org/2000/svg" viewBox="0 0 24 24"><path fill="#000000" d="M22 9.24l-7.19-.62L12 2 9.19 8.63 2 9.24l5.46 4.73L5.82 21 12 17.27 18.18 21l-1.63-7.03L22 9.24zM12 15.4l-3.76 2.27 1-4.28-3.32-2.88 4.38-.38L12 6.1l1.71 4.04 4.38.38-3.32 2.88 1 4.28L12 15.4z"/></svg>`)))
+	starFilledIcon = theme.NewThemedResource(fyne.NewStaticResource("star-filled.svg", []byte(
+		`<svg xmlns="http://www.w3.org/2000/svg" viewBox="0 0 24 24"><path fill="#000000" d="M12 17.27L18.18 21l-1.64-7.03L22 9.24l-7.19-.61L12 2 9.19 8.63 2 9.24l5.46 4.73L5.82 21z"/></svg>`)))
+)
+
 const (
 	viewBrowse   = "browse"
 	viewFeatured = "featured"
 )
 
+// catalogSourceLabel shortens a catalog URL to its host for display
+func catalogSourceLabel(source string) string {
+	if u, err := url.Parse(source); err == nil && u.Host != "" {
+		return u.Host
+	}
+	return source
+}
+
+// modpackCardSize is the fixed cell every modpack card is laid out in
+var modpackCardSize = fyne.NewSize(340, 400)
+
+// showStartupError shows a standalone error window for problems found before the
+// main GUI is built, with a shortcut to the folder involved. It blocks until closed.
+func showStartupError(title, message, folder string) {
+	a := app.New()
+	w := a.NewWindow(fmt.Sprintf("%s - %s", launcherName, title))
+
+	heading := widget.NewLabelWithStyle(title, fyne.TextAlignLeading, fyne.TextStyle{Bold: true})
+	body := widget.NewLabel(message)
+	body.Wrapping = fyne.TextWrapWord
+
+	openBtn := widget.NewButtonWithIcon("Open folder", theme.FolderOpenIcon(), func() {
+		target := folder
+		for target != "" && !exists(target) && filepath.Dir(target) != target {
+			target = filepath.Dir(target)
+		}
+		if err := openPath(target); err != nil {
+			logf("%s", warnLine(fmt.Sprintf("Failed to open %s: %v", target, err)))
+		}
+	})
+	quitBtn := widget.NewButtonWithIcon("Quit", theme.CancelIcon(), func() {
+		w.Close()
+	})
+	quitBtn.Importance = widget.HighImportance
+
+	w.SetContent(container.NewPadded(container.NewBorder(
+		heading,
+		container.NewHBox(layout.NewSpacer(), openBtn, quitBtn),
+		nil,
+		nil,
+		body,
+	)))
+	w.Resize(fyne.NewSize(560, 300))
+	w.CenterOnScreen()
+	w.ShowAndRun()
+}
+
 // NewGUI spins up the modern application shell.
 func NewGUI(modpacks []Modpack, root string) *GUI {
 	a := app.New()
@@ -299,26 +487,10 @@ func NewGUI(modpacks []Modpack, root string) *GUI {
 		}
 	}
 
-	// Initialize process registry asynchronously to avoid blocking GUI
-	var processRegistry *ProcessRegistry
-	var initErr error
-
-	// Use a channel to signal completion
-	initDone := make(chan struct{})
-	go func() {
-		processRegistry, initErr = GetGlobalProcessRegistry(root)
-		close(initDone)
-	}()
-
-	// Wait for initialization with timeout to avoid blocking GUI forever
-	select {
-	case <-initDone:
-		if initErr != nil {
-			logf("Warning: Failed to initialize process registry: %v", initErr)
-		}
-	case <-time.After(5 * time.Second):
-		logf("Warning: Process registry initialization timed out, continuing without it")
-		processRegistry = nil
+	// Initialize process registry with a timeout to avoid blocking GUI
+	processRegistry, initErr := initProcessRegistry(root, 5*time.Second)
+	if initErr != nil {
+		logf("Warning: %v", initErr)
 	}
 
 	gui := &GUI{
@@ -330,6 +502,8 @@ func NewGUI(modpacks []Modpack, root string) *GUI {
 		modpackStates:   make(map[string]*ModpackState),
 		cardBindings:    make(map[string][]*modpackCardBinding),
 		processRegistry: processRegistry,
+		logPoke:         make(chan struct{}, 1),
+		installQueue:    newInstallQueue(installConcurrency),
 	}
 
 	return gui
@@ -338,8 +512,23 @@ func NewGUI(modpacks []Modpack, root string) *GUI {
 // Show renders and runs the window loop.
 func (g *GUI) Show() {
 	g.buildUI()
+	if g.previewSource != "" {
+		g.window.SetTitle(fmt.Sprintf("%s %s - Catalog preview", launcherName, version))
+		g.updateStatus(fmt.Sprintf("Previewing %s - install and launch are disabled", g.previewSource))
+		g.showPreviewWarnings()
+		g.window.ShowAndRun()
+		return
+	}
 	g.startUpdateCheck()
 
+	if !settings.SetupComplete {
+		g.showSetupWizard()
+	}
+
+	if settings.Prefetch && !offlineMode() {
+		go g.prefetchDefaultModpack()
+	}
+
 	// Validate existing processes asynchronously to avoid blocking GUI
 	if g.processRegistry != nil {
 		go func() {
@@ -347,8 +536,18 @@ func (g *GUI) Show() {
 		}()
 	}
 
+	// Pack zips and pack.toml links dropped onto the window are added as custom packs
+	g.window.SetOnDropped(g.handleDrop)
+
+	// Once the window is up this version counts as working, so a later update may
+	// replace the executable kept for rollback with it
+	g.app.Lifecycle().SetOnStarted(func() {
+		markLauncherLaunched(g.root)
+	})
+
 	// Set up window close callback to clean up resources
 	g.window.SetCloseIntercept(func() {
+		g.saveViewState()
 		g.cleanup()
 		g.window.Close()
 	})
@@ -356,6 +555,36 @@ func (g *GUI) Show() {
 	g.window.ShowAndRun()
 }
 
+// showPreviewWarnings lists the catalog problems found by --preview-catalog
+func (g *GUI) showPreviewWarnings() {
+	if len(g.previewWarnings) == 0 {
+		g.updateStatus(fmt.Sprintf("Previewing %s - no catalog problems found", g.previewSource))
+		return
+	}
+	text := widget.NewLabel("• " + strings.Join(g.previewWarnings, "\n• "))
+	text.Wrapping = fyne.TextWrapWord
+	scroll := container.NewVScroll(text)
+	scroll.SetMinSize(fyne.NewSize(560, 280))
+	dialog.ShowCustom(fmt.Sprintf("Catalog warnings (%d)", len(g.previewWarnings)), "Close", scroll, g.window)
+}
+
+// prefetchDefaultModpack downloads Prism and the Java runtime for the default modpack
+// in the background when it is not installed yet. Failures are only logged; the
+// normal install retries everything it needs.
+func (g *GUI) prefetchDefaultModpack() {
+	mod, ok := g.findModpack(defaultModpackID)
+	if !ok || g.isModpackInstalled(mod) {
+		return
+	}
+
+	logf("%s", infoLine(fmt.Sprintf("Prefetching runtime for %s in the background", mod.DisplayName)))
+	if err := prefetchRuntime(g.root, mod); err != nil {
+		logf("%s", warnLine(fmt.Sprintf("Background prefetch failed: %v", err)))
+		return
+	}
+	debugf("Background prefetch for %s finished", mod.DisplayName)
+}
+
 // validateExistingProcesses validates existing processes in the registry and updates modpack states
 func (g *GUI) validateExistingProcesses() {
 	if g.processRegistry == nil {
@@ -380,6 +609,7 @@ func (g *GUI) validateExistingProcesses() {
 			state.Running = true
 			state.RunningPID = process.PID
 		})
+		g.trackReattachedSession(process.ModpackID, process.PID, process.StartTime)
 	}
 }
 
@@ -387,12 +617,12 @@ func (g *GUI) validateExistingProcesses() {
 func (g *GUI) cleanup() {
 	g.stopLogFileWatcher()
 
-	// TEMPORARILY DISABLED: Clean up expired process records
-	// if g.processRegistry != nil {
-	// 	if err := g.processRegistry.CleanupExpiredRecords(24 * time.Hour); err != nil {
-	// 		logf("Warning: Failed to cleanup expired process records: %v", err)
-	// 	}
-	// }
+	// Clean up expired process records
+	if g.processRegistry != nil {
+		if err := g.processRegistry.CleanupExpiredRecords(24 * time.Hour); err != nil {
+			logf("Warning: Failed to cleanup expired process records: %v", err)
+		}
+	}
 }
 
 func (g *GUI) launchWithCallback(prismProcess **os.Process, root, exePath string) {
@@ -420,24 +650,76 @@ func (g *GUI) buildUI() {
 	g.loadingOverlay = overlay
 	root := container.NewStack(body, overlay)
 	g.window.SetContent(root)
+	g.restoreViewState()
 	g.refreshAllModpackStates()
 }
 
+// saveViewState remembers the selected tab and category for the next start
+func (g *GUI) saveViewState() {
+	if g.tabs != nil {
+		settings.LastTab = g.tabs.SelectedIndex()
+	}
+	settings.LastCategory = g.activeCategory
+	if err := saveSettings(g.root); err != nil {
+		logf("%s", warnLine(fmt.Sprintf("Failed to save the selected tab and category: %v", err)))
+	}
+}
+
+// restoreViewState selects the tab and category saved by saveViewState. A category
+// that no longer matches any modpack falls back to showing all of them.
+func (g *GUI) restoreViewState() {
+	if g.tabs != nil && settings.LastTab > 0 && settings.LastTab < len(g.tabs.Items) {
+		g.tabs.SelectIndex(settings.LastTab)
+	}
+	if settings.LastCategory == "" {
+		return
+	}
+	if !categoryHasModpacks(g.modpacks, settings.LastCategory) {
+		debugf("Saved category %q matches no modpacks; showing all", settings.LastCategory)
+		return
+	}
+	g.activeCategory = settings.LastCategory
+	g.applyFilters()
+}
+
+// categoryHasModpacks reports whether the sidebar category would show any of mods
+func categoryHasModpacks(mods []Modpack, category string) bool {
+	for _, mod := range mods {
+		if category == categoryRecent {
+			if !lastPlayedAt(mod.ID).IsZero() {
+				return true
+			}
+		} else if category == "" || modMatchesCategory(mod, category) {
+			return true
+		}
+	}
+	return false
+}
+
 func (g *GUI) buildHeader() fyne.CanvasObject {
 	title := widget.NewLabelWithStyle(launcherName, fyne.TextAlignLeading, fyne.TextStyle{Bold: true})
 	titleBox := container.NewVBox(title)
 
 	g.searchEntry = widget.NewEntry()
-	g.searchEntry.SetPlaceHolder("Search modpacks...")
+	g.searchEntry.SetPlaceHolder(T("header.search"))
 	g.searchEntry.OnChanged = func(q string) {
 		g.searchQuery = strings.TrimSpace(q)
 		g.applyFilters()
 	}
 
 	searchWrap := container.New(layout.NewGridWrapLayout(fyne.NewSize(360, 40)), g.searchEntry)
+
+	sortSelect := widget.NewSelect([]string{sortCatalog, sortRecent, sortName}, func(mode string) {
+		g.sortMode = mode
+		g.applyFilters()
+	})
+	sortSelect.SetSelected(sortCatalog)
+	sortWrap := container.New(layout.NewGridWrapLayout(fyne.NewSize(180, 40)), sortSelect)
+
 	headerRow := container.NewHBox(
 		titleBox,
 		layout.NewSpacer(),
+		sortWrap,
 		searchWrap,
 	)
 
@@ -445,20 +727,42 @@ func (g *GUI) buildHeader() fyne.CanvasObject {
 }
 
 func (g *GUI) buildSidebar() fyne.CanvasObject {
-	refreshBtn := widget.NewButtonWithIcon("Refresh", theme.ViewRefreshIcon(), func() {
+	refreshBtn := widget.NewButtonWithIcon(T("action.refresh"), theme.ViewRefreshIcon(), func() {
 		g.refreshModpacks()
 	})
-	settingsBtn := widget.NewButtonWithIcon("Settings", theme.SettingsIcon(), func() {
+	settingsBtn := widget.NewButtonWithIcon(T("action.settings"), theme.SettingsIcon(), func() {
 		g.showSettings()
 	})
-	consoleBtn := widget.NewButtonWithIcon("Console", theme.ComputerIcon(), func() {
+	consoleBtn := widget.NewButtonWithIcon(T("action.console"), theme.ComputerIcon(), func() {
 		g.showConsole()
 	})
+	addPackBtn := widget.NewButtonWithIcon(T("action.addCustomPack"), theme.ContentAddIcon(), func() {
+		g.showAddCustomPack()
+	})
+	storageBtn := widget.NewButtonWithIcon(T("action.storage"), theme.StorageIcon(), func() {
+		g.showStorage()
+	})
+	g.updateBtn = widget.NewButtonWithIcon(T("action.checkUpdates"), theme.DownloadIcon(), func() {
+		g.checkForUpdates()
+	})
+	if selfUpdateDisabled() || g.exePath == "" {
+		g.updateBtn.Disable()
+	}
+	// Enabled by updateUIForState while any game is running or tracked in the registry
+	g.stopAllBtn = widget.NewButtonWithIcon(T("action.stopAll"), theme.MediaStopIcon(), func() {
+		g.confirmStopAll()
+	})
+	g.stopAllBtn.Importance = widget.DangerImportance
+	g.stopAllBtn.Disable()
 
-	quickActions := widget.NewCard("Actions", "", container.NewVBox(
+	quickActions := widget.NewCard(T("sidebar.actions"), "", container.NewVBox(
 		refreshBtn,
 		settingsBtn,
 		consoleBtn,
+		addPackBtn,
+		storageBtn,
+		g.updateBtn,
+		g.stopAllBtn,
 	))
 
 	categoryButtons := []fyne.CanvasObject{}
@@ -466,11 +770,14 @@ func (g *GUI) buildSidebar() fyne.CanvasObject {
 		label string
 		value string
 	}{
-		{"All", ""},
-		{"Featured", "featured"},
-		{"Performance", "performance"},
-		{"Visuals", "visuals"},
-		{"Adventure", "adventure"},
+		{T("category.all"), ""},
+		{T("category.recent"), categoryRecent},
+		{T("category.favorites"), categoryFavorites},
+		{T("category.featured"), "featured"},
+		{T("category.performance"), "performance"},
+		{T("category.visuals"), "visuals"},
+		{T("category.adventure"), "adventure"},
+		{T("category.custom"), customPackSource},
 	} {
 		value := cat.value
 		btn := widget.NewButton(cat.label, func() {
@@ -479,18 +786,30 @@ func (g *GUI) buildSidebar() fyne.CanvasObject {
 		categoryButtons = append(categoryButtons, btn)
 	}
 
-	categories := widget.NewCard("Categories", "", container.NewVBox(categoryButtons...))
+	categories := widget.NewCard(T("sidebar.categories"), "", container.NewVBox(categoryButtons...))
+
+	g.tagChips = container.NewGridWrap(fyne.NewSize(110, 36))
+	g.populateTagChips()
+	matchAll := widget.NewCheck(T("sidebar.matchAllTags"), func(on bool) {
+		g.matchAllTags = on
+		g.applyFilters()
+	})
+	matchAll.SetChecked(g.matchAllTags)
+	tags := widget.NewCard(T("sidebar.tags"), "", container.NewVBox(g.tagChips, matchAll))
 
 	g.memorySummaryLabel = widget.NewLabel("")
 	g.updateMemorySummaryLabel()
-	info := widget.NewCard("Status", "", container.NewVBox(
+	g.accountLabel = widget.NewLabel("")
+	g.updateAccountLabel()
+	info := widget.NewCard(T("sidebar.status"), "", container.NewVBox(
 		g.memorySummaryLabel,
-		widget.NewLabel(fmt.Sprintf("Signed in as: %s", getCurrentUser())),
+		g.accountLabel,
 	))
 
 	content := container.NewVBox(
 		quickActions,
 		categories,
+		tags,
 		info,
 		layout.NewSpacer(),
 	)
@@ -501,18 +820,13 @@ func (g *GUI) buildSidebar() fyne.CanvasObject {
 }
 
 func (g *GUI) buildContent() fyne.CanvasObject {
-	g.browseGrid = container.New(layout.NewGridWrapLayout(fyne.NewSize(340, 400)))
-	g.featuredGrid = container.New(layout.NewGridWrapLayout(fyne.NewSize(340, 400)))
+	g.browseGrid = g.buildBrowseGrid()
+	g.browseEmpty = container.NewVBox(widget.NewCard("", "", widget.NewLabel(T("browse.empty"))))
+	g.featuredGrid = container.New(layout.NewGridWrapLayout(modpackCardSize))
 	g.populateBrowseGrid()
 	g.populateFeaturedGrid()
 
-	browse := container.NewBorder(
-		nil,
-		nil,
-		nil,
-		nil,
-		container.NewVBox(g.browseGrid),
-	)
+	browse := container.NewStack(g.browseGrid, g.browseEmpty)
 
 	featured := container.NewBorder(
 		nil,
@@ -525,9 +839,9 @@ func (g *GUI) buildContent() fyne.CanvasObject {
 	console := g.buildConsoleView()
 
 	g.tabs = container.NewAppTabs(
-		container.NewTabItem("Browse", container.NewVScroll(browse)),
-		container.NewTabItem("Featured", container.NewVScroll(featured)),
-		container.NewTabItem("Console", console),
+		container.NewTabItem(T("tab.browse"), browse),
+		container.NewTabItem(T("tab.featured"), container.NewVScroll(featured)),
+		container.NewTabItem(T("tab.console"), console),
 	)
 	g.tabs.SetTabLocation(container.TabLocationTop)
 	return g.tabs
@@ -539,7 +853,7 @@ func (g *GUI) buildConsoleView() fyne.CanvasObject {
 	g.consoleOutput.SetText("Waiting for log file content...")
 
 	clearBtn := widget.NewButtonWithIcon("Clear", theme.ContentClearIcon(), func() {
-		g.consoleOutput.SetText("")
+		g.setConsoleText("")
 	})
 	copyBtn := widget.NewButtonWithIcon("Copy All", theme.ContentCopyIcon(), func() {
 		g.window.Clipboard().SetContent(g.consoleOutput.Text)
@@ -547,28 +861,480 @@ func (g *GUI) buildConsoleView() fyne.CanvasObject {
 	uploadBtn := widget.NewButtonWithIcon("Upload logs", theme.UploadIcon(), func() {
 		g.uploadLog()
 	})
+	openFolderBtn := widget.NewButtonWithIcon("Open logs folder", theme.FolderOpenIcon(), func() {
+		g.openInFileManager(filepath.Join(g.root, "logs"))
+	})
+	// Older sessions are shown as a snapshot; only latest.log is followed live
+	logDir := filepath.Join(g.root, "logs")
+	logSelect := widget.NewSelect(consoleLogFiles(logDir), nil)
+	logSelect.SetSelected("latest.log")
+	logSelect.OnChanged = func(name string) {
+		g.stopLogFileWatcher()
+		if name == "latest.log" {
+			g.setConsoleText("")
+			g.startLogFileWatcher()
+			return
+		}
+		data, err := os.ReadFile(filepath.Join(logDir, name))
+		if err != nil {
+			g.setConsoleText(fmt.Sprintf("Failed to read %s: %v", name, err))
+			return
+		}
+		g.setConsoleText(string(data))
+	}
+
+	openLogBtn := widget.NewButtonWithIcon("Open log file", theme.FileTextIcon(), func() {
+		g.openInFileManager(filepath.Join(logDir, logSelect.Selected))
+	})
 
-	toolbar := container.NewHBox(clearBtn, copyBtn, uploadBtn, layout.NewSpacer())
+	actions := []fyne.CanvasObject{clearBtn, copyBtn, uploadBtn}
+	if settings.DebugEnabled {
+		// Reproduces the upload request by hand when a paste server rejects it
+		actions = append(actions, widget.NewButtonWithIcon("Copy upload as curl", theme.ComputerIcon(), func() {
+			uploader, ok := configuredLogUploader().(dylanLogUploader)
+			if !ok {
+				g.updateStatus("Copy upload as curl only supports the i.dylan.lol provider")
+				return
+			}
+			copyPath, err := writeUploadCopy(filepath.Join(logDir, "latest.log"))
+			if err != nil {
+				g.updateStatus(fmt.Sprintf("Failed to prepare the log for upload: %v", err))
+				return
+			}
+			command := logUploadCurlCommand(uploader.baseURL(), copyPath, "latest.log")
+			logf("%s", infoLine("Equivalent upload request: "+command))
+			g.window.Clipboard().SetContent(command)
+			g.updateStatus("Upload command copied to clipboard")
+		}))
+	}
+	toolbar := container.NewHBox(append(actions, layout.NewSpacer(), logSelect, openFolderBtn, openLogBtn)...)
+
+	g.consoleMatchIndex = -1
+	g.consoleMatchLabel = widget.NewLabel("")
+	g.consoleSearch = widget.NewEntry()
+	g.consoleSearch.SetPlaceHolder("Search the log...")
+	g.consoleSearch.OnChanged = func(query string) {
+		g.refreshConsoleMatches(nil)
+		if query == "" {
+			// Drop the highlight and follow new output again
+			g.consoleOutput.TypedKey(&fyne.KeyEvent{Name: fyne.KeyEnd})
+			g.consoleOutput.CursorRow = g.consoleLines
+			g.consoleOutput.Refresh()
+			return
+		}
+		g.moveConsoleMatch(1)
+	}
+	g.consoleSearch.OnSubmitted = func(string) {
+		g.moveConsoleMatch(1)
+	}
+	prevBtn := widget.NewButtonWithIcon("", theme.MoveUpIcon(), func() {
+		g.moveConsoleMatch(-1)
+	})
+	nextBtn := widget.NewButtonWithIcon("", theme.MoveDownIcon(), func() {
+		g.moveConsoleMatch(1)
+	})
+	searchRow := container.NewBorder(nil, nil, nil, container.NewHBox(g.consoleMatchLabel, prevBtn, nextBtn), g.consoleSearch)
 
 	// Start log file monitoring when console view is created
 	g.startLogFileWatcher()
 
-	return container.NewBorder(toolbar, nil, nil, nil, g.consoleOutput)
+	return container.NewBorder(container.NewVBox(toolbar, searchRow), nil, nil, nil, g.consoleOutput)
+}
+
+// consoleMatch is where a search hit starts in the console, as the row and rune
+// column that Entry.CursorRow and CursorColumn use
+type consoleMatch struct {
+	Row, Col int
+}
+
+// findConsoleMatches returns every case-insensitive occurrence of query in text
+func findConsoleMatches(text, query string) []consoleMatch {
+	query = strings.ToLower(query)
+	if query == "" {
+		return nil
+	}
+	var matches []consoleMatch
+	for row, line := range strings.Split(text, "\n") {
+		// ToLower maps rune for rune, so rune columns in lower match the original line
+		lower := strings.ToLower(line)
+		for offset := 0; ; {
+			i := strings.Index(lower[offset:], query)
+			if i < 0 {
+				break
+			}
+			pos := offset + i
+			matches = append(matches, consoleMatch{Row: row, Col: utf8.RuneCountInString(lower[:pos])})
+			offset = pos + len(query)
+		}
+	}
+	return matches
+}
+
+// consoleMatchCount describes the selected match as "3 of 17"; index is -1 when none
+// is selected
+func consoleMatchCount(index, total int) string {
+	switch {
+	case total == 0:
+		return "No matches"
+	case index < 0:
+		return fmt.Sprintf("%d matches", total)
+	}
+	return fmt.Sprintf("%d of %d", index+1, total)
+}
+
+// selectedConsoleMatch returns the selected match, or nil when there is none
+func (g *GUI) selectedConsoleMatch() *consoleMatch {
+	if g.consoleMatchIndex < 0 || g.consoleMatchIndex >= len(g.consoleMatches) {
+		return nil
+	}
+	m := g.consoleMatches[g.consoleMatchIndex]
+	return &m
+}
+
+// refreshConsoleMatches searches the console again after its text or the query
+// changed. keep stays selected if it is still a match; otherwise nothing is.
+func (g *GUI) refreshConsoleMatches(keep *consoleMatch) {
+	if g.consoleSearch == nil || g.consoleOutput == nil {
+		return
+	}
+	g.consoleMatches = findConsoleMatches(g.consoleOutput.Text, g.consoleSearch.Text)
+	g.consoleMatchIndex = -1
+	if keep != nil {
+		for i, m := range g.consoleMatches {
+			if m == *keep {
+				g.consoleMatchIndex = i
+				break
+			}
+		}
+	}
+	if g.consoleSearch.Text == "" {
+		g.consoleMatchLabel.SetText("")
+	} else {
+		g.consoleMatchLabel.SetText(consoleMatchCount(g.consoleMatchIndex, len(g.consoleMatches)))
+	}
+}
+
+// moveConsoleMatch selects the next (step 1) or previous (step -1) match, wrapping
+// around at either end
+func (g *GUI) moveConsoleMatch(step int) {
+	if len(g.consoleMatches) == 0 {
+		return
+	}
+	i := g.consoleMatchIndex + step
+	if g.consoleMatchIndex < 0 && step < 0 {
+		i = len(g.consoleMatches) - 1
+	}
+	g.showConsoleMatch((i + len(g.consoleMatches)) % len(g.consoleMatches))
+}
+
+// showConsoleMatch moves the console cursor to match i and highlights it
+func (g *GUI) showConsoleMatch(i int) {
+	g.consoleMatchIndex = i
+	m := g.consoleMatches[i]
+	e := g.consoleOutput
+
+	// Entry can't select a range directly, so the match is selected the way holding
+	// shift and pressing the arrow keys would. Home first drops the old selection.
+	e.KeyUp(&fyne.KeyEvent{Name: desktop.KeyShiftLeft})
+	e.TypedKey(&fyne.KeyEvent{Name: fyne.KeyHome})
+	e.CursorRow, e.CursorColumn = m.Row, m.Col
+	e.Refresh()
+	e.KeyDown(&fyne.KeyEvent{Name: desktop.KeyShiftLeft})
+	for range utf8.RuneCountInString(g.consoleSearch.Text) {
+		e.TypedKey(&fyne.KeyEvent{Name: fyne.KeyRight})
+	}
+	e.KeyUp(&fyne.KeyEvent{Name: desktop.KeyShiftLeft})
+
+	g.consoleMatchLabel.SetText(consoleMatchCount(i, len(g.consoleMatches)))
+}
+
+// openInFileManager opens a file or folder with the OS default application
+func (g *GUI) openInFileManager(path string) {
+	if !exists(path) {
+		g.updateStatus(fmt.Sprintf("%s does not exist yet", filepath.Base(path)))
+		return
+	}
+	if err := openPath(path); err != nil {
+		logf("%s", warnLine(fmt.Sprintf("Failed to open %s: %v", path, err)))
+		dialog.ShowError(fmt.Errorf("Failed to open %s: %v", path, err), g.window)
+	}
+}
+
+// showLaunchFailure explains a failed launch using the issues found in Prism's output,
+// with shortcuts to upload the log or open the console. fallback is set when Prism's
+// window was opened after the direct launch failed.
+func (g *GUI) showLaunchFailure(mod Modpack, err error, issues []string, fallback bool) {
+	fyne.Do(func() {
+		message := fmt.Sprintf("%s could not be started.\n\n%v", mod.DisplayName, err)
+		if fallback {
+			message = fmt.Sprintf("%s could not be launched directly, so Prism's window was opened instead.\n\n%v", mod.DisplayName, err)
+		}
+		summary := widget.NewLabel(message)
+		summary.Wrapping = fyne.TextWrapWord
+
+		details := container.NewVBox()
+		if len(issues) == 0 {
+			hint := widget.NewLabel("No specific cause was recognized. Uploading the log and sharing the link is the quickest way to get help.")
+			hint.Wrapping = fyne.TextWrapWord
+			details.Add(hint)
+		}
+		for _, issue := range issues {
+			details.Add(widget.NewLabelWithStyle(issue, fyne.TextAlignLeading, fyne.TextStyle{Bold: true}))
+			for _, solution := range issueSolutions(issue) {
+				line := widget.NewLabel("• " + solution)
+				line.Wrapping = fyne.TextWrapWord
+				details.Add(line)
+			}
+		}
+		detailsScroll := container.NewVScroll(details)
+		detailsScroll.SetMinSize(fyne.NewSize(520, 200))
+
+		var d dialog.Dialog
+		uploadBtn := widget.NewButtonWithIcon("Upload log", theme.UploadIcon(), func() {
+			d.Hide()
+			g.uploadLog()
+		})
+		uploadBtn.Importance = widget.HighImportance
+		consoleBtn := widget.NewButtonWithIcon("Open console", theme.ComputerIcon(), func() {
+			d.Hide()
+			g.showConsole()
+		})
+		reportBtn := widget.NewButtonWithIcon(T("action.reportIssue"), theme.MailComposeIcon(), func() {
+			d.Hide()
+			g.reportIssue(fmt.Sprintf("%s: %v", mod.DisplayName, err))
+		})
+		actions := container.NewHBox(layout.NewSpacer(), reportBtn, consoleBtn, uploadBtn)
+		if runtime.GOOS == "linux" && hasQtIssue(issues) {
+			repairBtn := widget.NewButtonWithIcon(T("settings.repairQt"), theme.ViewRefreshIcon(), func() {
+				d.Hide()
+				g.repairQt()
+			})
+			actions.Objects = append([]fyne.CanvasObject{repairBtn}, actions.Objects...)
+		}
+
+		content := container.NewBorder(
+			summary,
+			actions,
+			nil,
+			nil,
+			detailsScroll,
+		)
+		d = dialog.NewCustom("Launch Failed", "Close", content, g.window)
+		d.Resize(fyne.NewSize(620, 460))
+		d.Show()
+	})
+}
+
+// promptManualDownloads lists every mod that must be downloaded by hand in a single
+// dialog and blocks until the user confirms they are saved or skips them.
+func (g *GUI) promptManualDownloads(items []manualItem) bool {
+	result := make(chan bool, 1)
+
+	fyne.Do(func() {
+		header := widget.NewLabel(fmt.Sprintf("%d file(s) can't be downloaded automatically. Open each download page, save the file into the folder shown, then choose \"I've saved them\".", len(items)))
+		header.Wrapping = fyne.TextWrapWord
+
+		rows := container.NewVBox()
+		var folders []string
+		seenFolder := make(map[string]bool)
+		for _, it := range items {
+			name := it.Name
+			if name == "" {
+				name = filepath.Base(it.Path)
+			}
+			var link fyne.CanvasObject = widget.NewLabel(name)
+			if u, err := url.Parse(it.URL); err == nil {
+				link = widget.NewHyperlink(name, u)
+			}
+			rows.Add(container.NewBorder(nil, nil, link, nil, widget.NewLabel(filepath.Base(it.Path))))
+
+			if dir := filepath.Dir(it.Path); !seenFolder[dir] {
+				seenFolder[dir] = true
+				folders = append(folders, dir)
+			}
+		}
+		list := container.NewVScroll(rows)
+		list.SetMinSize(fyne.NewSize(520, 200))
+
+		folderRows := container.NewVBox()
+		for _, dir := range folders {
+			dir := dir
+			pathLabel := widget.NewLabel(dir)
+			pathLabel.Wrapping = fyne.TextWrapBreak
+			openBtn := widget.NewButtonWithIcon("Open", theme.FolderOpenIcon(), func() {
+				g.openInFileManager(dir)
+			})
+			folderRows.Add(container.NewBorder(nil, nil, nil, openBtn, pathLabel))
+		}
+
+		openPagesBtn := widget.NewButtonWithIcon("Open all download pages", theme.DownloadIcon(), func() {
+			for _, it := range items {
+				if err := openPath(it.URL); err != nil {
+					logf("%s", warnLine(fmt.Sprintf("Failed to open %s: %v", it.URL, err)))
+				}
+			}
+		})
+
+		content := container.NewVBox(
+			header,
+			list,
+			widget.NewLabelWithStyle("Save to:", fyne.TextAlignLeading, fyne.TextStyle{Bold: true}),
+			folderRows,
+			openPagesBtn,
+		)
+
+		d := dialog.NewCustomConfirm("Manual Downloads Required", "I've saved them", "Skip", content, func(ok bool) {
+			result <- ok
+		}, g.window)
+		d.Resize(fyne.NewSize(620, 480))
+		d.Show()
+	})
+
+	return <-result
+}
+
+// promptRetryDownloads lists the prerequisite downloads that failed and blocks until
+// the user chooses to retry them or cancel. Downloads that finished are kept either way.
+func (g *GUI) promptRetryDownloads(failed []prerequisiteFailure) bool {
+	result := make(chan bool, 1)
+
+	fyne.Do(func() {
+		header := widget.NewLabel(fmt.Sprintf("%d download(s) failed. Everything else finished and won't be downloaded again.", len(failed)))
+		header.Wrapping = fyne.TextWrapWord
+
+		rows := container.NewVBox()
+		for _, f := range failed {
+			errLabel := widget.NewLabel(f.Err.Error())
+			errLabel.Wrapping = fyne.TextWrapWord
+			rows.Add(widget.NewLabelWithStyle(f.Stage.Label(), fyne.TextAlignLeading, fyne.TextStyle{Bold: true}))
+			rows.Add(errLabel)
+		}
+		list := container.NewVScroll(rows)
+		list.SetMinSize(fyne.NewSize(480, 160))
+
+		d := dialog.NewCustomConfirm("Downloads Failed", "Retry failed", "Cancel", container.NewVBox(header, list), func(ok bool) {
+			result <- ok
+		}, g.window)
+		d.Resize(fyne.NewSize(560, 360))
+		d.Show()
+	})
+
+	return <-result
+}
+
+// promptLowDiskSpace warns that a volume looks too full for an install whose size is
+// only partly known and blocks until the user chooses to install anyway or cancel
+func (g *GUI) promptLowDiskSpace(short diskSpaceShortage) bool {
+	result := make(chan bool, 1)
+
+	fyne.Do(func() {
+		message := fmt.Sprintf("%s has %s free, but this install needs about %s including some room to spare.\n\n"+
+			"Part of the modpack couldn't be sized, so it may still fit. Free up some space first, or install anyway?",
+			short.Path, formatSize(short.Free), formatSize(short.Bytes+diskSpaceMargin))
+		d := dialog.NewConfirm("Low Disk Space", message, func(ok bool) {
+			result <- ok
+		}, g.window)
+		d.SetConfirmText("Install anyway")
+		d.Show()
+	})
+
+	return <-result
 }
 
 func (g *GUI) buildStatusBar() fyne.CanvasObject {
-	g.statusLabel = widget.NewLabel("Launcher ready")
+	g.statusLabel = widget.NewLabel(T("status.ready"))
+	g.stageIcon = widget.NewIcon(nil)
+	g.stageIcon.Hide()
 	g.progressBar = widget.NewProgressBar()
 	g.progressBar.Hide()
 
+	g.cancelBtn = widget.NewButtonWithIcon(T("action.cancel"), theme.CancelIcon(), func() {
+		g.cancelAllOperations()
+	})
+	g.cancelBtn.Hide()
+
 	bar := container.NewBorder(
 		nil,
 		nil,
-		g.statusLabel,
-		container.NewHBox(layout.NewSpacer(), g.progressBar),
+		container.NewHBox(g.stageIcon, g.statusLabel),
+		container.NewHBox(layout.NewSpacer(), g.progressBar, g.cancelBtn),
+	)
+
+	// Warning shown when the process registry could not be opened
+	g.registryLabel = widget.NewLabel(T("registry.unavailable"))
+	g.registryLabel.Wrapping = fyne.TextWrapWord
+	g.registryRetry = widget.NewButtonWithIcon(T("action.retry"), theme.ViewRefreshIcon(), func() {
+		g.retryProcessRegistry()
+	})
+	dismissBtn := widget.NewButtonWithIcon("", theme.CancelIcon(), func() {
+		g.registryBanner.Hide()
+	})
+	dismissBtn.Importance = widget.LowImportance
+	g.registryBanner = container.NewBorder(
+		nil,
+		nil,
+		widget.NewIcon(theme.WarningIcon()),
+		container.NewHBox(g.registryRetry, dismissBtn),
+		g.registryLabel,
 	)
+	if g.processRegistry != nil {
+		g.registryBanner.Hide()
+	}
+
+	return container.NewVBox(widget.NewSeparator(), container.NewPadded(g.registryBanner), container.NewPadded(bar))
+}
 
-	return container.NewVBox(widget.NewSeparator(), container.NewPadded(bar))
+// initProcessRegistry opens the process registry, giving up after timeout so a slow
+// disk can't block the GUI. A timed-out open keeps running and is reused by the next call.
+func initProcessRegistry(root string, timeout time.Duration) (*ProcessRegistry, error) {
+	type result struct {
+		registry *ProcessRegistry
+		err      error
+	}
+	done := make(chan result, 1)
+	go func() {
+		registry, err := GetGlobalProcessRegistry(root)
+		done <- result{registry, err}
+	}()
+
+	select {
+	case r := <-done:
+		if r.err != nil {
+			return nil, fmt.Errorf("failed to initialize process registry: %w", r.err)
+		}
+		return r.registry, nil
+	case <-time.After(timeout):
+		return nil, fmt.Errorf("process registry initialization timed out after %s, continuing without it", timeout)
+	}
+}
+
+// retryProcessRegistry tries to open the process registry again in the background
+func (g *GUI) retryProcessRegistry() {
+	g.registryRetry.Disable()
+	g.registryLabel.SetText("Retrying process tracking...")
+
+	go func() {
+		registry, err := initProcessRegistry(g.root, 30*time.Second)
+		if err != nil {
+			logf("%s", warnLine(fmt.Sprintf("Process registry still unavailable: %v", err)))
+			fyne.Do(func() {
+				g.registryLabel.SetText(fmt.Sprintf("Process tracking is still unavailable: %v", err))
+				g.registryRetry.Enable()
+			})
+			return
+		}
+
+		logf("%s", successLine("Process registry initialized"))
+		// Published on the UI thread, which reads it without a lock; waiting here
+		// lets validateExistingProcesses below see it
+		fyne.DoAndWait(func() {
+			g.processRegistry = registry
+			g.registryBanner.Hide()
+			g.registryRetry.Enable()
+		})
+		g.updateStatus("Process tracking restored")
+		g.validateExistingProcesses()
+	}()
 }
 
 func (g *GUI) buildLoadingOverlay() fyne.CanvasObject {
@@ -588,15 +1354,45 @@ func (g *GUI) buildLoadingOverlay() fyne.CanvasObject {
 	return overlay
 }
 
+// buildBrowseGrid creates the virtualized browse grid. Only the cards in view exist;
+// scrolling hands a card to another modpack and moves its state binding with it, so
+// large catalogs don't build a card per pack.
+func (g *GUI) buildBrowseGrid() *widget.GridWrap {
+	grid := widget.NewGridWrap(
+		func() int { return len(g.filtered) },
+		func() fyne.CanvasObject {
+			binding := g.newModpackCard(viewBrowse)
+			return &browseCardItem{
+				Container: container.New(layout.NewGridWrapLayout(modpackCardSize), binding.card),
+				binding:   binding,
+			}
+		},
+		func(id widget.GridWrapItemID, obj fyne.CanvasObject) {
+			item, ok := obj.(*browseCardItem)
+			if !ok || id < 0 || id >= len(g.filtered) {
+				return
+			}
+			g.bindModpackCard(item.binding, g.filtered[id])
+		},
+	)
+	// Cards are not selectable; clear the highlight a click on empty card space leaves
+	grid.OnSelected = func(widget.GridWrapItemID) { grid.UnselectAll() }
+	return grid
+}
+
+// browseCardItem is one recycled cell of the browse grid
+type browseCardItem struct {
+	*fyne.Container
+	binding *modpackCardBinding
+}
+
 func (g *GUI) populateBrowseGrid() {
-	g.clearBindings(viewBrowse)
-	g.browseGrid.Objects = g.browseGrid.Objects[:0]
 	if len(g.filtered) == 0 {
-		g.browseGrid.Add(widget.NewCard("", "", widget.NewLabel("No modpacks match your filters yet.")))
+		g.browseGrid.Hide()
+		g.browseEmpty.Show()
 	} else {
-		for _, mod := range g.filtered {
-			g.browseGrid.Add(g.modpackCard(mod, viewBrowse))
-		}
+		g.browseEmpty.Hide()
+		g.browseGrid.Show()
 	}
 	g.browseGrid.Refresh()
 }
@@ -607,84 +1403,212 @@ func (g *GUI) populateFeaturedGrid() {
 
 	for _, mod := range g.modpacks {
 		if mod.Default || strings.EqualFold(mod.Category, "featured") {
-			g.featuredGrid.Add(g.modpackCard(mod, viewFeatured))
+			binding := g.newModpackCard(viewFeatured)
+			g.bindModpackCard(binding, mod)
+			g.featuredGrid.Add(binding.card)
 		}
 	}
 
 	if len(g.featuredGrid.Objects) == 0 {
-		g.featuredGrid.Add(widget.NewCard("", "", widget.NewLabel("No featured modpacks yet.")))
+		g.featuredGrid.Add(widget.NewCard("", "", widget.NewLabel(T("featured.empty"))))
 	}
 
 	g.featuredGrid.Refresh()
 }
 
-func (g *GUI) modpackCard(mod Modpack, view string) fyne.CanvasObject {
-	title := widget.NewLabelWithStyle(mod.DisplayName, fyne.TextAlignLeading, fyne.TextStyle{Bold: true})
-	meta := widget.NewLabel(fmt.Sprintf("by %s - %s", mod.Author, mod.LastUpdated))
-	meta.Wrapping = fyne.TextWrapWord
+// newModpackCard builds a card that isn't showing any modpack yet. Its buttons act on
+// whichever modpack the binding holds when clicked, so the card can be rebound.
+func (g *GUI) newModpackCard(view string) *modpackCardBinding {
+	binding := &modpackCardBinding{view: view}
 
-	description := widget.NewLabel(mod.Description)
-	description.Wrapping = fyne.TextWrapWord
+	binding.title = widget.NewLabelWithStyle("", fyne.TextAlignLeading, fyne.TextStyle{Bold: true})
+	binding.favoriteBtn = widget.NewButtonWithIcon("", starIcon, func() {
+		g.toggleFavorite(binding.modpack)
+	})
+	binding.favoriteBtn.Importance = widget.LowImportance
+	binding.meta = widget.NewLabel("")
+	binding.meta.Wrapping = fyne.TextWrapWord
 
-	ram := widget.NewLabel(fmt.Sprintf("Minimum RAM: %d GB - Recommended: %d GB", mod.MinRam/1024, mod.RecommendedRam/1024))
+	binding.description = widget.NewLabel("")
+	binding.description.Wrapping = fyne.TextWrapWord
 
-	tagObjects := make([]fyne.CanvasObject, 0, len(mod.Tags))
-	for _, tag := range mod.Tags {
-		if tag == "" {
-			continue
-		}
-		tagLabel := widget.NewLabel(fmt.Sprintf("#%s", strings.ToLower(tag)))
-		tagLabel.Alignment = fyne.TextAlignCenter
-		tagObjects = append(tagObjects, tagLabel)
-	}
-	tagLayout := container.New(layout.NewGridWrapLayout(fyne.NewSize(90, 24)), tagObjects...)
-	if len(tagObjects) == 0 {
-		tagLayout = container.NewHBox(widget.NewLabel("No tags yet"))
-	}
-
-	primaryBtn := widget.NewButtonWithIcon("Launch", theme.MediaPlayIcon(), func() {
-		g.handlePrimaryAction(mod)
+	binding.ram = widget.NewLabel("")
+	binding.ram.Wrapping = fyne.TextWrapWord
+	binding.ramBtn = widget.NewButtonWithIcon("RAM", theme.SettingsIcon(), func() {
+		g.showMemoryOverride(binding.modpack)
 	})
-	primaryBtn.Importance = widget.HighImportance
-
-	deleteBtn := widget.NewButtonWithIcon("Delete", theme.DeleteIcon(), func() {
-		g.deleteModpack(mod)
+	binding.ramBtn.Importance = widget.LowImportance
+	binding.source = widget.NewLabel("")
+	binding.source.Truncation = fyne.TextTruncateEllipsis
+	binding.source.Hide()
+	binding.sizeLabel = widget.NewLabel("")
+	binding.sizeLabel.Hide()
+
+	binding.tagGrid = container.New(layout.NewGridWrapLayout(fyne.NewSize(90, 24)))
+	binding.noTags = widget.NewLabel(T("card.noTags"))
+
+	binding.primaryBtn = widget.NewButtonWithIcon(T("action.launch"), theme.MediaPlayIcon(), func() {
+		g.handlePrimaryAction(binding.modpack)
 	})
-	reinstallBtn := widget.NewButtonWithIcon("Reinstall", theme.ViewRefreshIcon(), func() {
-		g.reinstallModpack(mod)
+	binding.primaryBtn.Importance = widget.HighImportance
+	binding.cancelBtn = widget.NewButtonWithIcon(T("action.cancel"), theme.CancelIcon(), func() {
+		g.cancelOperation(binding.modpack)
 	})
+	binding.cancelBtn.Hide()
 
-	statusLabel := widget.NewLabel("Checking status...")
-	statusLabel.Wrapping = fyne.TextWrapWord
-
-	buttonRow := container.NewHBox(primaryBtn, layout.NewSpacer())
-	secondaryRow := container.NewHBox(deleteBtn, reinstallBtn)
+	binding.deleteBtn = widget.NewButtonWithIcon(T("action.delete"), theme.DeleteIcon(), func() {
+		g.deleteModpack(binding.modpack)
+	})
+	binding.reinstallBtn = widget.NewButtonWithIcon(T("action.reinstall"), theme.ViewRefreshIcon(), func() {
+		g.reinstallModpack(binding.modpack)
+	})
+	binding.moreBtn = widget.NewButtonWithIcon("", theme.MoreHorizontalIcon(), nil)
+	binding.moreBtn.OnTapped = func() {
+		menu := widget.NewPopUpMenu(g.modpackMenu(binding.modpack), g.window.Canvas())
+		menu.ShowAtRelativePosition(fyne.NewPos(0, binding.moreBtn.Size().Height), binding.moreBtn)
+	}
 
-	card := widget.NewCard("", "", container.NewVBox(
-		title,
-		meta,
-		description,
-		tagLayout,
-		ram,
-		statusLabel,
+	binding.statusLabel = widget.NewLabel(T("status.checking"))
+	binding.statusLabel.Wrapping = fyne.TextWrapWord
+	binding.retryBtn = widget.NewButtonWithIcon("", theme.ViewRefreshIcon(), func() {
+		mod := binding.modpack
+		g.updateStatus(fmt.Sprintf("Checking %s again...", mod.DisplayName))
+		go g.refreshModpackState(mod)
+	})
+	binding.retryBtn.Importance = widget.LowImportance
+	binding.retryBtn.Hide()
+
+	binding.lastPlayed = widget.NewLabel("")
+
+	buttonRow := container.NewHBox(binding.primaryBtn, binding.cancelBtn, layout.NewSpacer())
+	secondaryRow := container.NewHBox(binding.deleteBtn, binding.reinstallBtn, layout.NewSpacer(), binding.moreBtn)
+
+	binding.card = widget.NewCard("", "", container.NewVBox(
+		container.NewBorder(nil, nil, nil, binding.favoriteBtn, binding.title),
+		binding.meta,
+		binding.description,
+		binding.tagGrid,
+		binding.noTags,
+		container.NewBorder(nil, nil, nil, binding.ramBtn, binding.ram),
+		binding.sizeLabel,
+		binding.source,
+		binding.lastPlayed,
+		container.NewBorder(nil, nil, nil, binding.retryBtn, binding.statusLabel),
 		buttonRow,
 		secondaryRow,
 	))
 
-	card.SetSubTitle(" ")
+	binding.card.SetSubTitle(" ")
+	return binding
+}
+
+// modpackMenu builds the card's "…" menu, enabling each action for the pack's current state.
+func (g *GUI) modpackMenu(mod Modpack) *fyne.Menu {
+	state := g.getModpackState(mod.ID)
+	preview := g.previewSource != ""
+	canModify := state != nil && state.Installed && !state.Busy && !state.Queued && !state.Running && !preview
+	item := func(label string, icon fyne.Resource, enabled bool, action func()) *fyne.MenuItem {
+		it := fyne.NewMenuItem(label, action)
+		it.Icon = icon
+		it.Disabled = !enabled
+		return it
+	}
+	return fyne.NewMenu("",
+		item(T("action.rename"), theme.DocumentCreateIcon(), state != nil && !state.Busy && !state.Running && !preview, func() {
+			g.renameModpack(mod.ID)
+		}),
+		item(T("action.resync"), theme.DownloadIcon(), canModify, func() {
+			g.forceResyncModpack(mod)
+		}),
+		item(T("action.verify"), theme.ConfirmIcon(), canModify && !offlineMode(), func() {
+			g.verifyModpack(mod)
+		}),
+		item(T("action.restoreBackup"), theme.HistoryIcon(), canModify, func() {
+			g.showRestoreBackup(mod)
+		}),
+		// Reading the mods is safe while the game runs, but not while packwiz rewrites them
+		item(T("action.modList"), theme.ListIcon(), state != nil && state.Installed && !state.Busy && !preview, func() {
+			g.showModList(mod)
+		}),
+		item(T("action.launchCommand"), theme.ComputerIcon(), canModify, func() {
+			g.showLaunchCommand(mod)
+		}),
+		item(T("action.openInPrism"), theme.VisibilityIcon(), canModify, func() {
+			g.openInPrism(mod)
+		}),
+		// Resource packs, screenshots and options.txt are worth reaching while the game runs
+		item(T("action.openFolder"), theme.FolderOpenIcon(), state != nil && state.Installed && !preview, func() {
+			g.openInFileManager(filepath.Join(g.modpackInstanceDir(mod), "minecraft"))
+		}),
+	)
+}
+
+// bindModpackCard shows mod on the card and moves the card's state binding to it.
+// Rebinding to the pack it already shows only refreshes its state.
+func (g *GUI) bindModpackCard(binding *modpackCardBinding, mod Modpack) {
+	g.bindingsMu.Lock()
+	if binding.modpack.ID != "" {
+		list := g.cardBindings[binding.modpack.ID]
+		for i, b := range list {
+			if b == binding {
+				list = append(list[:i], list[i+1:]...)
+				break
+			}
+		}
+		if len(list) == 0 {
+			delete(g.cardBindings, binding.modpack.ID)
+		} else {
+			g.cardBindings[binding.modpack.ID] = list
+		}
+	}
+	binding.modpack = mod
+	g.cardBindings[mod.ID] = append(g.cardBindings[mod.ID], binding)
+	g.bindingsMu.Unlock()
+
+	binding.title.SetText(mod.DisplayName)
+	setFavoriteIcon(binding.favoriteBtn, isFavorite(mod.ID))
+	binding.meta.SetText(Tf("card.meta", mod.Author, mod.LastUpdated))
+	binding.description.SetText(mod.Description)
+	ramText := Tf("card.ram", mod.MinRam/1024, mod.RecommendedRam/1024)
+	if mod.OverrideMemoryMB > 0 {
+		ramText += " - " + Tf("card.ramOverride", clampMemoryMB(mod.OverrideMemoryMB)/1024)
+	}
+	binding.ram.SetText(ramText)
+	if mod.SizeBytes > 0 {
+		binding.sizeLabel.SetText(Tf("card.size", formatSize(mod.SizeBytes)))
+		binding.sizeLabel.Show()
+	} else {
+		binding.sizeLabel.Hide()
+	}
+	binding.lastPlayed.SetText(formatLastPlayed(lastPlayedAt(mod.ID), time.Now()))
+	// Only packs from the user's own catalogs say where they came from
+	if source := catalogSourceOf(mod); source != remoteModpacksURL {
+		binding.source.SetText(Tf("card.source", catalogSourceLabel(source)))
+		binding.source.Show()
+	} else {
+		binding.source.Hide()
+	}
 
-	binding := &modpackCardBinding{
-		modpack:      mod,
-		view:         view,
-		card:         card,
-		statusLabel:  statusLabel,
-		primaryBtn:   primaryBtn,
-		deleteBtn:    deleteBtn,
-		reinstallBtn: reinstallBtn,
+	tagObjects := make([]fyne.CanvasObject, 0, len(mod.Tags))
+	for _, tag := range mod.Tags {
+		if tag == "" {
+			continue
+		}
+		tagLabel := widget.NewLabel(fmt.Sprintf("#%s", strings.ToLower(tag)))
+		tagLabel.Alignment = fyne.TextAlignCenter
+		tagObjects = append(tagObjects, tagLabel)
+	}
+	binding.tagGrid.Objects = tagObjects
+	binding.tagGrid.Refresh()
+	if len(tagObjects) == 0 {
+		binding.tagGrid.Hide()
+		binding.noTags.Show()
+	} else {
+		binding.noTags.Hide()
+		binding.tagGrid.Show()
 	}
-	g.registerCardBinding(binding)
 
-	return card
+	g.applyStateToBinding(binding)
 }
 
 func (g *GUI) applyFilters() {
@@ -692,15 +1616,31 @@ func (g *GUI) applyFilters() {
 
 	g.filtered = g.filtered[:0]
 	for _, mod := range g.modpacks {
-		if g.activeCategory != "" && !modMatchesCategory(mod, g.activeCategory) {
+		if g.activeCategory == categoryRecent {
+			if lastPlayedAt(mod.ID).IsZero() {
+				continue
+			}
+		} else if g.activeCategory != "" && !modMatchesCategory(mod, g.activeCategory) {
 			continue
 		}
 		if query != "" && !modMatchesQuery(mod, query) {
 			continue
 		}
+		if !modMatchesTags(mod, g.activeTags, g.matchAllTags) {
+			continue
+		}
 		g.filtered = append(g.filtered, mod)
 	}
 
+	switch {
+	case g.sortMode == sortRecent || g.activeCategory == categoryRecent:
+		sortByLastPlayed(g.filtered)
+	case g.sortMode == sortName:
+		sort.SliceStable(g.filtered, func(i, j int) bool {
+			return strings.ToLower(g.filtered[i].DisplayName) < strings.ToLower(g.filtered[j].DisplayName)
+		})
+	}
+
 	g.populateBrowseGrid()
 }
 
@@ -723,13 +1663,6 @@ func (g *GUI) clearBindings(view string) {
 	}
 }
 
-func (g *GUI) registerCardBinding(binding *modpackCardBinding) {
-	g.bindingsMu.Lock()
-	g.cardBindings[binding.modpack.ID] = append(g.cardBindings[binding.modpack.ID], binding)
-	g.bindingsMu.Unlock()
-	g.applyStateToBinding(binding)
-}
-
 func (g *GUI) applyStateToBinding(binding *modpackCardBinding) {
 	state := g.getModpackState(binding.modpack.ID)
 	fyne.Do(func() {
@@ -746,25 +1679,43 @@ func (g *GUI) updateBindingUI(binding *modpackCardBinding, state *ModpackState)
 		binding.card.SetSubTitle("")
 	}
 
-	summary := "Checking status..."
+	summary := T("status.checking")
 	if state != nil {
 		summary = state.StatusSummary()
 	}
 	if binding.statusLabel != nil {
 		binding.statusLabel.SetText(summary)
 	}
+	if binding.retryBtn != nil {
+		if state != nil && state.RemoteError != nil && !state.Busy {
+			binding.retryBtn.Show()
+		} else {
+			binding.retryBtn.Hide()
+		}
+	}
+	if binding.lastPlayed != nil && state != nil {
+		played := formatLastPlayed(state.LastPlayed, time.Now())
+		if state.Playtime >= time.Minute {
+			played += " - " + formatPlaytime(state.Playtime)
+		}
+		binding.lastPlayed.SetText(played)
+	}
+	if binding.sizeLabel != nil && state != nil && binding.modpack.SizeBytes == 0 && state.SizeEstimate.Bytes > 0 {
+		binding.sizeLabel.SetText(Tf("card.sizeEstimated", formatSize(state.SizeEstimate.Bytes)))
+		binding.sizeLabel.Show()
+	}
 
 	if binding.primaryBtn != nil {
 		if state != nil {
 			binding.primaryBtn.SetText(state.PrimaryLabel())
 			binding.primaryBtn.SetIcon(state.PrimaryIcon())
 		} else {
-			binding.primaryBtn.SetText("Checking...")
+			binding.primaryBtn.SetText(T("action.checking"))
 			binding.primaryBtn.SetIcon(theme.ViewRefreshIcon())
 		}
 
 		enabled := true
-		if state == nil {
+		if state == nil || g.previewSource != "" {
 			enabled = false
 		} else if state.Busy && !state.Running {
 			enabled = false
@@ -779,9 +1730,19 @@ func (g *GUI) updateBindingUI(binding *modpackCardBinding, state *ModpackState)
 		}
 	}
 
-	canModify := state != nil && state.Installed && !state.Busy && !state.Running
+	if binding.cancelBtn != nil {
+		if state != nil && state.Cancellable {
+			binding.cancelBtn.Show()
+		} else {
+			binding.cancelBtn.Hide()
+		}
+	}
+
+	canModify := state != nil && state.Installed && !state.Busy && !state.Queued && !state.Running && g.previewSource == ""
 	if binding.deleteBtn != nil {
-		if canModify {
+		// Custom packs can be removed from the list whether or not they are installed
+		canRemove := isCustomModpack(binding.modpack) && state != nil && !state.Busy && !state.Queued && !state.Running && g.previewSource == ""
+		if canModify || canRemove {
 			binding.deleteBtn.Enable()
 		} else {
 			binding.deleteBtn.Disable()
@@ -794,9 +1755,70 @@ func (g *GUI) updateBindingUI(binding *modpackCardBinding, state *ModpackState)
 			binding.reinstallBtn.Disable()
 		}
 	}
+	if binding.moreBtn != nil {
+		// Each menu item checks the pack's state again when the menu opens
+		if state != nil && g.previewSource == "" {
+			binding.moreBtn.Enable()
+		} else {
+			binding.moreBtn.Disable()
+		}
+	}
+	if binding.ramBtn != nil {
+		if state != nil && !state.Busy && g.previewSource == "" {
+			binding.ramBtn.Enable()
+		} else {
+			binding.ramBtn.Disable()
+		}
+	}
+}
+
+// formatLastPlayed describes a last played time relative to now
+func formatLastPlayed(at, now time.Time) string {
+	if at.IsZero() {
+		return T("played.never")
+	}
+	elapsed := now.Sub(at)
+	switch {
+	case elapsed < time.Minute:
+		return T("played.justNow")
+	case elapsed < time.Hour:
+		return Tf("played.minutes", int(elapsed.Minutes()))
+	case elapsed < 24*time.Hour:
+		return Tf("played.hours", int(elapsed.Hours()))
+	case elapsed < 48*time.Hour:
+		return T("played.yesterday")
+	case elapsed < 30*24*time.Hour:
+		return Tf("played.days", int(elapsed.Hours()/24))
+	}
+	return Tf("played.date", at.Format("Jan 2, 2006"))
+}
+
+// formatPlaytime renders a total playtime like "Played 12h 30m"
+func formatPlaytime(d time.Duration) string {
+	hours := int(d.Hours())
+	minutes := int(d.Minutes()) % 60
+	if hours == 0 {
+		return Tf("played.totalMinutes", minutes)
+	}
+	return Tf("played.total", hours, minutes)
+}
+
+// formatSize renders a byte count like "1.4 GB" or "350 MB"
+func formatSize(bytes int64) string {
+	const mb = 1024 * 1024
+	switch {
+	case bytes >= 1024*mb:
+		return fmt.Sprintf("%.1f GB", float64(bytes)/(1024*mb))
+	case bytes >= mb:
+		return fmt.Sprintf("%d MB", bytes/mb)
+	}
+	return fmt.Sprintf("%d KB", (bytes+1023)/1024)
 }
 
 func modMatchesCategory(mod Modpack, category string) bool {
+	if category == categoryFavorites {
+		return isFavorite(mod.ID)
+	}
 	if strings.EqualFold(mod.Category, category) {
 		return true
 	}
@@ -808,15 +1830,44 @@ func modMatchesCategory(mod Modpack, category string) bool {
 	return false
 }
 
-func modMatchesQuery(mod Modpack, query string) bool {
-	if strings.Contains(strings.ToLower(mod.DisplayName), query) {
+// modMatchesTags reports whether the modpack carries any (or, with matchAll, every)
+// of the active tags. No active tags matches every modpack.
+func modMatchesTags(mod Modpack, active map[string]bool, matchAll bool) bool {
+	if len(active) == 0 {
 		return true
 	}
-	if strings.Contains(strings.ToLower(mod.Description), query) {
-		return true
+	found := 0
+	for tag := range active {
+		for _, modTag := range mod.Tags {
+			if strings.EqualFold(strings.TrimSpace(modTag), tag) {
+				found++
+				break
+			}
+		}
 	}
-	if strings.Contains(strings.ToLower(mod.Author), query) {
-		return true
+	if matchAll {
+		return found == len(active)
+	}
+	return found > 0
+}
+
+// modMatchesQuery reports whether the modpack's name, description, author, category
+// or tags contain query. A "tag:" prefix searches the tags alone.
+func modMatchesQuery(mod Modpack, query string) bool {
+	query = strings.ToLower(strings.TrimSpace(query))
+	if tag, ok := strings.CutPrefix(query, "tag:"); ok {
+		tag = strings.TrimSpace(tag)
+		for _, modTag := range mod.Tags {
+			if strings.Contains(strings.ToLower(modTag), tag) {
+				return true
+			}
+		}
+		return false
+	}
+	for _, field := range append([]string{mod.DisplayName, mod.Description, mod.Author, mod.Category}, mod.Tags...) {
+		if strings.Contains(strings.ToLower(field), query) {
+			return true
+		}
 	}
 	return false
 }
@@ -851,13 +1902,34 @@ func (g *GUI) updateUIForState(id string, state *ModpackState) {
 	bindings := append([]*modpackCardBinding(nil), g.cardBindings[id]...)
 	g.bindingsMu.RUnlock()
 
-	if len(bindings) > 0 {
-		fyne.Do(func() {
-			for _, binding := range bindings {
-				g.updateBindingUI(binding, state)
+	running := g.anyModpackRunning()
+	if !running && g.processRegistry != nil {
+		running = len(g.processRegistry.GetRunningProcesses()) > 0
+	}
+	fyne.Do(func() {
+		for _, binding := range bindings {
+			g.updateBindingUI(binding, state)
+		}
+		if g.stopAllBtn != nil {
+			if running {
+				g.stopAllBtn.Enable()
+			} else {
+				g.stopAllBtn.Disable()
 			}
-		})
+		}
+	})
+}
+
+// anyModpackRunning reports whether any modpack instance is running
+func (g *GUI) anyModpackRunning() bool {
+	g.stateMu.RLock()
+	defer g.stateMu.RUnlock()
+	for _, state := range g.modpackStates {
+		if state.Running {
+			return true
+		}
 	}
+	return false
 }
 
 func (g *GUI) refreshAllModpackStates() {
@@ -878,35 +1950,44 @@ func (g *GUI) refreshModpackState(mod Modpack) {
 		err             error
 	)
 
-	if installed {
+	if installed && offlineMode() {
+		// No version checks offline; just show what's installed
+		localVersion, _ = getLocalPackVersion(mod, instDir)
+	} else if installed {
 		updateAvailable, localVersion, remoteVersion, err = checkModpackUpdate(mod, instDir)
-		if err == nil && localVersion == "" {
+		if err != nil {
+			// Still show what's installed when the pack server can't be reached
+			localVersion, _ = getLocalPackVersion(mod, instDir)
+		} else if localVersion == "" {
 			installed = false
 			updateAvailable = false
 		}
-	} else {
-		remoteVersion, err = fetchRemotePackVersion(mod.PackURL)
+	} else if !offlineMode() {
+		remoteVersion, err = fetchRemotePackVersion(mod.PackURL, mod.Headers)
 	}
 
-	// TEMPORARILY DISABLED: Check for reattachment opportunities if process registry is available
+	// Check for reattachment opportunities if process registry is available. The game
+	// this launcher started itself is tracked by its launch instead.
 	var reattachable bool = false
 	var processID string = ""
+	var processPID int
 	var processStatus ProcessStatus = ProcessStatusStopped
 	var processStartTime time.Time = time.Time{}
 
-	// if g.processRegistry != nil {
-	// 	records := g.processRegistry.GetRecordsByModpackID(mod.ID)
-	// 	for _, record := range records {
-	// 		if record.Status == ProcessStatusRunning {
-	// 			// Found a running process for this modpack
-	// 			reattachable = true
-	// 			processID = record.ID
-	// 			processStatus = record.Status
-	// 			processStartTime = record.StartTime
-	// 			break
-	// 		}
-	// 	}
-	// }
+	if _, ok := g.operationProcess(mod.ID); g.processRegistry != nil && !ok {
+		records := g.processRegistry.GetRecordsByModpackID(mod.ID)
+		for _, record := range records {
+			if record.Status == ProcessStatusRunning {
+				// Found a running process for this modpack
+				reattachable = true
+				processID = record.ID
+				processPID = record.PID
+				processStatus = record.Status
+				processStartTime = record.StartTime
+				break
+			}
+		}
+	}
 
 	errCopy := err
 	g.setModpackState(mod.ID, func(state *ModpackState) {
@@ -921,11 +2002,11 @@ func (g *GUI) refreshModpackState(mod Modpack) {
 			state.RemoteVersion = remoteVersion
 		}
 		state.LastChecked = time.Now()
-		if errCopy != nil {
-			state.Error = errCopy
-		} else {
-			state.Error = nil
-		}
+		state.LastPlayed = lastPlayedAt(mod.ID)
+		state.Playtime = playtimeFor(mod.ID)
+		state.Unsupported = !supportsPlatform(mod, runtime.GOOS, runtime.GOARCH)
+		state.Error = nil
+		state.RemoteError = errCopy
 		if !installed {
 			state.Running = false
 			state.RunningPID = 0
@@ -933,6 +2014,9 @@ func (g *GUI) refreshModpackState(mod Modpack) {
 
 		// Update reattachment information
 		state.Reattachable = reattachable
+		if reattachable {
+			state.RunningPID = processPID
+		}
 		state.ProcessID = processID
 		state.ProcessStatus = processStatus
 		state.ProcessStartTime = processStartTime
@@ -940,7 +2024,271 @@ func (g *GUI) refreshModpackState(mod Modpack) {
 }
 
 func (g *GUI) modpackInstanceDir(mod Modpack) string {
-	return filepath.Join(g.root, "prism", "instances", mod.InstanceName)
+	return filepath.Join(instancesDirFor(g.root), mod.InstanceName)
+}
+
+// anyModpackActive reports whether any modpack is running or has an operation in progress
+func (g *GUI) anyModpackActive() bool {
+	g.stateMu.RLock()
+	defer g.stateMu.RUnlock()
+	for _, state := range g.modpackStates {
+		if state.Running || state.Busy {
+			return true
+		}
+	}
+	return false
+}
+
+// changeInstancesDir moves existing instances into dir and makes it the instances
+// folder. An empty dir resets to the default under the launcher home. done runs on
+// the UI thread once the folder has changed.
+func (g *GUI) changeInstancesDir(dir string, done func()) {
+	from := instancesDirFor(g.root)
+	target := dir
+	if target == "" {
+		target = defaultInstancesDir(g.root)
+	}
+	target = filepath.Clean(target)
+	if target == filepath.Clean(from) {
+		return
+	}
+
+	if g.anyModpackActive() {
+		dialog.ShowError(fmt.Errorf("Close running modpacks and wait for installs to finish before moving instances."), g.window)
+		return
+	}
+	if err := validateInstancesDir(g.root, target); err != nil {
+		dialog.ShowError(err, g.window)
+		return
+	}
+
+	count := 0
+	if entries, err := os.ReadDir(from); err == nil {
+		for _, entry := range entries {
+			if entry.IsDir() {
+				count++
+			}
+		}
+	}
+
+	apply := func() {
+		g.showLoading(true, "Moving instances...")
+		g.updateStatus("Moving instances...")
+		go func() {
+			defer g.showLoading(false, "")
+
+			moved, err := moveInstances(from, target)
+			if err != nil {
+				logf("%s", warnLine(fmt.Sprintf("Failed to move instances to %s: %v", target, err)))
+				fyne.Do(func() {
+					msg := fmt.Sprintf("Failed to move instances: %v", err)
+					if moved > 0 {
+						msg += fmt.Sprintf("\n\n%d item(s) were already moved to %s. The instances folder was not changed; move them back manually.", moved, target)
+					}
+					dialog.ShowError(errors.New(msg), g.window)
+					g.updateStatus("Failed to move instances")
+				})
+				return
+			}
+
+			if target == filepath.Clean(defaultInstancesDir(g.root)) {
+				settings.InstancesDir = ""
+			} else {
+				settings.InstancesDir = target
+			}
+			if err := saveSettings(g.root); err != nil {
+				logf("%s", warnLine(fmt.Sprintf("Failed to save settings: %v", err)))
+			}
+			prismDir := filepath.Join(g.root, "prism")
+			if err := updatePrismInstanceDir(prismConfigFile(prismDir), prismDir, target); err != nil {
+				logf("%s", warnLine(fmt.Sprintf("Failed to set Prism instances folder: %v", err)))
+			}
+			logf("%s", successLine(fmt.Sprintf("Moved %d item(s) to instances folder %s", moved, target)))
+
+			g.refreshAllModpackStates()
+			fyne.Do(func() {
+				g.updateStatus("Instances folder changed")
+				if done != nil {
+					done()
+				}
+			})
+		}()
+	}
+
+	if count == 0 {
+		apply()
+		return
+	}
+	dialog.ShowConfirm("Move Instances",
+		fmt.Sprintf("Move %d existing instance(s) from\n%s\nto\n%s?\n\nWorlds, settings and mods move with them. This can take a while on another drive.", count, from, target),
+		func(ok bool) {
+			if ok {
+				apply()
+			}
+		}, g.window)
+}
+
+// changeLauncherHome makes dir the launcher folder from the next start, asking whether
+// to move the current data there or start fresh with just the settings. An empty dir
+// goes back to the default folder.
+func (g *GUI) changeLauncherHome(dir string) {
+	target := dir
+	if target == "" {
+		target = defaultLauncherHome()
+	}
+	target = filepath.Clean(target)
+	if target == filepath.Clean(g.root) {
+		return
+	}
+	if g.anyModpackActive() {
+		dialog.ShowError(fmt.Errorf("Close running modpacks and wait for installs to finish before changing the launcher folder."), g.window)
+		return
+	}
+
+	apply := func(move bool) {
+		if err := scheduleLauncherHomeChange(g.root, target, move); err != nil {
+			logf("%s", warnLine(fmt.Sprintf("Failed to change the launcher folder to %s: %v", target, err)))
+			dialog.ShowError(err, g.window)
+			return
+		}
+		logf("%s", infoLine(fmt.Sprintf("GUI: User changed the launcher folder to %s (move data: %t)", target, move)))
+		message := fmt.Sprintf("The launcher will use\n%s\nfrom the next start.", target)
+		if move {
+			message += " Your data is moved there before anything else starts, which can take a while on another drive."
+		} else {
+			message += fmt.Sprintf(" Your settings were copied; everything else stays in\n%s", g.root)
+		}
+		dialog.ShowConfirm("Launcher Folder Changed", message+"\n\nQuit the launcher now?", func(ok bool) {
+			if ok {
+				g.saveViewState()
+				g.cleanup()
+				g.window.Close()
+			}
+		}, g.window)
+	}
+
+	message := widget.NewLabel(fmt.Sprintf("Move Prism, Java, instances, backups and settings from\n%s\nto\n%s?\n\nStarting fresh keeps them where they are and downloads everything again in the new folder.", g.root, target))
+	message.Wrapping = fyne.TextWrapWord
+	var d dialog.Dialog
+	moveBtn := widget.NewButtonWithIcon(T("action.moveData"), theme.ContentPasteIcon(), func() {
+		d.Hide()
+		apply(true)
+	})
+	moveBtn.Importance = widget.HighImportance
+	freshBtn := widget.NewButtonWithIcon(T("action.startFresh"), theme.FolderNewIcon(), func() {
+		d.Hide()
+		apply(false)
+	})
+	content := container.NewBorder(nil, container.NewHBox(layout.NewSpacer(), freshBtn, moveBtn), nil, nil, message)
+	d = dialog.NewCustom("Change Launcher Folder", T("action.cancel"), content, g.window)
+	d.Resize(fyne.NewSize(520, 260))
+	d.Show()
+}
+
+// exportSettingsToFile asks where to save the current settings as a portable file
+// for another machine
+func (g *GUI) exportSettingsToFile() {
+	save := dialog.NewFileSave(func(writer fyne.URIWriteCloser, err error) {
+		if err != nil || writer == nil {
+			return
+		}
+		defer writer.Close()
+		if err := exportSettings(writer); err != nil {
+			logf("%s", warnLine(fmt.Sprintf("Failed to export settings: %v", err)))
+			dialog.ShowError(fmt.Errorf("Failed to export settings: %v", err), g.window)
+			return
+		}
+		logf("%s", successLine(fmt.Sprintf("Exported settings to %s", writer.URI().Path())))
+		g.updateStatus("Settings exported")
+	}, g.window)
+	save.SetFileName("theboyslauncher-settings.json")
+	save.SetFilter(storage.NewExtensionFileFilter([]string{".json"}))
+	save.Show()
+}
+
+// importSettingsFromFile asks for a settings export, or another launcher's
+// settings.json, and merges it into the current settings. done runs on the UI
+// thread once the settings have changed.
+func (g *GUI) importSettingsFromFile(done func()) {
+	open := dialog.NewFileOpen(func(reader fyne.URIReadCloser, err error) {
+		if err != nil || reader == nil {
+			return
+		}
+		path := reader.URI().Path()
+		data, err := io.ReadAll(reader)
+		reader.Close()
+		if err != nil {
+			dialog.ShowError(fmt.Errorf("Failed to read %s: %v", filepath.Base(path), err), g.window)
+			return
+		}
+		export, err := readSettingsExport(data)
+		if err != nil {
+			dialog.ShowError(err, g.window)
+			return
+		}
+
+		message := fmt.Sprintf("Import the settings in %s?", filepath.Base(path))
+		if !export.ExportedAt.IsZero() {
+			message = fmt.Sprintf("Import the settings exported from %s %s on %s?", launcherName, export.LauncherVersion, export.ExportedAt.Format("Jan 2, 2006"))
+		}
+		message += "\n\nMemory, update, download and log preferences are replaced. Favorites, playtime and per-modpack RAM are merged with this machine's, and the instances folder stays as it is."
+		apply := func() {
+			playStatsMu.Lock()
+			previous := settings
+			settings = mergeImportedSettings(settings, export.Settings)
+			playStatsMu.Unlock()
+			if err := saveSettings(g.root); err != nil {
+				playStatsMu.Lock()
+				settings = previous
+				playStatsMu.Unlock()
+				logf("%s", warnLine(fmt.Sprintf("Failed to save imported settings: %v", err)))
+				dialog.ShowError(fmt.Errorf("Failed to save settings: %v", err), g.window)
+				return
+			}
+			if err := setLanguage(settings.Language); err != nil {
+				logf("%s", warnLine(fmt.Sprintf("Failed to switch language: %v", err)))
+			}
+			logf("%s", infoLine(fmt.Sprintf("GUI: User imported settings from %s", path)))
+
+			g.updateMemorySummaryLabel()
+			g.updateAccountLabel()
+			g.updateStatus("Settings imported")
+			if g.previewSource == "" {
+				g.refreshModpacks()
+			}
+			if done != nil {
+				done()
+			}
+			if export.LauncherHome != "" && filepath.Clean(export.LauncherHome) != filepath.Clean(g.root) && launcherHomeFromEnv() == "" {
+				dialog.ShowConfirm("Launcher Folder", fmt.Sprintf("These settings used the launcher folder\n%s\n\nUse that folder here too?", export.LauncherHome), func(ok bool) {
+					if ok {
+						g.changeLauncherHome(export.LauncherHome)
+					}
+				}, g.window)
+			}
+		}
+		dialog.ShowConfirm("Import Settings", message, func(ok bool) {
+			if !ok {
+				return
+			}
+			sources := importedSources(settings, export.Settings)
+			if len(sources) == 0 {
+				apply()
+				return
+			}
+			lines := make([]string, 0, len(sources))
+			for _, source := range sources {
+				lines = append(lines, Tf("settingsImport.source."+source.Kind, source.URL))
+			}
+			dialog.ShowConfirm(T("settingsImport.sourcesTitle"), Tf("settingsImport.sourcesMessage", strings.Join(lines, "\n")), func(ok bool) {
+				if ok {
+					apply()
+				}
+			}, g.window)
+		}, g.window)
+	}, g.window)
+	open.SetFilter(storage.NewExtensionFileFilter([]string{".json"}))
+	open.Show()
 }
 
 func (g *GUI) isModpackInstalled(mod Modpack) bool {
@@ -951,6 +2299,11 @@ func (g *GUI) isModpackInstalled(mod Modpack) bool {
 }
 
 func (g *GUI) handlePrimaryAction(mod Modpack) {
+	if g.previewSource != "" {
+		g.updateStatus("Install and launch are disabled in catalog preview")
+		return
+	}
+
 	state := g.getModpackState(mod.ID)
 	if state == nil {
 		g.updateStatus("Checking modpack status...")
@@ -964,41 +2317,120 @@ func (g *GUI) handlePrimaryAction(mod Modpack) {
 
 	switch state.PrimaryAction() {
 	case ActionInstall:
-		g.runModpackOperation(mod, ActionInstall)
+		g.confirmInstallSizeThen(mod, func() {
+			g.confirmMemoryThen(mod, func() { g.runModpackOperation(mod, ActionInstall) })
+		})
 	case ActionUpdate:
-		g.runModpackOperation(mod, ActionUpdate)
+		g.confirmMemoryThen(mod, func() { g.runModpackOperation(mod, ActionUpdate) })
 	case ActionLaunch:
 		// Check if this is a reattachment action
 		if state.Reattachable && state.ProcessID != "" {
 			g.reattachToProcess(mod, state.ProcessID)
 		} else {
-			g.runModpackOperation(mod, ActionLaunch)
+			g.confirmMemoryThen(mod, func() { g.runModpackOperation(mod, ActionLaunch) })
 		}
 	case ActionKill:
 		g.killRunningInstance(mod)
+	case ActionQueued:
+		g.cancelQueuedOperation(mod)
 	default:
 		// No action available
 	}
 }
 
-func (g *GUI) handlePrimaryForSelected() {
-	if len(g.modpacks) == 0 {
+// confirmMemoryThen runs proceed right away unless the allocated RAM is below the
+// pack's minimum, in which case the user is asked first
+func (g *GUI) confirmMemoryThen(mod Modpack, proceed func()) {
+	memoryMB, below := memoryBelowMinimum(mod)
+	if !below {
+		proceed()
 		return
 	}
-	g.handlePrimaryAction(g.modpacks[0])
+
+	logf("%s", warnLine(fmt.Sprintf("%s: %d GB allocated but the pack needs at least %d GB", mod.DisplayName, memoryMB/1024, mod.MinRam/1024)))
+	message := fmt.Sprintf("%s recommends at least %d GB of RAM, but only %d GB is allocated.\n\nThe game may crash with out-of-memory errors. Continue anyway?",
+		mod.DisplayName, mod.MinRam/1024, memoryMB/1024)
+	dialog.ShowConfirm("Low Memory Allocation", message, func(ok bool) {
+		if ok {
+			proceed()
+		} else {
+			g.updateStatus("Launch cancelled; adjust RAM in Settings")
+		}
+	}, g.window)
 }
 
-func (g *GUI) startUpdateCheck() {
-	if g.exePath == "" {
+// confirmInstallSizeThen tells the user how much a pack will download and asks before
+// installing. The size comes from the catalog when the pack host publishes it and is
+// otherwise estimated from the pack index; if neither works the install goes ahead.
+func (g *GUI) confirmInstallSizeThen(mod Modpack, proceed func()) {
+	if offlineMode() {
+		// Nothing is downloaded offline; the launch reports what's missing instead
+		proceed()
+		return
+	}
+	estimate := packSizeEstimate{Bytes: mod.SizeBytes}
+	if state := g.getModpackState(mod.ID); estimate.Bytes == 0 && state != nil {
+		estimate = state.SizeEstimate
+	}
+	if estimate.Bytes > 0 {
+		g.showInstallSizeConfirm(mod, estimate, proceed)
 		return
 	}
+
+	g.showLoading(true, fmt.Sprintf("Estimating the size of %s...", mod.DisplayName))
 	go func() {
-		startMsg := "Checking for launcher updates..."
-		g.showLoading(true, startMsg)
-		err := selfUpdate(g.root, g.exePath, func(msg string) {
-			logf("%s", infoLine(msg))
-			g.showLoading(true, msg)
-		})
+		estimate, err := estimatePackSize(mod.PackURL, mod.Headers)
+		g.showLoading(false, "")
+		if err != nil || estimate.Bytes == 0 {
+			logf("%s", warnLine(fmt.Sprintf("Couldn't estimate the size of %s: %v", mod.DisplayName, err)))
+			fyne.Do(proceed)
+			return
+		}
+		g.setModpackState(mod.ID, func(state *ModpackState) {
+			state.SizeEstimate = estimate
+		})
+		fyne.Do(func() { g.showInstallSizeConfirm(mod, estimate, proceed) })
+	}()
+}
+
+// showInstallSizeConfirm asks whether to install a pack of the given size
+func (g *GUI) showInstallSizeConfirm(mod Modpack, estimate packSizeEstimate, proceed func()) {
+	message := fmt.Sprintf("%s is about %s to download.", mod.DisplayName, formatSize(estimate.Bytes))
+	if estimate.Unknown > 0 {
+		message += fmt.Sprintf(" %d file(s) couldn't be sized and aren't included, so the real size may be larger.", estimate.Unknown)
+	}
+	message += "\n\nInstall now?"
+	dialog.ShowConfirm("Install "+mod.DisplayName, message, func(ok bool) {
+		if ok {
+			proceed()
+		} else {
+			g.updateStatus("Install cancelled")
+		}
+	}, g.window)
+}
+
+func (g *GUI) handlePrimaryForSelected() {
+	if len(g.modpacks) == 0 {
+		return
+	}
+	g.handlePrimaryAction(g.modpacks[0])
+}
+
+func (g *GUI) startUpdateCheck() {
+	if g.exePath == "" {
+		return
+	}
+	if selfUpdateDisabled() {
+		logf("%s", infoLine("Self-update is disabled; skipping launcher update check"))
+		return
+	}
+	go func() {
+		startMsg := "Checking for launcher updates..."
+		g.showLoading(true, startMsg)
+		err := selfUpdate(g.root, g.exePath, func(msg string) {
+			logf("%s", infoLine(msg))
+			g.showLoading(true, msg)
+		})
 		if err != nil {
 			g.updateStatus("Update check failed; continuing")
 			g.showLoading(false, "")
@@ -1009,10 +2441,96 @@ func (g *GUI) startUpdateCheck() {
 	}()
 }
 
+// checkForUpdates looks for a newer launcher without installing it, badges the
+// update button and offers to install the update when there is one
+func (g *GUI) checkForUpdates() {
+	g.updateStatus("Checking for launcher updates...")
+	go func() {
+		available, current, latest, err := checkLauncherUpdate(updateChannelSetting())
+		if err != nil {
+			logf("%s", warnLine(fmt.Sprintf("Update check failed: %v", err)))
+			g.updateStatus("Update check failed")
+			return
+		}
+		g.setUpdateBadge(available, latest)
+		if !available {
+			g.updateStatus(fmt.Sprintf("%s is up to date (%s)", launcherShortName, current))
+			return
+		}
+		logf("%s", infoLine(fmt.Sprintf("Launcher update available: %s (current %s)", latest, current)))
+		fyne.Do(func() {
+			g.updateStatus(fmt.Sprintf("Launcher update %s available", latest))
+			dialog.ShowConfirm("Launcher Update", fmt.Sprintf("%s %s is available; you have %s.\n\nInstall it now? The launcher restarts to apply it.", launcherShortName, latest, current), func(ok bool) {
+				if !ok {
+					return
+				}
+				if g.anyModpackActive() {
+					dialog.ShowError(fmt.Errorf("Close running modpacks and wait for installs to finish before updating the launcher."), g.window)
+					return
+				}
+				g.startUpdateCheck()
+			}, g.window)
+		})
+	}()
+}
+
+// rollbackLauncher confirms and then restores the launcher version the last update
+// replaced, restarting into it
+func (g *GUI) rollbackLauncher(previous string) {
+	dialog.ShowConfirm(T("rollback.title"), Tf("rollback.confirm", launcherShortName, version, previous), func(ok bool) {
+		if !ok {
+			return
+		}
+		if g.anyModpackActive() {
+			dialog.ShowError(errors.New(T("rollback.busy")), g.window)
+			return
+		}
+		go func() {
+			g.updateStatus(Tf("rollback.status", previous))
+			if _, err := rollbackLauncher(g.root, g.exePath); err != nil {
+				logf("%s", warnLine(fmt.Sprintf("Rollback failed: %v", err)))
+				fyne.Do(func() {
+					dialog.ShowError(errors.New(Tf("rollback.failed", err)), g.window)
+				})
+				return
+			}
+			if err := relaunchAfterRollback(g.exePath); err != nil {
+				logf("%s", warnLine(err.Error()))
+				fyne.Do(func() {
+					dialog.ShowError(errors.New(Tf("rollback.relaunchFailed", previous, err)), g.window)
+				})
+				return
+			}
+			g.saveViewState()
+			g.cleanup()
+			os.Exit(0)
+		}()
+	}, g.window)
+}
+
+// setUpdateBadge marks the update button with the latest version while one is available
+func (g *GUI) setUpdateBadge(available bool, latest string) {
+	fyne.Do(func() {
+		if g.updateBtn == nil {
+			return
+		}
+		if available {
+			g.updateBtn.SetText(Tf("action.updateAvailable", latest))
+			g.updateBtn.Importance = widget.HighImportance
+		} else {
+			g.updateBtn.SetText(T("action.checkUpdates"))
+			g.updateBtn.Importance = widget.MediumImportance
+		}
+		g.updateBtn.Refresh()
+	})
+}
+
 func (g *GUI) configureRuntimeForModpack(mod Modpack) int {
 	memoryMB := MemoryForModpack(mod)
 	mode := "manual"
-	if settings.AutoRAM {
+	if mod.OverrideMemoryMB > 0 {
+		mode = "per-pack"
+	} else if settings.AutoRAM {
 		mode = "auto"
 	}
 	modeLabel := strings.Title(mode)
@@ -1033,8 +2551,8 @@ func (g *GUI) configureRuntimeForModpack(mod Modpack) int {
 	return memoryMB
 }
 
-func (g *GUI) makeProgressCallback(mod Modpack) func(stage string, step, total int) {
-	return func(stage string, step, total int) {
+func (g *GUI) makeProgressCallback(mod Modpack) func(stage InstallStage, step, total int) {
+	return func(stage InstallStage, step, total int) {
 		if total <= 0 {
 			total = 1
 		}
@@ -1045,7 +2563,7 @@ func (g *GUI) makeProgressCallback(mod Modpack) func(stage string, step, total i
 			step = total
 		}
 
-		logf("%s", infoLine(fmt.Sprintf("%s: %s (%d/%d)", mod.DisplayName, stage, step, total)))
+		logf("%s", infoLine(fmt.Sprintf("%s: %s (%d/%d)", mod.DisplayName, stage.String(), step, total)))
 
 		progress := float64(step) / float64(total)
 
@@ -1054,13 +2572,49 @@ func (g *GUI) makeProgressCallback(mod Modpack) func(stage string, step, total i
 				g.progressBar.SetValue(progress)
 				g.progressBar.Show()
 			}
+			if g.stageIcon != nil {
+				g.stageIcon.SetResource(stageIcon(stage))
+				g.stageIcon.Show()
+			}
+			if g.statusLabel != nil {
+				g.statusLabel.SetText(fmt.Sprintf("%s - %s (%d/%d)", mod.DisplayName, stage.Label(), step, total))
+			}
+		})
+	}
+}
+
+// makeDownloadProgressCallback shows a running download's speed and time left in the
+// status bar, between the stage updates of makeProgressCallback
+func (g *GUI) makeDownloadProgressCallback(mod Modpack) func(downloadProgress) {
+	return func(p downloadProgress) {
+		fyne.Do(func() {
 			if g.statusLabel != nil {
-				g.statusLabel.SetText(fmt.Sprintf("%s - %s (%d/%d)", mod.DisplayName, stage, step, total))
+				g.statusLabel.SetText(fmt.Sprintf("%s - %s", mod.DisplayName, p))
 			}
 		})
 	}
 }
 
+// stageIcon picks the status bar icon shown next to an install stage
+func stageIcon(stage InstallStage) fyne.Resource {
+	switch stage {
+	case StageReadingConfig, StageCheckingUpdates:
+		return theme.SearchIcon()
+	case StageEnsuringPrism, StageEnsuringJava, StageEnsuringBootstrap:
+		return theme.DownloadIcon()
+	case StagePreparingInstance:
+		return theme.FolderNewIcon()
+	case StageSyncingFiles:
+		return theme.ViewRefreshIcon()
+	case StageLaunching:
+		return theme.MediaPlayIcon()
+	case StageCheckingFiles:
+		return theme.ConfirmIcon()
+	default:
+		return theme.InfoIcon()
+	}
+}
+
 func (g *GUI) memorySummary() string {
 	if settings.AutoRAM {
 		auto := clampMemoryMB(DefaultAutoMemoryMB())
@@ -1078,8 +2632,63 @@ func (g *GUI) updateMemorySummaryLabel() {
 	})
 }
 
+// updateAccountLabel shows the Minecraft account the next launch will use
+func (g *GUI) updateAccountLabel() {
+	if g.accountLabel == nil {
+		return
+	}
+	text := T("sidebar.noAccount")
+	if name := activeAccountName(filepath.Join(g.root, "prism")); name != "" {
+		text = Tf("sidebar.account", name)
+	}
+	fyne.Do(func() {
+		g.accountLabel.SetText(text)
+	})
+}
+
 func (g *GUI) runModpackOperation(mod Modpack, action PrimaryAction) {
-	if action == ActionInstall || action == ActionUpdate || action == ActionLaunch {
+	g.runModpackOperationWithOptions(mod, action, launchOptions{})
+}
+
+func (g *GUI) runModpackOperationWithOptions(mod Modpack, action PrimaryAction, opts launchOptions) {
+	if action == ActionInstall && !supportsPlatform(mod, runtime.GOOS, runtime.GOARCH) {
+		g.updateStatus(Tf("status.unsupportedPack", mod.DisplayName))
+		logf("%s", warnLine(fmt.Sprintf("%s is not supported on %s/%s; skipping install", mod.DisplayName, runtime.GOOS, runtime.GOARCH)))
+		return
+	}
+
+	// Shown right away so a card never looks idle between the click and its turn;
+	// the start func clears it again
+	g.setModpackState(mod.ID, func(state *ModpackState) {
+		state.Queued = true
+		state.Error = nil
+	})
+	queued := g.installQueue.Enqueue(mod.ID, func(done func()) {
+		g.startModpackOperation(mod, action, opts, done)
+	})
+	if queued {
+		logf("%s", infoLine(fmt.Sprintf("Queued %s until the operation in progress finishes", mod.DisplayName)))
+		g.updateStatus(fmt.Sprintf("%s queued", mod.DisplayName))
+	}
+}
+
+// cancelQueuedOperation takes a modpack out of the install queue before it starts
+func (g *GUI) cancelQueuedOperation(mod Modpack) {
+	if !g.installQueue.Cancel(mod.ID) {
+		return
+	}
+	g.setModpackState(mod.ID, func(state *ModpackState) {
+		state.Queued = false
+	})
+	logf("%s", infoLine(fmt.Sprintf("Removed %s from the queue", mod.DisplayName)))
+	g.updateStatus(fmt.Sprintf("%s removed from the queue", mod.DisplayName))
+}
+
+// startModpackOperation runs an operation once the install queue gives it a slot.
+// The slot is given back as soon as Prism starts, so a game left running doesn't
+// hold up installs of other modpacks.
+func (g *GUI) startModpackOperation(mod Modpack, action PrimaryAction, opts launchOptions, done func()) {
+	if action == ActionInstall || action == ActionUpdate || action == ActionLaunch || action == ActionVerify {
 		g.configureRuntimeForModpack(mod)
 	}
 
@@ -1096,6 +2705,9 @@ func (g *GUI) runModpackOperation(mod Modpack, action PrimaryAction) {
 	case ActionLaunch:
 		statusMsg = fmt.Sprintf("Launching %s...", mod.DisplayName)
 		logMsg = fmt.Sprintf("Launching modpack: %s", mod.DisplayName)
+	case ActionVerify:
+		statusMsg = fmt.Sprintf("Verifying %s...", mod.DisplayName)
+		logMsg = fmt.Sprintf("Verifying modpack: %s", mod.DisplayName)
 	default:
 		statusMsg = fmt.Sprintf("Working on %s...", mod.DisplayName)
 		logMsg = fmt.Sprintf("Working on modpack: %s", mod.DisplayName)
@@ -1105,6 +2717,7 @@ func (g *GUI) runModpackOperation(mod Modpack, action PrimaryAction) {
 	logf("%s", infoLine(logMsg))
 
 	g.setModpackState(mod.ID, func(state *ModpackState) {
+		state.Queued = false
 		state.Busy = true
 		state.Running = false
 		state.RunningPID = 0
@@ -1122,18 +2735,41 @@ func (g *GUI) runModpackOperation(mod Modpack, action PrimaryAction) {
 	progressCb := g.makeProgressCallback(mod)
 
 	go func(mod Modpack, action PrimaryAction) {
-		g.setRunningModpackID(mod.ID)
-		go g.monitorProcessStart(mod)
+		defer done()
+		op := g.beginOperation(mod.ID)
+		go g.monitorProcessStart(mod, op)
 
-		runLauncherLogic(g.root, g.exePath, mod, g.prismProcess, progressCb)
-
-		g.setRunningModpackID("")
-
-		g.processMu.Lock()
-		if g.prismProcess != nil {
-			*g.prismProcess = nil
+		if opts.Output == nil {
+			opts.Output = consoleStreamWriter{g: g}
+		}
+		if opts.ManualDownloads == nil {
+			opts.ManualDownloads = g.promptManualDownloads
+		}
+		if opts.RetryDownloads == nil {
+			opts.RetryDownloads = g.promptRetryDownloads
+		}
+		if opts.DownloadProgress == nil {
+			opts.DownloadProgress = g.makeDownloadProgressCallback(mod)
+		}
+		if opts.LowDiskSpace == nil {
+			opts.LowDiskSpace = g.promptLowDiskSpace
+		}
+		if state := g.getModpackState(mod.ID); state != nil && opts.PackSize.Bytes == 0 {
+			opts.PackSize = state.SizeEstimate
+		}
+		opts.OnLaunch = func(proc *os.Process) {
+			g.setOperationProcess(op, proc)
+			done()
 		}
-		g.processMu.Unlock()
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		g.setCancelOperation(mod.ID, op, cancel)
+		var proc *os.Process
+		result, err := runLauncherLogicSafe(ctx, g.root, g.exePath, mod, &proc, opts, progressCb)
+
+		g.setCancelOperation(mod.ID, op, nil)
+		g.endOperation(mod.ID, op)
+		g.endPlaySession(mod.ID)
 
 		g.setModpackState(mod.ID, func(state *ModpackState) {
 			state.Running = false
@@ -1151,25 +2787,69 @@ func (g *GUI) runModpackOperation(mod Modpack, action PrimaryAction) {
 			}
 		})
 
-		g.updateStatus("Operation complete")
 		g.refreshModpackState(mod)
+		// The user may have signed in through Prism's own window meanwhile
+		g.updateAccountLabel()
+
+		// Leave the final output in view rather than making the user go find it
+		if settings.KeepConsoleOpen && result.Outcome != outcomeInstalled {
+			fyne.Do(func() {
+				g.showConsole()
+				g.window.RequestFocus()
+			})
+		}
+
+		switch {
+		case err == nil && action == ActionVerify:
+			g.updateStatus(fmt.Sprintf("%s verified - %d file(s) repaired", mod.DisplayName, result.Repaired))
+			g.showVerifyResult(mod, result)
+		case err == nil && result.CrashReport != "":
+			g.updateStatus(fmt.Sprintf("%s crashed - crash report: %s", mod.DisplayName, result.CrashReport))
+			g.showCrashReport(mod, result.CrashReport)
+		case err == nil:
+			g.updateStatus(fmt.Sprintf("%s closed", mod.DisplayName))
+			if result.FallbackErr != nil {
+				g.showLaunchFailure(mod, result.FallbackErr, result.Issues, true)
+			}
+			if result.Outcome == outcomeGameClosed && !g.anyModpackActive() {
+				g.cleanupJREs(false)
+			}
+		case errors.Is(err, context.Canceled):
+			logf("%s", infoLine(fmt.Sprintf("%s cancelled", mod.DisplayName)))
+			g.updateStatus(fmt.Sprintf("%s cancelled", mod.DisplayName))
+		case result.Outcome == outcomeLaunchFailed:
+			g.updateStatus(fmt.Sprintf("%s could not be started", mod.DisplayName))
+			g.showLaunchFailure(mod, err, result.Issues, false)
+		default:
+			logf("%s", warnLine(fmt.Sprintf("%s failed: %v", mod.DisplayName, err)))
+			g.setModpackState(mod.ID, func(state *ModpackState) {
+				state.Error = err
+			})
+			g.updateStatus(fmt.Sprintf("%s failed", mod.DisplayName))
+			fyne.Do(func() {
+				dialog.ShowError(fmt.Errorf("%s could not be prepared:\n\n%v", mod.DisplayName, err), g.window)
+			})
+		}
 	}(mod, action)
 }
 
-func (g *GUI) monitorProcessStart(mod Modpack) {
+func (g *GUI) monitorProcessStart(mod Modpack, op *modpackOperation) {
 	ticker := time.NewTicker(500 * time.Millisecond)
 	defer ticker.Stop()
 
 	for range ticker.C {
-		if g.getRunningModpackID() != mod.ID {
+		g.runningMu.RLock()
+		current, proc := g.operations[mod.ID] == op, op.process
+		g.runningMu.RUnlock()
+		if !current {
 			return
 		}
-
-		proc := g.getPrismProcess()
 		if proc == nil {
 			continue
 		}
 
+		// The game is up, so there is no install left to cancel
+		g.setCancelOperation(mod.ID, op, nil)
 		g.setModpackState(mod.ID, func(state *ModpackState) {
 			state.Running = true
 			state.Busy = false
@@ -1181,29 +2861,248 @@ func (g *GUI) monitorProcessStart(mod Modpack) {
 
 		g.updateStatus(fmt.Sprintf("Running %s (PID %d)", mod.DisplayName, proc.Pid))
 		logf("%s", infoLine(fmt.Sprintf("%s running (PID %d)", mod.DisplayName, proc.Pid)))
+		g.markPlayed(mod)
+		return
+	}
+}
+
+// markPlayed records a confirmed launch as the modpack's last played time
+// and starts counting playtime for the session
+func (g *GUI) markPlayed(mod Modpack) {
+	now := time.Now()
+	if err := recordLastPlayed(g.root, mod.ID, now); err != nil {
+		logf("%s", warnLine(fmt.Sprintf("Failed to save last played time: %v", err)))
+	}
+	g.setModpackState(mod.ID, func(state *ModpackState) {
+		state.LastPlayed = now
+		if state.SessionStart.IsZero() {
+			state.SessionStart = now
+		}
+	})
+	if g.sortMode == sortRecent || g.activeCategory == categoryRecent {
+		fyne.Do(g.applyFilters)
+	}
+}
+
+// endPlaySession adds the session in progress to the modpack's playtime.
+// Only the first call after a session starts counts, so exit and kill paths can both call it.
+func (g *GUI) endPlaySession(id string) {
+	var start time.Time
+	g.setModpackState(id, func(state *ModpackState) {
+		start = state.SessionStart
+		state.SessionStart = time.Time{}
+	})
+	if start.IsZero() {
+		return
+	}
+
+	session := time.Since(start)
+	total, err := addPlaytime(g.root, id, session)
+	if err != nil {
+		logf("%s", warnLine(fmt.Sprintf("Failed to save playtime: %v", err)))
+	}
+	debugf("Play session for %s lasted %s", id, session.Round(time.Second))
+	g.setModpackState(id, func(state *ModpackState) {
+		state.Playtime = total
+	})
+}
+
+// trackReattachedSession counts playtime for a game started by an earlier launcher run.
+// The session is measured from the recorded process start and ends when the process exits.
+func (g *GUI) trackReattachedSession(id string, pid int, start time.Time) {
+	if start.IsZero() || pid <= 0 {
+		return
+	}
+	started := false
+	g.setModpackState(id, func(state *ModpackState) {
+		if state.SessionStart.IsZero() {
+			state.SessionStart = start
+			started = true
+		}
+	})
+	if !started {
 		return
 	}
+
+	go func() {
+		ticker := time.NewTicker(5 * time.Second)
+		defer ticker.Stop()
+		for range ticker.C {
+			// Stop watching if the session was already ended, e.g. by Kill
+			if state := g.getModpackState(id); state == nil || !state.SessionStart.Equal(start) {
+				return
+			}
+			if running, err := isProcessRunning(pid); err == nil && running {
+				continue
+			}
+			g.endPlaySession(id)
+			return
+		}
+	}()
 }
 
-func (g *GUI) setRunningModpackID(id string) {
+// beginOperation records that id has an operation in progress, or a game reattached to
+func (g *GUI) beginOperation(id string) *modpackOperation {
+	op := &modpackOperation{}
 	g.runningMu.Lock()
-	g.runningModpackID = id
+	if g.operations == nil {
+		g.operations = make(map[string]*modpackOperation)
+	}
+	g.operations[id] = op
 	g.runningMu.Unlock()
+	return op
 }
 
-func (g *GUI) getRunningModpackID() string {
-	g.runningMu.RLock()
-	defer g.runningMu.RUnlock()
-	return g.runningModpackID
+// endOperation forgets id's operation once it has returned or its game was stopped.
+// With op set, it is only forgotten if no newer operation for id has replaced it.
+func (g *GUI) endOperation(id string, op *modpackOperation) {
+	g.runningMu.Lock()
+	current := g.operations[id]
+	if current == nil || (op != nil && current != op) {
+		g.runningMu.Unlock()
+		return
+	}
+	delete(g.operations, id)
+	proc := current.process
+	g.runningMu.Unlock()
+	if proc != nil {
+		g.clearPrismProcess(proc)
+	}
+}
+
+// setOperationProcess records the Prism process op launched. It is also kept as the
+// process the signal handler closes when the launcher is stopped.
+func (g *GUI) setOperationProcess(op *modpackOperation, proc *os.Process) {
+	g.runningMu.Lock()
+	op.process = proc
+	g.runningMu.Unlock()
+
+	g.processMu.Lock()
+	if g.prismProcess != nil {
+		*g.prismProcess = proc
+	}
+	g.processMu.Unlock()
 }
 
-func (g *GUI) getPrismProcess() *os.Process {
+// clearPrismProcess stops handing proc to the signal handler, unless another
+// operation has launched a process since
+func (g *GUI) clearPrismProcess(proc *os.Process) {
 	g.processMu.Lock()
-	defer g.processMu.Unlock()
-	if g.prismProcess == nil {
+	if g.prismProcess != nil && *g.prismProcess == proc {
+		*g.prismProcess = nil
+	}
+	g.processMu.Unlock()
+}
+
+// setCancelOperation records how to cancel op, the operation for id, and shows
+// Cancel on its card while there is one
+func (g *GUI) setCancelOperation(id string, op *modpackOperation, cancel context.CancelFunc) {
+	g.runningMu.Lock()
+	op.cancel = cancel
+	g.runningMu.Unlock()
+	g.setModpackState(id, func(state *ModpackState) {
+		state.Cancellable = cancel != nil
+	})
+	g.updateCancelButton()
+}
+
+// updateCancelButton shows the status bar's Cancel while any operation can be cancelled
+func (g *GUI) updateCancelButton() {
+	g.runningMu.RLock()
+	cancellable := false
+	for _, op := range g.operations {
+		if op.cancel != nil {
+			cancellable = true
+			break
+		}
+	}
+	g.runningMu.RUnlock()
+	fyne.Do(func() {
+		if g.cancelBtn == nil {
+			return
+		}
+		if cancellable {
+			g.cancelBtn.Show()
+		} else {
+			g.cancelBtn.Hide()
+			if g.stageIcon != nil {
+				g.stageIcon.Hide()
+			}
+		}
+	})
+}
+
+// takeCancel returns id's cancel func and clears it, so a second click does nothing
+func (g *GUI) takeCancel(id string) context.CancelFunc {
+	g.runningMu.Lock()
+	defer g.runningMu.Unlock()
+	op := g.operations[id]
+	if op == nil {
 		return nil
 	}
-	return *g.prismProcess
+	cancel := op.cancel
+	op.cancel = nil
+	return cancel
+}
+
+// cancelOperation stops mod's install or update. runLauncherLogic kills packwiz,
+// removes a half-installed pack and restores an updated one.
+func (g *GUI) cancelOperation(mod Modpack) {
+	cancel := g.takeCancel(mod.ID)
+	if cancel == nil {
+		return
+	}
+	logf("%s", infoLine(fmt.Sprintf("Cancelling %s", mod.DisplayName)))
+	g.updateStatus(fmt.Sprintf("Cancelling %s...", mod.DisplayName))
+	g.setModpackState(mod.ID, func(state *ModpackState) {
+		state.Cancellable = false
+	})
+	g.updateCancelButton()
+	cancel()
+}
+
+// cancelAllOperations stops every install or update in progress
+func (g *GUI) cancelAllOperations() {
+	g.runningMu.RLock()
+	var ids []string
+	for id, op := range g.operations {
+		if op.cancel != nil {
+			ids = append(ids, id)
+		}
+	}
+	g.runningMu.RUnlock()
+	for _, id := range ids {
+		mod, ok := g.findModpack(id)
+		if !ok {
+			mod = Modpack{ID: id, DisplayName: id}
+		}
+		g.cancelOperation(mod)
+	}
+}
+
+// operationProcess returns the Prism process id's operation launched, which is nil
+// until Prism starts. ok is false when id has no operation.
+func (g *GUI) operationProcess(id string) (proc *os.Process, ok bool) {
+	g.runningMu.RLock()
+	defer g.runningMu.RUnlock()
+	op, ok := g.operations[id]
+	if !ok {
+		return nil, false
+	}
+	return op.process, true
+}
+
+// operationProcesses returns every Prism process launched by an operation still in progress
+func (g *GUI) operationProcesses() []*os.Process {
+	g.runningMu.RLock()
+	defer g.runningMu.RUnlock()
+	var procs []*os.Process
+	for _, op := range g.operations {
+		if op.process != nil {
+			procs = append(procs, op.process)
+		}
+	}
+	return procs
 }
 
 func (g *GUI) killRunningInstance(mod Modpack) {
@@ -1222,12 +3121,11 @@ func (g *GUI) killRunningInstance(mod Modpack) {
 		processID = state.ProcessID
 	} else {
 		// Regular process
-		if g.getRunningModpackID() != mod.ID {
+		proc, ok := g.operationProcess(mod.ID)
+		if !ok {
 			g.updateStatus("No running process to kill for this modpack")
 			return
 		}
-
-		proc := g.getPrismProcess()
 		if proc == nil {
 			g.updateStatus("No running process detected")
 			return
@@ -1262,7 +3160,8 @@ func (g *GUI) killRunningInstance(mod Modpack) {
 	g.updateStatus(fmt.Sprintf("Kill signal sent to %s and related processes", mod.DisplayName))
 
 	// Update state
-	g.setRunningModpackID("")
+	g.endPlaySession(mod.ID)
+	g.endOperation(mod.ID, nil)
 	g.setModpackState(mod.ID, func(state *ModpackState) {
 		state.Running = false
 		state.Busy = false
@@ -1278,32 +3177,93 @@ func (g *GUI) killRunningInstance(mod Modpack) {
 			logf("Warning: Failed to remove process record: %v", err)
 		}
 	}
+}
 
-	// Clear regular process reference
-	g.processMu.Lock()
-	if g.prismProcess != nil {
-		*g.prismProcess = nil
-	}
-	g.processMu.Unlock()
+// confirmStopAll asks before force-closing every running instance
+func (g *GUI) confirmStopAll() {
+	dialog.ShowConfirm(T("stopAll.title"),
+		T("stopAll.confirm"),
+		func(ok bool) {
+			if ok {
+				go g.stopAllInstances()
+			}
+		}, g.window)
 }
 
-// reattachToProcess reattaches to an existing running process
-func (g *GUI) reattachToProcess(mod Modpack, processID string) {
-	if g.processRegistry == nil {
-		g.updateStatus("Process registry not available")
-		return
-	}
+// stopAllInstances kills the launched Prism process, every process in the registry
+// and any remaining Prism/Minecraft processes, then marks all modpacks as stopped
+func (g *GUI) stopAllInstances() {
+	logf("%s", infoLine("Stopping all running instances"))
+	g.updateStatus("Stopping all running instances...")
 
-	// Get the process record
-	record, err := g.processRegistry.GetRecord(processID)
-	if err != nil {
-		logf("%s", warnLine(fmt.Sprintf("Failed to get process record %s: %v", processID, err)))
-		g.updateStatus("Process not found in registry")
-		return
+	// Count each modpack once, whether the launcher started it or it was left running
+	// by an earlier session and is only known to the registry
+	stopped := make(map[string]bool)
+	g.stateMu.RLock()
+	var ids []string
+	for id, state := range g.modpackStates {
+		if state.Running || state.Reattachable {
+			ids = append(ids, id)
+			stopped[id] = true
+		}
+	}
+	g.stateMu.RUnlock()
+	if g.processRegistry != nil {
+		for _, record := range g.processRegistry.GetRunningProcesses() {
+			stopped[record.ModpackID] = true
+		}
+	}
+
+	killed := killRegisteredProcesses(g.processRegistry)
+	for _, proc := range g.operationProcesses() {
+		if err := killProcessByPID(proc.Pid); err != nil {
+			logf("%s", warnLine(fmt.Sprintf("Failed to kill Prism process %d: %v", proc.Pid, err)))
+		}
+	}
+	if err := forceCloseAllProcesses(nil); err != nil {
+		logf("%s", warnLine(fmt.Sprintf("Failed to force-close processes: %v", err)))
+	}
+
+	for _, id := range ids {
+		g.endPlaySession(id)
+		g.setModpackState(id, func(state *ModpackState) {
+			state.Running = false
+			state.Busy = false
+			state.RunningPID = 0
+			state.Reattachable = false
+			state.ProcessID = ""
+			state.ProcessStatus = ProcessStatusStopped
+		})
+		g.endOperation(id, nil)
+	}
+
+	logf("%s", successLine(fmt.Sprintf("Stopped %d instance(s) (%d tracked process(es) killed)", len(stopped), killed)))
+	// Games only the registry knew about have no modpack state to refresh the button
+	fyne.Do(func() {
+		if g.stopAllBtn != nil {
+			g.stopAllBtn.Disable()
+		}
+	})
+	g.updateStatus(fmt.Sprintf("Stopped %d running instance(s)", len(stopped)))
+}
+
+// reattachToProcess reattaches to an existing running process
+func (g *GUI) reattachToProcess(mod Modpack, processID string) {
+	if g.processRegistry == nil {
+		g.updateStatus("Process registry not available")
+		return
+	}
+
+	// Get the process record
+	record, err := g.processRegistry.GetRecord(processID)
+	if err != nil {
+		logf("%s", warnLine(fmt.Sprintf("Failed to get process record %s: %v", processID, err)))
+		g.updateStatus("Process not found in registry")
+		return
 	}
 
 	// Validate that the process is still running and matches expected details
-	isValid, err := validateProcessIdentity(record.PID, record.Executable, record.WorkingDir)
+	isValid, err := validateProcessIdentity(record.PID, record.Executable, record.WorkingDir, record.StartTime)
 	if err != nil {
 		logf("%s", warnLine(fmt.Sprintf("Failed to validate process identity: %v", err)))
 		g.updateStatus("Failed to validate process")
@@ -1331,7 +3291,7 @@ func (g *GUI) reattachToProcess(mod Modpack, processID string) {
 	g.updateStatus(fmt.Sprintf("Reattached to %s", mod.DisplayName))
 
 	// Update modpack state
-	g.setRunningModpackID(mod.ID)
+	g.beginOperation(mod.ID)
 	g.setModpackState(mod.ID, func(state *ModpackState) {
 		state.Running = true
 		state.RunningPID = record.PID
@@ -1339,6 +3299,7 @@ func (g *GUI) reattachToProcess(mod Modpack, processID string) {
 		state.ProcessID = processID
 		state.ProcessStatus = ProcessStatusRunning
 	})
+	g.trackReattachedSession(mod.ID, record.PID, record.StartTime)
 
 	// Update last seen time
 	if err := g.processRegistry.UpdateProcessLastSeen(processID); err != nil {
@@ -1346,89 +3307,991 @@ func (g *GUI) reattachToProcess(mod Modpack, processID string) {
 	}
 }
 
-func (g *GUI) deleteModpack(mod Modpack) {
+func (g *GUI) deleteModpack(mod Modpack) {
+	state := g.getModpackState(mod.ID)
+	if state != nil && (state.Busy || state.Running) {
+		g.updateStatus("Cannot delete while modpack is busy or running")
+		return
+	}
+
+	logf("%s", infoLine(fmt.Sprintf("Deleting modpack data: %s", mod.DisplayName)))
+
+	g.setModpackState(mod.ID, func(state *ModpackState) {
+		state.Busy = true
+		state.CurrentAction = ActionNone
+	})
+
+	go func() {
+		if err := g.removeModpackData(mod); err != nil {
+			logf("%s", warnLine(fmt.Sprintf("Failed to delete %s: %v", mod.DisplayName, err)))
+			g.updateStatus(fmt.Sprintf("Delete failed: %v", err))
+			g.setModpackState(mod.ID, func(state *ModpackState) {
+				state.Busy = false
+				state.Error = err
+			})
+			return
+		}
+
+		g.setModpackState(mod.ID, func(state *ModpackState) {
+			state.Busy = false
+			state.Installed = false
+			state.UpdateAvailable = false
+			state.LocalVersion = ""
+			state.Running = false
+			state.RunningPID = 0
+			state.Error = nil
+		})
+
+		if isCustomModpack(mod) {
+			if err := removeCustomModpack(g.root, mod.ID); err != nil {
+				logf("%s", warnLine(fmt.Sprintf("Failed to remove custom pack %s: %v", mod.DisplayName, err)))
+				g.updateStatus(fmt.Sprintf("Delete failed: %v", err))
+				return
+			}
+			g.updateStatus(fmt.Sprintf("Removed custom pack %s", mod.DisplayName))
+			logf("%s", successLine(fmt.Sprintf("Removed custom pack: %s", mod.DisplayName)))
+			fyne.Do(g.reloadCustomModpacks)
+			return
+		}
+
+		g.updateStatus(fmt.Sprintf("Deleted %s", mod.DisplayName))
+		logf("%s", successLine(fmt.Sprintf("Deleted modpack data: %s", mod.DisplayName)))
+		g.refreshModpackState(mod)
+	}()
+}
+
+// showCrashReport tells the player Minecraft crashed and offers its crash report
+func (g *GUI) showCrashReport(mod Modpack, reportPath string) {
+	fyne.Do(func() {
+		message := widget.NewLabel(Tf("crash.message", mod.DisplayName, reportPath))
+		message.Wrapping = fyne.TextWrapWord
+		var d dialog.Dialog
+		openBtn := widget.NewButtonWithIcon(T("crash.open"), theme.FileTextIcon(), func() {
+			if err := openPath(reportPath); err != nil {
+				dialog.ShowError(errors.New(Tf("crash.openFailed", reportPath, err)), g.window)
+			}
+		})
+		uploadBtn := widget.NewButtonWithIcon(T("crash.upload"), theme.UploadIcon(), func() {
+			d.Hide()
+			g.uploadLogFile(reportPath)
+		})
+		uploadBtn.Importance = widget.HighImportance
+		content := container.NewVBox(message, container.NewHBox(layout.NewSpacer(), openBtn, uploadBtn))
+		d = dialog.NewCustom(T("crash.title"), T("action.close"), content, g.window)
+		d.Resize(fyne.NewSize(560, 0))
+		d.Show()
+	})
+}
+
+// showModList shows a searchable list of the mods installed in the modpack
+func (g *GUI) showModList(mod Modpack) {
+	state := g.getModpackState(mod.ID)
+	if state == nil || !state.Installed {
+		g.updateStatus(fmt.Sprintf("%s is not installed", mod.DisplayName))
+		return
+	}
+	g.updateStatus(fmt.Sprintf("Reading the mods in %s...", mod.DisplayName))
+
+	go func() {
+		instDir := g.modpackInstanceDir(mod)
+		version, _ := getLocalPackVersion(mod, instDir)
+		mods, err := loadModList(instDir, version)
+		fyne.Do(func() {
+			if err != nil {
+				dialog.ShowError(fmt.Errorf("Failed to read the mod list: %v", err), g.window)
+				return
+			}
+			g.updateStatus(fmt.Sprintf("%s has %d mods", mod.DisplayName, len(mods)))
+			if len(mods) == 0 {
+				dialog.ShowInformation(T("modList.title"), Tf("modList.empty", mod.DisplayName), g.window)
+				return
+			}
+
+			shown := mods
+			count := widget.NewLabel(Tf("modList.count", len(mods)))
+			list := widget.NewList(
+				func() int { return len(shown) },
+				func() fyne.CanvasObject {
+					return container.NewBorder(nil, nil, nil, widget.NewLabel(""), widget.NewLabel(""))
+				},
+				func(id widget.ListItemID, item fyne.CanvasObject) {
+					m := shown[id]
+					row := item.(*fyne.Container)
+					row.Objects[0].(*widget.Label).SetText(m.Name)
+					details := strings.TrimSpace(m.Version + "  " + m.Loader)
+					if details == "" {
+						details = m.File
+					}
+					row.Objects[1].(*widget.Label).SetText(details)
+				},
+			)
+
+			search := widget.NewEntry()
+			search.SetPlaceHolder(T("modList.search"))
+			search.OnChanged = func(query string) {
+				query = strings.ToLower(strings.TrimSpace(query))
+				if query == "" {
+					shown = mods
+				} else {
+					shown = nil
+					for _, m := range mods {
+						if strings.Contains(strings.ToLower(m.Name), query) || strings.Contains(strings.ToLower(m.ID), query) ||
+							strings.Contains(strings.ToLower(m.File), query) {
+							shown = append(shown, m)
+						}
+					}
+				}
+				count.SetText(Tf("modList.matching", len(shown), len(mods)))
+				list.Refresh()
+			}
+
+			content := container.NewBorder(container.NewVBox(search, count), nil, nil, nil, list)
+			d := dialog.NewCustom(fmt.Sprintf("%s - %s", T("modList.title"), mod.DisplayName), "Close", content, g.window)
+			d.Resize(fyne.NewSize(620, 520))
+			d.Show()
+			g.window.Canvas().Focus(search)
+		})
+	}()
+}
+
+// showRestoreBackup lists the modpack's update backups and restores the chosen one
+func (g *GUI) showRestoreBackup(mod Modpack) {
+	state := g.getModpackState(mod.ID)
+	if state != nil && (state.Busy || state.Running) {
+		g.updateStatus("Cannot restore while modpack is busy or running")
+		return
+	}
+
+	go func() {
+		backups, err := listModpackBackups(g.root, mod)
+		options := make([]string, len(backups))
+		for i, b := range backups {
+			options[i] = backupLabel(b, dirSize(b.Path))
+		}
+		fyne.Do(func() {
+			if err != nil {
+				dialog.ShowError(fmt.Errorf("Failed to list backups: %v", err), g.window)
+				return
+			}
+			if len(backups) == 0 {
+				dialog.ShowInformation("Restore Backup", fmt.Sprintf("%s has no backups yet. One is made before every update.", mod.DisplayName), g.window)
+				return
+			}
+
+			choice := widget.NewRadioGroup(options, nil)
+			choice.SetSelected(options[0])
+			choice.Required = true
+			list := container.NewVScroll(choice)
+			list.SetMinSize(fyne.NewSize(520, 160))
+			d := dialog.NewCustomConfirm("Restore Backup", "Restore...", "Cancel", list, func(ok bool) {
+				if !ok {
+					return
+				}
+				for i, option := range options {
+					if option == choice.Selected {
+						g.confirmRestoreBackup(mod, backups[i])
+						return
+					}
+				}
+			}, g.window)
+			d.Resize(fyne.NewSize(600, 320))
+			d.Show()
+		})
+	}()
+}
+
+// backupLabel describes a backup by when it was made and what it holds
+func backupLabel(b modpackBackup, size int64) string {
+	parts := []string{b.Created.Format("2006-01-02 15:04")}
+	if b.Meta != nil && b.Meta.PackVersion != "" {
+		parts = append(parts, "version "+b.Meta.PackVersion)
+	}
+	if b.Meta != nil && b.Meta.MinecraftVersion != "" {
+		parts = append(parts, "Minecraft "+b.Meta.MinecraftVersion)
+	}
+	parts = append(parts, formatSize(size))
+	return strings.Join(parts, " · ")
+}
+
+// confirmRestoreBackup warns what restoring overwrites, and about a Minecraft or
+// modloader mismatch, before restoring
+func (g *GUI) confirmRestoreBackup(mod Modpack, b modpackBackup) {
+	message := fmt.Sprintf("Restore %s from the backup made %s?\n\nThis replaces the mods, configs, resource packs and shader packs in the instance. Worlds are not touched.", mod.DisplayName, b.Created.Format("2006-01-02 15:04"))
+	if warnings := backupCompatibilityWarnings(b.Path, g.modpackInstanceDir(mod)); len(warnings) > 0 {
+		message += "\n\nWarning:\n• " + strings.Join(warnings, "\n• ")
+	}
+	dialog.ShowConfirm("Restore Backup", message, func(ok bool) {
+		if ok {
+			g.restoreBackup(mod, b)
+		}
+	}, g.window)
+}
+
+// restoreBackup puts a backup's files back into the instance and refreshes the card
+// so it shows the restored version
+func (g *GUI) restoreBackup(mod Modpack, b modpackBackup) {
+	state := g.getModpackState(mod.ID)
+	if state != nil && (state.Busy || state.Running) {
+		g.updateStatus("Cannot restore while modpack is busy or running")
+		return
+	}
+
+	logf("%s", infoLine(fmt.Sprintf("Restoring %s from backup %s", mod.DisplayName, b.Name)))
+	g.setModpackState(mod.ID, func(state *ModpackState) {
+		state.Busy = true
+		state.CurrentAction = ActionNone
+	})
+
+	go func() {
+		mcDir := filepath.Join(g.modpackInstanceDir(mod), "minecraft")
+		// The user already accepted any mismatch in confirmRestoreBackup
+		err := restoreModpackBackup(mod, b.Path, mcDir, func([]string) bool { return true })
+		g.setModpackState(mod.ID, func(state *ModpackState) {
+			state.Busy = false
+			state.Error = err
+		})
+		if err != nil {
+			logf("%s", warnLine(fmt.Sprintf("Failed to restore %s: %v", mod.DisplayName, err)))
+			g.updateStatus(fmt.Sprintf("Restore failed: %v", err))
+			fyne.Do(func() {
+				dialog.ShowError(fmt.Errorf("%s could not be restored:\n\n%v", mod.DisplayName, err), g.window)
+			})
+			return
+		}
+		g.updateStatus(fmt.Sprintf("Restored %s from %s", mod.DisplayName, b.Created.Format("2006-01-02 15:04")))
+		g.refreshModpackState(mod)
+	}()
+}
+
+// showAddCustomPack asks for a pack.toml URL or an exported pack zip to add as a
+// custom modpack
+func (g *GUI) showAddCustomPack() {
+	if g.previewSource != "" {
+		g.updateStatus("Custom packs can't be added while previewing a catalog")
+		return
+	}
+
+	urlEntry := widget.NewEntry()
+	urlEntry.SetPlaceHolder("https://example.com/mypack/pack.toml")
+	urlEntry.Validator = func(raw string) error {
+		_, err := parseCustomPackURL(raw)
+		return err
+	}
+	hint := widget.NewLabel(T("customPack.hint"))
+	hint.Wrapping = fyne.TextWrapWord
+
+	var d dialog.Dialog
+	zipBtn := widget.NewButtonWithIcon(T("customPack.chooseZip"), theme.FolderOpenIcon(), func() {
+		open := dialog.NewFileOpen(func(reader fyne.URIReadCloser, err error) {
+			if err != nil || reader == nil {
+				return
+			}
+			zipPath := reader.URI().Path()
+			reader.Close()
+			d.Hide()
+			g.importCustomPack(filepath.Base(zipPath), func() (Modpack, error) {
+				return importCustomPackZip(g.root, zipPath)
+			})
+		}, g.window)
+		open.SetFilter(storage.NewExtensionFileFilter([]string{".zip"}))
+		open.Show()
+	})
+
+	items := []*widget.FormItem{
+		widget.NewFormItem("", hint),
+		widget.NewFormItem(T("customPack.url"), urlEntry),
+		widget.NewFormItem("", zipBtn),
+	}
+	d = dialog.NewForm(T("customPack.title"), T("customPack.add"), T("action.cancel"), items, func(confirmed bool) {
+		if !confirmed {
+			return
+		}
+		raw := urlEntry.Text
+		g.importCustomPack(raw, func() (Modpack, error) {
+			return addCustomModpackURL(g.root, raw)
+		})
+	}, g.window)
+	d.Resize(fyne.NewSize(560, 0))
+	d.Show()
+}
+
+// handleDrop adds pack zips and pack.toml links dropped onto the window as custom packs
+func (g *GUI) handleDrop(_ fyne.Position, uris []fyne.URI) {
+	if g.previewSource != "" {
+		g.updateStatus("Custom packs can't be added while previewing a catalog")
+		return
+	}
+	for _, uri := range uris {
+		switch {
+		case uri.Scheme() == "file" && strings.EqualFold(uri.Extension(), ".zip"):
+			zipPath := uri.Path()
+			g.importCustomPack(filepath.Base(zipPath), func() (Modpack, error) {
+				return importCustomPackZip(g.root, zipPath)
+			})
+		case uri.Scheme() == "http" || uri.Scheme() == "https":
+			raw := uri.String()
+			g.importCustomPack(raw, func() (Modpack, error) {
+				return addCustomModpackURL(g.root, raw)
+			})
+		default:
+			g.updateStatus(fmt.Sprintf("%s is not a pack zip or pack.toml link", uri.Name()))
+		}
+	}
+}
+
+// importCustomPack runs load in the background and adds the pack it returns to the list
+func (g *GUI) importCustomPack(label string, load func() (Modpack, error)) {
+	g.updateStatus(fmt.Sprintf("Adding custom pack %s...", label))
+	go func() {
+		mod, err := load()
+		if err != nil {
+			logf("%s", warnLine(fmt.Sprintf("Failed to add custom pack %s: %v", label, err)))
+			fyne.Do(func() {
+				g.updateStatus(fmt.Sprintf("Failed to add custom pack: %v", err))
+				dialog.ShowError(err, g.window)
+			})
+			return
+		}
+		logf("%s", successLine(fmt.Sprintf("Added custom pack %s from %s", mod.DisplayName, label)))
+		fyne.Do(func() {
+			g.reloadCustomModpacks()
+			g.updateStatus(fmt.Sprintf("Added custom pack %s", mod.DisplayName))
+		})
+	}()
+}
+
+// reloadCustomModpacks swaps the custom packs in the list for the ones saved on disk
+// and checks their install state
+func (g *GUI) reloadCustomModpacks() {
+	g.modpacks = withCustomModpacks(g.root, g.modpacks)
+	g.populateTagChips()
+	g.applyFilters()
+	for _, mod := range g.modpacks {
+		if isCustomModpack(mod) {
+			go g.refreshModpackState(mod)
+		}
+	}
+}
+
+func (g *GUI) removeModpackData(mod Modpack) error {
+	instDir := g.modpackInstanceDir(mod)
+	if !exists(instDir) {
+		return nil
+	}
+	return os.RemoveAll(instDir)
+}
+
+// reinstallModpack asks whether to keep worlds and options, then wipes the instance and
+// installs it again
+func (g *GUI) reinstallModpack(mod Modpack) {
+	state := g.getModpackState(mod.ID)
+	if state != nil && (state.Busy || state.Running) {
+		g.updateStatus("Cannot reinstall while modpack is busy or running")
+		return
+	}
+
+	message := widget.NewLabel(fmt.Sprintf("Reinstalling deletes %s and downloads it again.\n\nKeep saves moves your worlds, options.txt and servers.dat aside and puts them back once the fresh install is done.", mod.DisplayName))
+	message.Wrapping = fyne.TextWrapWord
+	var d dialog.Dialog
+	keepBtn := widget.NewButtonWithIcon(T("action.reinstallKeepSaves"), theme.DocumentSaveIcon(), func() {
+		d.Hide()
+		g.startReinstall(mod, true)
+	})
+	keepBtn.Importance = widget.HighImportance
+	wipeBtn := widget.NewButtonWithIcon(T("action.reinstallEverything"), theme.DeleteIcon(), func() {
+		d.Hide()
+		g.startReinstall(mod, false)
+	})
+	wipeBtn.Importance = widget.DangerImportance
+	content := container.NewBorder(nil, container.NewHBox(layout.NewSpacer(), wipeBtn, keepBtn), nil, nil, message)
+	d = dialog.NewCustom("Reinstall "+mod.DisplayName, T("action.cancel"), content, g.window)
+	d.Resize(fyne.NewSize(520, 240))
+	d.Show()
+}
+
+// startReinstall wipes the instance, optionally keeping player data for runLauncherLogic
+// to restore after packwiz has synced, and starts a fresh install
+func (g *GUI) startReinstall(mod Modpack, keepSaves bool) {
+	logf("%s", infoLine(fmt.Sprintf("Reinstalling modpack: %s", mod.DisplayName)))
+
+	g.setModpackState(mod.ID, func(s *ModpackState) {
+		s.Busy = true
+		s.CurrentAction = ActionInstall
+		s.Error = nil
+	})
+
+	go func() {
+		if keepSaves {
+			kept, err := keepPlayerData(filepath.Join(g.modpackInstanceDir(mod), "minecraft"), keptDataDir(g.root, mod))
+			if err != nil {
+				logf("%s", warnLine(fmt.Sprintf("Failed to set aside saves for %s: %v", mod.DisplayName, err)))
+				g.updateStatus(fmt.Sprintf("Reinstall cancelled: %v", err))
+				g.setModpackState(mod.ID, func(s *ModpackState) {
+					s.Busy = false
+					s.Error = err
+				})
+				return
+			}
+			if len(kept) > 0 {
+				logf("%s", infoLine(fmt.Sprintf("Keeping %s for the reinstall", strings.Join(kept, ", "))))
+			}
+		}
+
+		if err := g.removeModpackData(mod); err != nil {
+			logf("%s", warnLine(fmt.Sprintf("Failed to prepare reinstall for %s: %v", mod.DisplayName, err)))
+			g.updateStatus(fmt.Sprintf("Reinstall failed: %v", err))
+			g.setModpackState(mod.ID, func(s *ModpackState) {
+				s.Busy = false
+				s.Error = err
+			})
+			return
+		}
+
+		g.setModpackState(mod.ID, func(s *ModpackState) {
+			s.Installed = false
+			s.UpdateAvailable = false
+			s.LocalVersion = ""
+		})
+
+		g.runModpackOperation(mod, ActionInstall)
+	}()
+}
+
+// forceResyncModpack re-runs packwiz with the CDN cache bypassed, for when a stale
+// pack.toml keeps an update from applying
+func (g *GUI) forceResyncModpack(mod Modpack) {
+	state := g.getModpackState(mod.ID)
+	if state != nil && (state.Busy || state.Running) {
+		g.updateStatus("Cannot re-sync while modpack is busy or running")
+		return
+	}
+
+	logf("%s", infoLine(fmt.Sprintf("Force re-syncing modpack: %s", mod.DisplayName)))
+	g.confirmMemoryThen(mod, func() {
+		g.runModpackOperationWithOptions(mod, ActionUpdate, launchOptions{ForceResync: true})
+	})
+}
+
+// verifyModpack re-checks an installed modpack and downloads only the files that
+// are missing or damaged, which is much faster than a reinstall
+func (g *GUI) verifyModpack(mod Modpack) {
+	state := g.getModpackState(mod.ID)
+	if state != nil && (state.Busy || state.Running) {
+		g.updateStatus("Cannot verify while modpack is busy or running")
+		return
+	}
+	g.runModpackOperationWithOptions(mod, ActionVerify, launchOptions{Verify: true})
+}
+
+// showVerifyResult tells the user what a finished verify fixed
+func (g *GUI) showVerifyResult(mod Modpack, result launchResult) {
+	message := Tf("verify.intact", mod.DisplayName)
+	switch {
+	case result.UpdatePending != "":
+		message = Tf("verify.updatePending", mod.DisplayName, result.Version, result.UpdatePending)
+	case result.Repaired > 0:
+		message = Tf("verify.repaired", mod.DisplayName, result.Repaired)
+	}
+	if result.UpdatedFiles > 0 {
+		message += "\n\n" + Tf("verify.updatedFiles", result.UpdatedFiles, result.Version)
+	}
+	fyne.Do(func() {
+		dialog.ShowInformation(T("verify.title"), message, g.window)
+	})
+}
+
+// showLaunchCommand shows what launching the modpack will run and lets the user
+// change the JVM arguments for a single launch
+func (g *GUI) showLaunchCommand(mod Modpack) {
+	cmd, err := describeLaunchCommand(g.root, mod)
+	if err != nil {
+		dialog.ShowError(err, g.window)
+		return
+	}
+
+	commandText := widget.NewLabelWithStyle(cmd.String(), fyne.TextAlignLeading, fyne.TextStyle{Monospace: true})
+	commandText.Wrapping = fyne.TextWrapBreak
+
+	argsEntry := widget.NewEntry()
+	argsEntry.SetText(cmd.JvmArgs)
+	argsEntry.SetPlaceHolder("e.g. -XX:+UseG1GC -XX:MaxGCPauseMillis=50")
+
+	copyBtn := widget.NewButtonWithIcon("Copy", theme.ContentCopyIcon(), func() {
+		g.window.Clipboard().SetContent(cmd.String())
+		g.updateStatus("Launch command copied to clipboard")
+	})
+	// Reproduces the direct launch by hand, for debugging a Prism that won't start
+	copyShellBtn := widget.NewButtonWithIcon("Copy for terminal", theme.ComputerIcon(), func() {
+		shell := cmd.ShellCommand()
+		logf("%s", infoLine(fmt.Sprintf("Launch command for %s: %s", mod.DisplayName, shell)))
+		g.window.Clipboard().SetContent(shell)
+		g.updateStatus("Launch command with its environment copied to clipboard")
+	})
+
+	note := widget.NewLabel("The arguments below are used for the next launch only; the instance's saved arguments are restored when the game exits.")
+	note.Wrapping = fyne.TextWrapWord
+
+	content := container.NewVBox(
+		container.NewBorder(nil, nil, widget.NewLabelWithStyle("Resolved command", fyne.TextAlignLeading, fyne.TextStyle{Bold: true}), container.NewHBox(copyBtn, copyShellBtn)),
+		commandText,
+		widget.NewSeparator(),
+		widget.NewLabelWithStyle("JVM arguments for one launch", fyne.TextAlignLeading, fyne.TextStyle{Bold: true}),
+		argsEntry,
+		note,
+	)
+
+	d := dialog.NewCustomConfirm(fmt.Sprintf("Launch Command - %s", mod.DisplayName), "Launch once", "Close", content, func(ok bool) {
+		if !ok {
+			return
+		}
+		args := strings.TrimSpace(argsEntry.Text)
+		opts := launchOptions{}
+		if args != cmd.JvmArgs {
+			opts.JvmArgs = &args
+		}
+		g.confirmMemoryThen(mod, func() {
+			g.runModpackOperationWithOptions(mod, ActionLaunch, opts)
+		})
+	}, g.window)
+	d.Resize(fyne.NewSize(640, 420))
+	d.Show()
+}
+
+// openInPrism opens the modpack's instance in Prism's own window, for settings the
+// launcher doesn't expose
+func (g *GUI) openInPrism(mod Modpack) {
+	state := g.getModpackState(mod.ID)
+	if state != nil && (state.Busy || state.Running) {
+		g.updateStatus("Cannot open in Prism while modpack is busy or running")
+		return
+	}
+	if err := openInstanceInPrism(g.root, mod); err != nil {
+		logf("%s", warnLine(fmt.Sprintf("Failed to open %s in Prism: %v", mod.DisplayName, err)))
+		dialog.ShowError(err, g.window)
+		return
+	}
+	g.updateStatus(fmt.Sprintf("Opened %s in Prism Launcher", mod.DisplayName))
+}
+
+// showDoctorReport runs the installation health check and shows the report
+func (g *GUI) showDoctorReport() {
+	g.updateStatus("Checking installation...")
+	go func() {
+		report, ok := formatDoctorReport(runDoctor(g.root, g.modpacks))
+		logf("%s", sectionLine("Installation Health Check"))
+		logf("%s", report)
+		fyne.Do(func() {
+			if ok {
+				g.updateStatus("Health check passed")
+			} else {
+				g.updateStatus("Health check found problems")
+			}
+			reportLabel := widget.NewLabelWithStyle(report, fyne.TextAlignLeading, fyne.TextStyle{Monospace: true})
+			reportLabel.Wrapping = fyne.TextWrapWord
+			copyBtn := widget.NewButtonWithIcon("Copy", theme.ContentCopyIcon(), func() {
+				g.window.Clipboard().SetContent(report)
+				g.updateStatus("Health report copied to clipboard")
+			})
+			content := container.NewBorder(nil, container.NewHBox(layout.NewSpacer(), copyBtn), nil, nil, container.NewVScroll(reportLabel))
+			d := dialog.NewCustom("Installation Health Check", "Close", content, g.window)
+			d.Resize(fyne.NewSize(680, 480))
+			d.Show()
+		})
+	}()
+}
+
+// maxIssueErrorLen keeps pre-filled issue links well under GitHub's URL length limit
+const maxIssueErrorLen = 1500
+
+// issueReportURL builds a GitHub new-issue link pre-filled with the launcher version,
+// platform, the last error and the uploaded log link, if there is one
+func issueReportURL(lastError, logURL string) string {
+	if lastError == "" {
+		lastError = "<none shown>"
+	} else if len(lastError) > maxIssueErrorLen {
+		lastError = lastError[:maxIssueErrorLen] + "..."
+	}
+	if logURL == "" {
+		logURL = "<upload the log from the Console tab and paste the link here>"
+	}
+	body := fmt.Sprintf("**What happened?**\n<describe the problem and what you were doing>\n\n"+
+		"**Launcher version:** %s\n**OS / arch:** %s/%s\n**Log:** %s\n\n**Last error:**\n```\n%s\n```\n",
+		version, runtime.GOOS, runtime.GOARCH, logURL, lastError)
+	query := url.Values{}
+	query.Set("body", body)
+	return fmt.Sprintf("https://github.com/%s/%s/issues/new?%s", UPDATE_OWNER, UPDATE_REPO, query.Encode())
+}
+
+// recentErrors lists the errors currently shown on modpack cards
+func (g *GUI) recentErrors() string {
+	g.stateMu.RLock()
+	var lines []string
+	for id, state := range g.modpackStates {
+		if state.Error != nil {
+			name := id
+			if mod, ok := g.findModpack(id); ok {
+				name = mod.DisplayName
+			}
+			lines = append(lines, fmt.Sprintf("%s: %v", name, state.Error))
+		}
+	}
+	g.stateMu.RUnlock()
+	sort.Strings(lines)
+	return strings.Join(lines, "\n")
+}
+
+// reportIssue opens a pre-filled GitHub issue, first offering to upload the current
+// log so its link is included. lastError may be empty to use the errors on the cards.
+func (g *GUI) reportIssue(lastError string) {
+	if lastError == "" {
+		lastError = g.recentErrors()
+	}
+	open := func(logURL string) {
+		if err := openPath(issueReportURL(lastError, logURL)); err != nil {
+			logf("%s", warnLine(fmt.Sprintf("Failed to open the issue page: %v", err)))
+			fyne.Do(func() { dialog.ShowError(err, g.window) })
+			return
+		}
+		g.updateStatus("Opened a new issue in your browser")
+	}
+	dialog.ShowConfirm("Report Issue", "Upload the current log and include its link in the issue?\n\nThe log can contain your computer's user name and folder paths.", func(upload bool) {
+		if !upload {
+			open("")
+			return
+		}
+		g.updateStatus("Uploading log...")
+		go func() {
+			logURL, err := configuredLogUploader().Upload(filepath.Join(g.root, "logs", "latest.log"))
+			if err != nil {
+				logf("%s", warnLine(fmt.Sprintf("Log upload for issue report failed: %v", err)))
+				logURL = ""
+			}
+			open(logURL)
+		}()
+	}, g.window)
+}
+
+// hasQtIssue reports whether analyzePrismError found a problem the Qt repair can fix
+func hasQtIssue(issues []string) bool {
+	for _, issue := range issues {
+		if strings.Contains(issue, "Qt") || strings.Contains(issue, "RPATH") {
+			return true
+		}
+	}
+	return false
+}
+
+// repairQt re-runs the Linux Qt plugin fixes and shows what changed
+func (g *GUI) repairQt() {
+	if g.anyModpackActive() {
+		dialog.ShowError(fmt.Errorf("Close running modpacks and wait for installs to finish before repairing Qt."), g.window)
+		return
+	}
+	g.showLoading(true, "Repairing Qt environment...")
+	g.updateStatus("Repairing Qt environment...")
+	go func() {
+		defer g.showLoading(false, "")
+		result, err := repairQtEnvironment(filepath.Join(g.root, "prism"))
+		if err != nil {
+			logf("%s", warnLine(fmt.Sprintf("Qt repair failed: %v", err)))
+			fyne.Do(func() {
+				g.updateStatus("Qt repair failed")
+				dialog.ShowError(err, g.window)
+			})
+			return
+		}
+		report := formatQtRepairReport(result)
+		logf("%s", report)
+		fyne.Do(func() {
+			g.updateStatus("Qt repair finished")
+			reportLabel := widget.NewLabelWithStyle(report, fyne.TextAlignLeading, fyne.TextStyle{Monospace: true})
+			reportLabel.Wrapping = fyne.TextWrapWord
+			d := dialog.NewCustom("Repair Qt Environment", "Close", container.NewVScroll(reportLabel), g.window)
+			d.Resize(fyne.NewSize(620, 380))
+			d.Show()
+		})
+	}()
+}
+
+// cleanupJREs removes Java runtimes no installed instance needs. Run from Settings it
+// reports what it freed; after a launch it only logs.
+func (g *GUI) cleanupJREs(manual bool) {
+	removed, freed, err := cleanupUnusedJREs(g.root)
+	if err != nil {
+		logf("%s", warnLine(fmt.Sprintf("Java cleanup failed: %v", err)))
+		if manual {
+			fyne.Do(func() {
+				dialog.ShowError(err, g.window)
+			})
+		}
+		return
+	}
+	if !manual {
+		if len(removed) > 0 {
+			logf("%s", successLine(fmt.Sprintf("Freed %s by removing unused Java %s", formatSize(freed), strings.Join(removed, ", "))))
+		}
+		return
+	}
+
+	message := T("javaCleanup.none")
+	if len(removed) > 0 {
+		logf("%s", successLine(fmt.Sprintf("Removed Java %s and freed %s", strings.Join(removed, ", "), formatSize(freed))))
+		message = Tf("javaCleanup.removed", strings.Join(removed, ", "), formatSize(freed))
+	}
+	g.updateStatus(message)
+	fyne.Do(func() {
+		dialog.ShowInformation(T("javaCleanup.title"), message, g.window)
+	})
+}
+
+// showStorage shows how much space each modpack and the shared downloads take, with
+// buttons to delete backups and unused Java runtimes
+func (g *GUI) showStorage() {
+	// scanStorage runs off the UI thread, which owns g.modpacks
+	mods := append([]Modpack(nil), g.modpacks...)
+	body := container.NewVBox(widget.NewLabel(T("storage.scanning")))
+	d := dialog.NewCustom(T("storage.title"), "Close", container.NewVScroll(body), g.window)
+	d.Resize(fyne.NewSize(640, 520))
+	d.Show()
+
+	var reload func()
+	remove := func(item storageItem, del func() error) {
+		dialog.ShowConfirm("Delete "+item.Name, fmt.Sprintf("Delete %s and free %s?", item.Name, formatSize(item.Size)), func(ok bool) {
+			if !ok {
+				return
+			}
+			if g.anyModpackActive() {
+				dialog.ShowError(fmt.Errorf("Close running modpacks and wait for installs to finish before deleting files."), g.window)
+				return
+			}
+			go func() {
+				if err := del(); err != nil {
+					logf("%s", warnLine(fmt.Sprintf("Failed to delete %s: %v", item.Path, err)))
+					fyne.Do(func() { dialog.ShowError(err, g.window) })
+					return
+				}
+				logf("%s", successLine(fmt.Sprintf("Deleted %s (%s)", item.Name, formatSize(item.Size))))
+				reload()
+			}()
+		}, g.window)
+	}
+	// del deletes the item; rows without one have no delete button
+	row := func(item storageItem, del func() error) fyne.CanvasObject {
+		objects := []fyne.CanvasObject{widget.NewLabel(item.Name), layout.NewSpacer(), widget.NewLabel(formatSize(item.Size))}
+		if del != nil {
+			btn := widget.NewButtonWithIcon("", theme.DeleteIcon(), func() { remove(item, del) })
+			btn.Importance = widget.LowImportance
+			objects = append(objects, btn)
+		}
+		return container.NewHBox(objects...)
+	}
+
+	removeDir := func(path string) func() error {
+		return func() error { return os.RemoveAll(path) }
+	}
+	reload = func() {
+		report := scanStorage(g.root, mods)
+		fyne.Do(func() {
+			body.RemoveAll()
+			body.Add(widget.NewLabelWithStyle(Tf("storage.total", formatSize(report.Total)), fyne.TextAlignLeading, fyne.TextStyle{Bold: true}))
+
+			for _, usage := range report.Modpacks {
+				rows := container.NewVBox(row(storageItem{Name: Tf("storage.instance", formatSize(usage.Mods)), Size: usage.Instance}, nil))
+				for _, backup := range usage.Backups {
+					rows.Add(row(backup, removeDir(backup.Path)))
+				}
+				body.Add(widget.NewCard(usage.Modpack.DisplayName, formatSize(usage.Total()), rows))
+			}
+
+			shared := container.NewVBox(row(report.Prism, nil))
+			for _, jre := range report.Java {
+				item := jre.storageItem
+				var del func() error
+				if jre.Unused {
+					item.Name = Tf("storage.unusedJava", jre.Version)
+					version := jre.Version
+					del = func() error { return removeUnusedJRE(g.root, version) }
+				}
+				shared.Add(row(item, del))
+			}
+			for _, backup := range report.OtherBackups {
+				shared.Add(row(backup, removeDir(backup.Path)))
+			}
+			body.Add(widget.NewCard(T("storage.shared"), "", shared))
+		})
+	}
+	go reload()
+}
+
+func (g *GUI) findModpack(id string) (Modpack, bool) {
+	for _, mod := range g.modpacks {
+		if mod.ID == id {
+			return mod, true
+		}
+	}
+	return Modpack{}, false
+}
+
+func (g *GUI) renameModpack(id string) {
+	mod, ok := g.findModpack(id)
+	if !ok {
+		return
+	}
 	state := g.getModpackState(mod.ID)
 	if state != nil && (state.Busy || state.Running) {
-		g.updateStatus("Cannot delete while modpack is busy or running")
+		g.updateStatus("Cannot rename while modpack is busy or running")
 		return
 	}
 
-	logf("%s", infoLine(fmt.Sprintf("Deleting modpack data: %s", mod.DisplayName)))
-
-	g.setModpackState(mod.ID, func(state *ModpackState) {
-		state.Busy = true
-		state.CurrentAction = ActionNone
-	})
+	nameEntry := widget.NewEntry()
+	nameEntry.SetText(mod.InstanceName)
+	nameEntry.Validator = func(name string) error {
+		if err := validateInstanceName(name); err != nil {
+			return err
+		}
+		for _, other := range g.modpacks {
+			if other.ID != mod.ID && strings.EqualFold(other.InstanceName, strings.TrimSpace(name)) {
+				return fmt.Errorf("already used by %s", other.DisplayName)
+			}
+		}
+		return nil
+	}
 
-	go func() {
-		if err := g.removeModpackData(mod); err != nil {
-			logf("%s", warnLine(fmt.Sprintf("Failed to delete %s: %v", mod.DisplayName, err)))
-			g.updateStatus(fmt.Sprintf("Delete failed: %v", err))
-			g.setModpackState(mod.ID, func(state *ModpackState) {
-				state.Busy = false
-				state.Error = err
-			})
+	items := []*widget.FormItem{widget.NewFormItem("Instance name", nameEntry)}
+	dialog.ShowForm("Rename Instance", "Rename", "Cancel", items, func(confirmed bool) {
+		if !confirmed {
+			return
+		}
+		newName := strings.TrimSpace(nameEntry.Text)
+		if newName == mod.InstanceName {
 			return
 		}
 
-		g.setModpackState(mod.ID, func(state *ModpackState) {
-			state.Busy = false
-			state.Installed = false
-			state.UpdateAvailable = false
-			state.LocalVersion = ""
-			state.Running = false
-			state.RunningPID = 0
-			state.Error = nil
+		g.setModpackState(mod.ID, func(s *ModpackState) {
+			s.Busy = true
+			s.CurrentAction = ActionNone
 		})
 
-		g.updateStatus(fmt.Sprintf("Deleted %s", mod.DisplayName))
-		logf("%s", successLine(fmt.Sprintf("Deleted modpack data: %s", mod.DisplayName)))
-		g.refreshModpackState(mod)
-	}()
-}
+		go func() {
+			instancesDir := filepath.Dir(g.modpackInstanceDir(mod))
+			err := renameInstanceDir(instancesDir, mod.InstanceName, newName)
+			if err == nil {
+				err = setInstanceName(g.root, mod.ID, newName)
+			}
 
-func (g *GUI) removeModpackData(mod Modpack) error {
-	instDir := g.modpackInstanceDir(mod)
-	if !exists(instDir) {
-		return nil
-	}
-	return os.RemoveAll(instDir)
+			g.setModpackState(mod.ID, func(s *ModpackState) {
+				s.Busy = false
+				s.Error = err
+			})
+			if err != nil {
+				logf("%s", warnLine(fmt.Sprintf("Failed to rename %s: %v", mod.DisplayName, err)))
+				g.updateStatus(fmt.Sprintf("Rename failed: %v", err))
+				return
+			}
+
+			logf("%s", successLine(fmt.Sprintf("Renamed instance %s → %s", mod.InstanceName, newName)))
+			fyne.Do(func() {
+				for i := range g.modpacks {
+					if g.modpacks[i].ID == mod.ID {
+						g.modpacks[i].InstanceName = newName
+					}
+				}
+				g.applyFilters()
+				g.populateFeaturedGrid()
+				g.updateStatus(fmt.Sprintf("Renamed %s instance to %s", mod.DisplayName, newName))
+			})
+		}()
+	}, g.window)
 }
 
-func (g *GUI) reinstallModpack(mod Modpack) {
+// showMemoryOverride lets the user pin the modpack's RAM instead of using the
+// global Auto/Manual setting
+func (g *GUI) showMemoryOverride(mod Modpack) {
 	state := g.getModpackState(mod.ID)
-	if state != nil && (state.Busy || state.Running) {
-		g.updateStatus("Cannot reinstall while modpack is busy or running")
+	if state != nil && state.Busy {
+		g.updateStatus("Cannot change RAM while modpack is busy")
 		return
 	}
 
-	logf("%s", infoLine(fmt.Sprintf("Reinstalling modpack: %s", mod.DisplayName)))
+	current := MemoryForModpack(mod)
+	slider := widget.NewSlider(2, 16)
+	slider.Step = 1
+	slider.SetValue(float64(current / 1024))
+	valueLabel := widget.NewLabel(fmt.Sprintf("%d GB", current/1024))
+	slider.OnChanged = func(v float64) {
+		valueLabel.SetText(fmt.Sprintf("%d GB", int(v)))
+	}
 
-	g.setModpackState(mod.ID, func(s *ModpackState) {
-		s.Busy = true
-		s.CurrentAction = ActionInstall
-		s.Error = nil
+	globalMode := "Manual RAM"
+	if settings.AutoRAM {
+		globalMode = "Auto RAM"
+	}
+	note := widget.NewLabel(fmt.Sprintf("Recommended: %d GB. Use global setting to go back to %s.", mod.RecommendedRam/1024, globalMode))
+	note.Wrapping = fyne.TextWrapWord
+
+	var d dialog.Dialog
+	clearBtn := widget.NewButton("Use global setting", func() {
+		d.Hide()
+		g.setMemoryOverride(mod, 0)
 	})
+	if mod.OverrideMemoryMB == 0 {
+		clearBtn.Disable()
+	}
 
-	go func() {
-		if err := g.removeModpackData(mod); err != nil {
-			logf("%s", warnLine(fmt.Sprintf("Failed to prepare reinstall for %s: %v", mod.DisplayName, err)))
-			g.updateStatus(fmt.Sprintf("Reinstall failed: %v", err))
-			g.setModpackState(mod.ID, func(s *ModpackState) {
-				s.Busy = false
-				s.Error = err
-			})
-			return
+	content := container.NewVBox(
+		container.NewBorder(nil, nil, nil, valueLabel, slider),
+		note,
+		clearBtn,
+	)
+	d = dialog.NewCustomConfirm(fmt.Sprintf("RAM - %s", mod.DisplayName), "Save", "Cancel", content, func(ok bool) {
+		if ok {
+			g.setMemoryOverride(mod, int(slider.Value)*1024)
 		}
+	}, g.window)
+	d.Resize(fyne.NewSize(420, 240))
+	d.Show()
+}
 
-		g.setModpackState(mod.ID, func(s *ModpackState) {
-			s.Installed = false
-			s.UpdateAvailable = false
-			s.LocalVersion = ""
-		})
+// setMemoryOverride saves the modpack's RAM override, or clears it when memoryMB is
+// 0, and writes the resulting memory to the instance right away
+func (g *GUI) setMemoryOverride(mod Modpack, memoryMB int) {
+	key := strings.ToLower(mod.ID)
+	if memoryMB > 0 {
+		memoryMB = clampMemoryMB(memoryMB)
+		if settings.MemoryOverrides == nil {
+			settings.MemoryOverrides = make(map[string]int)
+		}
+		settings.MemoryOverrides[key] = memoryMB
+	} else {
+		delete(settings.MemoryOverrides, key)
+	}
+	if err := saveSettings(g.root); err != nil {
+		logf("%s", warnLine(fmt.Sprintf("Failed to save settings: %v", err)))
+		dialog.ShowError(fmt.Errorf("Failed to save settings: %v", err), g.window)
+		return
+	}
 
-		g.runModpackOperation(mod, ActionInstall)
-	}()
+	mod.OverrideMemoryMB = memoryMB
+	for i := range g.modpacks {
+		if g.modpacks[i].ID == mod.ID {
+			g.modpacks[i].OverrideMemoryMB = memoryMB
+		}
+	}
+	g.applyFilters()
+	g.populateFeaturedGrid()
+
+	applied := MemoryForModpack(mod)
+	if err := updateInstanceMemory(g.modpackInstanceDir(mod), applied); err != nil {
+		logf("%s", warnLine(fmt.Sprintf("Warning: failed to update instance memory for %s: %v", mod.DisplayName, err)))
+	}
+	if memoryMB > 0 {
+		logf("%s", infoLine(fmt.Sprintf("GUI: User set %s RAM to %d GB", mod.DisplayName, applied/1024)))
+		g.updateStatus(fmt.Sprintf("%s will use %d GB RAM", mod.DisplayName, applied/1024))
+	} else {
+		logf("%s", infoLine(fmt.Sprintf("GUI: User cleared the RAM override for %s", mod.DisplayName)))
+		g.updateStatus(fmt.Sprintf("%s follows the global RAM setting (%d GB)", mod.DisplayName, applied/1024))
+	}
+	g.updateMemorySummaryLabel()
 }
 
 func (g *GUI) filterByCategory(category string) {
@@ -1438,19 +4301,108 @@ func (g *GUI) filterByCategory(category string) {
 		g.updateStatus("Showing all modpacks")
 	case "featured":
 		g.updateStatus("Filtering by featured modpacks")
+	case categoryRecent:
+		g.updateStatus("Showing recently played modpacks")
+	case categoryFavorites:
+		g.updateStatus("Showing favorite modpacks")
 	default:
 		g.updateStatus(fmt.Sprintf("Filtering by %s modpacks", category))
 	}
 	g.applyFilters()
 }
 
+// toggleFavorite stars or unstars mod, updating every card that shows it
+func (g *GUI) toggleFavorite(mod Modpack) {
+	favorite := !isFavorite(mod.ID)
+	if err := setFavorite(g.root, mod.ID, favorite); err != nil {
+		logf("%s", warnLine(fmt.Sprintf("Failed to save favorites: %v", err)))
+	}
+	if favorite {
+		logf("%s", infoLine(fmt.Sprintf("GUI: User added %s to favorites", mod.DisplayName)))
+		g.updateStatus(fmt.Sprintf("Added %s to favorites", mod.DisplayName))
+	} else {
+		logf("%s", infoLine(fmt.Sprintf("GUI: User removed %s from favorites", mod.DisplayName)))
+		g.updateStatus(fmt.Sprintf("Removed %s from favorites", mod.DisplayName))
+	}
+
+	g.bindingsMu.RLock()
+	for _, binding := range g.cardBindings[mod.ID] {
+		setFavoriteIcon(binding.favoriteBtn, favorite)
+	}
+	g.bindingsMu.RUnlock()
+	if g.activeCategory == categoryFavorites {
+		g.applyFilters()
+	}
+}
+
+// setFavoriteIcon shows a filled star on a favorite's toggle and an outline otherwise
+func setFavoriteIcon(btn *widget.Button, favorite bool) {
+	if favorite {
+		btn.SetIcon(starFilledIcon)
+	} else {
+		btn.SetIcon(starIcon)
+	}
+}
+
+// populateTagChips rebuilds the sidebar tag toggles from the tags used in the catalog.
+// Active tags that are no longer in the catalog are dropped.
+func (g *GUI) populateTagChips() {
+	if g.tagChips == nil {
+		return
+	}
+	tags := catalogTags(g.modpacks)
+	kept := make(map[string]bool, len(g.activeTags))
+	for _, tag := range tags {
+		if g.activeTags[tag] {
+			kept[tag] = true
+		}
+	}
+	g.activeTags = kept
+
+	g.tagChips.Objects = g.tagChips.Objects[:0]
+	for _, tag := range tags {
+		tag := tag
+		var chip *widget.Button
+		chip = widget.NewButton("#"+tag, func() {
+			if g.activeTags[tag] {
+				delete(g.activeTags, tag)
+				chip.Importance = widget.MediumImportance
+			} else {
+				g.activeTags[tag] = true
+				chip.Importance = widget.HighImportance
+			}
+			chip.Refresh()
+			g.applyFilters()
+		})
+		if g.activeTags[tag] {
+			chip.Importance = widget.HighImportance
+		}
+		g.tagChips.Add(chip)
+	}
+	if len(tags) == 0 {
+		g.tagChips.Add(widget.NewLabel(T("card.noTags")))
+	}
+	g.tagChips.Refresh()
+}
+
 func (g *GUI) refreshModpacks() {
 	g.updateStatus("Refreshing modpack list...")
 	g.showLoading(true, "Refreshing modpacks...")
 
+	if g.previewSource != "" {
+		go g.reloadPreviewCatalog()
+		return
+	}
+
 	go func() {
-		// Actually reload the modpacks from remote
-		newModpacks, err := fetchRemoteModpacks(remoteModpacksURL, 30*time.Second)
+		// Actually reload the modpacks from every catalog source, or the saved copy offline
+		var normalized []Modpack
+		var err error
+		if offlineMode() {
+			normalized, err = loadCachedModpacks(g.root)
+		} else {
+			normalized, err = fetchModpackCatalog(g.root)
+		}
 		if err != nil {
 			fyne.Do(func() {
 				g.showLoading(false, "")
@@ -1459,26 +4411,32 @@ func (g *GUI) refreshModpacks() {
 			})
 			return
 		}
-
-		normalized := normalizeModpacks(newModpacks)
-		if len(normalized) == 0 {
-			fyne.Do(func() {
-				g.showLoading(false, "")
-				g.updateStatus("No valid modpacks found in remote catalog")
-				dialog.ShowError(errors.New("No valid modpacks found in remote catalog"), g.window)
-			})
-			return
+		if err := saveModpackCatalog(g.root, normalized); err != nil {
+			logf("%s", warnLine(fmt.Sprintf("Failed to save local modpack catalog: %v", err)))
 		}
+		updateDefaultModpackID(normalized)
+		normalized = withCustomModpacks(g.root, normalized)
 
 		// Update GUI's modpack list
 		fyne.Do(func() {
 			g.modpacks = normalized
 			g.filtered = append([]Modpack(nil), normalized...)
+			g.populateTagChips()
+			if g.activeCategory != "" && !categoryHasModpacks(normalized, g.activeCategory) {
+				g.activeCategory = ""
+			}
+			g.applyFilters()
 			g.updateStatus(fmt.Sprintf("Version %s - Loaded %d modpack(s)", version, len(normalized)))
 		})
 
-		// Check for launcher updates
-		if g.exePath != "" {
+		// Check for launcher updates, but never swap the binary under a running install or game
+		if g.exePath != "" && !selfUpdateDisabled() && g.anyModpackActive() {
+			logf("%s", infoLine("Skipping launcher update check while a modpack is running or installing"))
+			// Still say whether there is one, so it can be installed once the modpack is done
+			if available, _, latest, err := checkLauncherUpdate(updateChannelSetting()); err == nil {
+				g.setUpdateBadge(available, latest)
+			}
+		} else if g.exePath != "" && !selfUpdateDisabled() {
 			fyne.Do(func() {
 				g.updateStatus("Checking for launcher updates...")
 			})
@@ -1515,6 +4473,27 @@ func (g *GUI) refreshModpacks() {
 	}()
 }
 
+// reloadPreviewCatalog re-reads the previewed catalog so edits show up without a restart
+func (g *GUI) reloadPreviewCatalog() {
+	mods, warnings, err := loadPreviewCatalog(g.previewSource)
+	fyne.Do(func() {
+		g.showLoading(false, "")
+		if err != nil {
+			g.updateStatus(fmt.Sprintf("Failed to reload preview: %v", err))
+			dialog.ShowError(err, g.window)
+			return
+		}
+		g.modpacks = mods
+		g.filtered = append([]Modpack(nil), mods...)
+		g.previewWarnings = warnings
+		g.populateTagChips()
+		g.populateFeaturedGrid()
+		g.applyFilters()
+		g.showPreviewWarnings()
+		g.refreshAllModpackStates()
+	})
+}
+
 func (g *GUI) updateStatus(text string) {
 	if g.statusLabel == nil {
 		return
@@ -1545,7 +4524,7 @@ func (g *GUI) showLoading(show bool, message string) {
 func (g *GUI) showConsole() {
 	if g.tabs != nil {
 		for index, tab := range g.tabs.Items {
-			if tab.Text == "Console" {
+			if tab.Text == T("tab.console") {
 				g.tabs.SelectIndex(index)
 				break
 			}
@@ -1574,7 +4553,22 @@ func (g *GUI) startLogFileWatcher() {
 	logPath := filepath.Join(g.root, "logs", "latest.log")
 
 	// Start combined loading and monitoring
-	go g.loadAndWatchLogFile(logPath)
+	go g.loadAndWatchLogFile(logPath, g.logStopChan)
+}
+
+// consoleStreamWriter is handed to long-running subprocesses such as packwiz. It sits after
+// the log writer, so each write has already reached latest.log when it wakes the console
+// watcher, and lines show up immediately instead of on the next poll.
+type consoleStreamWriter struct {
+	g *GUI
+}
+
+func (w consoleStreamWriter) Write(p []byte) (int, error) {
+	select {
+	case w.g.logPoke <- struct{}{}:
+	default:
+	}
+	return len(p), nil
 }
 
 // stopLogFileWatcher stops the log file monitoring
@@ -1595,10 +4589,116 @@ func (g *GUI) stopLogFileWatcher() {
 		// Reset position tracking
 		g.logLastPosition = 0
 	}
+
+	// Drop output that was read but not shown yet
+	g.consoleMu.Lock()
+	g.consolePending.Reset()
+	g.consoleMu.Unlock()
+}
+
+const (
+	// consoleFlushInterval is how long new log output is collected before the console is updated
+	consoleFlushInterval = 200 * time.Millisecond
+	// consoleMaxLines caps the console; older lines stay in the log file
+	consoleMaxLines = 5000
+	// consoleTrimSlack lets the console grow past the cap before it is trimmed again,
+	// so trimming (which replaces the whole text) doesn't happen on every update
+	consoleTrimSlack = 1000
+)
+
+// queueConsoleText buffers new log output and schedules one console update for
+// everything that arrives within consoleFlushInterval
+func (g *GUI) queueConsoleText(text string) {
+	g.consoleMu.Lock()
+	defer g.consoleMu.Unlock()
+	g.consolePending.WriteString(text)
+	if g.consoleFlushQueued {
+		return
+	}
+	g.consoleFlushQueued = true
+	time.AfterFunc(consoleFlushInterval, g.flushConsoleText)
+}
+
+// flushConsoleText appends the buffered output to the console
+func (g *GUI) flushConsoleText() {
+	g.consoleMu.Lock()
+	text := g.consolePending.String()
+	g.consolePending.Reset()
+	g.consoleFlushQueued = false
+	g.consoleMu.Unlock()
+	if text == "" {
+		return
+	}
+
+	fyne.Do(func() {
+		if g.consoleOutput == nil {
+			return
+		}
+		// Another log was selected after this output was read
+		g.logMutex.RLock()
+		following := g.logWatcherActive
+		g.logMutex.RUnlock()
+		if !following {
+			return
+		}
+
+		g.consoleLines += strings.Count(text, "\n")
+		keep := g.selectedConsoleMatch()
+		if g.consoleLines > consoleMaxLines+consoleTrimSlack {
+			total := g.consoleLines
+			g.setConsoleText(g.consoleOutput.Text + text)
+			// Trimming moved the selected match up by the lines dropped from the top
+			if keep != nil {
+				keep.Row -= total - g.consoleLines
+				g.refreshConsoleMatches(keep)
+				if g.consoleMatchIndex >= 0 {
+					g.showConsoleMatch(g.consoleMatchIndex)
+				}
+			}
+			return
+		}
+		g.consoleOutput.Append(text)
+		// Appending keeps the selection, so a match being looked at stays in view
+		if keep == nil {
+			g.consoleOutput.CursorRow = g.consoleLines
+		}
+		g.refreshConsoleMatches(keep)
+	})
+}
+
+// setConsoleText replaces the console contents with the last consoleMaxLines lines of text
+// and scrolls to the bottom. Must be called on the UI goroutine.
+func (g *GUI) setConsoleText(text string) {
+	text = lastLines(text, consoleMaxLines)
+	g.consoleOutput.SetText(text)
+	g.consoleLines = strings.Count(text, "\n")
+	g.consoleOutput.CursorRow = g.consoleLines
+	g.refreshConsoleMatches(nil)
+}
+
+// lastLines returns the final n lines of text
+func lastLines(text string, n int) string {
+	end := len(text)
+	// A trailing newline ends the last line rather than starting a new one
+	if strings.HasSuffix(text, "\n") {
+		end--
+	}
+	for i := end - 1; i >= 0; i-- {
+		if text[i] == '\n' {
+			n--
+			if n == 0 {
+				return text[i+1:]
+			}
+		}
+	}
+	return text
 }
 
-// loadAndWatchLogFile loads existing log content and monitors for new content using incremental reading
-func (g *GUI) loadAndWatchLogFile(logPath string) {
+// loadAndWatchLogFile loads existing log content and monitors for new content using incremental reading.
+// The open handle is compared with the file at logPath on every pass, so a rotation
+// (latest.log renamed and recreated) is noticed even when the new file is already
+// larger than the old read position.
+func (g *GUI) loadAndWatchLogFile(logPath string, stop chan struct{}) {
 	ticker := time.NewTicker(500 * time.Millisecond) // Check every 500ms
 	defer ticker.Stop()
 
@@ -1606,123 +4706,112 @@ func (g *GUI) loadAndWatchLogFile(logPath string) {
 
 	for {
 		select {
-		case <-g.logStopChan:
+		case <-stop:
 			return
 		case <-ticker.C:
-			// Check if file exists
-			info, err := os.Stat(logPath)
+		case <-g.logPoke:
+			// Fresh output was just written; read it now instead of waiting for the next tick
+		}
+
+		// Check if file exists
+		info, err := os.Stat(logPath)
+		if err != nil {
+			// Mid-rotation or not created yet; keep the old handle so its tail is still read once the new file appears
+			continue
+		}
+
+		g.logMutex.Lock()
+		if !g.logWatcherActive || g.logStopChan != stop {
+			// Stopped (or replaced by a new watcher) while waiting for the lock; leave the handle alone
+			g.logMutex.Unlock()
+			return
+		}
+
+		if !initialLoadDone {
+			// Initial load - read the entire file once and keep following the same handle
+			file, err := os.Open(logPath)
 			if err != nil {
-				// File doesn't exist or can't be accessed, reset position
-				g.logMutex.Lock()
-				if g.logFileHandle != nil {
-					g.logFileHandle.Close()
-					g.logFileHandle = nil
-				}
-				g.logLastPosition = 0
 				g.logMutex.Unlock()
 				continue
 			}
+			g.logFileHandle = file
+			g.logLastPosition = 0
+			content := g.readLogHandle()
+			initialLoadDone = true
+			g.logMutex.Unlock()
 
-			g.logMutex.Lock()
-
-			if !initialLoadDone {
-				// Initial load - read entire file once
-				file, err := os.Open(logPath)
-				if err != nil {
-					g.logMutex.Unlock()
-					continue
-				}
-
-				content, err := io.ReadAll(file)
-				file.Close()
-
-				if err == nil && len(content) > 0 {
-					contentStr := string(content)
-					fyne.Do(func() {
-						if g.consoleOutput != nil {
-							// Replace placeholder with actual log content
-							g.consoleOutput.SetText(contentStr)
-							// Scroll to bottom
-							lines := strings.Split(contentStr, "\n")
-							g.consoleOutput.CursorRow = len(lines) - 1
-						}
-					})
-				}
-
-				// Set initial position to end of file
-				g.logLastPosition = info.Size()
-				initialLoadDone = true
-				g.logMutex.Unlock()
-			} else {
-				// Monitoring mode - only read new content incrementally
-				if info.Size() < g.logLastPosition {
-					// File was truncated or rotated, reset position
-					g.logLastPosition = 0
-					if g.logFileHandle != nil {
-						g.logFileHandle.Close()
-						g.logFileHandle = nil
+			if content != "" {
+				fyne.Do(func() {
+					if g.consoleOutput != nil {
+						// Replace placeholder with actual log content
+						g.setConsoleText(content)
 					}
-				}
+				})
+			}
+			continue
+		}
 
-				// Only read if file has grown
-				if info.Size() > g.logLastPosition {
-					// Open file if not already open
-					if g.logFileHandle == nil {
-						file, err := os.Open(logPath)
-						if err != nil {
-							g.logMutex.Unlock()
-							continue
-						}
-						g.logFileHandle = file
-					}
+		// Monitoring mode - only read new content incrementally
+		newContent := g.readNewLogContent(logPath, info)
+		g.logMutex.Unlock()
 
-					// Seek to last read position
-					_, err := g.logFileHandle.Seek(g.logLastPosition, io.SeekStart)
-					if err != nil {
-						// Seek failed, close and reopen file
-						g.logFileHandle.Close()
-						g.logFileHandle = nil
-						g.logMutex.Unlock()
-						continue
-					}
+		// Only update UI if there's actual new content
+		if strings.TrimSpace(newContent) != "" {
+			g.queueConsoleText(newContent)
+		}
+	}
+}
 
-					// Read only the new content
-					newContent := make([]byte, info.Size()-g.logLastPosition)
-					bytesRead, err := io.ReadFull(g.logFileHandle, newContent)
-					if err != nil && err != io.EOF && err != io.ErrUnexpectedEOF {
-						// Read failed, close file to force reopen next time
-						g.logFileHandle.Close()
-						g.logFileHandle = nil
-						g.logMutex.Unlock()
-						continue
-					}
+// readNewLogContent returns what was appended to the log at logPath since the last
+// read. info is the current Stat of logPath. The caller must hold logMutex.
+func (g *GUI) readNewLogContent(logPath string, info os.FileInfo) string {
+	var content string
+	if g.logFileHandle != nil {
+		if held, err := g.logFileHandle.Stat(); err != nil || !os.SameFile(held, info) {
+			// Rotated: finish what was written to the old file, then start the new one from the top
+			content = g.readLogHandle()
+			g.closeLogHandle()
+		} else if held.Size() < g.logLastPosition {
+			// Truncated in place
+			g.logLastPosition = 0
+		}
+	}
 
-					// Update position if we read something
-					if bytesRead > 0 {
-						g.logLastPosition += int64(bytesRead)
+	if g.logFileHandle == nil {
+		file, err := os.Open(logPath)
+		if err != nil {
+			return content
+		}
+		g.logFileHandle = file
+		g.logLastPosition = 0
+	}
+	return content + g.readLogHandle()
+}
 
-						// Only update UI if there's actual new content
-						newContentStr := string(newContent[:bytesRead])
-						if strings.TrimSpace(newContentStr) != "" {
-							fyne.Do(func() {
-								if g.consoleOutput != nil {
-									// Append new content to existing text
-									currentText := g.consoleOutput.Text
-									updatedText := currentText + newContentStr
-									g.consoleOutput.SetText(updatedText)
-									// Scroll to bottom
-									lines := strings.Split(updatedText, "\n")
-									g.consoleOutput.CursorRow = len(lines) - 1
-								}
-							})
-						}
-					}
-				}
+// readLogHandle reads everything after logLastPosition from the open log handle and
+// advances the position by exactly what was read. The caller must hold logMutex.
+func (g *GUI) readLogHandle() string {
+	if _, err := g.logFileHandle.Seek(g.logLastPosition, io.SeekStart); err != nil {
+		// Seek failed, close file to force reopen next time
+		g.closeLogHandle()
+		return ""
+	}
+	content, err := io.ReadAll(g.logFileHandle)
+	g.logLastPosition += int64(len(content))
+	if err != nil {
+		// Read failed, close file to force reopen next time
+		g.closeLogHandle()
+	}
+	return string(content)
+}
 
-				g.logMutex.Unlock()
-			}
-		}
+// closeLogHandle drops the followed log file. The caller must hold logMutex.
+func (g *GUI) closeLogHandle() {
+	if g.logFileHandle != nil {
+		g.logFileHandle.Close()
+		g.logFileHandle = nil
 	}
+	g.logLastPosition = 0
 }
 
 // generateRandomID generates a random 8-character hexadecimal string
@@ -1735,12 +4824,17 @@ func generateRandomID() (string, error) {
 	return fmt.Sprintf("%02x%02x%02x%02x", bytes[0], bytes[1], bytes[2], bytes[3]), nil
 }
 
-// uploadLog uploads the latest.log content to i.dylan.lol/logs/
+// uploadLog uploads the latest.log content with the log upload provider chosen in settings
 func (g *GUI) uploadLog() {
+	g.uploadLogFile(filepath.Join(g.root, "logs", "latest.log"))
+}
+
+// uploadLogFile uploads logPath, such as a log or crash report, and shows its link
+func (g *GUI) uploadLogFile(logPath string) {
 	// Log when the upload function is called
-	debugf("uploadLog function called")
+	debugf("uploadLogFile called for %s", logPath)
 
-	logPath := filepath.Join(g.root, "logs", "latest.log")
+	uploader := configuredLogUploader()
 
 	// Show upload progress dialog in the main thread
 	fyne.Do(func() {
@@ -1752,7 +4846,7 @@ func (g *GUI) uploadLog() {
 		if progressDialog == nil {
 			// Fallback to simple information dialog if custom dialog creation fails
 			debugf("Progress dialog creation failed, using fallback")
-			dialog.ShowInformation("Uploading Log", "Uploading log file to i.dylan.lol...", g.window)
+			dialog.ShowInformation("Uploading Log", fmt.Sprintf("Uploading log file to %s...", uploader.Host()), g.window)
 			return
 		}
 
@@ -1764,7 +4858,7 @@ func (g *GUI) uploadLog() {
 			debugf("Starting upload goroutine")
 
 			// Perform the upload and get the result
-			logURL, err := g.performLogUpload(logPath)
+			logURL, err := uploader.Upload(logPath)
 
 			// Hide the progress dialog first
 			fyne.Do(func() {
@@ -1791,123 +4885,12 @@ func (g *GUI) uploadLog() {
 	})
 }
 
-// performLogUpload handles the actual upload process and returns the URL or error
-func (g *GUI) performLogUpload(logPath string) (string, error) {
-	// Generate a random 8-character ID for the filename
-	randomID, err := generateRandomID()
-	if err != nil {
-		debugf("Failed to generate random ID: %v", err)
-		return "", fmt.Errorf("failed to generate random ID: %v", err)
-	}
-	filename := fmt.Sprintf("%s.log", randomID)
-	debugf("Generated filename: %s", filename)
-
-	// Create multipart form with file upload using CreateFormFile to match curl -F format
-	var requestBody bytes.Buffer
-	writer := multipart.NewWriter(&requestBody)
-
-	// Add the required "act" field with value "bput" as required by the endpoint
-	err = writer.WriteField("act", "bput")
-	if err != nil {
-		debugf("Failed to add act field: %v", err)
-		return "", fmt.Errorf("failed to add act field: %v", err)
-	}
-
-	// Open the log file for reading
-	file, err := os.Open(logPath)
-	if err != nil {
-		debugf("Failed to open log file: %v", err)
-		return "", fmt.Errorf("failed to open log file for upload: %v", err)
-	}
-	defer file.Close()
-
-	// Create form file part using CreateFormFile to match curl -F "file=@filename;type=application/octet-stream"
-	part, err := writer.CreateFormFile("file", filename)
-	if err != nil {
-		debugf("Failed to create form file: %v", err)
-		return "", fmt.Errorf("failed to create form file: %v", err)
-	}
-
-	// Copy file content to the form part
-	_, err = io.Copy(part, file)
-	if err != nil {
-		debugf("Failed to copy file content: %v", err)
-		return "", fmt.Errorf("failed to copy file content: %v", err)
-	}
-
-	writer.Close()
-
-	// Create a new HTTP request with the form data
-	req, err := http.NewRequest("POST", "https://i.dylan.lol/logs/", &requestBody)
-	if err != nil {
-		debugf("Failed to create request: %v", err)
-		return "", fmt.Errorf("failed to create request: %v", err)
-	}
-
-	// Set the content type header for form data
-	req.Header.Set("Content-Type", writer.FormDataContentType())
-	req.Header.Set("User-Agent", "TheBoysLauncher/1.0")
-
-	// Send the request with TLS 1.2 and timeout
-	client := &http.Client{
-		Timeout: 30 * time.Second,
-		Transport: &http.Transport{
-			TLSClientConfig: &tls.Config{
-				MinVersion: tls.VersionTLS12,
-				MaxVersion: tls.VersionTLS12,
-			},
-		},
-	}
-
-	debugf("Sending HTTP request to upload log")
-	resp, err := client.Do(req)
-	if err != nil {
-		debugf("Failed to upload log: %v", err)
-		return "", fmt.Errorf("failed to upload log: %v", err)
-	}
-	defer resp.Body.Close()
-
-	// Read the full response body
-	body, err := io.ReadAll(resp.Body)
-	if err != nil {
-		debugf("Failed to read response body: %v", err)
-		return "", fmt.Errorf("failed to read response body: %v", err)
-	}
-
-	// Log the raw response for debugging
-	debugf("Upload response status: %s", resp.Status)
-	bodyStr := string(body)
-	debugf("Upload response body (first 200 chars): %s", bodyStr[:min(200, len(bodyStr))])
-
-	// Check if the upload was successful (status code 200-299)
-	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
-		debugf("Upload failed with status: %s", resp.Status)
-		return "", fmt.Errorf("upload failed with status: %s\nResponse: %s", resp.Status, string(body)[:min(200, len(body))])
-	}
-
-	debugf("Upload successful, parsing response")
-
-	// Try to extract the filename from the HTML response using regex
-	// Pattern to match href="/logs/filename.log"
-	logPattern := `href="/logs/([^"]+\.log)"`
-	re := regexp.MustCompile(logPattern)
-	matches := re.FindStringSubmatch(bodyStr)
-
-	var logURL string
-	if len(matches) > 1 {
-		// Extract the filename from the match
-		extractedFilename := matches[1]
-		// Construct the full URL
-		logURL = fmt.Sprintf("https://i.dylan.lol/logs/%s", extractedFilename)
-		debugf("Successfully extracted filename from HTML: %s", extractedFilename)
-	} else {
-		// If regex fails, fall back to using our random ID
-		debugf("Failed to extract filename from HTML, falling back to random ID: %s", randomID)
-		logURL = fmt.Sprintf("https://i.dylan.lol/logs/%s.log", randomID)
+// shellQuote quotes s for the platform's shell: single quotes for sh, double quotes for cmd
+func shellQuote(s string) string {
+	if runtime.GOOS == "windows" {
+		return `"` + strings.ReplaceAll(s, `"`, `\"`) + `"`
 	}
-
-	debugf("Final log URL: %s", logURL)
-	return logURL, nil
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
 }
 
 // showSuccessDialog displays a simplified success dialog with the uploaded file URL
@@ -1969,105 +4952,253 @@ func (g *GUI) showSuccessDialog(logURL string) {
 	g.window.Clipboard().SetContent(logURL)
 }
 
-// extractFileIDFromHTML extracts the file ID from MicroBin's HTML response
-func (g *GUI) extractFileIDFromHTML(html string) string {
-	// Look for the pattern in the HTML that contains the file ID
-	// The file ID appears in URLs like https://logs.dylan.lol/upload/mouse-tiger-fly or https://logs.dylan.lol/file/mouse-tiger-fly
+func (g *GUI) showSettings() {
+	memLabel := widget.NewLabel("")
 
-	// First try to find the upload URL pattern (absolute URLs)
-	uploadPattern := `href="https://logs\.dylan\.lol/upload/([^"]+)"`
-	re := regexp.MustCompile(uploadPattern)
-	matches := re.FindStringSubmatch(html)
-	if len(matches) > 1 {
-		return matches[1]
-	}
+	// Current settings values
+	autoCheck := widget.NewCheck(T("settings.autoRam"), nil)
+	autoCheck.SetChecked(settings.AutoRAM)
 
-	// If that fails, try the file URL pattern (absolute URLs)
-	filePattern := `href="https://logs\.dylan\.lol/file/([^"]+)"`
-	re = regexp.MustCompile(filePattern)
-	matches = re.FindStringSubmatch(html)
-	if len(matches) > 1 {
-		return matches[1]
-	}
+	memSlider := widget.NewSlider(2, 16)
+	memSlider.Step = 1
+	memSlider.SetValue(float64(clampMemoryMB(settings.MemoryMB) / 1024))
 
-	// If that fails, try the edit URL pattern (absolute URLs)
-	editPattern := `href="https://logs\.dylan\.lol/edit/([^"]+)"`
-	re = regexp.MustCompile(editPattern)
-	matches = re.FindStringSubmatch(html)
-	if len(matches) > 1 {
-		return matches[1]
+	// System headroom kept free by Auto RAM
+	autoExplainLabel := widget.NewLabel("")
+	autoExplainLabel.Wrapping = fyne.TextWrapWord
+	headroomOptions := []string{"1 GB", "2 GB", "3 GB", "4 GB", "6 GB"}
+	headroomSelect := widget.NewSelect(headroomOptions, nil)
+	headroomSelect.SetSelected(fmt.Sprintf("%d GB", ramHeadroomMB()/1024))
+	headroomRow := container.NewHBox(widget.NewLabel(T("settings.headroom")), headroomSelect)
+
+	// Update channel dropdown
+	channelOptions := make([]string, len(updateChannels))
+	for i, channel := range updateChannels {
+		channelOptions[i] = channelDisplayName(channel)
 	}
-
-	// If that fails, try the JavaScript URL pattern
-	jsPattern := `const url = .*https://logs\.dylan\.lol/upload/([^"]+)`
-	re = regexp.MustCompile(jsPattern)
-	matches = re.FindStringSubmatch(html)
-	if len(matches) > 1 {
-		return matches[1]
+	channelSelect := widget.NewSelect(channelOptions, nil)
+	channelSelect.SetSelected(channelDisplayName(updateChannelSetting()))
+	selectedChannel := func() updateChannel {
+		for _, channel := range updateChannels {
+			if channelSelect.Selected == channelDisplayName(channel) {
+				return channel
+			}
+		}
+		return updateChannelSetting()
 	}
-
-	// Fallback: try relative patterns (in case the format changes)
-	relativeUploadPattern := `href="/upload/([^"]+)"`
-	re = regexp.MustCompile(relativeUploadPattern)
-	matches = re.FindStringSubmatch(html)
-	if len(matches) > 1 {
-		return matches[1]
+	resetChannelSelect := func() {
+		channelSelect.SetSelected(channelDisplayName(updateChannelSetting()))
 	}
+	channelRow := container.NewHBox(widget.NewLabel(T("settings.updateChannel")), channelSelect)
 
-	relativeFilePattern := `href="/file/([^"]+)"`
-	re = regexp.MustCompile(relativeFilePattern)
-	matches = re.FindStringSubmatch(html)
-	if len(matches) > 1 {
-		return matches[1]
+	// Debug logging checkbox
+	debugCheck := widget.NewCheck(T("settings.debug"), nil)
+	debugCheck.SetChecked(settings.DebugEnabled)
+
+	// Cache-bust checkbox
+	cacheBustCheck := widget.NewCheck(T("settings.cacheBust"), nil)
+	cacheBustCheck.SetChecked(settings.CacheBust)
+
+	// Prefetch checkbox
+	prefetchCheck := widget.NewCheck(T("settings.prefetch"), nil)
+	prefetchCheck.SetChecked(settings.Prefetch)
+
+	// Keep console open checkbox
+	keepConsoleCheck := widget.NewCheck(T("settings.keepConsoleOpen"), nil)
+	keepConsoleCheck.SetChecked(settings.KeepConsoleOpen)
+
+	prismGUICheck := widget.NewCheck(T("settings.launchPrismGui"), nil)
+	prismGUICheck.SetChecked(settings.LaunchPrismGUI)
+
+	// Qt platform dropdown, in the order of qtPlatformValues; Linux only
+	qtPlatformValues := []string{"", qtPlatformXCB, qtPlatformWayland}
+	qtPlatformSelect := widget.NewSelect([]string{T("settings.qtPlatformAuto"), "X11 (xcb)", "Wayland"}, nil)
+	qtPlatformSelect.SetSelectedIndex(0)
+	for i, value := range qtPlatformValues {
+		if strings.EqualFold(settings.QtPlatform, value) {
+			qtPlatformSelect.SetSelectedIndex(i)
+		}
 	}
 
-	return ""
-}
+	// Log redaction checkbox
+	redactCheck := widget.NewCheck(T("settings.redactLogs"), nil)
+	redactCheck.SetChecked(settings.RedactLogs)
 
-func (g *GUI) showSettings() {
-	memLabel := widget.NewLabel("")
+	jsonLogsCheck := widget.NewCheck(T("settings.jsonLogs"), nil)
+	jsonLogsCheck.SetChecked(strings.EqualFold(settings.LogFormat, logFormatJSON))
 
-	// Current settings values
-	autoCheck := widget.NewCheck("Enable Auto RAM", nil)
-	autoCheck.SetChecked(settings.AutoRAM)
+	// Offline mode checkbox; --offline forces it on for this session
+	offlineCheck := widget.NewCheck(T("settings.offlineMode"), nil)
+	offlineCheck.SetChecked(offlineMode())
+	if offlineFlag {
+		offlineCheck.Disable()
+	}
 
-	memSlider := widget.NewSlider(2, 16)
-	memSlider.Step = 1
-	memSlider.SetValue(float64(clampMemoryMB(settings.MemoryMB) / 1024))
+	// Download tuning
+	timeoutSelect := widget.NewSelect([]string{"15 s", "30 s", "60 s", "120 s", "300 s"}, nil)
+	timeoutSelect.SetSelected(fmt.Sprintf("%d s", int(downloadTimeout()/time.Second)))
+	concurrencySelect := widget.NewSelect([]string{"1", "2", "3", "4"}, nil)
+	concurrencySelect.SetSelected(strconv.Itoa(downloadConcurrency()))
+	installsSelect := widget.NewSelect([]string{"1", "2", "3"}, nil)
+	installsSelect.SetSelected(strconv.Itoa(installConcurrency()))
+	backupsSelect := widget.NewSelect([]string{"1", "2", "3", "5", "10"}, nil)
+	backupsSelect.SetSelected(strconv.Itoa(backupRetention()))
+
+	// Extra catalogs, one URL per line
+	catalogsEntry := widget.NewMultiLineEntry()
+	catalogsEntry.SetPlaceHolder("https://example.com/modpacks.json")
+	catalogsEntry.SetText(strings.Join(settings.CatalogURLs, "\n"))
+	catalogsEntry.SetMinRowsVisible(2)
+
+	// Language
+	languages := availableLanguages()
+	languageNames := make([]string, len(languages))
+	selectedLanguage := currentLanguage()
+	for i, lang := range languages {
+		languageNames[i] = lang.Name
+	}
+	languageSelect := widget.NewSelect(languageNames, func(name string) {
+		for _, lang := range languages {
+			if lang.Name == name {
+				selectedLanguage = lang.Code
+			}
+		}
+	})
+	for _, lang := range languages {
+		if lang.Code == selectedLanguage {
+			languageSelect.SetSelected(lang.Name)
+		}
+	}
 
-	// Dev builds checkbox
-	devCheck := widget.NewCheck("Enable dev builds (pre-release)", nil)
-	devCheck.SetChecked(settings.DevBuildsEnabled)
+	// Minecraft account; accounts are added and signed in through Prism itself
+	accountDefault := T("settings.accountDefault")
+	accountNames := []string{accountDefault}
+	if accounts, err := readPrismAccounts(filepath.Join(g.root, "prism")); err != nil {
+		logf("%s", warnLine(fmt.Sprintf("Failed to read Prism accounts: %v", err)))
+	} else {
+		for _, acc := range accounts {
+			accountNames = append(accountNames, acc.Name)
+		}
+	}
+	accountSelect := widget.NewSelect(accountNames, nil)
+	accountSelect.SetSelected(accountDefault)
+	for _, name := range accountNames[1:] {
+		if strings.EqualFold(name, settings.PrismAccount) {
+			accountSelect.SetSelected(name)
+		}
+	}
 
-	// Debug logging checkbox
-	debugCheck := widget.NewCheck("Enable debug logging", nil)
-	debugCheck.SetChecked(settings.DebugEnabled)
+	// Instances folder
+	instancesLabel := widget.NewLabel(instancesDirFor(g.root))
+	instancesLabel.Wrapping = fyne.TextWrapBreak
+	var instancesResetBtn *widget.Button
+	refreshInstancesRow := func() {
+		instancesLabel.SetText(instancesDirFor(g.root))
+		if settings.InstancesDir == "" {
+			instancesResetBtn.Disable()
+		} else {
+			instancesResetBtn.Enable()
+		}
+	}
+	instancesChangeBtn := widget.NewButtonWithIcon(T("action.change"), theme.FolderOpenIcon(), func() {
+		dialog.ShowFolderOpen(func(uri fyne.ListableURI, err error) {
+			if err != nil {
+				dialog.ShowError(err, g.window)
+				return
+			}
+			if uri == nil {
+				return
+			}
+			g.changeInstancesDir(uri.Path(), refreshInstancesRow)
+		}, g.window)
+	})
+	instancesResetBtn = widget.NewButtonWithIcon(T("action.reset"), theme.ContentUndoIcon(), func() {
+		g.changeInstancesDir("", refreshInstancesRow)
+	})
+	refreshInstancesRow()
+
+	// Launcher folder; a change takes effect at the next start
+	homeLabel := widget.NewLabel(g.root)
+	homeLabel.Wrapping = fyne.TextWrapBreak
+	homeChangeBtn := widget.NewButtonWithIcon(T("action.change"), theme.FolderOpenIcon(), func() {
+		dialog.ShowFolderOpen(func(uri fyne.ListableURI, err error) {
+			if err != nil {
+				dialog.ShowError(err, g.window)
+				return
+			}
+			if uri == nil {
+				return
+			}
+			g.changeLauncherHome(uri.Path())
+		}, g.window)
+	})
+	homeResetBtn := widget.NewButtonWithIcon(T("action.reset"), theme.ContentUndoIcon(), func() {
+		g.changeLauncherHome("")
+	})
+	if filepath.Clean(g.root) == filepath.Clean(defaultLauncherHome()) {
+		homeResetBtn.Disable()
+	}
+	if launcherHomeFromEnv() != "" {
+		homeLabel.SetText(g.root + " " + T("settings.launcherFolderEnv"))
+		homeChangeBtn.Disable()
+		homeResetBtn.Disable()
+	}
+	homeInfoBtn := createInfoButton("Launcher Folder", "Choose where the launcher keeps Prism Launcher, Java, instances, logs, backups and settings.\n\n• Useful when the system drive is small\n• You can move your data there or start fresh in the new folder\n• Moving happens the next time the launcher starts\n• Reset goes back to the default folder\n• The THEBOYS_HOME environment variable overrides this setting", g.window)
 
 	// Current channel status label
-	channelLabel := widget.NewLabel("")
-	if settings.DevBuildsEnabled {
-		channelLabel.SetText("Channel: Dev")
-	} else {
-		channelLabel.SetText("Channel: Stable")
+	channelLabel := widget.NewLabel(Tf("settings.channelCurrent", channelDisplayName(updateChannelSetting())))
+	if selfUpdateDisabled() {
+		channelSelect.Disable()
+		channelLabel.SetText(channelLabel.Text + " " + T("settings.selfUpdateDisabled"))
+	} else if g.anyModpackActive() {
+		// Switching channels replaces the launcher binary and relaunches it
+		channelSelect.Disable()
+		channelLabel.SetText(channelLabel.Text + " " + T("settings.channelBusy"))
 	}
 
 	// Info buttons for each setting
-	autoRAMInfoBtn := createInfoButton("Auto RAM", "Automatically calculates optimal memory allocation based on your system's total RAM.\n\n• Uses 50% of available system RAM by default, maxing out at 16GB\n• Ensures smooth performance while leaving memory for other applications\n• Recommended for most users\n• Can be overridden with manual RAM setting if needed", g.window)
+	autoRAMInfoBtn := createInfoButton("Auto RAM", "Automatically calculates optimal memory allocation based on your system's total RAM.\n\n• Uses 50% of available system RAM by default, maxing out at 16GB\n• Always keeps the chosen headroom free for your OS and browser\n• Never allocates more than a modpack's recommended RAM\n• Ensures smooth performance while leaving memory for other applications\n• Recommended for most users\n• Can be overridden with manual RAM setting if needed", g.window)
 
 	manualRAMInfoBtn := createInfoButton("Manual RAM", "Set a fixed amount of RAM for Minecraft to use.\n\n• Use this if you experience performance issues with Auto RAM\n• Recommended values:\n  - 4-6 GB for small modpacks\n  - 6-8 GB for medium modpacks\n  - 8-12 GB for large modpacks\n  - 12-16 GB for heavyweight modpacks\n• Ensure you have enough free system RAM available", g.window)
 
-	devBuildsInfoBtn := createInfoButton("Dev Builds", "Enable pre-release development builds of the launcher.\n\n• Dev builds include the latest features and improvements\n• May contain bugs or unfinished features\n• Updated more frequently than stable releases\n• Recommended for testing or advanced users\n• Stable builds are recommended for most users", g.window)
+	updateChannelInfoBtn := createInfoButton("Update Channel", "Choose which launcher releases you receive.\n\n• Stable: tested releases, recommended for most users\n• Release candidate: the next stable release, shortly before it ships\n• Beta: new features once they're ready for wider testing\n• Dev: the latest development builds, which may contain bugs\n• Each channel also gets the steadier channels' releases when they're newer", g.window)
+
+	debugLoggingInfoBtn := createInfoButton("Debug Logging", "Enable detailed debug logging for troubleshooting.\n\n• Provides detailed information about launcher operations\n• Useful for diagnosing issues with modpack installation/launch\n• On Linux, also turns on Qt plugin logging for Prism Launcher\n• Logs are saved to the logs directory\n• Can be accessed via the Console tab\n• May impact performance slightly when enabled", g.window)
+
+	cacheBustInfoBtn := createInfoButton("Bypass Cache", "Fetch the modpack's pack.toml with a cache-busting parameter on every launch.\n\n• Use this if updates are published but never show up\n• A single pack can also be re-synced from its card with Force re-sync\n• Same as setting THEBOYS_CACHEBUST=1\n• Slightly slower launches while enabled", g.window)
+
+	redactInfoBtn := createInfoButton("Hide Username in Uploads", "Remove personal details from logs before Upload Log sends them to a public paste.\n\n• Your home folder is replaced with ~\n• Your username is replaced with <user>\n• Only the uploaded copy is changed; logs on disk are untouched\n• Turn it off if a helper needs the exact paths", g.window)
+
+	qtPlatformInfoBtn := createInfoButton("Qt Platform", "Chooses how Prism Launcher's window connects to your Linux desktop.\n\n• Automatic uses Wayland on Wayland desktops, falling back to X11, and X11 everywhere else\n• Choose X11 if Prism's window misbehaves under Wayland\n• Choose Wayland on desktops without XWayland\n• If Prism can't open its window, the launch is retried on the other platform by itself\n• A modpack that sets QT_QPA_PLATFORM itself is left alone", g.window)
+	prismGUIInfoBtn := createInfoButton("Launch Prism GUI", "Open Prism Launcher's own window when you press Launch, instead of starting the game directly.\n\n• For modpack developers who want to change instance settings, add accounts or try extra mods\n• Start the game from Prism's window yourself\n• Kill and Stop all still close Prism", g.window)
+	keepConsoleInfoBtn := createInfoButton("Keep Console Open", "When the game closes, bring the launcher window forward on the Console tab so the final output stays in view.\n\n• Handy for reading crash output or packwiz errors\n• On Windows, a console window opened with the launcher is no longer hidden\n• The console window setting takes effect the next time the launcher starts", g.window)
+	offlineInfoBtn := createInfoButton("Offline Mode", "Launch installed modpacks straight from disk without any network requests.\n\n• Skips the catalog refresh, update checks and packwiz sync\n• Only packs that are fully installed can be launched\n• Installing and updating are unavailable until you turn it off\n• The --offline command-line flag turns it on for one session", g.window)
+	prefetchInfoBtn := createInfoButton("Prefetch Downloads", "Download Prism Launcher and the Java runtime for the default modpack in the background after the launcher opens.\n\n• Makes the first Install much faster\n• Uses some bandwidth while the launcher is idle\n• Skipped once the default modpack is installed\n• Takes effect the next time the launcher starts", g.window)
+
+	catalogsInfoBtn := createInfoButton("Extra Catalogs", "Add modpack catalogs on top of the official one, one URL per line.\n\n• Catalogs are merged in order after the official catalog\n• A later catalog replaces packs with the same ID from earlier ones\n• Cards show which catalog an added pack came from\n• If a catalog can't be reached, its packs from the last refresh are kept", g.window)
+	downloadsInfoBtn := createInfoButton("Downloads", "Tune how Prism Launcher, Java and packwiz are downloaded.\n\n• Timeout is how long to wait for a server to respond before giving up\n• Raise it if downloads fail on a slow or unreliable connection\n• Parallel downloads fetches Prism, Java and packwiz at the same time\n• Use 1 on slow connections so each download gets the full bandwidth\n• The mods themselves are fetched by packwiz, which these settings don't limit\n• Parallel installs is how many modpacks can install or run at once\n• Others wait in a queue; click Queued on a card to take it out", g.window)
 
-	debugLoggingInfoBtn := createInfoButton("Debug Logging", "Enable detailed debug logging for troubleshooting.\n\n• Provides detailed information about launcher operations\n• Useful for diagnosing issues with modpack installation/launch\n• Logs are saved to the logs directory\n• Can be accessed via the Console tab\n• May impact performance slightly when enabled", g.window)
+	accountInfoBtn := createInfoButton("Minecraft Account", "Choose which Minecraft account modpacks are launched with.\n\n• Accounts are added and signed in through Prism Launcher\n• Prism's active account is used by default\n• Useful when several people share this computer\n• If the chosen account is removed from Prism, the active account is used instead", g.window)
 
-	channelInfoBtn := createInfoButton("Release Channel", "Shows which release channel you're currently using.\n\n• Stable: Official releases with tested features\n• Dev: Pre-release builds with latest features\n• Channel can be changed using the dev builds checkbox\n• Switching channels will update the launcher automatically", g.window)
+	instancesInfoBtn := createInfoButton("Instances Folder", "Choose where modpack instances (worlds, mods and settings) are stored.\n\n• Defaults to prism/instances in the launcher folder\n• Useful for keeping large instances on another drive\n• Existing instances are moved to the new folder\n• Reset moves them back to the default location\n• Close all modpacks before changing it", g.window)
+
+	backupsInfoBtn := createInfoButton("Update Backups", "Choose how many backups are kept for each modpack.\n\n• A backup of mods, configs, resource packs and shaders is made before every update\n• If an update fails, the newest backup is restored automatically\n• Once a modpack has more backups than this, the oldest are deleted\n• Backups are kept in util/backups in the launcher folder", g.window)
+
+	jsonLogsInfoBtn := createInfoButton("JSON Log Format", "Write latest.log as one JSON object per line for support tools and scripts.\n\n• Each line has a timestamp, level, install stage and message\n• The terminal keeps the readable format\n• The console tab shows the JSON lines as written\n• Takes effect the next time the launcher starts\n• --log-format json or pretty overrides this for one run", g.window)
+
+	channelInfoBtn := createInfoButton("Release Channel", "Shows which release channel you're currently using.\n\n• Stable: Official releases with tested features\n• Release candidate, Beta: Pre-release builds closer to stable\n• Dev: Pre-release builds with latest features\n• Channel can be changed using the update channel dropdown\n• Switching channels will update the launcher automatically", g.window)
 
 	refreshUI := func() {
 		if settings.AutoRAM {
 			memLabel.SetText(fmt.Sprintf("Auto RAM baseline: %d GB", DefaultAutoMemoryMB()/1024))
+			autoExplainLabel.SetText(autoRAMExplanation())
+			autoExplainLabel.Show()
+			headroomRow.Show()
 			memSlider.Hide()
 			manualRAMInfoBtn.Hide()
 		} else {
+			autoExplainLabel.Hide()
+			headroomRow.Hide()
 			memSlider.Show()
 			memSlider.SetValue(float64(clampMemoryMB(settings.MemoryMB) / 1024))
 			memLabel.SetText(fmt.Sprintf("Manual RAM: %d GB", settings.MemoryMB/1024))
@@ -2085,6 +5216,18 @@ func (g *GUI) showSettings() {
 		refreshUI()
 	}
 
+	headroomSelect.OnChanged = func(choice string) {
+		var gb int
+		if _, err := fmt.Sscanf(choice, "%d GB", &gb); err != nil || gb <= 0 {
+			return
+		}
+		settings.RAMHeadroomMB = gb * 1024
+		if settings.AutoRAM {
+			settings.MemoryMB = clampMemoryMB(DefaultAutoMemoryMB())
+		}
+		refreshUI()
+	}
+
 	memSlider.OnChanged = func(v float64) {
 		if settings.AutoRAM {
 			return
@@ -2093,23 +5236,19 @@ func (g *GUI) showSettings() {
 		memLabel.SetText(fmt.Sprintf("Manual RAM: %.0f GB", v))
 	}
 
-	// Update channel label when dev mode checkbox is toggled
-	devCheck.OnChanged = func(on bool) {
-		if on {
-			channelLabel.SetText("Channel: Dev")
-		} else {
-			channelLabel.SetText("Channel: Stable")
-		}
+	// Update channel label when another channel is chosen
+	channelSelect.OnChanged = func(string) {
+		channelLabel.SetText(Tf("settings.channelCurrent", channelDisplayName(selectedChannel())))
 	}
 
 	// Set initial visibility state
 	refreshUI()
 
 	// Create a title with styling
-	titleLabel := widget.NewLabelWithStyle("Launcher Settings", fyne.TextAlignCenter, fyne.TextStyle{Bold: true})
+	titleLabel := widget.NewLabelWithStyle(T("settings.title"), fyne.TextAlignCenter, fyne.TextStyle{Bold: true})
 
 	// Create Memory Settings section with card
-	memoryCard := widget.NewCard("Memory Settings", "", container.NewVBox(
+	memoryCard := widget.NewCard(T("settings.memory"), "", container.NewVBox(
 		container.NewPadded(
 			container.NewHBox(
 				autoCheck,
@@ -2124,16 +5263,28 @@ func (g *GUI) showSettings() {
 				manualRAMInfoBtn,
 			),
 		),
+		container.NewPadded(autoExplainLabel),
+		container.NewPadded(headroomRow),
 		container.NewPadded(memSlider),
 	))
 
+	qtPlatformRow := container.NewPadded(container.NewHBox(
+		widget.NewLabel(T("settings.qtPlatform")),
+		qtPlatformSelect,
+		layout.NewSpacer(),
+		qtPlatformInfoBtn,
+	))
+	if runtime.GOOS != "linux" {
+		qtPlatformRow.Hide()
+	}
+
 	// Create Launcher Settings section with card
-	launcherCard := widget.NewCard("Launcher Configuration", "", container.NewVBox(
+	launcherCard := widget.NewCard(T("settings.launcher"), "", container.NewVBox(
 		container.NewPadded(
 			container.NewHBox(
-				devCheck,
+				channelRow,
 				layout.NewSpacer(),
-				devBuildsInfoBtn,
+				updateChannelInfoBtn,
 			),
 		),
 		container.NewPadded(
@@ -2143,10 +5294,189 @@ func (g *GUI) showSettings() {
 				debugLoggingInfoBtn,
 			),
 		),
+		container.NewPadded(
+			container.NewHBox(
+				cacheBustCheck,
+				layout.NewSpacer(),
+				cacheBustInfoBtn,
+			),
+		),
+		container.NewPadded(
+			container.NewHBox(
+				prefetchCheck,
+				layout.NewSpacer(),
+				prefetchInfoBtn,
+			),
+		),
+		container.NewPadded(
+			container.NewHBox(
+				keepConsoleCheck,
+				layout.NewSpacer(),
+				keepConsoleInfoBtn,
+			),
+		),
+		container.NewPadded(
+			container.NewHBox(
+				prismGUICheck,
+				layout.NewSpacer(),
+				prismGUIInfoBtn,
+			),
+		),
+		qtPlatformRow,
+		container.NewPadded(
+			container.NewHBox(
+				redactCheck,
+				layout.NewSpacer(),
+				redactInfoBtn,
+			),
+		),
+		container.NewPadded(
+			container.NewHBox(
+				jsonLogsCheck,
+				layout.NewSpacer(),
+				jsonLogsInfoBtn,
+			),
+		),
+		container.NewPadded(
+			container.NewHBox(
+				offlineCheck,
+				layout.NewSpacer(),
+				offlineInfoBtn,
+			),
+		),
+		container.NewPadded(
+			container.NewHBox(
+				widget.NewLabel(T("settings.downloadTimeout")),
+				timeoutSelect,
+				widget.NewLabel(T("settings.downloadConcurrency")),
+				concurrencySelect,
+				widget.NewLabel(T("settings.installConcurrency")),
+				installsSelect,
+				layout.NewSpacer(),
+				downloadsInfoBtn,
+			),
+		),
+		container.NewPadded(
+			container.NewBorder(
+				nil,
+				nil,
+				widget.NewLabel(T("settings.catalogs")),
+				catalogsInfoBtn,
+				catalogsEntry,
+			),
+		),
+		container.NewPadded(
+			container.NewHBox(
+				widget.NewLabel(T("settings.language")),
+				layout.NewSpacer(),
+				languageSelect,
+			),
+		),
+		container.NewPadded(
+			container.NewHBox(
+				widget.NewLabel(T("settings.account")),
+				layout.NewSpacer(),
+				accountSelect,
+				accountInfoBtn,
+			),
+		),
+		container.NewPadded(
+			container.NewHBox(
+				widget.NewLabel(T("settings.instancesFolder")),
+				layout.NewSpacer(),
+				instancesChangeBtn,
+				instancesResetBtn,
+				instancesInfoBtn,
+			),
+		),
+		container.NewPadded(instancesLabel),
+		container.NewPadded(
+			container.NewHBox(
+				widget.NewLabel(T("settings.launcherFolder")),
+				layout.NewSpacer(),
+				homeChangeBtn,
+				homeResetBtn,
+				homeInfoBtn,
+			),
+		),
+		container.NewPadded(homeLabel),
+		container.NewPadded(
+			container.NewHBox(
+				widget.NewLabel(T("settings.backupRetention")),
+				layout.NewSpacer(),
+				backupsSelect,
+				backupsInfoBtn,
+			),
+		),
+	))
+
+	doctorBtn := widget.NewButtonWithIcon(T("settings.doctor"), theme.ConfirmIcon(), func() {
+		g.showDoctorReport()
+	})
+	doctorInfoBtn := createInfoButton("Health Check", "Checks the launcher installation for common problems.\n\n• Launcher folder is writable\n• Prism Launcher and Java runtimes are present and run\n• Installed instances have valid configuration files\n• patchelf is available on Linux\n• The saved process list can be read\n• Same as running the launcher with --doctor\n• Run this first before reporting a bug", g.window)
+	reportIssueRow := container.NewPadded(container.NewHBox(
+		widget.NewButtonWithIcon(T("action.reportIssue"), theme.MailComposeIcon(), func() {
+			g.reportIssue("")
+		}),
+		layout.NewSpacer(),
+		createInfoButton("Report Issue", "Opens a new GitHub issue in your browser, already filled in with details that help fix the problem.\n\n• Launcher version and operating system\n• The last errors shown on modpack cards\n• Optionally uploads the current log and includes its link\n• Add what you were doing before submitting", g.window),
+	))
+	repairQtRow := container.NewPadded(container.NewHBox(
+		widget.NewButtonWithIcon(T("settings.repairQt"), theme.ViewRefreshIcon(), func() {
+			g.repairQt()
+		}),
+		layout.NewSpacer(),
+		createInfoButton("Repair Qt Environment", "Fixes Prism Launcher failing to start because of its bundled Qt libraries.\n\n• Restores execute permissions on Qt plugins\n• Re-applies the plugin RPATH fix with patchelf\n• Checks every plugin for corruption and missing libraries\n• Downloads Prism again if a plugin is corrupt or Prism's own Qt libraries are missing; instances are kept\n• Shows a before/after summary and the packages to install for anything still missing\n• Also runs once by itself when a launch finds broken plugins", g.window),
+	))
+	if runtime.GOOS != "linux" {
+		repairQtRow.Hide()
+	}
+	cleanupJavaRow := container.NewPadded(container.NewHBox(
+		widget.NewButtonWithIcon(T("settings.cleanupJava"), theme.DeleteIcon(), func() {
+			if g.anyModpackActive() {
+				dialog.ShowError(fmt.Errorf("Close running modpacks and wait for installs to finish before removing Java runtimes."), g.window)
+				return
+			}
+			go g.cleanupJREs(true)
+		}),
+		layout.NewSpacer(),
+		createInfoButton("Remove Unused Java", "Deletes Java runtimes that none of your installed modpacks use any more.\n\n• Each Minecraft version may need its own Java, downloaded into prism/java\n• Runtimes left behind after a pack moves to a newer Minecraft can take hundreds of MB\n• Runtimes of running games are never removed\n• Also runs by itself after a game closes\n• A removed runtime is downloaded again if a pack needs it later", g.window),
+	))
+
+	rollbackBtn := widget.NewButtonWithIcon(T("settings.rollback"), theme.MediaSkipPreviousIcon(), nil)
+	rollbackRow := container.NewPadded(container.NewHBox(
+		rollbackBtn,
+		layout.NewSpacer(),
+		createInfoButton("Roll Back Launcher", "Goes back to the launcher version you had before the last update.\n\n• Use this if an update, such as a dev build, doesn't work for you\n• The launcher restarts on the previous version\n• The next start checks for updates again; choose another update channel or disable self-update to stay on it\n• Same as running the launcher with --rollback", g.window),
+	))
+	if prev, ok := readPreviousLauncher(g.root); ok {
+		rollbackBtn.SetText(Tf("action.rollback", prev.Version))
+		rollbackBtn.OnTapped = func() { g.rollbackLauncher(prev.Version) }
+		if g.anyModpackActive() {
+			rollbackBtn.Disable()
+		}
+	} else {
+		rollbackRow.Hide()
+	}
+
+	// Settings are reopened after an import so every control shows the imported values
+	var pop *widget.PopUp
+	transferRow := container.NewPadded(container.NewHBox(
+		widget.NewButtonWithIcon(T("settings.export"), theme.UploadIcon(), func() {
+			g.exportSettingsToFile()
+		}),
+		widget.NewButtonWithIcon(T("settings.import"), theme.DownloadIcon(), func() {
+			g.importSettingsFromFile(func() {
+				pop.Hide()
+				g.showSettings()
+			})
+		}),
+		layout.NewSpacer(),
+		createInfoButton("Import/Export Settings", "Moves your launcher settings to another computer.\n\n• Export saves memory, update, download and log preferences, favorites, playtime and per-modpack RAM to a file\n• Import on the other computer to apply them\n• A settings.json from another launcher folder can be imported too\n• Favorites and playtime are merged with what's already there\n• The instances folder and downloaded modpacks are not included", g.window),
 	))
 
 	// Create Status section with card
-	statusCard := widget.NewCard("Status Information", "", container.NewVBox(
+	statusCard := widget.NewCard(T("settings.status"), "", container.NewVBox(
 		container.NewPadded(
 			container.NewHBox(
 				channelLabel,
@@ -2154,52 +5484,62 @@ func (g *GUI) showSettings() {
 				channelInfoBtn,
 			),
 		),
+		container.NewPadded(
+			container.NewHBox(
+				doctorBtn,
+				layout.NewSpacer(),
+				doctorInfoBtn,
+			),
+		),
+		rollbackRow,
+		repairQtRow,
+		cleanupJavaRow,
+		transferRow,
+		reportIssueRow,
 	))
 
 	// Create buttons section
-	cancelBtn := widget.NewButtonWithIcon("Cancel", theme.CancelIcon(), func() {
+	cancelBtn := widget.NewButtonWithIcon(T("action.cancel"), theme.CancelIcon(), func() {
 		// This will be set after the pop is created
 	})
 
-	saveApplyBtn := widget.NewButtonWithIcon("Save & Apply", theme.DocumentSaveIcon(), func() {
+	saveApplyBtn := widget.NewButtonWithIcon(T("action.saveApply"), theme.DocumentSaveIcon(), func() {
 		// This will be set after the pop is created
 
 		// Show loading in main UI instead of dialog
-		g.showLoading(true, "Applying settings...")
-		g.updateStatus("Applying settings...")
+		g.showLoading(true, T("settings.applying"))
+		g.updateStatus(T("settings.applying"))
 
 		go func() {
 			defer g.showLoading(false, "")
 
-			// Handle dev mode changes with validation
-			if devCheck.Checked != settings.DevBuildsEnabled {
+			// Handle update channel changes with validation. A pack may have started after
+			// the dialog opened, and the channel switch must not swap the binary under it.
+			currentChannel, targetChannel := updateChannelSetting(), selectedChannel()
+			if targetChannel != currentChannel && g.anyModpackActive() {
+				logf("%s", warnLine("Not switching update channel while a modpack is running or installing"))
+				fyne.Do(func() {
+					resetChannelSelect()
+					dialog.ShowInformation("Update Channel Unchanged", "The update channel can't be switched while a modpack is running or installing, because switching replaces and restarts the launcher.\n\nYour other settings were saved. Try again once the modpack has closed.", g.window)
+				})
+			} else if targetChannel != currentChannel {
 				g.updateStatus("Validating update availability...")
 
-				// Pre-update validation: check if the target version is available
-				targetDevMode := devCheck.Checked
-				var validationErr error
-
-				if targetDevMode {
-					// Check if dev builds are available
-					_, _, validationErr = FetchLatestAssetPreferPrerelease(UPDATE_OWNER, UPDATE_REPO, LauncherAssetName, true)
-				} else {
-					// Check if stable builds are available
-					_, _, validationErr = FetchLatestAssetPreferPrerelease(UPDATE_OWNER, UPDATE_REPO, LauncherAssetName, false)
-				}
-
+				// Pre-update validation: check if the target channel has a release
+				_, _, validationErr := FetchLatestAssetForChannel(UPDATE_OWNER, UPDATE_REPO, LauncherAssetName, targetChannel)
 				if validationErr != nil {
 					logf("%s", warnLine(fmt.Sprintf("Update validation failed: %v", validationErr)))
 					fyne.Do(func() {
 						dialog.ShowError(fmt.Errorf("Failed to validate update availability: %v\n\nPlease check your internet connection and try again.", validationErr), g.window)
-						// Revert checkbox to current state
-						devCheck.SetChecked(settings.DevBuildsEnabled)
+						// Revert dropdown to current channel
+						resetChannelSelect()
 					})
 					return
 				}
 
-				// Apply dev mode change
-				settings.DevBuildsEnabled = targetDevMode
-				logf("%s", infoLine(fmt.Sprintf("GUI: User %s dev builds", map[bool]string{true: "enabled", false: "disabled"}[targetDevMode])))
+				// Apply channel change
+				setUpdateChannel(targetChannel)
+				logf("%s", infoLine(fmt.Sprintf("GUI: User switched update channel from %s to %s", currentChannel, targetChannel)))
 
 				// Save settings before update
 				if err := saveSettings(g.root); err != nil {
@@ -2207,15 +5547,15 @@ func (g *GUI) showSettings() {
 					fyne.Do(func() {
 						dialog.ShowError(fmt.Errorf("Failed to save settings: %v", err), g.window)
 						// Revert changes
-						settings.DevBuildsEnabled = !targetDevMode
-						devCheck.SetChecked(settings.DevBuildsEnabled)
+						setUpdateChannel(currentChannel)
+						resetChannelSelect()
 					})
 					return
 				}
 
 				// Force update to the target channel
-				g.updateStatus(fmt.Sprintf("Updating to latest %s version...", map[bool]string{true: "dev", false: "stable"}[targetDevMode]))
-				updateErr := forceUpdate(g.root, g.exePath, targetDevMode, func(msg string) {
+				g.updateStatus(fmt.Sprintf("Updating to latest %s version...", targetChannel))
+				updateErr := forceUpdate(g.root, g.exePath, targetChannel, func(msg string) {
 					logf("%s", infoLine(msg))
 					fyne.Do(func() {
 						g.updateStatus(msg)
@@ -2223,15 +5563,15 @@ func (g *GUI) showSettings() {
 				})
 
 				if updateErr != nil {
-					logf("%s", warnLine(fmt.Sprintf("Failed to update to %s version: %v", map[bool]string{true: "dev", false: "stable"}[targetDevMode], updateErr)))
+					logf("%s", warnLine(fmt.Sprintf("Failed to update to %s version: %v", targetChannel, updateErr)))
 
-					// Fallback: if dev update failed, try to fallback to stable
-					if targetDevMode {
+					// Fallback: if a prerelease update failed, try to fallback to stable
+					if targetChannel != channelStable {
 						logf("%s", infoLine("Attempting fallback to stable channel..."))
 						fyne.Do(func() {
 							g.updateStatus("Attempting fallback to stable...")
 						})
-						fallbackErr := forceUpdate(g.root, g.exePath, false, func(msg string) {
+						fallbackErr := forceUpdate(g.root, g.exePath, channelStable, func(msg string) {
 							logf("%s", infoLine(fmt.Sprintf("Fallback: %s", msg)))
 							fyne.Do(func() {
 								g.updateStatus(msg)
@@ -2241,18 +5581,18 @@ func (g *GUI) showSettings() {
 						if fallbackErr != nil {
 							logf("%s", warnLine(fmt.Sprintf("Fallback to stable also failed: %v", fallbackErr)))
 							fyne.Do(func() {
-								dialog.ShowError(fmt.Errorf("Failed to update to dev version and fallback to stable also failed.\n\nDev error: %v\nFallback error: %v\n\nPlease check your internet connection and try again.", updateErr, fallbackErr), g.window)
+								dialog.ShowError(fmt.Errorf("Failed to update to %s version and fallback to stable also failed.\n\nUpdate error: %v\nFallback error: %v\n\nPlease check your internet connection and try again.", targetChannel, updateErr, fallbackErr), g.window)
 								// Revert to original state
-								settings.DevBuildsEnabled = !targetDevMode
-								devCheck.SetChecked(settings.DevBuildsEnabled)
+								setUpdateChannel(currentChannel)
+								resetChannelSelect()
 								saveSettings(g.root)
 							})
 						} else {
 							logf("%s", successLine("Successfully fell back to stable channel"))
 							fyne.Do(func() {
-								dialog.ShowInformation("Update Fallback", "Failed to update to dev version, but successfully fell back to stable channel.\n\nDev builds have been disabled.", g.window)
-								settings.DevBuildsEnabled = false
-								devCheck.SetChecked(false)
+								dialog.ShowInformation("Update Fallback", fmt.Sprintf("Failed to update to %s version, but successfully fell back to stable channel.\n\nThe update channel has been set to Stable.", targetChannel), g.window)
+								setUpdateChannel(channelStable)
+								resetChannelSelect()
 								saveSettings(g.root)
 							})
 						}
@@ -2261,15 +5601,15 @@ func (g *GUI) showSettings() {
 						fyne.Do(func() {
 							dialog.ShowError(fmt.Errorf("Failed to update to stable version: %v\n\nPlease check your internet connection and try again.", updateErr), g.window)
 							// Revert to original state
-							settings.DevBuildsEnabled = !targetDevMode
-							devCheck.SetChecked(settings.DevBuildsEnabled)
+							setUpdateChannel(currentChannel)
+							resetChannelSelect()
 							saveSettings(g.root)
 						})
 					}
 					return
 				}
 
-				logf("%s", successLine(fmt.Sprintf("Successfully updated to %s channel", map[bool]string{true: "dev", false: "stable"}[targetDevMode])))
+				logf("%s", successLine(fmt.Sprintf("Successfully updated to %s channel", targetChannel)))
 			}
 
 			// Apply debug logging change
@@ -2278,6 +5618,111 @@ func (g *GUI) showSettings() {
 				logf("%s", infoLine(fmt.Sprintf("GUI: User %s debug logging", map[bool]string{true: "enabled", false: "disabled"}[debugCheck.Checked])))
 			}
 
+			// Apply cache-bust change
+			if cacheBustCheck.Checked != settings.CacheBust {
+				settings.CacheBust = cacheBustCheck.Checked
+				logf("%s", infoLine(fmt.Sprintf("GUI: User %s pack cache bypass", map[bool]string{true: "enabled", false: "disabled"}[cacheBustCheck.Checked])))
+			}
+
+			// Apply prefetch change
+			if prefetchCheck.Checked != settings.Prefetch {
+				settings.Prefetch = prefetchCheck.Checked
+				logf("%s", infoLine(fmt.Sprintf("GUI: User %s background prefetch", map[bool]string{true: "enabled", false: "disabled"}[prefetchCheck.Checked])))
+			}
+
+			// Apply keep console open change
+			if keepConsoleCheck.Checked != settings.KeepConsoleOpen {
+				settings.KeepConsoleOpen = keepConsoleCheck.Checked
+				logf("%s", infoLine(fmt.Sprintf("GUI: User %s keeping the console open", map[bool]string{true: "enabled", false: "disabled"}[keepConsoleCheck.Checked])))
+			}
+
+			// Apply Prism GUI launch change
+			if prismGUICheck.Checked != settings.LaunchPrismGUI {
+				settings.LaunchPrismGUI = prismGUICheck.Checked
+				logf("%s", infoLine(fmt.Sprintf("GUI: User %s launching through the Prism GUI", map[bool]string{true: "enabled", false: "disabled"}[prismGUICheck.Checked])))
+			}
+
+			// Apply Qt platform change
+			if i := qtPlatformSelect.SelectedIndex(); i >= 0 && !strings.EqualFold(qtPlatformValues[i], settings.QtPlatform) {
+				settings.QtPlatform = qtPlatformValues[i]
+				platform, _ := qtPlatform()
+				logf("%s", infoLine(fmt.Sprintf("GUI: User set the Qt platform to %s", platform)))
+			}
+
+			// Apply log redaction change
+			if redactCheck.Checked != settings.RedactLogs {
+				settings.RedactLogs = redactCheck.Checked
+				logf("%s", infoLine(fmt.Sprintf("GUI: User %s log redaction for uploads", map[bool]string{true: "enabled", false: "disabled"}[redactCheck.Checked])))
+			}
+
+			// Apply log format change; the log in use keeps its format until restart
+			if jsonLogsCheck.Checked != strings.EqualFold(settings.LogFormat, logFormatJSON) {
+				settings.LogFormat = ""
+				if jsonLogsCheck.Checked {
+					settings.LogFormat = logFormatJSON
+				}
+				logf("%s", infoLine(fmt.Sprintf("GUI: User %s JSON logs from the next start", map[bool]string{true: "enabled", false: "disabled"}[jsonLogsCheck.Checked])))
+			}
+
+			// Apply offline mode change; the modpack list is reloaded below
+			offlineChanged := !offlineFlag && offlineCheck.Checked != settings.OfflineMode
+			if offlineChanged {
+				settings.OfflineMode = offlineCheck.Checked
+				logf("%s", infoLine(fmt.Sprintf("GUI: User %s offline mode", map[bool]string{true: "enabled", false: "disabled"}[offlineCheck.Checked])))
+			}
+
+			// Apply download tuning
+			var timeoutSec int
+			if _, err := fmt.Sscanf(timeoutSelect.Selected, "%d s", &timeoutSec); err == nil && timeoutSec != int(downloadTimeout()/time.Second) {
+				settings.DownloadTimeoutSec = timeoutSec
+				logf("%s", infoLine(fmt.Sprintf("GUI: User set download timeout to %d seconds", timeoutSec)))
+			}
+			if n, err := strconv.Atoi(concurrencySelect.Selected); err == nil && n != downloadConcurrency() {
+				settings.DownloadConcurrency = n
+				logf("%s", infoLine(fmt.Sprintf("GUI: User set parallel downloads to %d", n)))
+			}
+			if n, err := strconv.Atoi(installsSelect.Selected); err == nil && n != installConcurrency() {
+				settings.InstallConcurrency = n
+				logf("%s", infoLine(fmt.Sprintf("GUI: User set parallel installs to %d", n)))
+			}
+			if n, err := strconv.Atoi(backupsSelect.Selected); err == nil && n != backupRetention() {
+				settings.BackupRetention = n
+				logf("%s", infoLine(fmt.Sprintf("GUI: User set backups kept per modpack to %d", n)))
+			}
+
+			// Apply extra catalogs
+			var catalogURLs []string
+			for _, line := range strings.Split(catalogsEntry.Text, "\n") {
+				if line = strings.TrimSpace(line); line != "" {
+					catalogURLs = append(catalogURLs, line)
+				}
+			}
+			catalogsChanged := strings.Join(catalogURLs, "\n") != strings.Join(settings.CatalogURLs, "\n")
+			if catalogsChanged {
+				settings.CatalogURLs = catalogURLs
+				logf("%s", infoLine(fmt.Sprintf("GUI: User set %d extra catalog(s)", len(catalogURLs))))
+			}
+
+			// Apply account change
+			account := accountSelect.Selected
+			if account == accountDefault {
+				account = ""
+			}
+			if account != settings.PrismAccount {
+				settings.PrismAccount = account
+				logf("%s", infoLine(fmt.Sprintf("GUI: User chose Minecraft account %q", account)))
+			}
+
+			// Apply language change; labels already on screen keep their text until restart
+			languageChanged := selectedLanguage != currentLanguage()
+			if languageChanged {
+				settings.Language = selectedLanguage
+				if err := setLanguage(selectedLanguage); err != nil {
+					logf("%s", warnLine(fmt.Sprintf("Failed to switch language: %v", err)))
+				}
+				logf("%s", infoLine(fmt.Sprintf("GUI: User switched language to %s", selectedLanguage)))
+			}
+
 			// Save all settings
 			if err := saveSettings(g.root); err != nil {
 				logf("%s", warnLine(fmt.Sprintf("Failed to save settings: %v", err)))
@@ -2288,9 +5733,16 @@ func (g *GUI) showSettings() {
 			}
 
 			g.updateMemorySummaryLabel()
+			g.updateAccountLabel()
 
 			fyne.Do(func() {
-				g.updateStatus("Settings applied successfully")
+				g.updateStatus(T("settings.applied"))
+				if languageChanged {
+					dialog.ShowInformation(T("settings.language"), T("settings.languageRestart"), g.window)
+				}
+				if (catalogsChanged || offlineChanged) && g.previewSource == "" {
+					g.refreshModpacks()
+				}
 			})
 		}()
 	})
@@ -2318,7 +5770,7 @@ func (g *GUI) showSettings() {
 	)
 
 	// Create modal popup with better sizing
-	pop := widget.NewModalPopUp(
+	pop = widget.NewModalPopUp(
 		container.NewScroll(
 			container.NewPadded(dialogContent),
 		),
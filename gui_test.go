@@ -0,0 +1,312 @@
+package main
+
+import (
+	"errors"
+	"net/url"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+)
+
+// TestReadNewLogContent tests that the console tailer follows rotation and truncation without repeating output
+func TestReadNewLogContent(t *testing.T) {
+	dir := t.TempDir()
+	latest := filepath.Join(dir, "latest.log")
+	g := &GUI{}
+	defer g.closeLogHandle()
+	appendLog := func(path, text string) {
+		f, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+		if err != nil {
+			t.Fatalf("Failed to open %s: %v", path, err)
+		}
+		defer f.Close()
+		if _, err := f.WriteString(text); err != nil {
+			t.Fatalf("Failed to write %s: %v", path, err)
+		}
+	}
+	read := func() string {
+		info, err := os.Stat(latest)
+		if err != nil {
+			t.Fatalf("Failed to stat latest.log: %v", err)
+		}
+		return g.readNewLogContent(latest, info)
+	}
+
+	appendLog(latest, "one\n")
+	if got := read(); got != "one\n" {
+		t.Errorf("Expected the initial content, got %q", got)
+	}
+	appendLog(latest, "two\n")
+	if got := read(); got != "two\n" {
+		t.Errorf("Expected only the appended line, got %q", got)
+	}
+	if got := read(); got != "" {
+		t.Errorf("Expected nothing new, got %q", got)
+	}
+
+	if runtime.GOOS == "windows" {
+		// Windows refuses to rename a file that is still open
+		return
+	}
+
+	// Rotate: the old file gets one more line after the rename, and the new file is
+	// already longer than the old read position
+	previous := filepath.Join(dir, "previous.log")
+	if err := os.Rename(latest, previous); err != nil {
+		t.Fatalf("Failed to rotate log: %v", err)
+	}
+	appendLog(previous, "three\n")
+	appendLog(latest, "four\nfive\nsix\nseven\n")
+	if got := read(); got != "three\nfour\nfive\nsix\nseven\n" {
+		t.Errorf("Expected the old tail followed by the new file, got %q", got)
+	}
+
+	// Truncate in place
+	if err := os.WriteFile(latest, []byte("eight\n"), 0644); err != nil {
+		t.Fatalf("Failed to truncate log: %v", err)
+	}
+	if got := read(); got != "eight\n" {
+		t.Errorf("Expected the truncated file from the start, got %q", got)
+	}
+}
+
+// TestStatusSummaryUnreachable tests that a failed version check reads differently from a real status
+func TestStatusSummaryUnreachable(t *testing.T) {
+	offline := errors.New("dial tcp: no such host")
+	if got := (&ModpackState{RemoteError: offline}).StatusSummary(); got != T("status.unreachable") {
+		t.Errorf("Expected the unreachable status for a pack that isn't installed, got %q", got)
+	}
+	installed := &ModpackState{Installed: true, LocalVersion: "1.0.0", RemoteError: offline}
+	if got := installed.StatusSummary(); got != Tf("status.unreachableInstalled", "1.0.0") {
+		t.Errorf("Expected the installed version with the unreachable note, got %q", got)
+	}
+	if got := (&ModpackState{Installed: true, LocalVersion: "1.0.0"}).StatusSummary(); got != Tf("status.upToDateVersion", "1.0.0") {
+		t.Errorf("Expected the up to date status without a check error, got %q", got)
+	}
+}
+
+// TestCategoryHasModpacks tests the check that decides whether a saved category is restored
+func TestCategoryHasModpacks(t *testing.T) {
+	saved := settings
+	defer func() { settings = saved }()
+	settings.LastPlayed = map[string]time.Time{"played": time.Now()}
+
+	mods := []Modpack{
+		{ID: "played", Category: "Adventure"},
+		{ID: "other", Tags: []string{"performance"}},
+	}
+	for category, want := range map[string]bool{
+		"":             true,
+		"adventure":    true,
+		"performance":  true,
+		"visuals":      false,
+		categoryRecent: true,
+	} {
+		if got := categoryHasModpacks(mods, category); got != want {
+			t.Errorf("categoryHasModpacks(%q) = %v, want %v", category, got, want)
+		}
+	}
+	if categoryHasModpacks(mods[1:], categoryRecent) {
+		t.Error("Expected no recent modpacks when none have been played")
+	}
+}
+
+// TestModMatchesQuery tests that the search matches tags and category, and that a
+// tag: prefix only matches tags
+func TestModMatchesQuery(t *testing.T) {
+	mod := Modpack{
+		DisplayName: "The Boys Lite",
+		Description: "A light pack for older machines",
+		Author:      "TheBoys",
+		Category:    "Vanilla+",
+		Tags:        []string{"Performance", "Fabric"},
+	}
+	for query, want := range map[string]bool{
+		"lite":            true,
+		"perform":         true,
+		"vanilla":         true,
+		"FABRIC":          true,
+		"tag:performance": true,
+		"tag: Fabric":     true,
+		"tag:lite":        false,
+		"tag:vanilla":     false,
+		"shaders":         false,
+		"tag:shaders":     false,
+	} {
+		if got := modMatchesQuery(mod, query); got != want {
+			t.Errorf("modMatchesQuery(%q) = %v, want %v", query, got, want)
+		}
+	}
+}
+
+// TestFindConsoleMatches tests that console search finds every hit, ignoring case,
+// at the rune column the console cursor uses
+func TestFindConsoleMatches(t *testing.T) {
+	text := "Starting\n  ⚠ Error: java crashed\nerror again, ERROR twice\n"
+	want := []consoleMatch{{Row: 1, Col: 4}, {Row: 2, Col: 0}, {Row: 2, Col: 13}}
+	got := findConsoleMatches(text, "error")
+	if len(got) != len(want) {
+		t.Fatalf("Expected %d matches, got %+v", len(want), got)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("Match %d: expected %+v, got %+v", i, want[i], got[i])
+		}
+	}
+	if got := findConsoleMatches(text, ""); got != nil {
+		t.Errorf("Expected no matches for an empty query, got %+v", got)
+	}
+
+	for _, tt := range []struct {
+		index, total int
+		want         string
+	}{
+		{2, 17, "3 of 17"},
+		{-1, 17, "17 matches"},
+		{-1, 0, "No matches"},
+	} {
+		if got := consoleMatchCount(tt.index, tt.total); got != tt.want {
+			t.Errorf("consoleMatchCount(%d, %d) = %q, want %q", tt.index, tt.total, got, tt.want)
+		}
+	}
+}
+
+// TestLogUploadCurlCommand tests that the curl command mirrors the multipart upload fields
+func TestLogUploadCurlCommand(t *testing.T) {
+	command := logUploadCurlCommand(logUploadURL, filepath.Join("logs", "latest.log"), "abc123.log")
+	for _, want := range []string{"curl ", "act=bput", "file=@" + filepath.Join("logs", "latest.log") + ";filename=abc123.log;type=application/octet-stream", logUploadURL} {
+		if !strings.Contains(command, want) {
+			t.Errorf("Expected %q in %s", want, command)
+		}
+	}
+	if runtime.GOOS != "windows" {
+		if got := shellQuote("it's"); got != `'it'\''s'` {
+			t.Errorf("Expected single quotes to be escaped, got %s", got)
+		}
+	}
+}
+
+// TestIssueReportURL tests that the issue link targets the repo and carries the report details
+func TestIssueReportURL(t *testing.T) {
+	link := issueReportURL("alpha: packwiz failed", "https://i.dylan.lol/logs/abc.log")
+	parsed, err := url.Parse(link)
+	if err != nil {
+		t.Fatalf("Failed to parse issue URL: %v", err)
+	}
+	if want := "/" + UPDATE_OWNER + "/" + UPDATE_REPO + "/issues/new"; parsed.Host != "github.com" || parsed.Path != want {
+		t.Errorf("Expected github.com%s, got %s%s", want, parsed.Host, parsed.Path)
+	}
+	body := parsed.Query().Get("body")
+	for _, want := range []string{version, runtime.GOOS + "/" + runtime.GOARCH, "alpha: packwiz failed", "https://i.dylan.lol/logs/abc.log"} {
+		if !strings.Contains(body, want) {
+			t.Errorf("Expected issue body to contain %q, got:\n%s", want, body)
+		}
+	}
+
+	long := issueReportURL(strings.Repeat("x", 5000), "")
+	parsed, _ = url.Parse(long)
+	body = parsed.Query().Get("body")
+	if strings.Contains(body, strings.Repeat("x", maxIssueErrorLen+1)) {
+		t.Error("Expected long errors to be truncated")
+	}
+	if !strings.Contains(body, "paste the link here") {
+		t.Errorf("Expected a log link placeholder when no log was uploaded, got:\n%s", body)
+	}
+}
+
+// TestModpackOperations tests that each modpack keeps its own launched process, and
+// that an operation ending doesn't forget a newer one for the same modpack
+func TestModpackOperations(t *testing.T) {
+	var shared *os.Process
+	g := &GUI{prismProcess: &shared}
+	first := g.beginOperation("skyblock")
+	second := g.beginOperation("vanilla")
+	firstProc, secondProc := &os.Process{Pid: 100}, &os.Process{Pid: 200}
+	g.setOperationProcess(first, firstProc)
+	g.setOperationProcess(second, secondProc)
+
+	g.endOperation("vanilla", second)
+	if proc, ok := g.operationProcess("skyblock"); !ok || proc != firstProc {
+		t.Errorf("Expected skyblock to keep its process, got %v, %v", proc, ok)
+	}
+	if _, ok := g.operationProcess("vanilla"); ok {
+		t.Error("Expected vanilla's operation to be gone")
+	}
+	if shared != nil {
+		t.Errorf("Expected the ended process to be cleared for the signal handler, got %v", shared)
+	}
+
+	replaced := g.beginOperation("skyblock")
+	g.endOperation("skyblock", first)
+	if _, ok := g.operationProcess("skyblock"); !ok {
+		t.Error("Expected an old operation ending to leave the newer one")
+	}
+	g.endOperation("skyblock", nil)
+	if _, ok := g.operationProcess("skyblock"); ok || replaced.process != nil {
+		t.Error("Expected a stopped modpack to have no operation left")
+	}
+}
+
+// TestTakeCancel tests that cancelling one modpack leaves the other's operation
+// cancellable, and that a cancel func is only handed out once
+func TestTakeCancel(t *testing.T) {
+	g := &GUI{}
+	cancelled := map[string]int{}
+	for _, id := range []string{"skyblock", "vanilla"} {
+		op := g.beginOperation(id)
+		op.cancel = func() { cancelled[id]++ }
+	}
+
+	if cancel := g.takeCancel("skyblock"); cancel == nil {
+		t.Fatal("Expected skyblock to be cancellable")
+	} else {
+		cancel()
+	}
+	if cancel := g.takeCancel("skyblock"); cancel != nil {
+		t.Error("Expected skyblock's cancel func to be handed out only once")
+	}
+	if cancelled["skyblock"] != 1 || cancelled["vanilla"] != 0 {
+		t.Errorf("Expected only skyblock to be cancelled, got %v", cancelled)
+	}
+	if cancel := g.takeCancel("vanilla"); cancel == nil {
+		t.Error("Expected vanilla to still be cancellable")
+	}
+	if cancel := g.takeCancel("missing"); cancel != nil {
+		t.Error("Expected nothing to cancel without an operation")
+	}
+}
+
+// TestModpackMenu tests that the card menu only enables actions the pack's state allows
+func TestModpackMenu(t *testing.T) {
+	saved := settings
+	defer func() { settings = saved }()
+	settings.OfflineMode = false
+
+	mod := Modpack{ID: "skyblock"}
+	enabled := func(g *GUI) map[string]bool {
+		items := map[string]bool{}
+		for _, item := range g.modpackMenu(mod).Items {
+			items[item.Label] = !item.Disabled
+		}
+		return items
+	}
+
+	g := &GUI{modpackStates: map[string]*ModpackState{"skyblock": {Installed: true, Running: true}}}
+	items := enabled(g)
+	if items[T("action.resync")] || items[T("action.rename")] {
+		t.Errorf("Expected resync and rename to be disabled while the game runs, got %v", items)
+	}
+	if !items[T("action.modList")] || !items[T("action.openFolder")] {
+		t.Errorf("Expected the mod list and folder to stay available while the game runs, got %v", items)
+	}
+
+	g.modpackStates["skyblock"] = &ModpackState{Installed: true}
+	for label, ok := range enabled(g) {
+		if !ok {
+			t.Errorf("Expected %q to be enabled for an idle installed pack", label)
+		}
+	}
+}
@@ -0,0 +1,242 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// -------------------- Launcher home --------------------
+
+// launcherHomeFile records a launcher home chosen in Settings. It is kept in the
+// default home, since settings.json lives in the chosen home and can't point to it.
+const launcherHomeFile = "launcher-home.json"
+
+// launcherHomeRecord is the content of launcherHomeFile
+type launcherHomeRecord struct {
+	// Path is the chosen launcher home; empty means the platform default
+	Path string `json:"path,omitempty"`
+	// MoveFrom is the previous home whose data moves into Path at the next start
+	MoveFrom string `json:"moveFrom,omitempty"`
+}
+
+// getLauncherHome returns the folder holding settings, Prism, Java, logs and
+// backups: THEBOYS_HOME if set, then the folder chosen in Settings, then the
+// platform default
+func getLauncherHome() string {
+	if dir := launcherHomeFromEnv(); dir != "" {
+		return dir
+	}
+	if record, err := readLauncherHomeRecord(); err == nil && record.Path != "" {
+		return record.Path
+	}
+	return defaultLauncherHome()
+}
+
+// launcherHomeFromEnv returns the absolute THEBOYS_HOME, or "" when it isn't set.
+// It overrides the launcher home for a run, ahead of any folder chosen in Settings.
+func launcherHomeFromEnv() string {
+	dir := strings.TrimSpace(os.Getenv(envHome))
+	if dir == "" {
+		return ""
+	}
+	if abs, err := filepath.Abs(dir); err == nil {
+		return abs
+	}
+	return filepath.Clean(dir)
+}
+
+// readLauncherHomeRecord reads launcherHomeFile from the default home
+func readLauncherHomeRecord() (launcherHomeRecord, error) {
+	var record launcherHomeRecord
+	data, err := os.ReadFile(filepath.Join(defaultLauncherHome(), launcherHomeFile))
+	if err != nil {
+		return record, err
+	}
+	err = json.Unmarshal(data, &record)
+	return record, err
+}
+
+// writeLauncherHomeRecord saves record to the default home, removing the file
+// once it no longer points anywhere else
+func writeLauncherHomeRecord(record launcherHomeRecord) error {
+	path := filepath.Join(defaultLauncherHome(), launcherHomeFile)
+	if record.Path != "" && filepath.Clean(record.Path) == filepath.Clean(defaultLauncherHome()) {
+		record.Path = ""
+	}
+	if record.Path == "" && record.MoveFrom == "" {
+		if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+		return nil
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(record, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0644)
+}
+
+// validateLauncherHome checks that dir can become the launcher home in place of root.
+// It must be an absolute path that is neither inside root nor contains it, must be
+// writable, and when root's data is moving there (move) it must be empty and have
+// room for that data if it is on another drive.
+func validateLauncherHome(root, dir string, move bool) error {
+	if !filepath.IsAbs(dir) {
+		return fmt.Errorf("launcher folder must be an absolute path: %s", dir)
+	}
+	dir = filepath.Clean(dir)
+	if info, err := os.Stat(dir); err == nil && !info.IsDir() {
+		return fmt.Errorf("%s is a file, not a folder", dir)
+	}
+	root = filepath.Clean(root)
+	if isSubPath(root, dir) || isSubPath(dir, root) {
+		return fmt.Errorf("launcher folder cannot be inside %s or contain it", root)
+	}
+
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return fmt.Errorf("cannot create %s: %w", dir, err)
+	}
+	if err := probeWritable(dir); err != nil {
+		return err
+	}
+	if !move {
+		return nil
+	}
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return err
+	}
+	for _, entry := range entries {
+		// The default home keeps launcherHomeFile even while another folder is in use
+		if entry.Name() == launcherHomeFile {
+			continue
+		}
+		return fmt.Errorf("%s is not empty; choose an empty folder to move the launcher data into", dir)
+	}
+	// A move within one drive is a rename; across drives everything is copied first
+	if !sameFilesystem(root, dir) {
+		need := dirSize(root)
+		if free, err := freeDiskSpace(dir); err == nil && free < need+diskSpaceMargin {
+			return fmt.Errorf("not enough free disk space in %s: the launcher data takes %s but only %s is free",
+				dir, formatSize(need), formatSize(free))
+		}
+	}
+	return nil
+}
+
+// sameFilesystem reports whether a and b are on the same drive, by renaming a
+// temporary file from one to the other
+func sameFilesystem(a, b string) bool {
+	probe, err := os.CreateTemp(a, ".move-test-*")
+	if err != nil {
+		return false
+	}
+	name := probe.Name()
+	probe.Close()
+	target := filepath.Join(b, filepath.Base(name))
+	if err := os.Rename(name, target); err != nil {
+		os.Remove(name)
+		return false
+	}
+	os.Remove(target)
+	return true
+}
+
+// scheduleLauncherHomeChange makes dir the launcher home from the next start. With
+// move, root's data is moved there before anything opens it; otherwise dir starts
+// out with just the current settings and root is left as it is. An empty dir
+// goes back to the platform default.
+func scheduleLauncherHomeChange(root, dir string, move bool) error {
+	if dir == "" {
+		dir = defaultLauncherHome()
+	}
+	dir = filepath.Clean(dir)
+	if err := validateLauncherHome(root, dir, move); err != nil {
+		return err
+	}
+
+	record := launcherHomeRecord{Path: dir}
+	if move {
+		record.MoveFrom = filepath.Clean(root)
+	} else if !exists(filepath.Join(dir, "settings.json")) {
+		if err := saveSettings(dir); err != nil {
+			return fmt.Errorf("failed to copy settings to %s: %w", dir, err)
+		}
+	}
+	return writeLauncherHomeRecord(record)
+}
+
+// migrateLauncherHome finishes a move scheduled by scheduleLauncherHomeChange. It
+// runs at startup before logging opens anything in either folder, and returns the
+// old home and how many items moved. If nothing could be moved the old home stays
+// in use; after a partial move the new one is used and the rest is left behind.
+func migrateLauncherHome() (from string, moved int, err error) {
+	if launcherHomeFromEnv() != "" {
+		return "", 0, nil
+	}
+	record, err := readLauncherHomeRecord()
+	if err != nil || record.MoveFrom == "" {
+		return "", 0, nil
+	}
+	from = record.MoveFrom
+	to := record.Path
+	if to == "" {
+		to = defaultLauncherHome()
+	}
+
+	moved, err = moveLauncherHome(from, to)
+	if err != nil && moved == 0 {
+		record.Path = from
+	}
+	record.MoveFrom = ""
+	if werr := writeLauncherHomeRecord(record); werr != nil {
+		err = errors.Join(err, fmt.Errorf("failed to record the launcher folder: %w", werr))
+	}
+	return from, moved, err
+}
+
+// moveLauncherHome moves everything in fromDir into toDir like moveInstances, except
+// launcherHomeFile and the running executable, which a launcher installed into its
+// home can't move out from under itself
+func moveLauncherHome(fromDir, toDir string) (int, error) {
+	entries, err := os.ReadDir(fromDir)
+	if os.IsNotExist(err) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+	exe, _ := os.Executable()
+
+	var names []string
+	for _, entry := range entries {
+		path := filepath.Join(fromDir, entry.Name())
+		if entry.Name() == launcherHomeFile || (exe != "" && filepath.Clean(path) == filepath.Clean(exe)) {
+			continue
+		}
+		if exists(filepath.Join(toDir, entry.Name())) {
+			return 0, fmt.Errorf("%s already exists in %s", entry.Name(), toDir)
+		}
+		names = append(names, entry.Name())
+	}
+	if err := os.MkdirAll(toDir, 0755); err != nil {
+		return 0, err
+	}
+
+	moved := 0
+	for _, name := range names {
+		if err := movePath(filepath.Join(fromDir, name), filepath.Join(toDir, name)); err != nil {
+			return moved, err
+		}
+		moved++
+	}
+	return moved, nil
+}
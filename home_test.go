@@ -0,0 +1,70 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+)
+
+// TestLauncherHomeChange tests choosing a launcher folder, moving the data into it
+// at the next start, and THEBOYS_HOME taking precedence over both
+func TestLauncherHomeChange(t *testing.T) {
+	if runtime.GOOS != "linux" {
+		t.Skip("the default launcher home is only redirected through HOME on Linux")
+	}
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv(envHome, "")
+	saved := settings
+	defer func() { settings = saved }()
+
+	root := defaultLauncherHome()
+	if got := getLauncherHome(); got != root {
+		t.Fatalf("Expected the default launcher home %s, got %s", root, got)
+	}
+	writeTestFile(t, filepath.Join(root, "settings.json"), 10)
+	writeTestFile(t, filepath.Join(root, "prism", "PrismLauncher"), 100)
+
+	target := filepath.Join(t.TempDir(), "launcher")
+	if err := scheduleLauncherHomeChange(root, filepath.Join(root, "nested"), true); err == nil {
+		t.Error("Expected a folder inside the current launcher home to be refused")
+	}
+	if err := scheduleLauncherHomeChange(root, target, true); err != nil {
+		t.Fatalf("scheduleLauncherHomeChange failed: %v", err)
+	}
+	// Nothing moves until the next start
+	if got := getLauncherHome(); got != target || !exists(filepath.Join(root, "prism", "PrismLauncher")) {
+		t.Fatalf("Expected %s to be chosen with the data still in place, got %s", target, got)
+	}
+
+	from, moved, err := migrateLauncherHome()
+	if err != nil || from != root || moved != 2 {
+		t.Fatalf("Expected 2 items moved from %s, got %d from %s: %v", root, moved, from, err)
+	}
+	if !exists(filepath.Join(target, "prism", "PrismLauncher")) || exists(filepath.Join(root, "prism")) {
+		t.Error("Expected Prism to have moved into the new launcher home")
+	}
+	if !exists(filepath.Join(root, launcherHomeFile)) || getLauncherHome() != target {
+		t.Errorf("Expected the default home to keep pointing at %s, got %s", target, getLauncherHome())
+	}
+	if from, _, _ := migrateLauncherHome(); from != "" {
+		t.Errorf("Expected the move to run only once, got another from %s", from)
+	}
+
+	// Going back without moving copies the settings and drops the pointer
+	if err := os.Remove(filepath.Join(root, "settings.json")); err != nil && !os.IsNotExist(err) {
+		t.Fatal(err)
+	}
+	if err := scheduleLauncherHomeChange(target, "", false); err != nil {
+		t.Fatalf("scheduleLauncherHomeChange failed: %v", err)
+	}
+	if exists(filepath.Join(root, launcherHomeFile)) || !exists(filepath.Join(root, "settings.json")) || getLauncherHome() != root {
+		t.Errorf("Expected the default home with copied settings, got %s", getLauncherHome())
+	}
+
+	override := t.TempDir()
+	t.Setenv(envHome, override)
+	if got := getLauncherHome(); got != override {
+		t.Errorf("Expected THEBOYS_HOME %s to win, got %s", override, got)
+	}
+}
@@ -0,0 +1,137 @@
+package main
+
+import (
+	"embed"
+	"encoding/json"
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// -------------------- Localization --------------------
+
+// Translations live in locales/<code>.json as flat key -> string maps. The
+// "language.name" entry is the language's own name, shown in Settings.
+// Missing keys fall back to English, then to the key itself.
+
+//go:embed locales/*.json
+var localeFiles embed.FS
+
+const defaultLanguage = "en"
+
+var (
+	localeMu       sync.RWMutex
+	activeLanguage = defaultLanguage
+	activeCatalog  map[string]string
+	englishCatalog map[string]string
+)
+
+// languageOption is a shipped translation
+type languageOption struct {
+	Code string
+	Name string
+}
+
+// loadCatalog reads the embedded catalog for a language code
+func loadCatalog(code string) (map[string]string, error) {
+	data, err := localeFiles.ReadFile(path.Join("locales", code+".json"))
+	if err != nil {
+		return nil, fmt.Errorf("no translation for language %q", code)
+	}
+	var catalog map[string]string
+	if err := json.Unmarshal(data, &catalog); err != nil {
+		return nil, fmt.Errorf("invalid translation file for %q: %w", code, err)
+	}
+	return catalog, nil
+}
+
+// setLanguage selects the active catalog; an unknown code falls back to English
+func setLanguage(code string) error {
+	code = strings.ToLower(strings.TrimSpace(code))
+	if code == "" {
+		code = defaultLanguage
+	}
+
+	english, err := loadCatalog(defaultLanguage)
+	if err != nil {
+		return err
+	}
+	catalog := english
+	var loadErr error
+	if code != defaultLanguage {
+		if catalog, loadErr = loadCatalog(code); loadErr != nil {
+			catalog, code = english, defaultLanguage
+		}
+	}
+
+	localeMu.Lock()
+	englishCatalog = english
+	activeCatalog = catalog
+	activeLanguage = code
+	localeMu.Unlock()
+	return loadErr
+}
+
+// currentLanguage returns the code of the active catalog
+func currentLanguage() string {
+	localeMu.RLock()
+	defer localeMu.RUnlock()
+	return activeLanguage
+}
+
+// availableLanguages lists the shipped translations, English first
+func availableLanguages() []languageOption {
+	entries, err := localeFiles.ReadDir("locales")
+	if err != nil {
+		return []languageOption{{Code: defaultLanguage, Name: "English"}}
+	}
+
+	var options []languageOption
+	for _, entry := range entries {
+		code := strings.TrimSuffix(entry.Name(), ".json")
+		catalog, err := loadCatalog(code)
+		if err != nil {
+			continue
+		}
+		name := catalog["language.name"]
+		if name == "" {
+			name = code
+		}
+		options = append(options, languageOption{Code: code, Name: name})
+	}
+	sort.SliceStable(options, func(i, j int) bool {
+		if options[i].Code == defaultLanguage || options[j].Code == defaultLanguage {
+			return options[i].Code == defaultLanguage
+		}
+		return options[i].Name < options[j].Name
+	})
+	return options
+}
+
+// T returns the translation of key in the active language
+func T(key string) string {
+	localeMu.RLock()
+	active, english := activeCatalog, englishCatalog
+	localeMu.RUnlock()
+
+	if active == nil && english == nil {
+		// Not initialized yet (e.g. in tests); load English on first use
+		if err := setLanguage(defaultLanguage); err == nil {
+			return T(key)
+		}
+	}
+	if text, ok := active[key]; ok {
+		return text
+	}
+	if text, ok := english[key]; ok {
+		return text
+	}
+	return key
+}
+
+// Tf formats the translation of key with fmt.Sprintf
+func Tf(key string, args ...interface{}) string {
+	return fmt.Sprintf(T(key), args...)
+}
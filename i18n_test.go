@@ -0,0 +1,56 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+// TestLocaleCatalogs tests that every shipped translation covers the English keys with matching format verbs
+func TestLocaleCatalogs(t *testing.T) {
+	english, err := loadCatalog(defaultLanguage)
+	if err != nil {
+		t.Fatalf("Failed to load English catalog: %v", err)
+	}
+
+	for _, lang := range availableLanguages() {
+		catalog, err := loadCatalog(lang.Code)
+		if err != nil {
+			t.Fatalf("Failed to load %s catalog: %v", lang.Code, err)
+		}
+		for key, text := range english {
+			translated, ok := catalog[key]
+			if !ok {
+				t.Errorf("%s: missing key %q", lang.Code, key)
+				continue
+			}
+			if strings.Count(translated, "%") != strings.Count(text, "%") {
+				t.Errorf("%s: %q has different format verbs than English: %q vs %q", lang.Code, key, translated, text)
+			}
+		}
+	}
+}
+
+// TestSetLanguageFallback tests that unknown languages and missing keys fall back to English
+func TestSetLanguageFallback(t *testing.T) {
+	defer setLanguage(defaultLanguage)
+
+	if err := setLanguage("xx"); err == nil {
+		t.Error("Expected error for unknown language")
+	}
+	if currentLanguage() != defaultLanguage || T("action.launch") != "Launch" {
+		t.Errorf("Expected English fallback, got %s/%q", currentLanguage(), T("action.launch"))
+	}
+
+	if err := setLanguage("es"); err != nil {
+		t.Fatalf("setLanguage failed: %v", err)
+	}
+	if T("action.launch") == "Launch" {
+		t.Error("Expected a Spanish translation for action.launch")
+	}
+	if T("no.such.key") != "no.such.key" {
+		t.Errorf("Expected unknown key to be returned as-is, got %q", T("no.such.key"))
+	}
+	if got := Tf("status.runningPID", 42); !strings.Contains(got, "42") {
+		t.Errorf("Expected formatted PID in %q", got)
+	}
+}
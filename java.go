@@ -1,13 +1,15 @@
 package main
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"os"
+	"path/filepath"
 	"runtime"
 	"strconv"
 	"strings"
-	"time"
 )
 
 // -------------------- Java Version Detection --------------------
@@ -34,8 +36,7 @@ func getJavaVersionForMinecraft(mcVersion string) string {
 	}
 	req.Header.Set("User-Agent", getUserAgent("Java"))
 
-	client := &http.Client{Timeout: 30 * time.Second}
-	resp, err := client.Do(req)
+	resp, err := doWithRetry(req, httpRetryAttempts(), downloadTimeout())
 	if err != nil {
 		logf("%s", warnLine(fmt.Sprintf("Failed to fetch Java compatibility data for Minecraft %s: %v", cleanVersion, err)))
 		return "17" // default fallback
@@ -103,8 +104,7 @@ func getLWJGLVersionForMinecraft(mcVersion string) LWJGLInfo {
 	}
 	req.Header.Set("User-Agent", getUserAgent("LWJGL"))
 
-	client := &http.Client{Timeout: 30 * time.Second}
-	resp, err := client.Do(req)
+	resp, err := doWithRetry(req, httpRetryAttempts(), downloadTimeout())
 	if err != nil {
 		logf("%s", warnLine(fmt.Sprintf("Failed to fetch LWJGL data for Minecraft %s: %v", cleanVersion, err)))
 		return LWJGLInfo{Version: "3.3.3", UID: "org.lwjgl3", Name: "LWJGL 3"} // default fallback
@@ -173,7 +173,8 @@ func getPlatformJavaParams() (osName, arch string) {
 
 // Prefer Adoptium API (stable), fall back to GitHub release asset.
 // We want: OS=windows, arch=x64, image_type=jre (or jdk for Java 16), vm=hotspot, latest for specified version.
-func fetchJREURL(javaVersion string) (string, error) {
+// The archive's SHA-256 is returned alongside when Adoptium or the release publishes one.
+func fetchJREURL(ctx context.Context, javaVersion string) (string, string, error) {
 	debugf("Fetching JRE URL for Java version %s", javaVersion)
 	// Java 16 only has JDK builds available, not JRE
 	imageType := "jre"
@@ -188,19 +189,20 @@ func fetchJREURL(javaVersion string) (string, error) {
 	adoptium := fmt.Sprintf("https://api.adoptium.net/v3/assets/latest/%s/hotspot?architecture=%s&image_type=%s&os=%s", javaVersion, arch, imageType, osName)
 	debugf("Adoptium API URL: %s", adoptium)
 
-	req, _ := http.NewRequest("GET", adoptium, nil)
+	req, _ := http.NewRequestWithContext(ctx, "GET", adoptium, nil)
 	req.Header.Set("User-Agent", getUserAgent("Adoptium"))
 	req.Header.Set("Cache-Control", "no-cache")
 	req.Header.Set("Pragma", "no-cache")
-	resp, err := http.DefaultClient.Do(req)
+	resp, err := doWithRetry(req, httpRetryAttempts(), downloadTimeout())
 	if err == nil && resp.StatusCode == 200 {
 		debugf("Adoptium API response: HTTP %d", resp.StatusCode)
 		defer resp.Body.Close()
 		var payload []struct {
 			Binary struct {
 				Package struct {
-					Link string `json:"link"`
-					Name string `json:"name"`
+					Link     string `json:"link"`
+					Name     string `json:"name"`
+					Checksum string `json:"checksum"`
 				} `json:"package"`
 			} `json:"binary"`
 		}
@@ -211,7 +213,7 @@ func fetchJREURL(javaVersion string) (string, error) {
 				// Prefer zip files (packages) over installers
 				if v.Binary.Package.Link != "" && strings.HasSuffix(strings.ToLower(v.Binary.Package.Link), ".zip") {
 					debugf("Selected Java package: %s", v.Binary.Package.Name)
-					return v.Binary.Package.Link, nil
+					return v.Binary.Package.Link, v.Binary.Package.Checksum, nil
 				}
 			}
 		} else {
@@ -226,19 +228,17 @@ func fetchJREURL(javaVersion string) (string, error) {
 	// Only used if Adoptium API fails
 	releaseURL := fmt.Sprintf("https://github.com/adoptium/temurin%s-binaries/releases/latest", javaVersion)
 
-	client := &http.Client{
-		CheckRedirect: func(req *http.Request, via []*http.Request) error {
-			return nil
-		},
+	req2, err2 := http.NewRequestWithContext(ctx, "GET", releaseURL, nil)
+	if err2 != nil {
+		return "", "", err2
 	}
-
-	resp2, err2 := client.Get(releaseURL)
+	resp2, err2 := doWithRetry(req2, httpRetryAttempts(), downloadTimeout())
 	if err2 != nil {
-		return "", fmt.Errorf("adoptium api and github fallback failed: %v", err2)
+		return "", "", fmt.Errorf("adoptium api and github fallback failed: %v", err2)
 	}
 	defer resp2.Body.Close()
 	if resp2.StatusCode != 200 {
-		return "", fmt.Errorf("github adoptium status %d", resp2.StatusCode)
+		return "", "", fmt.Errorf("github adoptium status %d", resp2.StatusCode)
 	}
 
 	// Extract tag from the final redirected URL
@@ -252,14 +252,14 @@ func fetchJREURL(javaVersion string) (string, error) {
 	}
 
 	if latestTag == "" {
-		return "", fmt.Errorf("could not extract tag from GitHub redirect URL: %s", finalURL)
+		return "", "", fmt.Errorf("could not extract tag from GitHub redirect URL: %s", finalURL)
 	}
 
 	// Generate platform-specific asset name
 	assetName := generateJavaAssetName(javaVersion, imageType, osName, arch, latestTag)
 	assetURL := fmt.Sprintf("https://github.com/adoptium/temurin%s-binaries/releases/download/%s/%s", javaVersion, latestTag, assetName)
 
-	return assetURL, nil
+	return assetURL, fetchSHA256Sidecar(ctx, assetURL), nil
 }
 
 // generateJavaAssetName creates platform-specific asset names for Adoptium releases
@@ -284,3 +284,123 @@ func generateJavaAssetName(javaVersion, imageType, osName, arch, tag string) str
 		return fmt.Sprintf("OpenJDK%sU-%s_x64_windows_hotspot_%s.zip", javaVersion, imageType, tagCleaned)
 	}
 }
+
+// cleanupUnusedJREs deletes the managed JREs under prism/java that no installed
+// instance needs any more. An instance keeps the runtime its Minecraft version needs
+// and the one instance.cfg pins it to, and runtimes of running games are always kept.
+// It returns the Java versions removed and the bytes freed.
+func cleanupUnusedJREs(root string) ([]string, int64, error) {
+	// Held for the scan and the delete so an install can't pick up a runtime mid-removal
+	jreSetupMu.Lock()
+	defer jreSetupMu.Unlock()
+
+	javaDir := filepath.Join(root, "prism", "java")
+	entries, err := os.ReadDir(javaDir)
+	if os.IsNotExist(err) {
+		return nil, 0, nil
+	}
+	if err != nil {
+		return nil, 0, err
+	}
+
+	needed, err := javaVersionsInUse(root)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	var removed []string
+	var freed int64
+	for _, entry := range entries {
+		version, ok := strings.CutPrefix(entry.Name(), "jre")
+		if !entry.IsDir() || !ok || version == "" || needed[version] {
+			continue
+		}
+		jreDir := filepath.Join(javaDir, entry.Name())
+		size := dirSize(jreDir)
+		if err := os.RemoveAll(jreDir); err != nil {
+			return removed, freed, fmt.Errorf("failed to remove Java %s: %w", version, err)
+		}
+		logf("%s", infoLine(fmt.Sprintf("Removed unused Java %s runtime (%s)", version, formatSize(size))))
+		removed = append(removed, version)
+		freed += size
+	}
+	return removed, freed, nil
+}
+
+// removeUnusedJRE deletes one managed JRE the storage view listed as unused, checking
+// again under cleanupUnusedJREs' lock that no install or launch has picked it up since
+func removeUnusedJRE(root, version string) error {
+	jreSetupMu.Lock()
+	defer jreSetupMu.Unlock()
+
+	needed, err := javaVersionsInUse(root)
+	if err != nil {
+		return err
+	}
+	if needed[version] {
+		return fmt.Errorf("Java %s is in use again and was kept", version)
+	}
+	if err := os.RemoveAll(filepath.Join(root, "prism", "java", "jre"+version)); err != nil {
+		return fmt.Errorf("failed to remove Java %s: %w", version, err)
+	}
+	return nil
+}
+
+// javaVersionsInUse returns the Java majors that installed instances, running games
+// or the prefetched default modpack still use, which cleanup must keep
+func javaVersionsInUse(root string) (map[string]bool, error) {
+	needed, err := neededJavaVersions(instancesDirFor(root), filepath.Join(root, "prism", "java"))
+	if err != nil {
+		return nil, err
+	}
+	if version := prefetchedJavaVersion(); version != "" {
+		needed[version] = true
+	}
+	if registry := loadedProcessRegistry(); registry != nil {
+		for _, record := range registry.GetRunningProcesses() {
+			if record.JavaVersion != "" {
+				needed[record.JavaVersion] = true
+			}
+		}
+	}
+	return needed, nil
+}
+
+// neededJavaVersions returns the Java majors used by the instances in instancesDir.
+// An unreadable instances folder is an error rather than "nothing installed", so
+// runtimes survive the folder being on a drive that isn't mounted right now.
+func neededJavaVersions(instancesDir, javaDir string) (map[string]bool, error) {
+	entries, err := os.ReadDir(instancesDir)
+	if err != nil {
+		return nil, fmt.Errorf("cannot read instances folder %s: %w", instancesDir, err)
+	}
+
+	needed := make(map[string]bool)
+	for _, entry := range entries {
+		instDir := filepath.Join(instancesDir, entry.Name())
+		if !entry.IsDir() || !exists(filepath.Join(instDir, "instance.cfg")) {
+			continue
+		}
+		pinned := false
+		if cfg, err := readInstanceConfig(instDir); err == nil && cfg["JavaPath"] != "" {
+			// Managed runtimes are pinned as prism/java/jre<major>/bin/javaw
+			jreDir := filepath.Dir(filepath.Dir(filepath.FromSlash(cfg["JavaPath"])))
+			if filepath.Clean(filepath.Dir(jreDir)) == filepath.Clean(javaDir) {
+				needed[strings.TrimPrefix(filepath.Base(jreDir), "jre")] = true
+				pinned = true
+			}
+		}
+		info, err := readInstancePackInfo(instDir)
+		if err != nil || info.Minecraft == "" {
+			continue
+		}
+		if offlineMode() {
+			if !pinned {
+				return nil, fmt.Errorf("cannot tell which Java %s needs while offline", entry.Name())
+			}
+			continue
+		}
+		needed[getJavaVersionForMinecraft(info.Minecraft)] = true
+	}
+	return needed, nil
+}
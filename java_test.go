@@ -0,0 +1,131 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// writeTestInstance creates an instance whose instance.cfg pins javaPath, with an
+// mmc-pack.json for minecraft when it is set
+func writeTestInstance(t *testing.T, instancesDir, name, javaPath, minecraft string) {
+	t.Helper()
+	instDir := filepath.Join(instancesDir, name)
+	if err := os.MkdirAll(instDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	cfg := "InstanceType=OneSix\nJavaPath=" + filepath.ToSlash(javaPath) + "\n"
+	if err := os.WriteFile(filepath.Join(instDir, "instance.cfg"), []byte(cfg), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if minecraft != "" {
+		pack := `{"components":[{"uid":"net.minecraft","version":"` + minecraft + `"}]}`
+		if err := os.WriteFile(filepath.Join(instDir, "mmc-pack.json"), []byte(pack), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+// TestCleanupUnusedJREs tests that only runtimes no instance is pinned to are removed
+func TestCleanupUnusedJREs(t *testing.T) {
+	saved := settings
+	defer func() { settings = saved }()
+	root := t.TempDir()
+	settings.InstancesDir = ""
+	settings.OfflineMode = true
+	javaDir := filepath.Join(root, "prism", "java")
+	instancesDir := instancesDirFor(root)
+
+	for _, version := range []string{"8", "17", "21"} {
+		bin := filepath.Join(javaDir, "jre"+version, "bin")
+		if err := os.MkdirAll(bin, 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(filepath.Join(bin, JavawBinName), []byte("java"), 0755); err != nil {
+			t.Fatal(err)
+		}
+	}
+	writeTestInstance(t, instancesDir, "Modern", filepath.Join(javaDir, "jre21", "bin", JavawBinName), "1.21.1")
+	writeTestInstance(t, instancesDir, "Own Java", "/usr/lib/jvm/java-17/bin/java", "")
+
+	removed, freed, err := cleanupUnusedJREs(root)
+	if err != nil {
+		t.Fatalf("cleanupUnusedJREs failed: %v", err)
+	}
+	if got := strings.Join(removed, ","); got != "17,8" && got != "8,17" {
+		t.Errorf("Expected Java 8 and 17 to be removed, got %q", got)
+	}
+	if freed <= 0 {
+		t.Errorf("Expected the freed space to be counted, got %d", freed)
+	}
+	if !exists(filepath.Join(javaDir, "jre21")) {
+		t.Error("Expected the pinned Java 21 runtime to be kept")
+	}
+
+	// Offline, an instance without a pinned runtime could need any of them
+	writeTestInstance(t, instancesDir, "Unpinned", "", "1.20.1")
+	if _, _, err := cleanupUnusedJREs(root); err == nil || !exists(filepath.Join(javaDir, "jre21")) {
+		t.Errorf("Expected cleanup to stop when an offline instance's Java is unknown, got %v", err)
+	}
+
+	settings.InstancesDir = filepath.Join(root, "unmounted")
+	if _, _, err := cleanupUnusedJREs(root); err == nil {
+		t.Error("Expected a missing instances folder to stop the cleanup")
+	}
+}
+
+// TestCleanupKeepsPrefetchedJava tests that the runtime prefetched for a modpack that
+// isn't installed yet survives cleanup
+func TestCleanupKeepsPrefetchedJava(t *testing.T) {
+	saved := settings
+	defer func() { settings = saved }()
+	defer setPrefetchedJava(prefetchedJavaVersion())
+	root := t.TempDir()
+	settings.InstancesDir = ""
+	if err := os.MkdirAll(instancesDirFor(root), 0755); err != nil {
+		t.Fatal(err)
+	}
+	javaDir := filepath.Join(root, "prism", "java")
+	for _, version := range []string{"17", "21"} {
+		if err := os.MkdirAll(filepath.Join(javaDir, "jre"+version, "bin"), 0755); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	setPrefetchedJava("21")
+	removed, _, err := cleanupUnusedJREs(root)
+	if err != nil {
+		t.Fatalf("cleanupUnusedJREs failed: %v", err)
+	}
+	if strings.Join(removed, ",") != "17" || !exists(filepath.Join(javaDir, "jre21")) {
+		t.Errorf("Expected only Java 17 removed and the prefetched Java 21 kept, got %v", removed)
+	}
+}
+
+// TestRemoveUnusedJRE tests that the storage view only deletes a runtime nothing uses
+func TestRemoveUnusedJRE(t *testing.T) {
+	saved := settings
+	defer func() { settings = saved }()
+	root := t.TempDir()
+	settings.InstancesDir = ""
+	settings.OfflineMode = true
+	javaDir := filepath.Join(root, "prism", "java")
+	for _, version := range []string{"17", "21"} {
+		bin := filepath.Join(javaDir, "jre"+version, "bin")
+		if err := os.MkdirAll(bin, 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(filepath.Join(bin, JavawBinName), []byte("java"), 0755); err != nil {
+			t.Fatal(err)
+		}
+	}
+	writeTestInstance(t, instancesDirFor(root), "Modern", filepath.Join(javaDir, "jre21", "bin", JavawBinName), "1.21.1")
+
+	if err := removeUnusedJRE(root, "21"); err == nil || !exists(filepath.Join(javaDir, "jre21")) {
+		t.Errorf("Expected the Java 21 an instance uses to be kept, got %v", err)
+	}
+	if err := removeUnusedJRE(root, "17"); err != nil || exists(filepath.Join(javaDir, "jre17")) {
+		t.Errorf("Expected the unused Java 17 to be removed, got %v", err)
+	}
+}
@@ -2,6 +2,7 @@ package main
 
 import (
 	"bytes"
+	"context"
 	"errors"
 	"fmt"
 	"io"
@@ -9,15 +10,19 @@ import (
 	"os/exec"
 	"path/filepath"
 	"runtime"
+	"runtime/debug"
+	"sort"
 	"strconv"
 	"strings"
+	"sync"
+	"sync/atomic"
 	"time"
 )
 
 // -------------------- Qt Environment Helper Functions --------------------
 
 // buildQtEnvironment builds Qt-specific environment variables for Linux
-func buildQtEnvironment(prismDir, jreDir string) []string {
+func buildQtEnvironment(prismDir, jreDir string, envVars map[string]string) []string {
 	qtEnv := []string{
 		"JAVA_HOME=" + jreDir,
 		"PATH=" + BuildPathEnv(filepath.Join(jreDir, "bin")),
@@ -47,19 +52,98 @@ func buildQtEnvironment(prismDir, jreDir string) []string {
 		}
 
 		// Additional Qt environment variables for better compatibility
-		qtEnv = append(qtEnv, "QT_QPA_PLATFORM=xcb")           // Force X11 backend
-		qtEnv = append(qtEnv, "QT_XCB_GL_INTEGRATION=xcb_glx") // OpenGL integration
+		platform, _ := qtPlatform()
+		qtEnv = append(qtEnv, "QT_QPA_PLATFORM="+platform)     // Wayland or X11 backend
+		qtEnv = append(qtEnv, "QT_XCB_GL_INTEGRATION=xcb_glx") // OpenGL integration on X11
+
+		// Qt debug variables for comprehensive logging, only with debug logging on since
+		// they flood latest.log. Failed launches are still diagnosed from Prism's stderr.
+		if settings.DebugEnabled {
+			qtEnv = append(qtEnv, "QT_DEBUG_PLUGINS=1")         // Enable detailed plugin loading information
+			qtEnv = append(qtEnv, "QT_LOGGING_RULES*=true")     // Enable comprehensive logging
+			qtEnv = append(qtEnv, "QT_DEBUG_PLUGINS_VERBOSE=1") // More verbose plugin debugging
+			qtEnv = append(qtEnv, "QT_QPA_VERBOSE=1")           // QPA platform debugging
+			qtEnv = append(qtEnv, "QT_XCB_DEBUG=1")             // XCB backend debugging
+		}
+	}
+
+	// Per-modpack overrides go last; exec uses the last value for duplicate keys
+	return append(qtEnv, envOverrides(envVars)...)
+}
+
+// Qt platform plugins Prism can run on under Linux
+const (
+	qtPlatformXCB     = "xcb"
+	qtPlatformWayland = "wayland"
+)
+
+// qtPlatform returns QT_QPA_PLATFORM for Prism and why it was chosen: the Qt platform
+// setting if there is one, otherwise Wayland with X11 as Qt's own fallback on Wayland
+// sessions, and X11 everywhere else
+func qtPlatform() (platform, reason string) {
+	switch strings.ToLower(strings.TrimSpace(settings.QtPlatform)) {
+	case qtPlatformXCB:
+		return qtPlatformXCB, "chosen in Settings"
+	case qtPlatformWayland:
+		return qtPlatformWayland, "chosen in Settings"
+	}
+	if waylandSession() {
+		return qtPlatformWayland + ";" + qtPlatformXCB, "Wayland session detected"
+	}
+	return qtPlatformXCB, "X11 session"
+}
+
+// waylandSession reports whether the launcher runs in a Wayland desktop session
+func waylandSession() bool {
+	return strings.EqualFold(os.Getenv("XDG_SESSION_TYPE"), "wayland") || os.Getenv("WAYLAND_DISPLAY") != ""
+}
+
+// alternateQtPlatform returns the other platform to retry a launch that failed on
+// platform, or "" when this session has no display for it
+func alternateQtPlatform(platform string) string {
+	if strings.HasPrefix(platform, qtPlatformWayland) {
+		if os.Getenv("DISPLAY") != "" {
+			return qtPlatformXCB
+		}
+		return ""
+	}
+	if waylandSession() {
+		return qtPlatformWayland
+	}
+	return ""
+}
+
+// qtStartupWindow is how soon Prism must exit for a Qt platform failure to count as
+// Prism never opening its window, rather than a game that ran and then crashed
+const qtStartupWindow = 15 * time.Second
 
-		// Qt debug variables for comprehensive logging
-		qtEnv = append(qtEnv, "QT_DEBUG_PLUGINS=1")         // Enable detailed plugin loading information
-		qtEnv = append(qtEnv, "QT_LOGGING_RULES*=true")     // Enable comprehensive logging
-		qtEnv = append(qtEnv, "QT_DEBUG_PLUGINS_VERBOSE=1") // More verbose plugin debugging
-		qtEnv = append(qtEnv, "QT_QPA_VERBOSE=1")           // QPA platform debugging
-		qtEnv = append(qtEnv, "QT_XCB_DEBUG=1")             // XCB backend debugging
+// qtPlatformIssue reports whether analyzePrismError found a problem that another Qt
+// platform might not have, in a Prism that exited within qtStartupWindow of starting
+func qtPlatformIssue(issues []string, ran time.Duration) bool {
+	if ran >= qtStartupWindow {
+		return false
+	}
+	for _, issue := range issues {
+		if strings.Contains(issue, "Qt platform plugin") || strings.Contains(issue, "Graphics/GLX") {
+			return true
+		}
+	}
+	return false
+}
 
+// envOverrides formats per-modpack environment variables as KEY=VALUE in a stable order
+func envOverrides(envVars map[string]string) []string {
+	keys := make([]string, 0, len(envVars))
+	for key := range envVars {
+		keys = append(keys, key)
 	}
+	sort.Strings(keys)
 
-	return qtEnv
+	env := make([]string, 0, len(keys))
+	for _, key := range keys {
+		env = append(env, key+"="+envVars[key])
+	}
+	return env
 }
 
 // logQtEnvironment logs Qt environment setup for debugging
@@ -72,6 +156,9 @@ func logQtEnvironment(prismDir string) {
 	actualPrismDir := getPrismBaseDir(prismDir)
 
 	logf("Actual Prism Base Directory: %s", actualPrismDir)
+	platform, reason := qtPlatform()
+	logf("Qt Platform: %s (%s; XDG_SESSION_TYPE=%q, WAYLAND_DISPLAY=%q, DISPLAY=%q)",
+		platform, reason, os.Getenv("XDG_SESSION_TYPE"), os.Getenv("WAYLAND_DISPLAY"), os.Getenv("DISPLAY"))
 
 	// Check directory structure
 	pluginsDir := filepath.Join(actualPrismDir, "plugins")
@@ -317,10 +404,24 @@ func isSharedLibrary(filePath string) bool {
 		strings.Contains(outputStr, "dynamically linked")
 }
 
+// qtPluginReport summarizes a scan of Prism's bundled Qt plugins
+type qtPluginReport struct {
+	Checked     int
+	Valid       int
+	Invalid     []string
+	MissingDeps []string
+}
+
 // checkPluginDependencies checks if plugins are valid shared libraries and can find their dependencies
 func checkPluginDependencies(prismDir string) error {
+	_, err := inspectPluginDependencies(prismDir)
+	return err
+}
+
+// inspectPluginDependencies is checkPluginDependencies, also returning what it found
+func inspectPluginDependencies(prismDir string) (qtPluginReport, error) {
 	if runtime.GOOS != "linux" {
-		return nil
+		return qtPluginReport{}, nil
 	}
 
 	logf("%s", stepLine("Checking plugin dependencies"))
@@ -331,7 +432,7 @@ func checkPluginDependencies(prismDir string) error {
 
 	if !exists(pluginsDir) {
 		logf("%s", warnLine("Plugins directory not found, skipping dependency checking"))
-		return nil
+		return qtPluginReport{}, nil
 	}
 
 	// Check critical plugins
@@ -467,6 +568,7 @@ func checkPluginDependencies(prismDir string) error {
 	}
 
 	// Report results
+	report := qtPluginReport{Checked: checkedPlugins, Valid: validPlugins, Invalid: invalidPlugins, MissingDeps: missingDeps}
 	if len(invalidPlugins) > 0 {
 		logf("%s", warnLine(fmt.Sprintf("Found %d invalid/corrupted plugins:", len(invalidPlugins))))
 		for _, plugin := range invalidPlugins {
@@ -481,16 +583,211 @@ func checkPluginDependencies(prismDir string) error {
 		for _, dep := range missingDeps {
 			logf("  - %s", dep)
 		}
-		return fmt.Errorf("plugin dependencies missing: %s", strings.Join(missingDeps, "; "))
+		return report, fmt.Errorf("plugin dependencies missing: %s", strings.Join(missingDeps, "; "))
 	} else if validPlugins > 0 {
 		logf("%s", successLine(fmt.Sprintf("All %d plugins validated with resolved dependencies", validPlugins)))
 	} else {
 		logf("%s", warnLine("No valid plugins found"))
 	}
 
+	return report, nil
+}
+
+// qtRepairResult is the before/after summary of repairQtEnvironment
+type qtRepairResult struct {
+	Before       qtPluginReport
+	After        qtPluginReport
+	Redownloaded bool
+}
+
+// repairQtEnvironment re-runs the Qt plugin fixes applied after Prism is downloaded on
+// Linux, first downloading Prism again over the existing copy if any plugin is corrupt
+// or can't find one of Prism's own Qt libraries
+func repairQtEnvironment(prismDir string) (qtRepairResult, error) {
+	if runtime.GOOS != "linux" {
+		return qtRepairResult{}, fmt.Errorf("Qt repair is only available on Linux")
+	}
+	if !exists(GetPrismExecutablePath(prismDir)) {
+		return qtRepairResult{}, fmt.Errorf("Prism Launcher is not installed yet; install a modpack first")
+	}
+
+	// A launch or prefetch installing Prism meanwhile would race the download
+	prismSetupMu.Lock()
+	defer prismSetupMu.Unlock()
+	logf("%s", sectionLine("Repairing Qt Environment"))
+	before, _ := inspectPluginDependencies(prismDir)
+	return repairQtPlugins(prismDir, before, true)
+}
+
+// repairQtPlugins is repairQtEnvironment for a scan that has already been made. Without
+// redownload, Prism is only patched in place even if it needs downloading again.
+// Callers hold prismSetupMu.
+func repairQtPlugins(prismDir string, before qtPluginReport, redownload bool) (qtRepairResult, error) {
+	result := qtRepairResult{Before: before}
+	if !redownload {
+		debugf("Not downloading Prism again while it is in use")
+	} else if len(result.Before.Invalid) > 0 || missingBundledQtLibraries(result.Before) {
+		if err := redownloadPrism(prismDir); err != nil {
+			return result, err
+		}
+		result.Redownloaded = true
+	}
+
+	if err := ensurePatchelfInstalled(); err != nil {
+		logf("%s", warnLine(fmt.Sprintf("Failed to ensure patchelf is installed: %v", err)))
+	}
+	actualPrismDir := getPrismBaseDir(prismDir)
+	if pluginsDir := filepath.Join(actualPrismDir, "plugins"); exists(pluginsDir) {
+		if err := fixPluginPermissions(pluginsDir); err != nil {
+			logf("%s", warnLine(fmt.Sprintf("Failed to fix plugin permissions: %v", err)))
+		}
+	}
+	if err := fixQtPluginRPATH(actualPrismDir); err != nil {
+		logf("%s", warnLine(fmt.Sprintf("Failed to fix Qt plugin RPATH: %v", err)))
+	}
+
+	// Missing system libraries are part of the summary rather than an error
+	result.After, _ = inspectPluginDependencies(prismDir)
+	return result, nil
+}
+
+// missingBundledQtLibraries reports whether a plugin can't find one of the Qt
+// libraries Prism ships itself, which downloading Prism again restores. Other missing
+// libraries come from the system and need a package installed instead.
+func missingBundledQtLibraries(report qtPluginReport) bool {
+	for _, dep := range report.MissingDeps {
+		lib := dep
+		if i := strings.LastIndex(dep, ": "); i >= 0 {
+			lib = dep[i+2:]
+		}
+		if strings.HasPrefix(lib, "libQt") {
+			return true
+		}
+	}
+	return false
+}
+
+// qtAutoRepairMu guards qtAutoRepairTried
+var qtAutoRepairMu sync.Mutex
+
+// qtAutoRepairTried is set once autoRepairQtPlugins has attempted a full repair
+var qtAutoRepairTried bool
+
+// autoRepairQtPlugins checks Prism's Qt plugins before a launch and, when any is corrupt
+// or missing libraries, repairs Prism as the Repair Qt button does. Every launch
+// checks, but the repair is attempted at most once per launcher run rather than once
+// per launch: a problem it can't fix, such as a missing system library, would
+// otherwise download Prism again before every launch. While registry shows a Prism
+// still running, for another modpack say, Prism is only patched in place and the
+// full repair is left for a later launch.
+func autoRepairQtPlugins(prismDir string, registry *ProcessRegistry) {
+	report, err := inspectPluginDependencies(prismDir)
+	if len(report.Invalid) == 0 && len(report.MissingDeps) == 0 {
+		return
+	}
+	if err == nil {
+		err = fmt.Errorf("%d corrupt plugin(s)", len(report.Invalid))
+	}
+
+	prismSetupMu.Lock()
+	defer prismSetupMu.Unlock()
+	inUse := prismInUse(registry)
+	qtAutoRepairMu.Lock()
+	tried := qtAutoRepairTried
+	if !inUse {
+		qtAutoRepairTried = true
+	}
+	qtAutoRepairMu.Unlock()
+	if tried {
+		logf("%s", warnLine(fmt.Sprintf("Plugin dependency check failed: %v (automatic repair was already attempted)", err)))
+		return
+	}
+
+	logf("%s", warnLine(fmt.Sprintf("Plugin dependency check failed: %v; repairing Prism automatically", err)))
+	if inUse {
+		logf("%s", warnLine("Prism is running for another modpack, so it won't be downloaded again until it closes"))
+	}
+	result, err := repairQtPlugins(prismDir, report, !inUse)
+	if err != nil {
+		logf("%s", warnLine(fmt.Sprintf("Automatic Qt repair failed: %v", err)))
+		return
+	}
+	if len(result.After.Invalid) == 0 && len(result.After.MissingDeps) == 0 {
+		logf("%s", successLine("Automatic Qt repair fixed Prism's plugins"))
+		return
+	}
+	logf("%s", warnLine("Automatic Qt repair couldn't fix everything:\n"+formatQtRepairReport(result)))
+}
+
+// prismInUse reports whether registry tracks a Prism process that is still alive
+func prismInUse(registry *ProcessRegistry) bool {
+	if registry == nil {
+		return false
+	}
+	for _, record := range registry.GetAllRecords() {
+		if record.Status != ProcessStatusRunning && record.Status != ProcessStatusStarting {
+			continue
+		}
+		if running, err := isProcessRunning(record.PID); err == nil && running {
+			return true
+		}
+	}
+	return false
+}
+
+// redownloadPrism downloads Prism over the existing copy in prismDir, keeping the old
+// executable until the new one is in place. Callers hold prismSetupMu.
+func redownloadPrism(prismDir string) error {
+	exe := GetPrismExecutablePath(prismDir)
+	backup := exe + ".old"
+	if err := os.Rename(exe, backup); err != nil {
+		return fmt.Errorf("failed to move the old Prism executable aside: %w", err)
+	}
+	if _, err := ensurePrism(context.Background(), prismDir); err != nil {
+		if !exists(GetPrismExecutablePath(prismDir)) {
+			_ = os.Rename(backup, exe)
+		}
+		return fmt.Errorf("failed to download Prism again: %w", err)
+	}
+	_ = os.Remove(backup)
 	return nil
 }
 
+// formatQtRepairReport renders the before/after plugin counts for the repair dialog
+func formatQtRepairReport(result qtRepairResult) string {
+	summary := func(r qtPluginReport) string {
+		return fmt.Sprintf("%d of %d plugins valid, %d corrupt, %d missing libraries", r.Valid, r.Checked, len(r.Invalid), len(r.MissingDeps))
+	}
+
+	var b strings.Builder
+	b.WriteString("Before: " + summary(result.Before) + "\n")
+	b.WriteString("After:  " + summary(result.After) + "\n")
+	if result.Redownloaded {
+		b.WriteString("\nPrism Launcher was downloaded again to replace corrupt plugins.\n")
+	}
+	if len(result.After.Invalid) > 0 {
+		b.WriteString("\nStill corrupt:\n")
+		for _, plugin := range result.After.Invalid {
+			b.WriteString("  - " + plugin + "\n")
+		}
+	}
+	if len(result.After.MissingDeps) > 0 {
+		b.WriteString("\nMissing system libraries:\n")
+		for _, dep := range result.After.MissingDeps {
+			b.WriteString("  - " + dep + "\n")
+		}
+		if pm := getPackageManager(); pm != nil {
+			if packages := getQtPackages(pm.Name); len(packages) > 0 {
+				b.WriteString(fmt.Sprintf("\nInstall them with: sudo %s %s\n", pm.InstallCmd, strings.Join(packages, " ")))
+			}
+		}
+	}
+	if len(result.After.Invalid) == 0 && len(result.After.MissingDeps) == 0 {
+		b.WriteString("\nThe Qt environment looks healthy.\n")
+	}
+	return strings.TrimRight(b.String(), "\n")
+}
+
 // calculateRelativePath calculates the relative path from a plugin to the lib directory
 func calculateRelativePath(pluginPath, libDir string) string {
 	// Get the directory containing the plugin
@@ -600,32 +897,83 @@ func provideErrorContext(issues []string) {
 	logf("%s", sectionLine("Recommended Solutions"))
 
 	for _, issue := range issues {
-		switch {
-		case strings.Contains(issue, "Missing shared library"):
-			logf("%s", infoLine("• Run: sudo apt install libqt6core6t64 libqt6gui6 libqt6widgets6 libqt6network6 libqt6svg6"))
-			logf("%s", infoLine("• Ensure patchelf is installed: sudo apt install patchelf"))
-		case strings.Contains(issue, "Qt platform plugin"):
-			logf("%s", infoLine("• Check plugin permissions in the plugins directory"))
-			logf("%s", infoLine("• Verify RPATH settings with: readelf -d plugins/platforms/libqxcb.so"))
-		case strings.Contains(issue, "Permission denied"):
-			logf("%s", infoLine("• Fix permissions: chmod +x plugins/**/*.so"))
-			logf("%s", infoLine("• Check directory ownership: ls -la prism/"))
-		case strings.Contains(issue, "Graphics/GLX"):
-			logf("%s", infoLine("• Try different Qt platform: export QT_QPA_PLATFORM=wayland"))
-			logf("%s", infoLine("• Update graphics drivers"))
-		case strings.Contains(issue, "Java configuration"):
-			logf("%s", infoLine("• Verify Java installation: java -version"))
-			logf("%s", infoLine("• Check JAVA_HOME is set correctly"))
-		case strings.Contains(issue, "RPATH/library linking"):
-			logf("%s", infoLine("• Reinstall patchelf: sudo apt install --reinstall patchelf"))
-			logf("%s", infoLine("• Manually fix RPATH: patchelf --set-rpath '$ORIGIN/../lib' plugins/**/*.so"))
-		case strings.Contains(issue, "Unusual error format"):
-			logf("%s", infoLine("• This may be a Prism Launcher internal error"))
-			logf("%s", infoLine("• Try launching Prism GUI directly for more details"))
+		for _, solution := range issueSolutions(issue) {
+			logf("%s", infoLine("• "+solution))
 		}
 	}
 }
 
+// issueSolutions returns the recommended fixes for an issue from analyzePrismError
+func issueSolutions(issue string) []string {
+	switch {
+	case strings.Contains(issue, "Missing shared library"):
+		return []string{
+			"Run: sudo apt install libqt6core6t64 libqt6gui6 libqt6widgets6 libqt6network6 libqt6svg6",
+			"Ensure patchelf is installed: sudo apt install patchelf",
+		}
+	case strings.Contains(issue, "Qt platform plugin"):
+		return []string{
+			"Check plugin permissions in the plugins directory",
+			"Verify RPATH settings with: readelf -d plugins/platforms/libqxcb.so",
+		}
+	case strings.Contains(issue, "Permission denied"):
+		return []string{
+			"Fix permissions: chmod +x plugins/**/*.so",
+			"Check directory ownership: ls -la prism/",
+		}
+	case strings.Contains(issue, "Graphics/GLX"):
+		return []string{
+			"Try different Qt platform: export QT_QPA_PLATFORM=wayland",
+			"Update graphics drivers",
+		}
+	case strings.Contains(issue, "Java configuration"):
+		return []string{
+			"Verify Java installation: java -version",
+			"Check JAVA_HOME is set correctly",
+		}
+	case strings.Contains(issue, "RPATH/library linking"):
+		return []string{
+			"Reinstall patchelf: sudo apt install --reinstall patchelf",
+			"Manually fix RPATH: patchelf --set-rpath '$ORIGIN/../lib' plugins/**/*.so",
+		}
+	case strings.Contains(issue, "Unusual error format"):
+		return []string{
+			"This may be a Prism Launcher internal error",
+			"Try launching Prism GUI directly for more details",
+		}
+	}
+	return nil
+}
+
+// prismLaunchError is returned when Prism fails to start or exits with an error.
+// It carries the issues analyzePrismError found so the GUI can show them.
+type prismLaunchError struct {
+	err    error
+	issues []string
+}
+
+func (e *prismLaunchError) Error() string { return e.err.Error() }
+func (e *prismLaunchError) Unwrap() error { return e.err }
+
+// launchIssues collects the distinct issues found across failed launch attempts
+func launchIssues(errs ...error) []string {
+	var issues []string
+	seen := make(map[string]bool)
+	for _, err := range errs {
+		var launchErr *prismLaunchError
+		if !errors.As(err, &launchErr) {
+			continue
+		}
+		for _, issue := range launchErr.issues {
+			if !seen[issue] {
+				seen[issue] = true
+				issues = append(issues, issue)
+			}
+		}
+	}
+	return issues
+}
+
 // createPrismWrapperScript creates a wrapper script for launching Prism with proper environment
 func createPrismWrapperScript(prismDir, jreDir string) (string, error) {
 	if runtime.GOOS != "linux" {
@@ -733,10 +1081,8 @@ if [ -d "$LIB_DIR" ]; then
 	   log_debug "Set LD_LIBRARY_PATH=$LD_LIBRARY_PATH"
 fi
 
-# Qt debug variables
-export QT_DEBUG_PLUGINS=1
-export QT_LOGGING_RULES="*=true"
-export QT_QPA_PLATFORM=xcb
+# Qt platform; debug variables come from the launcher when debug logging is on
+export QT_QPA_PLATFORM="${QT_QPA_PLATFORM:-xcb}"
 export QT_XCB_GL_INTEGRATION=xcb_glx
 
 log_debug "Environment variables set"
@@ -756,7 +1102,7 @@ exec "$PRISM_EXE" "$@"
 }
 
 // launchPrismWithWrapper launches Prism using the wrapper script approach
-func launchPrismWithWrapper(prismDir, jreDir, instanceName string) error {
+func launchPrismWithWrapper(prismDir, jreDir, instanceName string, envVars map[string]string) error {
 	if runtime.GOOS != "linux" {
 		return fmt.Errorf("wrapper script approach only supported on Linux")
 	}
@@ -772,13 +1118,13 @@ func launchPrismWithWrapper(prismDir, jreDir, instanceName string) error {
 	// Launch using the wrapper script
 	var cmd *exec.Cmd
 	if instanceName != "" {
-		cmd = exec.Command(wrapperPath, "--dir", ".", "--launch", instanceName)
+		cmd = exec.Command(wrapperPath, prismLaunchArgs(prismDir, instanceName)...)
 	} else {
 		cmd = exec.Command(wrapperPath, "--dir", ".")
 	}
 
 	cmd.Dir = prismDir
-	cmd.Env = append(os.Environ(), buildQtEnvironment(prismDir, jreDir)...)
+	cmd.Env = append(os.Environ(), buildQtEnvironment(prismDir, jreDir, envVars)...)
 
 	// Capture output for error analysis
 	var stdoutBuf, stderrBuf bytes.Buffer
@@ -796,7 +1142,7 @@ func launchPrismWithWrapper(prismDir, jreDir, instanceName string) error {
 		issues := analyzePrismError(stderrStr, stdoutStr)
 		provideErrorContext(issues)
 
-		return fmt.Errorf("failed to launch Prism with wrapper: %w", err)
+		return &prismLaunchError{err: fmt.Errorf("failed to launch Prism with wrapper: %w", err), issues: issues}
 	}
 
 	logf("%s", successLine(fmt.Sprintf("Prism launched via wrapper (PID: %d)", cmd.Process.Pid)))
@@ -807,26 +1153,152 @@ func launchPrismWithWrapper(prismDir, jreDir, instanceName string) error {
 	// Analyze output even if the process completed
 	stderrStr := stderrBuf.String()
 	stdoutStr := stdoutBuf.String()
+	var issues []string
 	if err != nil || stderrStr != "" {
-		issues := analyzePrismError(stderrStr, stdoutStr)
+		issues = analyzePrismError(stderrStr, stdoutStr)
 		if len(issues) > 0 {
 			provideErrorContext(issues)
 		}
 	}
 
-	return err
+	if err != nil {
+		return &prismLaunchError{err: err, issues: issues}
+	}
+	return nil
+}
+
+// resolvePrismExecutable returns the Prism binary to launch. On macOS a missing
+// local copy falls back to the app in /Applications.
+func resolvePrismExecutable(prismDir string) string {
+	prismExe := GetPrismExecutablePath(prismDir)
+	if runtime.GOOS != "darwin" || exists(prismExe) {
+		return prismExe
+	}
+
+	// Try both naming conventions in /Applications
+	applicationsPrismWithSpace := filepath.Join("/Applications", "Prism Launcher.app", "Contents", "MacOS", "prismlauncher")
+	applicationsPrismWithoutSpace := filepath.Join("/Applications", "PrismLauncher.app", "Contents", "MacOS", "prismlauncher")
+	if exists(applicationsPrismWithSpace) {
+		logf("Using Prism Launcher from /Applications folder (with space)")
+		return applicationsPrismWithSpace
+	}
+	if exists(applicationsPrismWithoutSpace) {
+		logf("Using Prism Launcher from /Applications folder (without space)")
+		return applicationsPrismWithoutSpace
+	}
+	logf("Warning: Prism Launcher not found at %s, %s, or %s", prismExe, applicationsPrismWithSpace, applicationsPrismWithoutSpace)
+	return prismExe
+}
+
+// launchCommand is what a direct launch hands to Prism and, through the
+// instance configuration, to Java
+type launchCommand struct {
+	PrismExe string
+	WorkDir  string
+	Args     []string
+	JavaPath string
+	MemoryMB int
+	JvmArgs  string
+	Env      []string
+	// LaunchEnv is everything a direct launch adds to the launcher's own environment
+	LaunchEnv []string
+}
+
+// describeLaunchCommand resolves the command launchPrismDirect would run for an
+// installed modpack, without launching anything
+func describeLaunchCommand(root string, modpack Modpack) (*launchCommand, error) {
+	prismDir := filepath.Join(root, "prism")
+	instDir := filepath.Join(instancesDirFor(root), modpack.InstanceName)
+	cfg, err := readInstanceConfig(instDir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read instance configuration: %w", err)
+	}
+
+	javaPath := cfg["JavaPath"]
+	jreDir := filepath.Dir(filepath.Dir(filepath.FromSlash(javaPath)))
+	if info, err := readInstancePackInfo(instDir); err == nil && info.Minecraft != "" {
+		jreDir = filepath.Join(prismDir, "java", "jre"+getJavaVersionForMinecraft(info.Minecraft))
+		javaPath = filepath.ToSlash(filepath.Join(jreDir, "bin", JavawBinName))
+	}
+
+	cmd := &launchCommand{
+		PrismExe:  resolvePrismExecutable(prismDir),
+		WorkDir:   prismDir,
+		Args:      prismLaunchArgs(prismDir, modpack.InstanceName),
+		JavaPath:  javaPath,
+		MemoryMB:  MemoryForModpack(modpack),
+		Env:       envOverrides(modpack.EnvVars),
+		LaunchEnv: buildQtEnvironment(prismDir, jreDir, modpack.EnvVars),
+	}
+	if cfg["OverrideJavaArgs"] == "true" {
+		cmd.JvmArgs = cfg["JvmArgs"]
+	}
+	return cmd, nil
+}
+
+// String renders the command for display, one part per line
+func (c *launchCommand) String() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "Working directory: %s\n", c.WorkDir)
+	fmt.Fprintf(&b, "Prism: %q %s\n", c.PrismExe, strings.Join(quoteArgs(c.Args), " "))
+	fmt.Fprintf(&b, "Java: %s\n", c.JavaPath)
+	fmt.Fprintf(&b, "Memory: -Xms%dm -Xmx%dm\n", c.MemoryMB, c.MemoryMB)
+	if c.JvmArgs != "" {
+		fmt.Fprintf(&b, "JVM arguments: %s\n", c.JvmArgs)
+	} else {
+		b.WriteString("JVM arguments: Prism defaults\n")
+	}
+	if len(c.Env) > 0 {
+		fmt.Fprintf(&b, "Extra environment: %s\n", strings.Join(c.Env, " "))
+	}
+	return b.String()
+}
+
+// ShellCommand renders the launch as one line to paste into a terminal, sh on Linux
+// and macOS and cmd on Windows, with the environment a direct launch sets. sh gets
+// the variables through env, since names like QT_LOGGING_RULES* can't be assigned.
+func (c *launchCommand) ShellCommand() string {
+	command := []string{shellQuote(c.PrismExe)}
+	for _, arg := range c.Args {
+		command = append(command, shellQuote(arg))
+	}
+	if runtime.GOOS == "windows" {
+		parts := []string{"cd /d " + shellQuote(c.WorkDir)}
+		for _, kv := range c.LaunchEnv {
+			parts = append(parts, "set "+shellQuote(kv))
+		}
+		return strings.Join(append(parts, strings.Join(command, " ")), " && ")
+	}
+	words := []string{"env"}
+	for _, kv := range c.LaunchEnv {
+		words = append(words, shellQuote(kv))
+	}
+	return "cd " + shellQuote(c.WorkDir) + " && " + strings.Join(append(words, command...), " ")
+}
+
+// quoteArgs quotes arguments containing spaces so a rendered command can be copied
+func quoteArgs(args []string) []string {
+	quoted := make([]string, len(args))
+	for i, arg := range args {
+		if strings.ContainsAny(arg, " \t\"") {
+			quoted[i] = strconv.Quote(arg)
+		} else {
+			quoted[i] = arg
+		}
+	}
+	return quoted
 }
 
 // launchPrismDirect launches Prism directly with enhanced error handling
-func launchPrismDirect(prismExe, prismDir, jreDir, instanceName, packName string, prismProcess **os.Process) error {
+func launchPrismDirect(prismExe, prismDir, jreDir, instanceName, packName string, envVars map[string]string, prismProcess **os.Process, onStart func(*os.Process)) error {
 	logf("%s", stepLine("Attempting direct Prism launch"))
 
 	// Launch the instance directly (this should not show the Prism GUI)
-	launch := exec.Command(prismExe, "--dir", ".", "--launch", instanceName)
+	launch := exec.Command(prismExe, prismLaunchArgs(prismDir, instanceName)...)
 	launch.Dir = prismDir
 
 	// Build Qt environment variables
-	qtEnv := buildQtEnvironment(prismDir, jreDir)
+	qtEnv := buildQtEnvironment(prismDir, jreDir, envVars)
 	launch.Env = append(os.Environ(), qtEnv...)
 
 	// Capture both stdout and stderr for better error reporting
@@ -849,12 +1321,15 @@ func launchPrismDirect(prismExe, prismDir, jreDir, instanceName, packName string
 		issues := analyzePrismError(stderrStr, stdoutStr)
 		provideErrorContext(issues)
 
-		return fmt.Errorf("direct launch failed: %w", err)
+		return &prismLaunchError{err: fmt.Errorf("direct launch failed: %w", err), issues: issues}
 	}
 
 	// Store the process reference for signal handling
 	*prismProcess = launch.Process
 	logf("%s", successLine(fmt.Sprintf("%s launched (PID: %d)", packName, launch.Process.Pid)))
+	if onStart != nil {
+		onStart(launch.Process)
+	}
 
 	// Wait for the game process to complete
 	err := launch.Wait()
@@ -873,20 +1348,20 @@ func launchPrismDirect(prismExe, prismDir, jreDir, instanceName, packName string
 		// Provide user-friendly error context and solutions
 		provideErrorContext(issues)
 
-		return fmt.Errorf("Prism process exited with error: %w", err)
+		return &prismLaunchError{err: fmt.Errorf("Prism process exited with error: %w", err), issues: issues}
 	}
 
 	return nil
 }
 
 // launchPrismGUIFallback launches Prism GUI as a fallback
-func launchPrismGUIFallback(prismExe, prismDir, jreDir, packName string, prismProcess **os.Process) error {
+func launchPrismGUIFallback(prismExe, prismDir, jreDir, packName string, envVars map[string]string, prismProcess **os.Process, onStart func(*os.Process)) error {
 	logf("%s", stepLine("Opening Prism Launcher UI instead"))
 	launchFallback := exec.Command(prismExe, "--dir", ".")
 	launchFallback.Dir = prismDir
 
 	// Use the same Qt environment setup for fallback
-	qtEnv := buildQtEnvironment(prismDir, jreDir)
+	qtEnv := buildQtEnvironment(prismDir, jreDir, envVars)
 	launchFallback.Env = append(os.Environ(), qtEnv...)
 
 	// Capture fallback output as well
@@ -905,11 +1380,14 @@ func launchPrismGUIFallback(prismExe, prismDir, jreDir, packName string, prismPr
 		issues := analyzePrismError(stderrStr, stdoutStr)
 		provideErrorContext(issues)
 
-		return fmt.Errorf("GUI fallback failed: %w", err)
+		return &prismLaunchError{err: fmt.Errorf("GUI fallback failed: %w", err), issues: issues}
 	}
 
 	*prismProcess = launchFallback.Process
 	logf("%s", successLine(fmt.Sprintf("Prism Launcher UI launched for %s (PID: %d)", packName, launchFallback.Process.Pid)))
+	if onStart != nil {
+		onStart(launchFallback.Process)
+	}
 
 	// Wait for the GUI process to complete
 	err := launchFallback.Wait()
@@ -917,47 +1395,698 @@ func launchPrismGUIFallback(prismExe, prismDir, jreDir, packName string, prismPr
 	// Log fallback completion output for debugging
 
 	// Analyze output even if process completed successfully
+	var issues []string
 	if err != nil || fallbackStderr.Len() > 0 {
 		stderrStr := fallbackStderr.String()
 		stdoutStr := fallbackStdout.String()
-		issues := analyzePrismError(stderrStr, stdoutStr)
+		issues = analyzePrismError(stderrStr, stdoutStr)
 		if len(issues) > 0 {
 			provideErrorContext(issues)
 		}
 	}
 
-	return err
+	if err != nil {
+		return &prismLaunchError{err: err, issues: issues}
+	}
+	return nil
+}
+
+// openInstanceInPrism starts Prism's own window on the modpack's instance so its
+// settings can be changed by hand. Prism is left running on its own.
+func openInstanceInPrism(root string, modpack Modpack) error {
+	prismDir := filepath.Join(root, "prism")
+	prismExe := resolvePrismExecutable(prismDir)
+	if !exists(prismExe) {
+		return fmt.Errorf("Prism Launcher is not installed at %s", prismExe)
+	}
+
+	minecraft := ""
+	if info, err := readInstancePackInfo(filepath.Join(instancesDirFor(root), modpack.InstanceName)); err == nil {
+		minecraft = info.Minecraft
+	}
+	jreDir := filepath.Join(prismDir, "java", "jre"+getJavaVersionForMinecraft(minecraft))
+
+	cmd := exec.Command(prismExe, prismShowArgs(modpack.InstanceName)...)
+	cmd.Dir = prismDir
+	cmd.Env = append(os.Environ(), buildQtEnvironment(prismDir, jreDir, modpack.EnvVars)...)
+	cmd.Stdout = out
+	cmd.Stderr = out
+	if err := cmd.Start(); err != nil {
+		return fmt.Errorf("failed to open Prism Launcher: %w", err)
+	}
+	logf("%s", successLine(fmt.Sprintf("Opened %s in Prism Launcher (PID: %d)", modpackLabel(modpack), cmd.Process.Pid)))
+
+	// Reap the process once the user closes Prism
+	done := trackDetachedPrism(cmd.Process.Pid)
+	go func() {
+		_ = cmd.Wait()
+		done()
+	}()
+	return nil
+}
+
+// detachedPrism holds a channel for each Prism window openInstanceInPrism left running,
+// closed once that window exits. A launch started meanwhile is handed to that window,
+// whose own Prism process exits straight away while the game runs on.
+var (
+	detachedPrismMu sync.Mutex
+	detachedPrism   = make(map[int]chan struct{})
+)
+
+// trackDetachedPrism records a running Prism window and returns the func to call once it exits
+func trackDetachedPrism(pid int) func() {
+	exited := make(chan struct{})
+	detachedPrismMu.Lock()
+	detachedPrism[pid] = exited
+	detachedPrismMu.Unlock()
+	return func() {
+		detachedPrismMu.Lock()
+		delete(detachedPrism, pid)
+		detachedPrismMu.Unlock()
+		close(exited)
+	}
+}
+
+// waitForDetachedPrism blocks until every Prism window openInstanceInPrism started has exited
+func waitForDetachedPrism() {
+	detachedPrismMu.Lock()
+	pending := make([]chan struct{}, 0, len(detachedPrism))
+	for _, exited := range detachedPrism {
+		pending = append(pending, exited)
+	}
+	detachedPrismMu.Unlock()
+	if len(pending) > 0 {
+		logf("%s", infoLine("Waiting for the open Prism Launcher window to close before restoring JVM arguments"))
+	}
+	for _, exited := range pending {
+		<-exited
+	}
+}
+
+// quarantineAttribute is the extended attribute macOS Gatekeeper puts on downloads
+const quarantineAttribute = "com.apple.quarantine"
+
+// launchQuarantineBundle returns the path whose quarantine is cleared for prismExe:
+// its app bundle, or prismExe itself outside of one. Only a Prism in prismDir or the
+// bundle ensurePrism installed into /Applications qualifies; "" is returned for a
+// Prism the user installed themselves, which is left as it is.
+func launchQuarantineBundle(prismDir, prismExe string) string {
+	bundle := prismExe
+	for dir := prismExe; dir != filepath.Dir(dir); dir = filepath.Dir(dir) {
+		if strings.EqualFold(filepath.Ext(dir), ".app") {
+			bundle = dir
+			break
+		}
+	}
+	switch installed := installedPrismApp(prismDir); {
+	case isSubPath(prismDir, bundle):
+		return bundle
+	case isSubPath(prismDir, prismExe):
+		return prismExe
+	case installed != "" && filepath.Clean(installed) == bundle:
+		return bundle
+	}
+	return ""
+}
+
+// clearLaunchQuarantine removes the macOS quarantine from the Prism app bundle that
+// prismExe belongs to and from the Java runtime in jreDir
+func clearLaunchQuarantine(prismDir, prismExe, jreDir string) {
+	var paths []string
+	if bundle := launchQuarantineBundle(prismDir, prismExe); bundle != "" {
+		paths = append(paths, bundle)
+	}
+	if jreDir != "" && exists(jreDir) {
+		paths = append(paths, jreDir)
+	}
+
+	for _, path := range paths {
+		cleared, err := clearQuarantine(path)
+		switch {
+		case err != nil:
+			logf("%s", warnLine(fmt.Sprintf("Failed to remove the macOS quarantine from %s: %v", path, err)))
+		case cleared:
+			logf("%s", successLine(fmt.Sprintf("Removed the macOS quarantine from %s", path)))
+		default:
+			debugf("%s is not quarantined", path)
+		}
+	}
 }
 
 // -------------------- Launcher Logic --------------------
 
-func runLauncherLogic(root, exePath string, modpack Modpack, prismProcess **os.Process, progressCb func(stage string, step, total int)) {
+// prismSetupMu serializes installing and repairing Prism, so a background prefetch,
+// a user-started install and a Qt repair never extract into the Prism folder at once
+var prismSetupMu sync.Mutex
+
+// jreSetupMu is held for reading while a JRE is installed and for writing while unused
+// ones are removed. Installs of different Java versions still run side by side, and
+// jreDirLock keeps two installs of the same one apart.
+var jreSetupMu sync.RWMutex
+
+var (
+	jreDirLocksMu sync.Mutex
+	jreDirLocks   = make(map[string]*sync.Mutex)
+)
+
+// jreDirLock returns the lock for installing the JRE in jreDir
+func jreDirLock(jreDir string) *sync.Mutex {
+	jreDirLocksMu.Lock()
+	defer jreDirLocksMu.Unlock()
+	key := filepath.Clean(jreDir)
+	mu, ok := jreDirLocks[key]
+	if !ok {
+		mu = &sync.Mutex{}
+		jreDirLocks[key] = mu
+	}
+	return mu
+}
+
+// ensureJRE installs the Temurin JRE for a Java major version into jreDir if it is
+// missing and reports whether it had to be downloaded. A download that fails or is
+// cancelled part way removes jreDir so the next attempt starts clean, and an
+// installed runtime whose files no longer match its checksums is downloaded again.
+func ensureJRE(ctx context.Context, jreDir, javaVersion string) (bool, error) {
+	jreSetupMu.RLock()
+	defer jreSetupMu.RUnlock()
+	dirMu := jreDirLock(jreDir)
+	dirMu.Lock()
+	defer dirMu.Unlock()
+
+	javaBin := filepath.Join(jreDir, "bin", JavaBinName)
+	javawBin := filepath.Join(jreDir, "bin", JavawBinName)
+	if exists(javaBin) && exists(javawBin) {
+		// Runtimes installed before checksums were recorded have no manifest and are trusted
+		err := verifyChecksumManifest(jreDir)
+		if err == nil || errors.Is(err, os.ErrNotExist) {
+			return false, nil
+		}
+		logf("%s", warnLine(fmt.Sprintf("Java %s looks damaged (%v); downloading it again", javaVersion, err)))
+		if err := os.RemoveAll(jreDir); err != nil {
+			return false, fmt.Errorf("failed to remove damaged Java %s: %w", javaVersion, err)
+		}
+	}
+
+	logf("%s", stepLine(fmt.Sprintf("Installing Temurin JRE %s", javaVersion)))
+	jreURL, jreSHA256, err := fetchJREURL(ctx, javaVersion)
+	if err != nil {
+		return false, fmt.Errorf("failed to resolve Java %s download: %w", javaVersion, err)
+	}
+	if jreSHA256 == "" {
+		logf("%s", warnLine(fmt.Sprintf("No checksum published for Java %s; the download can't be verified", javaVersion)))
+	}
+	if err := downloadAndUnzipTo(withDownloadName(ctx, "Java "+javaVersion), jreURL, jreSHA256, jreDir); err != nil {
+		_ = os.RemoveAll(jreDir)
+		return false, err
+	}
+	_ = flattenJREExtraction(jreDir)
+	if !exists(javaBin) || !exists(javawBin) {
+		return false, fmt.Errorf("Java %s installation looks incomplete (bin/%s or bin/%s not found)", javaVersion, JavaBinName, JavawBinName)
+	}
+	if err := writeChecksumManifest(jreDir); err != nil {
+		logf("%s", warnLine(fmt.Sprintf("Failed to record Java %s checksums: %v", javaVersion, err)))
+	}
+	return true, nil
+}
+
+// prefetchedJava is the Java major prefetchRuntime downloaded for a modpack that isn't
+// installed yet. No instance refers to it, so cleanup has to be told to keep it.
+var (
+	prefetchedJavaMu sync.Mutex
+	prefetchedJava   string
+)
+
+// setPrefetchedJava records the Java major prefetchRuntime is downloading
+func setPrefetchedJava(version string) {
+	prefetchedJavaMu.Lock()
+	prefetchedJava = version
+	prefetchedJavaMu.Unlock()
+}
+
+// prefetchedJavaVersion returns the Java major prefetchRuntime downloaded, or ""
+func prefetchedJavaVersion() string {
+	prefetchedJavaMu.Lock()
+	defer prefetchedJavaMu.Unlock()
+	return prefetchedJava
+}
+
+// prefetchRuntime downloads Prism and the JRE a modpack needs ahead of its first
+// install, so pressing Install only has to fetch the pack itself
+func prefetchRuntime(root string, modpack Modpack) error {
+	packInfo, err := fetchPackInfo(modpack.PackURL, modpack.Headers)
+	if err != nil {
+		return fmt.Errorf("failed to read modpack configuration: %w", err)
+	}
+
+	prismDir := filepath.Join(root, "prism")
+	prismSetupMu.Lock()
+	prismDownloaded, err := ensurePrism(context.Background(), prismDir)
+	prismSetupMu.Unlock()
+	if err != nil {
+		return fmt.Errorf("failed to prefetch Prism Launcher: %w", err)
+	}
+	if prismDownloaded {
+		logf("%s", successLine("Prefetched Prism Launcher"))
+	}
+
+	javaVersion := getJavaVersionForMinecraft(packInfo.Minecraft)
+	jreDir := filepath.Join(prismDir, "java", "jre"+javaVersion)
+	// Recorded before the download so a cleanup can't slip in between the two
+	setPrefetchedJava(javaVersion)
+	installed, err := ensureJRE(context.Background(), jreDir, javaVersion)
+	if err != nil {
+		return fmt.Errorf("failed to prefetch Java %s: %w", javaVersion, err)
+	}
+	if installed {
+		logf("%s", successLine(fmt.Sprintf("Prefetched Java %s for %s", javaVersion, modpackLabel(modpack))))
+	}
+	return nil
+}
+
+// prerequisiteTask is one independent download runLauncherLogic needs before syncing
+type prerequisiteTask struct {
+	Stage InstallStage
+	Run   func() error
+}
+
+// prerequisiteFailure is one task in a runPrerequisites batch that returned an error
+type prerequisiteFailure struct {
+	Stage InstallStage
+	Err   error
+}
+
+// prerequisiteError reports which tasks of a batch failed. Pending holds the failed
+// tasks plus any a sequential run never started, so a retry can run just those.
+type prerequisiteError struct {
+	Failed  []prerequisiteFailure
+	Pending []prerequisiteTask
+}
+
+func (e *prerequisiteError) Error() string {
+	if len(e.Failed) == 1 {
+		return e.Failed[0].Err.Error()
+	}
+	parts := make([]string, len(e.Failed))
+	for i, f := range e.Failed {
+		parts[i] = fmt.Sprintf("%s: %v", f.Stage, f.Err)
+	}
+	return fmt.Sprintf("%d downloads failed: %s", len(e.Failed), strings.Join(parts, "; "))
+}
+
+// retryablePrerequisites reports whether failed downloads in err are worth offering to
+// retry. They aren't once ctx is cancelled, or when a download failed by being cancelled.
+func retryablePrerequisites(ctx context.Context, err error) bool {
+	return err != nil && ctx.Err() == nil && !errors.Is(err, context.Canceled)
+}
+
+func (e *prerequisiteError) Unwrap() []error {
+	errs := make([]error, len(e.Failed))
+	for i, f := range e.Failed {
+		errs[i] = f.Err
+	}
+	return errs
+}
+
+// runPrerequisites runs tasks with at most limit running at once, reporting each
+// stage as it starts. Once every task has finished it returns a *prerequisiteError
+// listing the ones that failed. A limit of 1 runs them one after another in order
+// and stops at the first failure.
+func runPrerequisites(tasks []prerequisiteTask, limit int, report func(stage InstallStage)) error {
+	if limit <= 1 {
+		for i, task := range tasks {
+			report(task.Stage)
+			if err := task.Run(); err != nil {
+				return &prerequisiteError{
+					Failed:  []prerequisiteFailure{{Stage: task.Stage, Err: err}},
+					Pending: append([]prerequisiteTask(nil), tasks[i:]...),
+				}
+			}
+		}
+		return nil
+	}
+
+	var (
+		wg sync.WaitGroup
+		mu sync.Mutex
+	)
+	errs := make([]error, len(tasks))
+	sem := make(chan struct{}, limit)
+	for i, task := range tasks {
+		wg.Add(1)
+		go func(i int, task prerequisiteTask) {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+
+			mu.Lock()
+			report(task.Stage)
+			mu.Unlock()
+
+			errs[i] = task.Run()
+		}(i, task)
+	}
+	wg.Wait()
+
+	var failed *prerequisiteError
+	for i, err := range errs {
+		if err == nil {
+			continue
+		}
+		if failed == nil {
+			failed = &prerequisiteError{}
+		}
+		failed.Failed = append(failed.Failed, prerequisiteFailure{Stage: tasks[i].Stage, Err: err})
+		failed.Pending = append(failed.Pending, tasks[i])
+	}
+	if failed == nil {
+		return nil
+	}
+	return failed
+}
+
+// stepTiming is how long one stage of runLauncherLogic took
+type stepTiming struct {
+	Stage    string
+	Duration time.Duration
+}
+
+// stepTimer collects stage timings. Sequential stages run from one begin to the next;
+// prerequisites that download side by side record their own durations.
+type stepTimer struct {
+	mu      sync.Mutex
+	steps   []stepTiming
+	current string
+	started time.Time
+}
+
+// begin ends the running stage, if any, and starts timing stage
+func (t *stepTimer) begin(stage string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.endLocked()
+	t.current = stage
+	t.started = time.Now()
+}
+
+// end stops timing the running stage
+func (t *stepTimer) end() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.endLocked()
+}
+
+func (t *stepTimer) endLocked() {
+	if t.current != "" {
+		t.steps = append(t.steps, stepTiming{Stage: t.current, Duration: time.Since(t.started)})
+		t.current = ""
+	}
+}
+
+// record adds a stage that was timed separately
+func (t *stepTimer) record(stage string, d time.Duration) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.steps = append(t.steps, stepTiming{Stage: stage, Duration: d})
+}
+
+// timed wraps each task so its duration is recorded under its stage
+func (t *stepTimer) timed(tasks []prerequisiteTask) []prerequisiteTask {
+	wrapped := make([]prerequisiteTask, len(tasks))
+	for i, task := range tasks {
+		wrapped[i] = prerequisiteTask{Stage: task.Stage, Run: func() error {
+			start := time.Now()
+			defer func() { t.record(task.Stage.String(), time.Since(start)) }()
+			return task.Run()
+		}}
+	}
+	return wrapped
+}
+
+// timings ends the running stage and returns every stage timed so far
+func (t *stepTimer) timings() []stepTiming {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.endLocked()
+	return append([]stepTiming(nil), t.steps...)
+}
+
+// formatStepTimings renders timings like "Ensuring Java runtime: 14.3s, ..." followed
+// by the slowest stage
+func formatStepTimings(steps []stepTiming) string {
+	if len(steps) == 0 {
+		return ""
+	}
+	parts := make([]string, 0, len(steps))
+	slowest := steps[0]
+	for _, step := range steps {
+		parts = append(parts, fmt.Sprintf("%s: %.1fs", step.Stage, step.Duration.Seconds()))
+		if step.Duration > slowest.Duration {
+			slowest = step
+		}
+	}
+	return fmt.Sprintf("%s (slowest: %s)", strings.Join(parts, ", "), slowest.Stage)
+}
+
+// InstallStage is one step of installing and launching a modpack. Progress callbacks
+// receive these rather than log text, so labels can be translated and tests can tell
+// which stage a run stopped at.
+type InstallStage int
+
+const (
+	StageNone InstallStage = iota
+	StageReadingConfig
+	StageEnsuringPrism
+	StageEnsuringJava
+	StageEnsuringBootstrap
+	StagePreparingInstance
+	StageCheckingUpdates
+	StageSyncingFiles
+	StageLaunching
+	// StageCheckingFiles replaces every stage before launching in offline mode
+	StageCheckingFiles
+)
+
+// installStageDescriptions are the English stage names used in logs and step timings
+var installStageDescriptions = map[InstallStage]string{
+	StageReadingConfig:     "Reading modpack configuration",
+	StageEnsuringPrism:     "Ensuring Prism Launcher",
+	StageEnsuringJava:      "Ensuring Java runtime",
+	StageEnsuringBootstrap: "Ensuring packwiz bootstrap",
+	StagePreparingInstance: "Preparing modpack instance",
+	StageCheckingUpdates:   "Checking modpack updates",
+	StageSyncingFiles:      "Synchronizing modpack files",
+	StageLaunching:         "Launching via Prism",
+	StageCheckingFiles:     "Checking installed files",
+}
+
+// installStageKeys are the locale keys of the stage labels shown in the GUI
+var installStageKeys = map[InstallStage]string{
+	StageReadingConfig:     "stage.readingConfig",
+	StageEnsuringPrism:     "stage.ensuringPrism",
+	StageEnsuringJava:      "stage.ensuringJava",
+	StageEnsuringBootstrap: "stage.ensuringBootstrap",
+	StagePreparingInstance: "stage.preparingInstance",
+	StageCheckingUpdates:   "stage.checkingUpdates",
+	StageSyncingFiles:      "stage.syncingFiles",
+	StageLaunching:         "stage.launching",
+	StageCheckingFiles:     "stage.checkingFiles",
+}
+
+func (s InstallStage) String() string {
+	if desc, ok := installStageDescriptions[s]; ok {
+		return desc
+	}
+	return fmt.Sprintf("stage %d", int(s))
+}
+
+// Label returns the stage name in the current UI language
+func (s InstallStage) Label() string {
+	if key, ok := installStageKeys[s]; ok {
+		return T(key)
+	}
+	return s.String()
+}
+
+// launchOutcome says how far runLauncherLogic got
+type launchOutcome int
+
+const (
+	// outcomeFailed means preparing, installing or updating the pack failed
+	outcomeFailed launchOutcome = iota
+	// outcomeLaunchFailed means the pack is ready but Prism could not be started
+	outcomeLaunchFailed
+	// outcomeGameClosed means the pack was launched and Prism has exited
+	outcomeGameClosed
+	// outcomeInstalled means the pack is synced and SkipLaunch stopped before launching
+	outcomeInstalled
+)
+
+// launchResult reports what runLauncherLogic did, alongside the error that stopped it
+type launchResult struct {
+	Outcome launchOutcome
+	// Updated is true when pack files were installed or updated during this run
+	Updated bool
+	// Version is the pack version installed once syncing succeeded
+	Version string
+	// Issues are the problems identified in Prism's output when the launch failed
+	Issues []string
+	// Timings are how long each stage took, up to Prism starting
+	Timings []stepTiming
+	// Stage is the last stage started, which is the one that failed when err is set
+	Stage InstallStage
+	// Repaired counts the pack files, plus Prism and Java, that Verify downloaded again
+	Repaired int
+	// UpdatedFiles counts the pack files Verify changed because the pack itself had
+	// changed since it was installed, which are not repairs
+	UpdatedFiles int
+	// UpdatePending is the newer pack version Verify found and left for Update to apply
+	UpdatePending string
+	// CrashReport is the crash report Minecraft wrote during this launch; empty after a clean quit
+	CrashReport string
+	// FallbackErr is why the direct launch failed when Prism's window was opened instead
+	FallbackErr error
+}
+
+// launchOptions holds per-run overrides for runLauncherLogic
+type launchOptions struct {
+	// ForceResync bypasses any cached pack.toml for this run only
+	ForceResync bool
+	// Output, if set, also receives packwiz output as it is produced
+	Output io.Writer
+	// ManualDownloads, if set, shows mods that must be downloaded by hand and blocks
+	// until the user has saved them (true) or chose to skip them (false)
+	ManualDownloads func(items []manualItem) bool
+	// JvmArgs, if set, replaces the instance's JVM arguments for this launch only
+	JvmArgs *string
+	// SkipLaunch stops once the pack is installed and synced instead of starting Prism
+	SkipLaunch bool
+	// RetryDownloads, if set, is shown the prerequisite downloads that failed and
+	// blocks until the user chooses to retry just those (true) or give up (false)
+	RetryDownloads func(failed []prerequisiteFailure) bool
+	// Verify checks an installed pack instead of launching it: Prism and Java are
+	// checked against their recorded checksums and packwiz re-hashes every file
+	// rather than trusting its cache, so only damaged or missing files are downloaded
+	Verify bool
+	// DownloadProgress, if set, receives the speed and time left of Prism, Java and
+	// bootstrap downloads as they run
+	DownloadProgress func(downloadProgress)
+	// PackSize, if known, is an earlier estimate of the pack's download size used
+	// when checking for free disk space, instead of sizing the pack index again
+	PackSize packSizeEstimate
+	// LowDiskSpace, if set, is shown a volume that looks too full when part of the
+	// pack couldn't be sized and blocks until the user chooses to install anyway
+	// (true) or cancel (false). Without it the install goes ahead with a warning.
+	LowDiskSpace func(short diskSpaceShortage) bool
+	// OnLaunch, if set, is called with each Prism process as soon as it starts, while
+	// runLauncherLogic goes on to wait for the game to close
+	OnLaunch func(proc *os.Process)
+}
+
+// installModpack installs or updates a modpack without launching it or needing the
+// GUI. progressCb may be nil; log output goes wherever logf is writing.
+func installModpack(ctx context.Context, root string, modpack Modpack, opts launchOptions, progressCb func(stage InstallStage, step, total int)) (launchResult, error) {
+	opts.SkipLaunch = true
+	return runLauncherLogicSafe(ctx, root, "", modpack, new(*os.Process), opts, progressCb)
+}
+
+// cancelPackwizOnDone makes cancelling cmd's context kill the whole packwiz process
+// tree, since the bootstrap hands the real work to a child Java process
+func cancelPackwizOnDone(cmd *exec.Cmd) {
+	cmd.Cancel = func() error { return killProcessByPID(cmd.Process.Pid) }
+	cmd.WaitDelay = 5 * time.Second
+}
+
+// runLauncherLogicSafe runs runLauncherLogic and turns a panic into an error, so a
+// bug hit by one pack's operation doesn't take down the whole launcher
+func runLauncherLogicSafe(ctx context.Context, root, exePath string, modpack Modpack, prismProcess **os.Process, opts launchOptions, progressCb func(stage InstallStage, step, total int)) (result launchResult, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			logf("%s\n%s", warnLine(fmt.Sprintf("Recovered from a panic while working on %s: %v", modpack.ID, r)), debug.Stack())
+			err = fmt.Errorf("unexpected error while working on %s: %v", modpackLabel(modpack), r)
+		}
+	}()
+	return runLauncherLogic(ctx, root, exePath, modpack, prismProcess, opts, progressCb)
+}
+
+// runLauncherLogic prepares, syncs and launches a modpack. Errors are returned
+// rather than exiting so one pack's failure leaves the launcher usable. Cancelling
+// ctx stops the downloads and packwiz; the returned error then wraps ctx.Err().
+func runLauncherLogic(ctx context.Context, root, exePath string, modpack Modpack, prismProcess **os.Process, opts launchOptions, progressCb func(stage InstallStage, step, total int)) (launchResult, error) {
 	packName := modpackLabel(modpack)
+	var result launchResult
 	// Note: Update check already happened at startup in main()
 
 	totalSteps := 8
 	currentStep := 0
-	report := func(stage string) {
+	progress := func(stage InstallStage) {
 		currentStep++
+		result.Stage = stage
 		if progressCb != nil {
 			progressCb(stage, currentStep, totalSteps)
 		}
 	}
+	timer := &stepTimer{}
+	report := func(stage InstallStage) {
+		timer.begin(stage.String())
+		jsonLog.SetStage(stage.String())
+		progress(stage)
+	}
+	defer jsonLog.SetStage("")
+	logTimings := func() {
+		result.Timings = timer.timings()
+		logf("%s", infoLine("Step timings: "+formatStepTimings(result.Timings)))
+	}
+
+	if opts.Verify {
+		opts.SkipLaunch = true
+	}
+	if opts.DownloadProgress != nil {
+		ctx = withDownloadReporter(ctx, opts.DownloadProgress)
+	}
+
+	// Offline mode launches what is already on disk without touching the network
+	if offlineMode() {
+		if opts.SkipLaunch {
+			return result, fmt.Errorf("cannot install or update %s in offline mode", packName)
+		}
+		totalSteps = 2
+		report(StageCheckingFiles)
+		logf("%s", infoLine(fmt.Sprintf("Offline mode: launching the installed copy of %s without checking for updates", packName)))
+		inst, err := offlineInstance(root, modpack)
+		if err != nil {
+			return result, err
+		}
+		result.Version, _ = getLocalPackVersion(modpack, inst.InstDir)
+		err = launchPreparedInstance(root, modpack, inst, prismProcess, opts, &result, report, logTimings)
+		return result, err
+	}
+
+	if opts.Verify && !exists(filepath.Join(instancesDirFor(root), modpack.InstanceName, "instance.cfg")) {
+		return result, fmt.Errorf("%s is not installed, so there is nothing to verify", packName)
+	}
 
-	report("Reading modpack configuration")
+	report(StageReadingConfig)
 
 	// 0) Read pack.toml to get correct Minecraft and modloader versions
 	logf("%s", stepLine("Reading modpack configuration"))
-	packInfo, err := fetchPackInfo(modpack.PackURL)
+	packInfo, err := fetchPackInfo(modpack.PackURL, modpack.Headers)
 	if err != nil {
-		fail(fmt.Errorf("failed to read modpack configuration: %w", err))
+		return result, fmt.Errorf("failed to read modpack configuration: %w", err)
 	}
 	logf("%s", successLine(fmt.Sprintf("Detected: Minecraft %s with %s %s", packInfo.Minecraft, packInfo.ModLoader, packInfo.LoaderVersion)))
 
-	// Initialize process registry
-	processRegistry, err := GetGlobalProcessRegistry(root)
-	if err != nil {
-		logf("Warning: Failed to initialize process registry: %v", err)
+	// Verify repairs the version that is installed. The pack host only serves the
+	// latest one, so with an update pending a sync would apply it; that is left to Update.
+	if opts.Verify {
+		installed, err := getLocalPackVersion(modpack, filepath.Join(instancesDirFor(root), modpack.InstanceName))
+		if err == nil && installed != "" && packInfo.Version != "" && !sameVersion(installed, packInfo.Version) {
+			logf("%s", infoLine(fmt.Sprintf("%s %s has an update to %s pending; not verifying against the new version's files", packName, installed, packInfo.Version)))
+			result.Version = installed
+			result.UpdatePending = packInfo.Version
+			result.Outcome = outcomeInstalled
+			logTimings()
+			return result, nil
+		}
 	}
 
 	// 1) Ensure prerequisites — organize directories cleanly
@@ -975,18 +2104,29 @@ func runLauncherLogic(root, exePath string, modpack Modpack, prismProcess **os.P
 
 	// Create util directory for miscellaneous files
 	if err := os.MkdirAll(utilDir, 0755); err != nil {
-		fail(fmt.Errorf("failed to create util directory: %w", err))
+		return result, fmt.Errorf("failed to create util directory: %w", err)
 	}
 
 	// Create Prism Java directory for managed Java runtimes
 	if err := os.MkdirAll(prismJavaDir, 0755); err != nil {
-		fail(fmt.Errorf("failed to create Prism Java directory: %w", err))
+		return result, fmt.Errorf("failed to create Prism Java directory: %w", err)
 	}
 
-	logf("%s", sectionLine("Preparing Environment"))
+	// Check there's room for everything before the first download, so a full disk
+	// doesn't leave a half-installed instance behind
+	if !opts.Verify {
+		for _, short := range checkDiskSpace(installSpaceNeeds(root, modpack, jreDir, opts.PackSize), freeDiskSpace) {
+			if !short.Uncertain {
+				return result, short
+			}
+			logf("%s", warnLine(fmt.Sprintf("%v; part of %s couldn't be sized, so this is only an estimate", short, packName)))
+			if opts.LowDiskSpace != nil && !opts.LowDiskSpace(short) {
+				return result, fmt.Errorf("install of %s cancelled: %w", packName, short)
+			}
+		}
+	}
 
-	report("Ensuring Prism Launcher")
-	logf("%s", stepLine("Ensuring Prism Launcher portable build"))
+	logf("%s", sectionLine("Preparing Environment"))
 
 	// Check and install Qt dependencies if needed (Linux only)
 	if runtime.GOOS == "linux" {
@@ -998,76 +2138,144 @@ func runLauncherLogic(root, exePath string, modpack Modpack, prismProcess **os.P
 		}
 	}
 
-	prismDownloaded, err := ensurePrism(prismDir)
-	if err != nil {
-		fail(err)
-	}
-	if prismDownloaded {
-		logf("%s", successLine("Prism Launcher downloaded"))
-	} else {
-		logf("%s", successLine("Prism Launcher ready"))
-	}
-
-	report("Ensuring Java runtime")
-	if !exists(javaBin) || !exists(javawBin) {
-		logf("%s", stepLine(fmt.Sprintf("Installing Temurin JRE %s", requiredJavaVersion)))
-		jreURL, err := fetchJREURL(requiredJavaVersion)
-		if err != nil {
-			fail(fmt.Errorf("failed to resolve Java %s download: %w", requiredJavaVersion, err))
-		}
-		if err := downloadAndUnzipTo(jreURL, jreDir); err != nil {
-			fail(err)
-		}
-		_ = flattenJREExtraction(jreDir)
-		if !exists(javaBin) || !exists(javawBin) {
-			fail(fmt.Errorf("Java %s installation looks incomplete (bin/%s or bin/%s not found)", requiredJavaVersion, JavaBinName, JavawBinName))
-		}
-		logf("%s", successLine(fmt.Sprintf("Java %s installed", requiredJavaVersion)))
-	} else {
-		logf("%s", successLine(fmt.Sprintf("Java %s already installed", requiredJavaVersion)))
+	// Prism, Java and the packwiz bootstrap don't depend on each other, so they
+	// download side by side up to the configured concurrency and are timed one by one
+	var runtimesRepaired int32
+	timer.end()
+	prereqs := timer.timed([]prerequisiteTask{
+		{Stage: StageEnsuringPrism, Run: func() error {
+			logf("%s", stepLine("Ensuring Prism Launcher portable build"))
+			prismSetupMu.Lock()
+			prismDownloaded, err := ensurePrism(ctx, prismDir)
+			prismSetupMu.Unlock()
+			if err != nil {
+				return err
+			}
+			if prismDownloaded {
+				atomic.AddInt32(&runtimesRepaired, 1)
+				logf("%s", successLine("Prism Launcher downloaded"))
+			} else {
+				logf("%s", successLine("Prism Launcher ready"))
+			}
+			return nil
+		}},
+		{Stage: StageEnsuringJava, Run: func() error {
+			installed, err := ensureJRE(ctx, jreDir, requiredJavaVersion)
+			if err != nil {
+				return err
+			}
+			if installed {
+				atomic.AddInt32(&runtimesRepaired, 1)
+				logf("%s", successLine(fmt.Sprintf("Java %s installed", requiredJavaVersion)))
+			} else {
+				logf("%s", successLine(fmt.Sprintf("Java %s already installed", requiredJavaVersion)))
+			}
+			return nil
+		}},
+		{Stage: StageEnsuringBootstrap, Run: func() error {
+			logf("%s", stepLine("Ensuring packwiz bootstrap"))
+			ready := false
+			for _, path := range []string{bootstrapExe, bootstrapJar} {
+				if !exists(path) {
+					continue
+				}
+				if err := verifyFileChecksum(path); err != nil {
+					logf("%s", warnLine(fmt.Sprintf("%s looks damaged (%v); downloading it again", filepath.Base(path), err)))
+					_ = os.Remove(path)
+					continue
+				}
+				ready = true
+			}
+			if ready {
+				logf("%s", successLine("Packwiz bootstrap already installed"))
+				return nil
+			}
+			pwURL, pwSHA256, err := fetchPackwizBootstrapURL(ctx)
+			if err != nil {
+				return fmt.Errorf("failed to resolve packwiz bootstrap: %w", err)
+			}
+			target := bootstrapExe
+			if strings.HasSuffix(strings.ToLower(pwURL), ".jar") {
+				target = bootstrapJar
+			}
+			if err := downloadVerifiedTo(withDownloadName(ctx, "packwiz bootstrap"), pwURL, pwSHA256, target, 0755); err != nil {
+				return err
+			}
+			if err := saveFileChecksum(target); err != nil {
+				logf("%s", warnLine(fmt.Sprintf("Failed to record packwiz bootstrap checksum: %v", err)))
+			}
+			logf("%s", successLine("Packwiz bootstrap installed"))
+			return nil
+		}},
+	})
+	err = runPrerequisites(prereqs, downloadConcurrency(), progress)
+	// Downloads that succeeded stay on disk, so a retry only runs the failed ones
+	var prereqErr *prerequisiteError
+	for retryablePrerequisites(ctx, err) && errors.As(err, &prereqErr) && opts.RetryDownloads != nil && opts.RetryDownloads(prereqErr.Failed) {
+		logf("%s", stepLine(fmt.Sprintf("Retrying %d failed download(s)", len(prereqErr.Pending))))
+		err = runPrerequisites(prereqErr.Pending, downloadConcurrency(), func(stage InstallStage) {
+			result.Stage = stage
+			if progressCb != nil {
+				progressCb(stage, currentStep, totalSteps)
+			}
+		})
 	}
-
-	report("Ensuring packwiz bootstrap")
-	logf("%s", stepLine("Ensuring packwiz bootstrap"))
-	if !exists(bootstrapExe) && !exists(bootstrapJar) {
-		pwURL, err := fetchPackwizBootstrapURL()
-		if err != nil {
-			fail(fmt.Errorf("failed to resolve packwiz bootstrap: %w", err))
-		}
-		target := bootstrapExe
-		if strings.HasSuffix(strings.ToLower(pwURL), ".jar") {
-			target = bootstrapJar
+	if err != nil {
+		// Parallel downloads leave result.Stage at the last one started, not the one that failed
+		if errors.As(err, &prereqErr) && len(prereqErr.Failed) > 0 {
+			result.Stage = prereqErr.Failed[0].Stage
 		}
-		if err := downloadTo(pwURL, target, 0755); err != nil {
-			fail(err)
+		if ctx.Err() != nil {
+			return result, fmt.Errorf("%s cancelled: %w", packName, ctx.Err())
 		}
-		logf("%s", successLine("Packwiz bootstrap installed"))
-	} else {
-		logf("%s", successLine("Packwiz bootstrap already installed"))
+		return result, err
 	}
 
 	// 3) Create proper MultiMC/Prism instance first
-	instDir := filepath.Join(prismDir, "instances", modpack.InstanceName)
+	instancesDir := instancesDirFor(root)
+	if err := updatePrismInstanceDir(prismConfigFile(prismDir), prismDir, instancesDir); err != nil {
+		logf("%s", warnLine(fmt.Sprintf("Failed to set Prism instances folder: %v", err)))
+	}
+	instDir := filepath.Join(instancesDir, modpack.InstanceName)
 	mcDir := filepath.Join(instDir, "minecraft") // Use minecraft, not .minecraft
+	// A cancelled first install leaves nothing behind; an existing instance is
+	// left in place and restored from its safety backup instead
+	if !exists(instDir) {
+		defer func() {
+			if ctx.Err() != nil && result.Outcome == outcomeFailed {
+				logf("%s", infoLine(fmt.Sprintf("Removing partially installed %s", packName)))
+				_ = os.RemoveAll(instDir)
+			}
+		}()
+	}
 	if err := os.MkdirAll(mcDir, 0755); err != nil {
-		fail(err)
+		return result, err
 	}
 
 	logf("%s", sectionLine("Instance Setup"))
 
-	report("Preparing modpack instance")
+	report(StagePreparingInstance)
 	instanceConfigFile := filepath.Join(instDir, "instance.cfg")
 	mmcPackFile := filepath.Join(instDir, "mmc-pack.json")
 
+	// Set when the pack moved to a new Minecraft version. The components are only
+	// switched once packwiz has synced the new mods, so a failed update restored from
+	// its backup still has the components its old mods were made for.
+	var migrateFrom string
 	needsInstanceCreation := !exists(instanceConfigFile) || !exists(mmcPackFile)
 	if needsInstanceCreation {
 		logf("%s", stepLine(fmt.Sprintf("Creating Prism instance structure with %s %s", packInfo.ModLoader, packInfo.LoaderVersion)))
 		if err := createMultiMCInstance(modpack, packInfo, instDir, javawBin); err != nil {
-			fail(fmt.Errorf("failed to create MultiMC instance: %w", err))
+			return result, fmt.Errorf("failed to create MultiMC instance: %w", err)
 		}
 		logf("%s", successLine("Instance structure ready"))
 	} else {
 		logf("%s", successLine("Instance structure already present"))
+		if current, err := readInstancePackInfo(instDir); err != nil {
+			logf("%s", warnLine(fmt.Sprintf("Failed to read instance components: %v", err)))
+		} else if current.Minecraft != "" && current.Minecraft != packInfo.Minecraft {
+			migrateFrom = current.Minecraft
+		}
 	}
 
 	// Check if the modloader is already installed
@@ -1082,8 +2290,8 @@ func runLauncherLogic(root, exePath string, modpack Modpack, prismProcess **os.P
 
 	if !modloaderInstalled {
 		logf("%s", stepLine(fmt.Sprintf("Installing %s %s", packInfo.ModLoader, packInfo.LoaderVersion)))
-		if err := installModLoaderForInstance(instDir, javaBin, packInfo); err != nil {
-			fail(fmt.Errorf("failed to install %s: %w", packInfo.ModLoader, err))
+		if err := installModLoaderForInstance(ctx, instDir, javaBin, packInfo); err != nil {
+			return result, fmt.Errorf("failed to install %s: %w", packInfo.ModLoader, err)
 		}
 		logf("%s", successLine(fmt.Sprintf("%s ready", strings.Title(packInfo.ModLoader))))
 	} else {
@@ -1099,7 +2307,7 @@ func runLauncherLogic(root, exePath string, modpack Modpack, prismProcess **os.P
 		updateAvailable = true
 	}
 
-	report("Checking modpack updates")
+	report(StageCheckingUpdates)
 	var action string
 	var backupPath string
 
@@ -1111,7 +2319,7 @@ func runLauncherLogic(root, exePath string, modpack Modpack, prismProcess **os.P
 			action = fmt.Sprintf("Updating %s %s → %s", packName, localVersion, remoteVersion)
 			logf("%s", stepLine(action))
 			logf("%s", stepLine("Creating safety backup before update"))
-			backupPath, err = createModpackBackup(modpack, mcDir)
+			backupPath, err = createModpackBackup(root, modpack, mcDir)
 			if err != nil {
 				logf("%s", warnLine(fmt.Sprintf("Backup creation failed: %v", err)))
 			}
@@ -1122,7 +2330,8 @@ func runLauncherLogic(root, exePath string, modpack Modpack, prismProcess **os.P
 	}
 
 	packURL := modpack.PackURL
-	if os.Getenv(envCacheBust) == "1" {
+	if opts.ForceResync || cacheBustEnabled() {
+		logf("%s", infoLine("Bypassing cache for pack.toml"))
 		sep := "?"
 		if strings.Contains(packURL, "?") {
 			sep = "&"
@@ -1130,11 +2339,21 @@ func runLauncherLogic(root, exePath string, modpack Modpack, prismProcess **os.P
 		packURL = packURL + sep + "cb=" + strconv.FormatInt(time.Now().Unix(), 10)
 	}
 
+	if len(modpack.Headers) > 0 {
+		logf("%s", infoLine(fmt.Sprintf("Sending custom headers with pack requests (%s)", describeHeaders(modpack.Headers))))
+		proxyURL, stopProxy, err := startPackProxy(packURL, modpack.Headers)
+		if err != nil {
+			return result, fmt.Errorf("failed to start pack proxy: %w", err)
+		}
+		defer stopProxy()
+		packURL = proxyURL
+	}
+
 	// Show progress indicator for packwiz operation
 	progressTicker := time.NewTicker(2 * time.Second)
 	defer progressTicker.Stop()
 
-	report("Synchronizing modpack files")
+	report(StageSyncingFiles)
 	go func() {
 		for range progressTicker.C {
 			if updateAvailable {
@@ -1149,19 +2368,42 @@ func runLauncherLogic(root, exePath string, modpack Modpack, prismProcess **os.P
 	mainJarPath := filepath.Join(utilDir, "packwiz-installer.jar")
 	if !exists(mainJarPath) {
 		logf("%s", stepLine("Downloading packwiz-installer.jar"))
-		if err := downloadPackwizInstaller(mainJarPath); err != nil {
-			fail(fmt.Errorf("failed to download packwiz-installer.jar: %w", err))
+		if err := downloadPackwizInstaller(ctx, mainJarPath); err != nil {
+			return result, fmt.Errorf("failed to download packwiz-installer.jar: %w", err)
 		}
 		logf("%s", successLine("packwiz-installer.jar downloaded"))
 	}
 
+	// Fetch manual-download mods up front so they are handled in one batch
+	// instead of interrupting the packwiz run
+	if manual := scanManualMods(packInfo, mcDir, utilDir); len(manual) > 0 {
+		logf("%s", stepLine(fmt.Sprintf("%d mod(s) must be downloaded manually before syncing", len(manual))))
+		assistManualFromPackwiz(manual, opts.ManualDownloads)
+	}
+
+	var verifyBefore map[string]fileStamp
+	if opts.Verify {
+		logf("%s", stepLine("Re-checking every modpack file against the pack index"))
+		restoreManifest, err := setAsidePackwizManifest(mcDir)
+		if err != nil {
+			return result, fmt.Errorf("failed to set aside the packwiz cache: %w", err)
+		}
+		defer restoreManifest()
+		if verifyBefore, err = snapshotPackFiles(mcDir); err != nil {
+			return result, fmt.Errorf("failed to list installed files: %w", err)
+		}
+	}
+
+	// packwiz-installer downloads the mods itself and has no option to limit its
+	// threads or network timeout (its -t is how long the optional mods prompt waits),
+	// so the Downloads settings don't reach it; only cancelling ctx does
 	var cmd *exec.Cmd
 	if exists(bootstrapExe) {
-		cmd = exec.Command(bootstrapExe, "--bootstrap-no-update", "--bootstrap-main-jar", mainJarPath, "-g", packURL) // run from minecraft directory
+		cmd = exec.CommandContext(ctx, bootstrapExe, "--bootstrap-no-update", "--bootstrap-main-jar", mainJarPath, "-g", packURL) // run from minecraft directory
 	} else if exists(bootstrapJar) {
-		cmd = exec.Command(javaBin, "-jar", bootstrapJar, "--bootstrap-no-update", "--bootstrap-main-jar", mainJarPath, "-g", packURL)
+		cmd = exec.CommandContext(ctx, javaBin, "-jar", bootstrapJar, "--bootstrap-no-update", "--bootstrap-main-jar", mainJarPath, "-g", packURL)
 	} else {
-		fail(errors.New("packwiz bootstrap not found after download"))
+		return result, errors.New("packwiz bootstrap not found after download")
 	}
 	cmd.Dir = mcDir // critical: minecraft directory so packwiz installs mods in correct place
 	cmd.Env = append(os.Environ(),
@@ -1171,24 +2413,32 @@ func runLauncherLogic(root, exePath string, modpack Modpack, prismProcess **os.P
 
 	// Set platform-specific process attributes
 	setPackwizProcessAttributes(cmd)
+	cancelPackwizOnDone(cmd)
+
+	// Stream packwiz output to the log first, then to any live listener
+	packwizOut := out
+	if opts.Output != nil {
+		packwizOut = io.MultiWriter(out, opts.Output)
+	}
 
 	var buf bytes.Buffer
-	mw := io.MultiWriter(out, &buf)
+	mw := io.MultiWriter(packwizOut, &buf)
 	cmd.Stdout, cmd.Stderr = mw, mw
 
 	progressTicker.Stop() // Stop progress ticker before running packwiz
 	err = cmd.Run()
-	if err != nil {
+	if err != nil && ctx.Err() == nil {
 		// Parse packwiz output for manual-download instructions
 		items := parsePackwizManuals(buf.String())
 		if len(items) > 0 {
-			assistManualFromPackwiz(items)
+			rememberManualMods(utilDir, items)
+			assistManualFromPackwiz(items, opts.ManualDownloads)
 			// Retry ONCE after user saves files, but create a new command to avoid "already started" error
 			var retryCmd *exec.Cmd
 			if exists(bootstrapExe) {
-				retryCmd = exec.Command(bootstrapExe, "--bootstrap-no-update", "--bootstrap-main-jar", mainJarPath, "-g", packURL)
+				retryCmd = exec.CommandContext(ctx, bootstrapExe, "--bootstrap-no-update", "--bootstrap-main-jar", mainJarPath, "-g", packURL)
 			} else if exists(bootstrapJar) {
-				retryCmd = exec.Command(javaBin, "-jar", bootstrapJar, "--bootstrap-no-update", "--bootstrap-main-jar", mainJarPath, "-g", packURL)
+				retryCmd = exec.CommandContext(ctx, javaBin, "-jar", bootstrapJar, "--bootstrap-no-update", "--bootstrap-main-jar", mainJarPath, "-g", packURL)
 			}
 			if retryCmd != nil {
 				retryCmd.Dir = mcDir // also run from minecraft directory
@@ -1199,8 +2449,9 @@ func runLauncherLogic(root, exePath string, modpack Modpack, prismProcess **os.P
 
 				// Set platform-specific process attributes for retry
 				setPackwizRetryProcessAttributes(retryCmd)
+				cancelPackwizOnDone(retryCmd)
 
-				retryCmd.Stdout, retryCmd.Stderr = out, out
+				retryCmd.Stdout, retryCmd.Stderr = packwizOut, packwizOut
 				err = retryCmd.Run()
 			}
 		}
@@ -1210,13 +2461,34 @@ func runLauncherLogic(root, exePath string, modpack Modpack, prismProcess **os.P
 		// Update failed - attempt to restore from backup if we have one
 		if backupPath != "" {
 			logf("%s", warnLine("Packwiz update failed, attempting to restore from backup"))
-			if restoreErr := restoreModpackBackup(modpack, backupPath, mcDir); restoreErr != nil {
+			// The backup was taken from this same instance moments ago, so a mismatch only
+			// means the update got part way; rolling back is what's needed either way
+			allowRestore := func(warnings []string) bool {
+				logf("%s", infoLine("Restoring the backup anyway, since it was taken just before this update"))
+				return true
+			}
+			if restoreErr := restoreModpackBackup(modpack, backupPath, mcDir, allowRestore); restoreErr != nil {
 				logf("%s", warnLine(fmt.Sprintf("Failed to restore backup: %v", restoreErr)))
 			} else {
 				logf("%s", successLine("Restored previous modpack state"))
 			}
 		}
-		fail(fmt.Errorf("packwiz update failed: %w", err))
+		if ctx.Err() != nil {
+			return result, fmt.Errorf("%s cancelled: %w", packName, ctx.Err())
+		}
+		return result, fmt.Errorf("packwiz update failed: %w", err)
+	}
+
+	if migrateFrom != "" {
+		// The pack moved to a new Minecraft version; old LWJGL/Minecraft components would break the launch
+		logf("%s", stepLine(fmt.Sprintf("Migrating instance from Minecraft %s to %s", migrateFrom, packInfo.Minecraft)))
+		if err := migrateInstanceComponents(instDir, packInfo); err != nil {
+			return result, fmt.Errorf("failed to migrate instance to Minecraft %s: %w", packInfo.Minecraft, err)
+		}
+		if err := updateInstanceJava(instDir, javawBin); err != nil {
+			logf("%s", warnLine(fmt.Sprintf("Failed to switch instance to Java %s: %v", requiredJavaVersion, err)))
+		}
+		logf("%s", successLine(fmt.Sprintf("Instance migrated to Minecraft %s with Java %s", packInfo.Minecraft, requiredJavaVersion)))
 	}
 
 	// Post-update verification and version saving
@@ -1229,43 +2501,171 @@ func runLauncherLogic(root, exePath string, modpack Modpack, prismProcess **os.P
 		} else {
 			logf("%s", successLine(fmt.Sprintf("%s now running version %s", packName, remoteVersion)))
 		}
+		// Read the new mods now so the mod list opens straight away
+		if _, err := loadModList(instDir, remoteVersion); err != nil {
+			logf("%s", warnLine(fmt.Sprintf("Failed to read the mod list: %v", err)))
+		}
 	} else {
 		logf("%s", successLine(fmt.Sprintf("%s installation verification completed", packName)))
 	}
+	if opts.Verify {
+		result.Repaired = int(atomic.LoadInt32(&runtimesRepaired))
+		if after, err := snapshotPackFiles(mcDir); err != nil {
+			logf("%s", warnLine(fmt.Sprintf("Failed to count repaired files: %v", err)))
+		} else if updateAvailable {
+			// The pack changed after the pending-update check above, so what packwiz
+			// changed came from the new version rather than from damage
+			result.UpdatedFiles = countChangedFiles(verifyBefore, after)
+		} else {
+			result.Repaired += countChangedFiles(verifyBefore, after)
+		}
+		logf("%s", successLine(fmt.Sprintf("Verify downloaded %d file(s) again", result.Repaired)))
+		if result.UpdatedFiles > 0 {
+			logf("%s", infoLine(fmt.Sprintf("Verify also updated %d file(s) to %s", result.UpdatedFiles, remoteVersion)))
+		}
+	}
+
+	// Put back worlds and options set aside by "Reinstall (keep saves)"
+	if kept := keptDataDir(root, modpack); exists(kept) {
+		restored, err := restorePlayerData(kept, mcDir)
+		if err != nil {
+			logf("%s", warnLine(fmt.Sprintf("Failed to restore kept saves from %s: %v", kept, err)))
+		} else if len(restored) > 0 {
+			logf("%s", successLine(fmt.Sprintf("Restored %s after reinstall", strings.Join(restored, ", "))))
+		}
+	}
+	result.Updated = updateAvailable
+	result.Version = remoteVersion
+	if result.Version == "" {
+		result.Version = localVersion
+	}
+
+	if opts.SkipLaunch {
+		// Pin the Java runtime anyway so starting the instance from Prism works
+		if err := updateInstanceJava(instDir, javawBin); err != nil {
+			logf("%s", warnLine(fmt.Sprintf("Failed to update instance Java path: %v", err)))
+		}
+		logf("%s", successLine(fmt.Sprintf("%s %s is installed and ready to launch", packName, result.Version)))
+		logTimings()
+		result.Outcome = outcomeInstalled
+		return result, nil
+	}
+
+	if err := ctx.Err(); err != nil {
+		return result, fmt.Errorf("%s cancelled: %w", packName, err)
+	}
+
+	err = launchPreparedInstance(root, modpack, preparedInstance{
+		PrismDir:    prismDir,
+		InstDir:     instDir,
+		JreDir:      jreDir,
+		JavawBin:    javawBin,
+		JavaVersion: requiredJavaVersion,
+		Minecraft:   packInfo.Minecraft,
+	}, prismProcess, opts, &result, report, logTimings)
+	return result, err
+}
+
+// preparedInstance is an installed instance and the Java runtime it launches with
+type preparedInstance struct {
+	PrismDir    string
+	InstDir     string
+	JreDir      string
+	JavawBin    string
+	JavaVersion string
+	Minecraft   string
+}
+
+// offlineInstance checks that everything needed to launch modpack is already on disk
+// and describes it from the instance's own files, so an offline launch makes no requests
+func offlineInstance(root string, modpack Modpack) (preparedInstance, error) {
+	missing := func(what string) (preparedInstance, error) {
+		return preparedInstance{}, fmt.Errorf("cannot launch %s offline, missing %s", modpackLabel(modpack), what)
+	}
+
+	prismDir := filepath.Join(root, "prism")
+	instDir := filepath.Join(instancesDirFor(root), modpack.InstanceName)
+	for _, name := range []string{"instance.cfg", "mmc-pack.json", "minecraft"} {
+		if path := filepath.Join(instDir, name); !exists(path) {
+			return missing(path)
+		}
+	}
+	if prismExe := resolvePrismExecutable(prismDir); !exists(prismExe) {
+		return missing("Prism Launcher (" + prismExe + ")")
+	}
+
+	packInfo, err := readInstancePackInfo(instDir)
+	if err != nil || packInfo.Minecraft == "" {
+		return missing("the Minecraft version in mmc-pack.json")
+	}
+	cfg, err := readInstanceConfig(instDir)
+	if err != nil || cfg["JavaPath"] == "" {
+		return missing("the Java path in instance.cfg")
+	}
+	javawBin := filepath.FromSlash(cfg["JavaPath"])
+	if !exists(javawBin) {
+		return missing("Java (" + javawBin + ")")
+	}
+
+	// Managed runtimes live in prism/java/jre<major>/bin
+	jreDir := filepath.Dir(filepath.Dir(javawBin))
+	return preparedInstance{
+		PrismDir:    prismDir,
+		InstDir:     instDir,
+		JreDir:      jreDir,
+		JavawBin:    javawBin,
+		JavaVersion: strings.TrimPrefix(filepath.Base(jreDir), "jre"),
+		Minecraft:   packInfo.Minecraft,
+	}, nil
+}
+
+// launchPreparedInstance starts Prism for an installed instance, trying each launch
+// approach in turn, and waits for it to close. The outcome is recorded in result.
+func launchPreparedInstance(root string, modpack Modpack, inst preparedInstance, prismProcess **os.Process, opts launchOptions, result *launchResult, report func(stage InstallStage), logTimings func()) error {
+	packName := modpackLabel(modpack)
+	processRegistry, err := GetGlobalProcessRegistry(root)
+	if err != nil {
+		logf("Warning: Failed to initialize process registry: %v", err)
+	}
 
 	// 8) Launch selected instance directly
 	logf("%s", sectionLine("Launching"))
 	logf("%s", stepLine(fmt.Sprintf("Launching %s", packName)))
 
-	report("Launching via Prism")
+	report(StageLaunching)
 
-	// Update global JavaPath in prismlauncher.cfg for this modpack
-	logf("%s", stepLine("Updating Prism Java configuration"))
-	if err := updatePrismJavaPath(prismDir, javawBin); err != nil {
-		logf("%s", warnLine(fmt.Sprintf("Failed to update Prism Java path: %v", err)))
+	// Pin this instance to its own Java runtime; the global Prism config is left untouched
+	logf("%s", stepLine("Updating instance Java configuration"))
+	if err := updateInstanceJava(inst.InstDir, inst.JavawBin); err != nil {
+		logf("%s", warnLine(fmt.Sprintf("Failed to update instance Java path: %v", err)))
 	}
 
-	prismExe := GetPrismExecutablePath(prismDir)
-
-	// On macOS, check if Prism exists in the local directory, otherwise use /Applications
-	if runtime.GOOS == "darwin" && !exists(prismExe) {
-		// Try both naming conventions in /Applications
-		applicationsPrismWithSpace := filepath.Join("/Applications", "Prism Launcher.app", "Contents", "MacOS", "prismlauncher")
-		applicationsPrismWithoutSpace := filepath.Join("/Applications", "PrismLauncher.app", "Contents", "MacOS", "prismlauncher")
-		
-		if exists(applicationsPrismWithSpace) {
-			prismExe = applicationsPrismWithSpace
-			logf("Using Prism Launcher from /Applications folder (with space)")
-		} else if exists(applicationsPrismWithoutSpace) {
-			prismExe = applicationsPrismWithoutSpace
-			logf("Using Prism Launcher from /Applications folder (without space)")
+	// One-off JVM arguments from the launch command preview
+	if opts.JvmArgs != nil {
+		restore, err := setInstanceJvmArgs(inst.InstDir, *opts.JvmArgs)
+		if err != nil {
+			logf("%s", warnLine(fmt.Sprintf("Failed to apply one-off JVM arguments: %v", err)))
 		} else {
-			logf("Warning: Prism Launcher not found at %s, %s, or %s", prismExe, applicationsPrismWithSpace, applicationsPrismWithoutSpace)
+			logf("%s", infoLine(fmt.Sprintf("Using JVM arguments for this launch only: %s", *opts.JvmArgs)))
+			defer func() {
+				// The game may still be running under a Prism window the launch was handed to
+				waitForDetachedPrism()
+				if err := restore(); err != nil {
+					logf("%s", warnLine(fmt.Sprintf("Failed to restore JVM arguments: %v", err)))
+				}
+			}()
 		}
 	}
 
+	prismExe := resolvePrismExecutable(inst.PrismDir)
+
+	// Gatekeeper refuses to start a quarantined Prism or Java without saying why
+	if runtime.GOOS == "darwin" {
+		clearLaunchQuarantine(inst.PrismDir, prismExe, inst.JreDir)
+	}
+
 	// Log Qt environment setup for debugging
-	logQtEnvironment(prismDir)
+	logQtEnvironment(inst.PrismDir)
 
 	// Ensure patchelf is installed before attempting RPATH fixes
 	if runtime.GOOS == "linux" {
@@ -1276,22 +2676,75 @@ func runLauncherLogic(root, exePath string, modpack Modpack, prismProcess **os.P
 		}
 	}
 
-	// Check plugin dependencies before launching
+	// Check plugin dependencies before launching, repairing Prism if any are broken.
+	// Don't fail the launch, but warn the user about anything left.
 	if runtime.GOOS == "linux" {
-		if err := checkPluginDependencies(prismDir); err != nil {
-			logf("%s", warnLine(fmt.Sprintf("Plugin dependency check failed: %v", err)))
-			// Don't fail the launch, but warn the user
-		}
+		autoRepairQtPlugins(inst.PrismDir, processRegistry)
 	}
 
 	// Try multiple launch approaches with fallbacks
 	var launchErr error
-	var launchedProcess *os.Process
 
-	// Approach 1: Direct launch with enhanced error handling
-	launchErr = launchPrismDirect(prismExe, prismDir, jreDir, modpack.InstanceName, packName, prismProcess)
-	if launchErr == nil && *prismProcess != nil {
-		launchedProcess = *prismProcess
+	// Register each Prism process as soon as it starts, so a restarted launcher can find
+	// a game that is still running, and drop the record once Prism exits
+	var registered []string
+	onStart := func(proc *os.Process) {
+		if id := registerLaunchedProcess(processRegistry, modpack, inst, prismExe, proc); id != "" {
+			registered = append(registered, id)
+		}
+		if opts.OnLaunch != nil {
+			opts.OnLaunch(proc)
+		}
+	}
+	defer func() {
+		for _, id := range registered {
+			if err := processRegistry.RemoveRecord(id); err != nil {
+				logf("Warning: Failed to remove process record %s: %v", id, err)
+			}
+		}
+	}()
+
+	if len(modpack.EnvVars) > 0 {
+		logf("%s", infoLine(fmt.Sprintf("Extra environment for %s: %s", packName, strings.Join(envOverrides(modpack.EnvVars), " "))))
+	}
+
+	launchedAt := time.Now()
+	var attemptErrs []error
+	if settings.LaunchPrismGUI {
+		// Prism's own window, for changing the instance by hand. It is registered like a
+		// direct launch, so Kill still stops it.
+		logf("%s", infoLine("Launch Prism GUI is on; opening Prism's window instead of launching directly"))
+		launchErr = launchPrismGUIFallback(prismExe, inst.PrismDir, inst.JreDir, packName, modpack.EnvVars, prismProcess, onStart)
+		if launchErr != nil {
+			logf("%s", warnLine(fmt.Sprintf("Prism Launcher UI failed: %v", launchErr)))
+			result.Outcome = outcomeLaunchFailed
+			result.Issues = launchIssues(launchErr)
+			return launchErr
+		}
+		logf("%s", successLine("Prism Launcher UI closed"))
+	} else {
+		// Approach 1: Direct launch with enhanced error handling
+		directStart := time.Now()
+		launchErr = launchPrismDirect(prismExe, inst.PrismDir, inst.JreDir, modpack.InstanceName, packName, modpack.EnvVars, prismProcess, onStart)
+		attemptErrs = append(attemptErrs, launchErr)
+
+		// Approach 1b: Direct launch on the other Qt platform, when this one failed to
+		// start Prism's window and the modpack doesn't pick a platform itself. A Prism
+		// that ran for a while did open, so launching it again would restart the game.
+		if _, pinned := modpack.EnvVars["QT_QPA_PLATFORM"]; launchErr != nil && runtime.GOOS == "linux" && !pinned && qtPlatformIssue(launchIssues(launchErr), time.Since(directStart)) {
+			platform, _ := qtPlatform()
+			if alternate := alternateQtPlatform(platform); alternate != "" {
+				logf("%s", stepLine(fmt.Sprintf("Retrying with the %s Qt platform instead of %s", alternate, platform)))
+				envVars := map[string]string{"QT_QPA_PLATFORM": alternate}
+				for key, value := range modpack.EnvVars {
+					envVars[key] = value
+				}
+				if launchErr = launchPrismDirect(prismExe, inst.PrismDir, inst.JreDir, modpack.InstanceName, packName, envVars, prismProcess, onStart); launchErr == nil {
+					logf("%s", infoLine(fmt.Sprintf("Prism worked with the %s Qt platform; choose it under Qt platform in Settings to use it from the start", alternate)))
+				}
+				attemptErrs = append(attemptErrs, launchErr)
+			}
+		}
 	}
 
 	if launchErr != nil {
@@ -1300,7 +2753,8 @@ func runLauncherLogic(root, exePath string, modpack Modpack, prismProcess **os.P
 		// Approach 2: Wrapper script approach (Linux only)
 		if runtime.GOOS == "linux" {
 			logf("%s", stepLine("Attempting wrapper script launch"))
-			launchErr = launchPrismWithWrapper(prismDir, jreDir, modpack.InstanceName)
+			launchErr = launchPrismWithWrapper(inst.PrismDir, inst.JreDir, modpack.InstanceName, modpack.EnvVars)
+			attemptErrs = append(attemptErrs, launchErr)
 			if launchErr != nil {
 				logf("%s", warnLine(fmt.Sprintf("Wrapper script launch failed: %v", launchErr)))
 			} else {
@@ -1312,84 +2766,120 @@ func runLauncherLogic(root, exePath string, modpack Modpack, prismProcess **os.P
 
 		// Approach 3: Fallback to GUI launch
 		logf("%s", stepLine("Attempting fallback to Prism GUI"))
-		launchErr = launchPrismGUIFallback(prismExe, prismDir, jreDir, packName, prismProcess)
+		launchErr = launchPrismGUIFallback(prismExe, inst.PrismDir, inst.JreDir, packName, modpack.EnvVars, prismProcess, onStart)
+		attemptErrs = append(attemptErrs, launchErr)
 		if launchErr != nil {
 			logf("%s", warnLine(fmt.Sprintf("GUI fallback launch failed: %v", launchErr)))
 			logf("%s", warnLine("All launch attempts failed"))
+			result.Outcome = outcomeLaunchFailed
+			result.Issues = launchIssues(attemptErrs...)
+			return launchErr
 		} else {
 			logf("%s", successLine("Prism launched successfully via GUI fallback"))
-			if *prismProcess != nil {
-				launchedProcess = *prismProcess
-			}
+			// Prism's window opening doesn't fix what stopped the direct launch
+			result.FallbackErr = attemptErrs[0]
+			result.Issues = launchIssues(attemptErrs...)
 		}
-	} else {
+	} else if !settings.LaunchPrismGUI {
 		logf("%s", successLine("Prism launched successfully via direct launch"))
 	}
+	logTimings()
 
-	// Persist process information to registry if we have a valid process
-	if launchedProcess != nil && processRegistry != nil {
-		// Get process details
-		executable, workingDir, err := getProcessDetails(launchedProcess.Pid)
-		if err != nil {
-			logf("Warning: Failed to get process details: %v", err)
-			// Use fallback information
-			executable = prismExe
-			workingDir = prismDir
-		}
-
-		// Create process record
-		processID := fmt.Sprintf("%s_%d", modpack.ID, launchedProcess.Pid)
-		record := &PersistentProcessRecord{
-			ID:               processID,
-			ModpackID:        modpack.ID,
-			ModpackName:      modpack.DisplayName,
-			PID:              launchedProcess.Pid,
-			Executable:       executable,
-			WorkingDir:       workingDir,
-			StartTime:        time.Now(),
-			LastSeen:         time.Now(),
-			Status:           ProcessStatusStarting,
-			JavaVersion:      requiredJavaVersion,
-			MinecraftVersion: packInfo.Minecraft,
-			InstanceName:     modpack.InstanceName,
-			LauncherPath:     prismExe,
-		}
-
-		// Add to registry
-		if err := processRegistry.AddRecord(record); err != nil {
-			logf("Warning: Failed to add process record to registry: %v", err)
-		} else {
-			logf("Added process %s to registry for reattachment", processID)
-		}
+	// Prism's exit code doesn't say whether Minecraft crashed, but a crash leaves a report behind
+	if report := findCrashReport(filepath.Join(inst.InstDir, "minecraft"), launchedAt); report != "" {
+		logf("%s", warnLine(fmt.Sprintf("%s crashed; crash report: %s", packName, report)))
+		result.CrashReport = report
+	} else {
+		logf("%s", successLine(fmt.Sprintf("Prism Launcher closed for %s", packName)))
+	}
+	result.Outcome = outcomeGameClosed
+	return nil
+}
 
-		// Update process registry with running status after successful launch
-		// This is done in a separate goroutine to avoid blocking the main launcher logic
-		go func() {
-			// Give the process a moment to fully initialize
-			time.Sleep(2 * time.Second)
+// registerLaunchedProcess records a freshly started Prism process in the registry and
+// returns its record ID, or "" when there is no registry to record it in
+func registerLaunchedProcess(registry *ProcessRegistry, modpack Modpack, inst preparedInstance, prismExe string, proc *os.Process) string {
+	if registry == nil || proc == nil {
+		return ""
+	}
+	startTime := time.Now()
 
-			// Check if the process is still running
-			if isRunning, err := isProcessRunning(launchedProcess.Pid); err == nil && isRunning {
-				// Update the record status to running
-				if err := processRegistry.UpdateProcessStatus(processID, ProcessStatusRunning); err != nil {
-					logf("Warning: Failed to update process status to running: %v", err)
-				} else {
-					logf("Updated process %s status to running", processID)
-				}
+	executable, workingDir, err := getProcessDetails(proc.Pid)
+	if err != nil {
+		logf("Warning: Failed to get process details: %v", err)
+		// Use fallback information
+		executable = prismExe
+		workingDir = inst.PrismDir
+	}
+
+	processID := fmt.Sprintf("%s_%d", modpack.ID, proc.Pid)
+	record := &PersistentProcessRecord{
+		ID:               processID,
+		ModpackID:        modpack.ID,
+		ModpackName:      modpack.DisplayName,
+		PID:              proc.Pid,
+		Executable:       executable,
+		WorkingDir:       workingDir,
+		StartTime:        startTime,
+		LastSeen:         startTime,
+		Status:           ProcessStatusStarting,
+		JavaVersion:      inst.JavaVersion,
+		MinecraftVersion: inst.Minecraft,
+		InstanceName:     modpack.InstanceName,
+		LauncherPath:     prismExe,
+	}
+	if err := registry.AddRecord(record); err != nil {
+		logf("Warning: Failed to add process record to registry: %v", err)
+		return ""
+	}
+	logf("Added process %s to registry for reattachment", processID)
+
+	// Mark the record running once the process has had a moment to initialize
+	go func() {
+		time.Sleep(2 * time.Second)
+		if _, err := registry.GetRecord(processID); err != nil {
+			// Prism already exited and the record was dropped
+			return
+		}
+		if isRunning, err := isProcessRunning(proc.Pid); err == nil && isRunning {
+			if err := registry.UpdateProcessStatus(processID, ProcessStatusRunning); err != nil {
+				logf("Warning: Failed to update process status to running: %v", err)
 			} else {
-				// Process already exited or error checking, update status accordingly
-				if err := processRegistry.UpdateProcessStatus(processID, ProcessStatusStopped); err != nil {
-					logf("Warning: Failed to update process status to stopped: %v", err)
-				} else {
-					if err != nil {
-						logf("Updated process %s status to stopped (error checking: %v)", processID, err)
-					} else {
-						logf("Updated process %s status to stopped (already exited)", processID)
-					}
-				}
+				logf("Updated process %s status to running", processID)
 			}
-		}()
-	}
+		}
+	}()
+	return processID
+}
 
-	logf("%s", successLine(fmt.Sprintf("Prism Launcher closed for %s", packName)))
+// findCrashReport returns the newest crash report in mcDir written after since: a
+// Minecraft report in crash-reports, or a JVM hs_err_pid log when Java itself died.
+// It returns "" when the game quit cleanly.
+func findCrashReport(mcDir string, since time.Time) string {
+	var newest string
+	var newestTime time.Time
+	consider := func(dir string, match func(name string) bool) {
+		entries, err := os.ReadDir(dir)
+		if err != nil {
+			return
+		}
+		for _, entry := range entries {
+			if entry.IsDir() || !match(entry.Name()) {
+				continue
+			}
+			info, err := entry.Info()
+			if err != nil || info.ModTime().Before(since) || !info.ModTime().After(newestTime) {
+				continue
+			}
+			newest = filepath.Join(dir, entry.Name())
+			newestTime = info.ModTime()
+		}
+	}
+	consider(filepath.Join(mcDir, "crash-reports"), func(name string) bool {
+		return strings.HasPrefix(name, "crash-") && strings.HasSuffix(name, ".txt")
+	})
+	consider(mcDir, func(name string) bool {
+		return strings.HasPrefix(name, "hs_err_pid") && strings.HasSuffix(name, ".log")
+	})
+	return newest
 }
@@ -0,0 +1,465 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// TestRunPrerequisites tests that prerequisites respect the concurrency limit and report the first error
+func TestRunPrerequisites(t *testing.T) {
+	var running, peak int32
+	task := func(stage InstallStage, err error) prerequisiteTask {
+		return prerequisiteTask{Stage: stage, Run: func() error {
+			n := atomic.AddInt32(&running, 1)
+			for {
+				p := atomic.LoadInt32(&peak)
+				if n <= p || atomic.CompareAndSwapInt32(&peak, p, n) {
+					break
+				}
+			}
+			time.Sleep(20 * time.Millisecond)
+			atomic.AddInt32(&running, -1)
+			return err
+		}}
+	}
+
+	var mu sync.Mutex
+	var stages []InstallStage
+	report := func(stage InstallStage) {
+		mu.Lock()
+		stages = append(stages, stage)
+		mu.Unlock()
+	}
+
+	boom := errors.New("boom")
+	tasks := []prerequisiteTask{
+		task(StageEnsuringPrism, nil),
+		task(StageEnsuringJava, boom),
+		task(StageEnsuringBootstrap, nil),
+		task(StagePreparingInstance, nil),
+	}
+	if err := runPrerequisites(tasks, 2, report); !errors.Is(err, boom) {
+		t.Errorf("Expected the failing task's error, got %v", err)
+	}
+	if peak > 2 {
+		t.Errorf("Expected at most 2 tasks at once, got %d", peak)
+	}
+	if len(stages) != 4 {
+		t.Errorf("Expected every stage to run, got %v", stages)
+	}
+
+	// Sequential runs keep the order and stop at the first error
+	stages = nil
+	if err := runPrerequisites(tasks, 1, report); !errors.Is(err, boom) {
+		t.Errorf("Expected the failing task's error, got %v", err)
+	}
+	if len(stages) != 2 || stages[0] != StageEnsuringPrism || stages[1] != StageEnsuringJava {
+		t.Errorf("Expected sequential run to stop after Java, got %v", stages)
+	}
+}
+
+// TestRunPrerequisitesRetryFailed tests that only the failed tasks, plus any never started, are left to retry
+func TestRunPrerequisitesRetryFailed(t *testing.T) {
+	runs := make(map[InstallStage]int)
+	var mu sync.Mutex
+	task := func(stage InstallStage, failures int) prerequisiteTask {
+		return prerequisiteTask{Stage: stage, Run: func() error {
+			mu.Lock()
+			defer mu.Unlock()
+			runs[stage]++
+			if runs[stage] <= failures {
+				return fmt.Errorf("%s failed", stage)
+			}
+			return nil
+		}}
+	}
+	tasks := []prerequisiteTask{task(StageEnsuringPrism, 0), task(StageEnsuringJava, 1), task(StageEnsuringBootstrap, 1)}
+
+	err := runPrerequisites(tasks, 3, func(InstallStage) {})
+	var prereqErr *prerequisiteError
+	if !errors.As(err, &prereqErr) {
+		t.Fatalf("Expected a *prerequisiteError, got %v", err)
+	}
+	if len(prereqErr.Failed) != 2 || prereqErr.Failed[0].Stage != StageEnsuringJava || prereqErr.Failed[1].Stage != StageEnsuringBootstrap {
+		t.Errorf("Expected java and bootstrap to fail in task order, got %+v", prereqErr.Failed)
+	}
+	if !strings.Contains(err.Error(), "2 downloads failed") {
+		t.Errorf("Expected the message to count the failures, got %q", err.Error())
+	}
+	if err := runPrerequisites(prereqErr.Pending, 3, func(InstallStage) {}); err != nil {
+		t.Fatalf("Expected the retry to succeed, got %v", err)
+	}
+	if runs[StageEnsuringPrism] != 1 || runs[StageEnsuringJava] != 2 || runs[StageEnsuringBootstrap] != 2 {
+		t.Errorf("Expected only the failed tasks to run again, got %v", runs)
+	}
+
+	// Sequential runs also leave the tasks after the failure pending
+	for k := range runs {
+		delete(runs, k)
+	}
+	err = runPrerequisites([]prerequisiteTask{task(StageEnsuringPrism, 1), task(StageEnsuringJava, 0)}, 1, func(InstallStage) {})
+	if !errors.As(err, &prereqErr) || len(prereqErr.Pending) != 2 {
+		t.Fatalf("Expected both tasks pending after a sequential failure, got %v", err)
+	}
+	if err.Error() != "Ensuring Prism Launcher failed" {
+		t.Errorf("Expected a single failure to keep its own message, got %q", err.Error())
+	}
+
+	// Cancelled downloads are never offered for a retry
+	ctx, cancel := context.WithCancel(context.Background())
+	if !retryablePrerequisites(ctx, err) {
+		t.Error("Expected a failed download to be retryable")
+	}
+	cancelled := &prerequisiteError{Failed: []prerequisiteFailure{{Stage: StageEnsuringJava, Err: fmt.Errorf("download: %w", context.Canceled)}}}
+	if retryablePrerequisites(ctx, cancelled) {
+		t.Error("Expected a cancelled download not to be retryable")
+	}
+	cancel()
+	if retryablePrerequisites(ctx, err) {
+		t.Error("Expected nothing to be retryable once the operation is cancelled")
+	}
+}
+
+// TestFormatQtRepairReport tests the before/after summary shown after a Qt repair
+func TestFormatQtRepairReport(t *testing.T) {
+	report := formatQtRepairReport(qtRepairResult{
+		Before:       qtPluginReport{Checked: 3, Valid: 2, Invalid: []string{"libqxcb.so: not a valid shared library"}},
+		After:        qtPluginReport{Checked: 3, Valid: 3},
+		Redownloaded: true,
+	})
+	for _, want := range []string{
+		"Before: 2 of 3 plugins valid, 1 corrupt, 0 missing libraries",
+		"After:  3 of 3 plugins valid, 0 corrupt, 0 missing libraries",
+		"downloaded again",
+		"looks healthy",
+	} {
+		if !strings.Contains(report, want) {
+			t.Errorf("Expected report to contain %q, got:\n%s", want, report)
+		}
+	}
+}
+
+// TestMissingBundledQtLibraries tests that only Qt libraries Prism ships itself lead
+// to Prism being downloaded again
+func TestMissingBundledQtLibraries(t *testing.T) {
+	if missingBundledQtLibraries(qtPluginReport{MissingDeps: []string{"platforms/libqxcb.so: libxcb-cursor.so.0"}}) {
+		t.Error("Expected a missing system library not to need Prism downloaded again")
+	}
+	if !missingBundledQtLibraries(qtPluginReport{MissingDeps: []string{"libqsvg.so: libxkbcommon.so.0", "libqjpeg.so: libQt6Gui.so.6"}}) {
+		t.Error("Expected a missing Qt library to need Prism downloaded again")
+	}
+}
+
+// TestQtPlatform tests that Wayland sessions prefer Wayland with X11 as Qt's fallback,
+// that the setting wins, and which platform a failed launch is retried on
+func TestQtPlatform(t *testing.T) {
+	saved := settings
+	defer func() { settings = saved }()
+	settings.QtPlatform = ""
+
+	t.Setenv("XDG_SESSION_TYPE", "x11")
+	t.Setenv("WAYLAND_DISPLAY", "")
+	t.Setenv("DISPLAY", ":0")
+	if platform, _ := qtPlatform(); platform != "xcb" || alternateQtPlatform(platform) != "" {
+		t.Errorf("Expected xcb with nothing to retry on X11, got %s", platform)
+	}
+
+	t.Setenv("XDG_SESSION_TYPE", "wayland")
+	t.Setenv("WAYLAND_DISPLAY", "wayland-0")
+	if platform, _ := qtPlatform(); platform != "wayland;xcb" || alternateQtPlatform(platform) != "xcb" {
+		t.Errorf("Expected wayland;xcb retried on xcb under XWayland, got %s", platform)
+	}
+	t.Setenv("DISPLAY", "")
+	if alternate := alternateQtPlatform("wayland;xcb"); alternate != "" {
+		t.Errorf("Expected no xcb retry without XWayland, got %s", alternate)
+	}
+
+	settings.QtPlatform = "XCB"
+	if platform, _ := qtPlatform(); platform != "xcb" || alternateQtPlatform(platform) != "wayland" {
+		t.Errorf("Expected the xcb setting retried on wayland, got %s", platform)
+	}
+}
+
+// TestQtPlatformIssue tests that only a Qt failure right after Prism starts leads to a
+// retry on the other platform
+func TestQtPlatformIssue(t *testing.T) {
+	issues := []string{"Qt platform plugin could not be initialized"}
+	if !qtPlatformIssue(issues, 2*time.Second) {
+		t.Error("Expected a Qt failure at startup to be retried")
+	}
+	if qtPlatformIssue(issues, 10*time.Minute) {
+		t.Error("Expected no retry once Prism had been running for a while")
+	}
+	if qtPlatformIssue([]string{"Out of memory"}, 2*time.Second) {
+		t.Error("Expected no retry for an issue unrelated to Qt")
+	}
+}
+
+// TestBuildQtEnvironmentDebug tests that Qt debug variables are only set with debug
+// logging on
+func TestBuildQtEnvironmentDebug(t *testing.T) {
+	if runtime.GOOS != "linux" {
+		t.Skip("Qt environment variables are only set on Linux")
+	}
+	saved := settings
+	defer func() { settings = saved }()
+
+	hasDebugVars := func() bool {
+		for _, kv := range buildQtEnvironment(t.TempDir(), "/jre", nil) {
+			if strings.HasPrefix(kv, "QT_DEBUG_PLUGINS=") || strings.HasPrefix(kv, "QT_QPA_VERBOSE=") {
+				return true
+			}
+		}
+		return false
+	}
+	settings.DebugEnabled = false
+	if hasDebugVars() {
+		t.Error("Expected no Qt debug variables with debug logging off")
+	}
+	settings.DebugEnabled = true
+	if !hasDebugVars() {
+		t.Error("Expected Qt debug variables with debug logging on")
+	}
+}
+
+// TestInstallModpackHeadless tests that the install pipeline can be driven without the GUI and reports failures
+func TestInstallModpackHeadless(t *testing.T) {
+	server := httptest.NewServer(http.NotFoundHandler())
+	defer server.Close()
+
+	var stages []InstallStage
+	mp := Modpack{ID: "missing", DisplayName: "Missing", PackURL: server.URL + "/pack.toml", InstanceName: "Missing"}
+	result, err := installModpack(context.Background(), t.TempDir(), mp, launchOptions{}, func(stage InstallStage, step, total int) {
+		stages = append(stages, stage)
+	})
+	if err == nil {
+		t.Fatal("Expected an error for a pack.toml that can't be fetched")
+	}
+	if result.Outcome != outcomeFailed {
+		t.Errorf("Expected outcomeFailed, got %v", result.Outcome)
+	}
+	if len(stages) != 1 || stages[0] != StageReadingConfig {
+		t.Errorf("Expected progress to stop at the first stage, got %v", stages)
+	}
+	if result.Stage != StageReadingConfig {
+		t.Errorf("Expected the result to record the failed stage, got %v", result.Stage)
+	}
+
+	// Verify refuses a pack that isn't installed before fetching anything
+	stages = nil
+	_, err = installModpack(context.Background(), t.TempDir(), mp, launchOptions{Verify: true}, func(stage InstallStage, step, total int) {
+		stages = append(stages, stage)
+	})
+	if err == nil || !strings.Contains(err.Error(), "not installed") || len(stages) != 0 {
+		t.Errorf("Expected verify to fail up front for a missing pack, got %v after %v", err, stages)
+	}
+
+	// Verify leaves a pending pack update alone instead of applying it
+	saved := settings
+	defer func() { settings = saved }()
+	settings.InstancesDir = ""
+	packServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("version = \"1.1.0\"\n[versions]\nminecraft = \"1.20.1\"\nfabric = \"0.16.0\"\n"))
+	}))
+	defer packServer.Close()
+	mp.PackURL = packServer.URL + "/pack.toml"
+	root := t.TempDir()
+	instDir := filepath.Join(instancesDirFor(root), mp.InstanceName)
+	if err := os.MkdirAll(instDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	os.WriteFile(filepath.Join(instDir, "instance.cfg"), []byte("InstanceType=OneSix\n"), 0644)
+	if err := saveLocalVersion(mp, instDir, "1.0.0"); err != nil {
+		t.Fatal(err)
+	}
+	result, err = installModpack(context.Background(), root, mp, launchOptions{Verify: true}, nil)
+	if err != nil || result.UpdatePending != "1.1.0" || result.Version != "1.0.0" || result.Repaired != 0 {
+		t.Errorf("Expected verify to report the pending 1.1.0 update without syncing, got %+v, %v", result, err)
+	}
+	if v, _ := getLocalPackVersion(mp, instDir); v != "1.0.0" {
+		t.Errorf("Expected the installed version to stay 1.0.0, got %q", v)
+	}
+}
+
+// TestStepTimer tests that sequential stages and separately timed prerequisites are both summarized
+func TestStepTimer(t *testing.T) {
+	timer := &stepTimer{}
+	timer.begin("Reading modpack configuration")
+	timer.end()
+	tasks := timer.timed([]prerequisiteTask{{Stage: StageEnsuringJava, Run: func() error {
+		time.Sleep(20 * time.Millisecond)
+		return nil
+	}}})
+	if err := runPrerequisites(tasks, 2, func(InstallStage) {}); err != nil {
+		t.Fatalf("runPrerequisites failed: %v", err)
+	}
+	timer.begin("Synchronizing modpack files")
+
+	steps := timer.timings()
+	if len(steps) != 3 {
+		t.Fatalf("Expected 3 timed stages, got %+v", steps)
+	}
+	if steps[1].Stage != "Ensuring Java runtime" || steps[1].Duration < 20*time.Millisecond {
+		t.Errorf("Expected the prerequisite to be timed by its task, got %+v", steps[1])
+	}
+
+	summary := formatStepTimings([]stepTiming{{"Prism", 1200 * time.Millisecond}, {"Java", 14300 * time.Millisecond}, {"packwiz", 47 * time.Second}})
+	if want := "Prism: 1.2s, Java: 14.3s, packwiz: 47.0s (slowest: packwiz)"; summary != want {
+		t.Errorf("Expected %q, got %q", want, summary)
+	}
+}
+
+// TestOfflineInstance tests that an offline launch is described from the instance's own files
+// and names the first thing missing
+func TestOfflineInstance(t *testing.T) {
+	saved := settings
+	defer func() { settings = saved }()
+	settings.InstancesDir = ""
+
+	root := t.TempDir()
+	mp := Modpack{ID: "alpha", DisplayName: "Alpha", InstanceName: "Alpha"}
+	instDir := filepath.Join(instancesDirFor(root), "Alpha")
+
+	if _, err := offlineInstance(root, mp); err == nil || !strings.Contains(err.Error(), "instance.cfg") {
+		t.Fatalf("missing instance: got %v", err)
+	}
+
+	javaw := filepath.Join(root, "prism", "java", "jre21", "bin", JavawBinName)
+	os.MkdirAll(filepath.Join(instDir, "minecraft"), 0755)
+	os.WriteFile(filepath.Join(instDir, "instance.cfg"), []byte("InstanceType=OneSix\nJavaPath="+filepath.ToSlash(javaw)+"\n"), 0644)
+	os.WriteFile(filepath.Join(instDir, "mmc-pack.json"), []byte(`{"components":[{"uid":"net.minecraft","version":"1.21.1"}]}`), 0644)
+
+	if _, err := offlineInstance(root, mp); err == nil || !strings.Contains(err.Error(), "Prism Launcher") {
+		t.Fatalf("missing Prism: got %v", err)
+	}
+	prismExe := GetDirectPrismExecutablePath(filepath.Join(root, "prism"))
+	os.MkdirAll(filepath.Dir(prismExe), 0755)
+	os.WriteFile(prismExe, nil, 0755)
+
+	if _, err := offlineInstance(root, mp); err == nil || !strings.Contains(err.Error(), "Java") {
+		t.Fatalf("missing Java: got %v", err)
+	}
+	os.MkdirAll(filepath.Dir(javaw), 0755)
+	os.WriteFile(javaw, nil, 0755)
+
+	inst, err := offlineInstance(root, mp)
+	if err != nil {
+		t.Fatalf("offlineInstance() error = %v", err)
+	}
+	if inst.JavaVersion != "21" || inst.Minecraft != "1.21.1" || inst.JreDir != filepath.Join(root, "prism", "java", "jre21") {
+		t.Errorf("offlineInstance() = %+v", inst)
+	}
+}
+
+// TestFindCrashReport tests that only reports written during the launch count as a crash
+func TestFindCrashReport(t *testing.T) {
+	mcDir := t.TempDir()
+	reportsDir := filepath.Join(mcDir, "crash-reports")
+	if err := os.MkdirAll(reportsDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	launchedAt := time.Now()
+	write := func(path string, modTime time.Time) {
+		t.Helper()
+		if err := os.WriteFile(path, []byte("---- Minecraft Crash Report ----"), 0644); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.Chtimes(path, modTime, modTime); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	write(filepath.Join(reportsDir, "crash-2026-01-01_10.00.00-client.txt"), launchedAt.Add(-time.Hour))
+	if got := findCrashReport(mcDir, launchedAt); got != "" {
+		t.Errorf("Expected a report from an earlier session to be ignored, got %s", got)
+	}
+
+	write(filepath.Join(mcDir, "hs_err_pid4242.log"), launchedAt.Add(time.Minute))
+	newest := filepath.Join(reportsDir, "crash-2026-01-01_11.00.00-client.txt")
+	write(newest, launchedAt.Add(2*time.Minute))
+	write(filepath.Join(reportsDir, "notes.txt"), launchedAt.Add(3*time.Minute))
+	if got := findCrashReport(mcDir, launchedAt); got != newest {
+		t.Errorf("Expected the newest crash report %s, got %s", newest, got)
+	}
+
+	if got := findCrashReport(filepath.Join(mcDir, "missing"), launchedAt); got != "" {
+		t.Errorf("Expected no report for an instance that never ran, got %s", got)
+	}
+}
+
+// TestLaunchCommandShellCommand tests that the copied command carries the launch
+// environment and quotes what the shell would split
+func TestLaunchCommandShellCommand(t *testing.T) {
+	cmd := &launchCommand{
+		PrismExe:  filepath.Join("/home/player", "The Boys", "prism", "PrismLauncher"),
+		WorkDir:   filepath.Join("/home/player", "The Boys", "prism"),
+		Args:      []string{"--dir", ".", "--launch", "The Boys Lite"},
+		LaunchEnv: []string{"JAVA_HOME=/opt/jre 21", "QT_LOGGING_RULES*=true"},
+	}
+	got := cmd.ShellCommand()
+	want := []string{
+		shellQuote(cmd.WorkDir),
+		shellQuote("JAVA_HOME=/opt/jre 21"),
+		shellQuote("QT_LOGGING_RULES*=true"),
+		shellQuote(cmd.PrismExe) + " " + shellQuote("--dir") + " " + shellQuote(".") + " " + shellQuote("--launch") + " " + shellQuote("The Boys Lite"),
+	}
+	for _, part := range want {
+		if !strings.Contains(got, part) {
+			t.Errorf("Expected %s in the shell command, got %s", part, got)
+		}
+	}
+	if runtime.GOOS == "windows" {
+		if !strings.HasPrefix(got, "cd /d ") || !strings.Contains(got, " && set ") {
+			t.Errorf("Expected a cmd command line, got %s", got)
+		}
+	} else if !strings.HasPrefix(got, "cd ") || !strings.Contains(got, " && env ") {
+		t.Errorf("Expected an sh command line, got %s", got)
+	}
+}
+
+// TestJreDirLock tests that installs of one runtime share a lock while other runtimes get their own
+func TestJreDirLock(t *testing.T) {
+	dir := t.TempDir()
+	java17 := filepath.Join(dir, "java", "jre17")
+	if jreDirLock(java17) != jreDirLock(java17+string(filepath.Separator)) {
+		t.Error("Expected the same runtime to share one lock")
+	}
+	if jreDirLock(java17) == jreDirLock(filepath.Join(dir, "java", "jre21")) {
+		t.Error("Expected different runtimes to be locked separately")
+	}
+}
+
+// TestWaitForDetachedPrism tests that restoring waits for an open Prism window to exit
+func TestWaitForDetachedPrism(t *testing.T) {
+	waitForDetachedPrism() // nothing open, so this returns at once
+
+	done := trackDetachedPrism(12345)
+	waited := make(chan struct{})
+	go func() {
+		waitForDetachedPrism()
+		close(waited)
+	}()
+
+	select {
+	case <-waited:
+		t.Fatal("Expected to wait while the Prism window is open")
+	case <-time.After(50 * time.Millisecond):
+	}
+	done()
+	select {
+	case <-waited:
+	case <-time.After(time.Second):
+		t.Fatal("Expected the wait to end once the Prism window exited")
+	}
+}
@@ -0,0 +1,351 @@
+package main
+
+import (
+	"bytes"
+	"crypto/tls"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// -------------------- Log upload providers --------------------
+
+// LogUploader sends a log file to a paste service and returns the URL it can be viewed at
+type LogUploader interface {
+	Upload(logPath string) (string, error)
+	// Host names the service in messages shown while uploading
+	Host() string
+}
+
+// Log upload providers accepted in settings.json
+const (
+	logProviderDylan    = "dylan"
+	logProviderMicroBin = "microbin"
+)
+
+// logUploadURL is the paste server used when settings.json doesn't choose one
+const logUploadURL = "https://i.dylan.lol/logs/"
+
+// configuredLogUploader returns the provider chosen in settings, defaulting to i.dylan.lol
+func configuredLogUploader() LogUploader {
+	base := strings.TrimSpace(settings.LogUploadURL)
+	switch strings.ToLower(strings.TrimSpace(settings.LogUploadProvider)) {
+	case logProviderMicroBin:
+		return microBinUploader{BaseURL: base}
+	case "", logProviderDylan:
+		if base == "" {
+			base = logUploadURL
+		}
+		return dylanLogUploader{BaseURL: base}
+	default:
+		logf("%s", warnLine(fmt.Sprintf("Unknown log upload provider %q; using %s", settings.LogUploadProvider, logUploadURL)))
+		return dylanLogUploader{BaseURL: logUploadURL}
+	}
+}
+
+// uploadHost returns the host part of a provider's base URL for display
+func uploadHost(base string) string {
+	if u, err := url.Parse(base); err == nil && u.Host != "" {
+		return u.Host
+	}
+	return base
+}
+
+// logUploadClient sends upload requests with TLS 1.2, which the paste servers require
+func logUploadClient() *http.Client {
+	return &http.Client{
+		Timeout: 30 * time.Second,
+		Transport: &http.Transport{
+			TLSClientConfig: &tls.Config{
+				MinVersion: tls.VersionTLS12,
+				MaxVersion: tls.VersionTLS12,
+			},
+		},
+	}
+}
+
+// sendLogUpload posts an upload request and returns the response body once the
+// server accepted it
+func sendLogUpload(req *http.Request) (string, error) {
+	req.Header.Set("User-Agent", "TheBoysLauncher/1.0")
+
+	debugf("Sending HTTP request to upload log")
+	resp, err := logUploadClient().Do(req)
+	if err != nil {
+		debugf("Failed to upload log: %v", err)
+		return "", fmt.Errorf("failed to upload log: %v", err)
+	}
+	defer resp.Body.Close()
+
+	// Read the full response body
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		debugf("Failed to read response body: %v", err)
+		return "", fmt.Errorf("failed to read response body: %v", err)
+	}
+
+	// Log the raw response for debugging
+	debugf("Upload response status: %s", resp.Status)
+	bodyStr := string(body)
+	debugf("Upload response body (first 200 chars): %s", bodyStr[:min(200, len(bodyStr))])
+
+	// Check if the upload was successful (status code 200-299)
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		debugf("Upload failed with status: %s", resp.Status)
+		return "", fmt.Errorf("upload failed with status: %s\nResponse: %s", resp.Status, bodyStr[:min(200, len(bodyStr))])
+	}
+	return bodyStr, nil
+}
+
+// readLogForUpload reads a log file for upload, redacted unless the user turned that off
+func readLogForUpload(logPath string) (string, error) {
+	data, err := os.ReadFile(logPath)
+	if err != nil {
+		debugf("Failed to open log file: %v", err)
+		return "", fmt.Errorf("failed to open log file for upload: %v", err)
+	}
+	if !settings.RedactLogs {
+		return string(data), nil
+	}
+	home, _ := os.UserHomeDir()
+	return redactLog(string(data), home, getCurrentUser()), nil
+}
+
+// writeUploadCopy writes the log as readLogForUpload would send it to the temp folder
+// and returns its path, so a curl command reproducing the upload is redacted as well.
+// The copy is overwritten on each call rather than left to pile up.
+func writeUploadCopy(logPath string) (string, error) {
+	content, err := readLogForUpload(logPath)
+	if err != nil {
+		return "", err
+	}
+	copyPath := filepath.Join(os.TempDir(), "theboys-upload-"+filepath.Base(logPath))
+	if err := os.WriteFile(copyPath, []byte(content), 0600); err != nil {
+		return "", fmt.Errorf("failed to write the log for upload: %v", err)
+	}
+	return copyPath, nil
+}
+
+// minRedactedUsername is the shortest username scrubbed on its own; shorter ones
+// would mangle ordinary words, though they still disappear from home paths
+const minRedactedUsername = 3
+
+// redactLog replaces the home folder with ~ and the username with <user> so a
+// public paste doesn't reveal who uploaded it. Paths are matched with either
+// slash, JSON-escaped backslashes and any letter case, as Windows paths vary.
+func redactLog(content, home, username string) string {
+	if home = strings.TrimRight(home, `/\`); home != "" && home != "." {
+		variants := []string{
+			home,
+			strings.ReplaceAll(home, `\`, "/"),
+			strings.ReplaceAll(home, `\`, `\\`),
+		}
+		for _, variant := range variants {
+			content = regexp.MustCompile(`(?i)`+regexp.QuoteMeta(variant)).ReplaceAllLiteralString(content, "~")
+		}
+		if username == "" {
+			username = filepath.Base(home)
+		}
+	}
+	if len(username) >= minRedactedUsername {
+		content = regexp.MustCompile(`(?i)\b`+regexp.QuoteMeta(username)+`\b`).ReplaceAllLiteralString(content, "<user>")
+	}
+	return content
+}
+
+// dylanLogUploader posts the log as a multipart file to an i.dylan.lol style
+// server, which answers with an HTML page linking to the stored file
+type dylanLogUploader struct {
+	// BaseURL is the upload endpoint; uploaded logs are served below it
+	BaseURL string
+}
+
+func (u dylanLogUploader) Host() string { return uploadHost(u.BaseURL) }
+
+// baseURL returns BaseURL with the trailing slash file URLs are built on
+func (u dylanLogUploader) baseURL() string {
+	if strings.HasSuffix(u.BaseURL, "/") {
+		return u.BaseURL
+	}
+	return u.BaseURL + "/"
+}
+
+func (u dylanLogUploader) Upload(logPath string) (string, error) {
+	base := u.baseURL()
+
+	// Generate a random 8-character ID for the filename
+	randomID, err := generateRandomID()
+	if err != nil {
+		debugf("Failed to generate random ID: %v", err)
+		return "", fmt.Errorf("failed to generate random ID: %v", err)
+	}
+	filename := fmt.Sprintf("%s.log", randomID)
+	debugf("Generated filename: %s", filename)
+	if settings.DebugEnabled {
+		if copyPath, err := writeUploadCopy(logPath); err == nil {
+			logf("%s", infoLine("Equivalent upload request: "+logUploadCurlCommand(base, copyPath, filename)))
+		}
+	}
+
+	// Create multipart form with file upload using CreateFormFile to match curl -F format
+	var requestBody bytes.Buffer
+	writer := multipart.NewWriter(&requestBody)
+
+	// Add the required "act" field with value "bput" as required by the endpoint
+	err = writer.WriteField("act", "bput")
+	if err != nil {
+		debugf("Failed to add act field: %v", err)
+		return "", fmt.Errorf("failed to add act field: %v", err)
+	}
+
+	content, err := readLogForUpload(logPath)
+	if err != nil {
+		return "", err
+	}
+
+	// Create form file part using CreateFormFile to match curl -F "file=@filename;type=application/octet-stream"
+	part, err := writer.CreateFormFile("file", filename)
+	if err != nil {
+		debugf("Failed to create form file: %v", err)
+		return "", fmt.Errorf("failed to create form file: %v", err)
+	}
+
+	// Copy file content to the form part
+	_, err = io.WriteString(part, content)
+	if err != nil {
+		debugf("Failed to copy file content: %v", err)
+		return "", fmt.Errorf("failed to copy file content: %v", err)
+	}
+
+	writer.Close()
+
+	// Create a new HTTP request with the form data
+	req, err := http.NewRequest("POST", base, &requestBody)
+	if err != nil {
+		debugf("Failed to create request: %v", err)
+		return "", fmt.Errorf("failed to create request: %v", err)
+	}
+	req.Header.Set("Content-Type", writer.FormDataContentType())
+
+	bodyStr, err := sendLogUpload(req)
+	if err != nil {
+		return "", err
+	}
+
+	debugf("Upload successful, parsing response")
+
+	// The response links to the stored file relative to the server root,
+	// e.g. href="/logs/filename.log" for https://i.dylan.lol/logs/
+	basePath := "/"
+	if parsed, err := url.Parse(base); err == nil && parsed.Path != "" {
+		basePath = parsed.Path
+	}
+	re := regexp.MustCompile(`href="` + regexp.QuoteMeta(basePath) + `([^"]+\.log)"`)
+	matches := re.FindStringSubmatch(bodyStr)
+
+	var logURL string
+	if len(matches) > 1 {
+		// Extract the filename from the match
+		extractedFilename := matches[1]
+		// Construct the full URL
+		logURL = base + extractedFilename
+		debugf("Successfully extracted filename from HTML: %s", extractedFilename)
+	} else {
+		// If regex fails, fall back to using our random ID
+		debugf("Failed to extract filename from HTML, falling back to random ID: %s", randomID)
+		logURL = base + randomID + ".log"
+	}
+
+	debugf("Final log URL: %s", logURL)
+	return logURL, nil
+}
+
+// logUploadCurlCommand returns a curl command sending the same multipart request as
+// dylanLogUploader, for testing the upload endpoint by hand. logPath should be the copy
+// from writeUploadCopy, since curl sends the file exactly as it is.
+func logUploadCurlCommand(uploadURL, logPath, filename string) string {
+	args := []string{
+		"curl", "--tlsv1.2", "--tls-max", "1.2", "-A", shellQuote("TheBoysLauncher/1.0"),
+		"-F", shellQuote("act=bput"),
+		"-F", shellQuote(fmt.Sprintf("file=@%s;filename=%s;type=application/octet-stream", logPath, filename)),
+		shellQuote(uploadURL),
+	}
+	return strings.Join(args, " ")
+}
+
+// microBinUploader pastes the log as text into a MicroBin server, which answers
+// with an HTML page linking to the new paste
+type microBinUploader struct {
+	// BaseURL is the root of the MicroBin server, e.g. https://logs.example.com
+	BaseURL string
+}
+
+func (u microBinUploader) Host() string { return uploadHost(u.BaseURL) }
+
+func (u microBinUploader) Upload(logPath string) (string, error) {
+	base := strings.TrimRight(u.BaseURL, "/")
+	if base == "" {
+		return "", fmt.Errorf("the MicroBin provider needs logUploadUrl set in settings.json")
+	}
+
+	content, err := readLogForUpload(logPath)
+	if err != nil {
+		return "", err
+	}
+
+	form := url.Values{}
+	form.Set("expiration", "never")
+	form.Set("syntax_highlight", "none")
+	form.Set("privacy", "public")
+	form.Set("content", content)
+
+	req, err := http.NewRequest("POST", base+"/upload", strings.NewReader(form.Encode()))
+	if err != nil {
+		debugf("Failed to create request: %v", err)
+		return "", fmt.Errorf("failed to create request: %v", err)
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	bodyStr, err := sendLogUpload(req)
+	if err != nil {
+		return "", err
+	}
+
+	id := extractFileIDFromHTML(bodyStr, base)
+	if id == "" {
+		return "", fmt.Errorf("upload succeeded but %s did not say where the paste is", u.Host())
+	}
+	logURL := base + "/upload/" + id
+	debugf("Final log URL: %s", logURL)
+	return logURL, nil
+}
+
+// extractFileIDFromHTML extracts the paste ID from MicroBin's HTML response. The ID
+// appears in links like <base>/upload/mouse-tiger-fly or <base>/file/mouse-tiger-fly.
+func extractFileIDFromHTML(html, base string) string {
+	quoted := regexp.QuoteMeta(base)
+	patterns := []string{
+		// Absolute links, tried in the order MicroBin lists them
+		`href="` + quoted + `/upload/([^"]+)"`,
+		`href="` + quoted + `/file/([^"]+)"`,
+		`href="` + quoted + `/edit/([^"]+)"`,
+		// The copy-to-clipboard script
+		`const url = .*` + quoted + `/upload/([^"]+)`,
+		// Relative links, in case the format changes
+		`href="/upload/([^"]+)"`,
+		`href="/file/([^"]+)"`,
+	}
+	for _, pattern := range patterns {
+		if matches := regexp.MustCompile(pattern).FindStringSubmatch(html); len(matches) > 1 {
+			return matches[1]
+		}
+	}
+	return ""
+}
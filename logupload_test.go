@@ -0,0 +1,193 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// writeTestLog writes a latest.log for the upload tests
+func writeTestLog(t *testing.T, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "latest.log")
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatalf("Failed to write log: %v", err)
+	}
+	return path
+}
+
+// TestDylanLogUploader tests the multipart upload and reading the stored file's name from the reply
+func TestDylanLogUploader(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/logs/" {
+			http.Error(w, "unexpected request", http.StatusBadRequest)
+			return
+		}
+		if r.FormValue("act") != "bput" {
+			http.Error(w, "missing act", http.StatusBadRequest)
+			return
+		}
+		file, header, err := r.FormFile("file")
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		defer file.Close()
+		if data, _ := io.ReadAll(file); string(data) != "game crashed" || !strings.HasSuffix(header.Filename, ".log") {
+			http.Error(w, "unexpected file", http.StatusBadRequest)
+			return
+		}
+		fmt.Fprint(w, `<html><body><a href="/logs/stored.log">stored.log</a></body></html>`)
+	}))
+	defer server.Close()
+
+	uploader := dylanLogUploader{BaseURL: server.URL + "/logs"}
+	got, err := uploader.Upload(writeTestLog(t, "game crashed"))
+	if err != nil {
+		t.Fatalf("Upload failed: %v", err)
+	}
+	if want := server.URL + "/logs/stored.log"; got != want {
+		t.Errorf("Expected %s, got %s", want, got)
+	}
+}
+
+// TestMicroBinUploader tests the form upload and reading the paste ID from MicroBin's reply
+func TestMicroBinUploader(t *testing.T) {
+	var base string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/upload" {
+			http.Error(w, "unexpected request", http.StatusBadRequest)
+			return
+		}
+		if r.FormValue("content") != "game crashed" || r.FormValue("privacy") != "public" {
+			http.Error(w, "unexpected form", http.StatusBadRequest)
+			return
+		}
+		w.Header().Set("Content-Type", "text/html")
+		fmt.Fprintf(w, `<html><body><a href="%s/upload/mouse-tiger-fly">View</a><a href="%s/edit/mouse-tiger-fly">Edit</a></body></html>`, base, base)
+	}))
+	defer server.Close()
+	base = server.URL
+
+	uploader := microBinUploader{BaseURL: server.URL + "/"}
+	got, err := uploader.Upload(writeTestLog(t, "game crashed"))
+	if err != nil {
+		t.Fatalf("Upload failed: %v", err)
+	}
+	if want := server.URL + "/upload/mouse-tiger-fly"; got != want {
+		t.Errorf("Expected %s, got %s", want, got)
+	}
+
+	// A reply without a link is an error rather than a made-up URL
+	empty := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, "<html></html>")
+	}))
+	defer empty.Close()
+	if _, err := (microBinUploader{BaseURL: empty.URL}).Upload(writeTestLog(t, "x")); err == nil {
+		t.Error("Expected an error when the reply has no paste link")
+	}
+}
+
+// TestExtractFileIDFromHTML tests the absolute and relative MicroBin link formats
+func TestExtractFileIDFromHTML(t *testing.T) {
+	base := "https://logs.example.com"
+	for html, want := range map[string]string{
+		`<a href="https://logs.example.com/file/cat-dog">x</a>`: "cat-dog",
+		`<a href="/upload/owl-bee">x</a>`:                       "owl-bee",
+		`<a href="https://other.example.com/upload/nope">x</a>`: "",
+	} {
+		if got := extractFileIDFromHTML(html, base); got != want {
+			t.Errorf("extractFileIDFromHTML(%q) = %q, want %q", html, got, want)
+		}
+	}
+}
+
+// TestConfiguredLogUploader tests that settings pick the provider and that the default is unchanged
+func TestConfiguredLogUploader(t *testing.T) {
+	saved := settings
+	defer func() { settings = saved }()
+
+	settings.LogUploadProvider = ""
+	settings.LogUploadURL = ""
+	if got, ok := configuredLogUploader().(dylanLogUploader); !ok || got.BaseURL != logUploadURL {
+		t.Errorf("Expected the i.dylan.lol uploader by default, got %#v", configuredLogUploader())
+	}
+
+	settings.LogUploadProvider = "MicroBin"
+	settings.LogUploadURL = "https://logs.example.com"
+	if got, ok := configuredLogUploader().(microBinUploader); !ok || got.BaseURL != "https://logs.example.com" {
+		t.Errorf("Expected the MicroBin uploader, got %#v", configuredLogUploader())
+	}
+	if host := configuredLogUploader().Host(); host != "logs.example.com" {
+		t.Errorf("Expected the host to be shown, got %q", host)
+	}
+}
+
+// TestRedactLog tests that the home folder and username are scrubbed in every form they appear
+func TestRedactLog(t *testing.T) {
+	log := strings.Join([]string{
+		`Launcher home: C:\Users\Alice\.theboyslauncher`,
+		`JSON path: "c:\\users\\alice\\AppData\\Roaming"`,
+		`Forward slashes: C:/Users/Alice/.theboyslauncher/prism`,
+		`Signed in to Windows as ALICE`,
+		`Player 'alicePlays' joined`,
+	}, "\n")
+
+	got := redactLog(log, `C:\Users\Alice`, "Alice")
+	if strings.Contains(strings.ToLower(got), "users") || strings.Contains(got, "as ALICE") {
+		t.Errorf("Expected the home folder and username to be removed, got:\n%s", got)
+	}
+	for _, want := range []string{`~\.theboyslauncher`, `"~\\AppData\\Roaming"`, "~/.theboyslauncher/prism", "as <user>", "alicePlays"} {
+		if !strings.Contains(got, want) {
+			t.Errorf("Expected %q in the redacted log, got:\n%s", want, got)
+		}
+	}
+
+	unix := redactLog("/home/bob/.theboyslauncher/logs and bob's world", "/home/bob", "")
+	if unix != "~/.theboyslauncher/logs and <user>'s world" {
+		t.Errorf("Expected the username from the home folder to be used, got %q", unix)
+	}
+	if got := redactLog("jo joined", "", "jo"); got != "jo joined" {
+		t.Errorf("Expected very short usernames to be left alone, got %q", got)
+	}
+}
+
+// TestUploadRedactsLog tests that uploads only scrub the log while redaction is enabled
+func TestUploadRedactsLog(t *testing.T) {
+	saved := settings
+	defer func() { settings = saved }()
+	home, err := os.UserHomeDir()
+	if err != nil || len(home) < 2 {
+		t.Skip("no home directory to redact")
+	}
+	path := writeTestLog(t, "loaded "+filepath.Join(home, "mods"))
+
+	settings.RedactLogs = true
+	content, err := readLogForUpload(path)
+	if err != nil {
+		t.Fatalf("readLogForUpload failed: %v", err)
+	}
+	if strings.Contains(content, home) {
+		t.Errorf("Expected the home folder to be redacted, got %q", content)
+	}
+
+	// The copy a curl command uploads must be redacted too
+	t.Setenv("TMPDIR", t.TempDir())
+	copyPath, err := writeUploadCopy(path)
+	if err != nil {
+		t.Fatalf("writeUploadCopy failed: %v", err)
+	}
+	if data, _ := os.ReadFile(copyPath); strings.Contains(string(data), home) || !strings.Contains(string(data), "loaded") {
+		t.Errorf("Expected the upload copy to be redacted, got %q", data)
+	}
+
+	settings.RedactLogs = false
+	if content, _ = readLogForUpload(path); !strings.Contains(content, home) {
+		t.Errorf("Expected the log unchanged with redaction off, got %q", content)
+	}
+}
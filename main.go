@@ -9,7 +9,7 @@
 // - Creates instance in launcher home directory, writes instance.cfg (name/RAM/Java)
 // - Runs packwiz from the *instance root* (detects MultiMC/Prism mode)
 // - Console output + logs/latest.log (rotates to logs/previous.log)
-// - Optional cache-bust for the modpack URL: set THEBOYS_CACHEBUST=1
+// - Optional cache-bust for the modpack URL: set THEBOYS_CACHEBUST=1 or enable it in Settings
 // - Uses Fyne GUI for modpack selection and configuration
 // - Supports multiple modpacks via modpacks.json
 //
@@ -22,34 +22,88 @@
 package main
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"os"
 	"os/signal"
 	"runtime"
+	"strings"
 	"syscall"
 	"time"
 )
 
 func main() {
 	runtime.LockOSThread()
-	hideConsoleWindow()
+	os.Exit(run())
+}
+
+// run is the launcher proper. It returns the exit code instead of exiting, so
+// deferred cleanup such as closing the log still runs on failure.
+func run() int {
+	opts := parseOptions()
+	// Headless runs print their progress to the console they were started from
+	headless := opts.install || opts.noGUI
+
+	// A launcher folder change chosen in Settings moves the data before anything opens it
+	var movedFrom string
+	var moved int
+	var moveErr error
+	if !opts.cleanupAfterUpdate {
+		movedFrom, moved, moveErr = migrateLauncherHome()
+	}
+	keepConsole := headless || keepConsoleOpenSetting(getLauncherHome())
+	if !keepConsole {
+		hideConsoleWindow()
+	}
 
 	// Get executable path for potential use by GUI
 	exePath, _ := os.Executable()
 
-	opts := parseOptions()
+	noUpdateFlag = opts.noUpdate
+	offlineFlag = opts.offline
 
 	if opts.cleanupAfterUpdate {
 		// This is a cleanup run after an update
 		performUpdateCleanup(opts.cleanupOldExe, opts.cleanupNewExe)
-		return
+		return 0
 	}
 
 	// Platform check now handled by platform abstraction
 	// Windows hard block removed for cross-platform support
 
 	root := getLauncherHome()
+	if headless && opts.modpack == "" {
+		fmt.Fprintln(os.Stderr, "Error: --install and --no-gui need --modpack <id>")
+		return 2
+	}
+
+	// Catch a read-only launcher home here, where the user can understand it
+	if err := checkHomeWritable(root); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		showStartupError("Launcher folder is not writable", fmt.Sprintf(
+			"%s needs to create and change files in its data folder, but it can't:\n\n%v\n\n"+
+				"Make sure the folder is not read-only, is not locked by a sync or backup tool, "+
+				"and that your user account is allowed to modify it. Then start the launcher again.\n\nData folder: %s",
+			launcherName, err, root), root)
+		return 1
+	}
+
+	// JSON reports own stdout; log lines only go to latest.log
+	quietConsole = opts.jsonOutput && (opts.listModpacks || opts.printSettings || opts.doctor || opts.checkUpdate)
+
+	logFormat := opts.logFormat
+	if logFormat == "" {
+		logFormat = logFormatSetting(root)
+	}
+	switch strings.ToLower(logFormat) {
+	case "", logFormatPretty:
+	case logFormatJSON:
+		jsonLogs = true
+	default:
+		fmt.Fprintf(os.Stderr, "Error: unknown log format %q; use %q or %q\n", logFormat, logFormatPretty, logFormatJSON)
+		return 2
+	}
 
 	// Set up emergency crash logger BEFORE anything else that might crash
 	setupEmergencyCrashLogger(root)
@@ -58,14 +112,27 @@ func main() {
 	closeLog := setupLogging(root)
 	defer closeLog()
 
+	if moveErr != nil && moved == 0 {
+		logf("%s", warnLine(fmt.Sprintf("Failed to move the launcher folder, so %s is still in use: %v", root, moveErr)))
+	} else if moveErr != nil {
+		logf("%s", warnLine(fmt.Sprintf("Moved %d item(s) to %s, but the rest has to be moved from %s by hand: %v", moved, root, movedFrom, moveErr)))
+	} else if movedFrom != "" {
+		logf("%s", successLine(fmt.Sprintf("Moved the launcher folder from %s to %s", movedFrom, root)))
+	}
+
 	// Hide any console window that might have appeared during initialization
-	hideConsoleWindow()
+	if !keepConsole {
+		hideConsoleWindow()
+	}
 
 	// 1) Load settings
 	if err := loadSettings(root); err != nil {
 		logf("%s", warnLine(fmt.Sprintf("Failed to load settings: %v", err)))
 	} else {
 	}
+	if err := setLanguage(settings.Language); err != nil {
+		logf("%s", warnLine(fmt.Sprintf("Failed to load language %q, using English: %v", settings.Language, err)))
+	}
 
 	// Show beautiful welcome message
 	logf("\n%s", headerLine(launcherName))
@@ -76,10 +143,85 @@ func main() {
 	logf("%s", infoLine(fmt.Sprintf("Memory allocation: %d GB", settings.MemoryMB/1024)))
 	logf("%s", dividerLine())
 
-	modpacks := loadModpacks(root)
-	if len(modpacks) == 0 {
-		fail(errors.New("no modpacks configured"))
-	} else {
+	if opts.printSettings {
+		if err := runSettings(os.Stdout, opts.jsonOutput); err != nil {
+			return fail(err)
+		}
+		return 0
+	}
+
+	if opts.checkUpdate {
+		available, err := runCheckUpdate(os.Stdout, opts.jsonOutput)
+		if err != nil {
+			return fail(err)
+		}
+		if available {
+			return exitUpdateAvailable
+		}
+		return 0
+	}
+
+	if opts.rollback {
+		if err := runRollback(os.Stdout, root, exePath); err != nil {
+			return fail(err)
+		}
+		return 0
+	}
+
+	if opts.previewCatalog != "" {
+		modpacks, warnings, err := loadPreviewCatalog(opts.previewCatalog)
+		if err != nil {
+			return fail(err)
+		}
+		for _, warning := range warnings {
+			logf("%s", warnLine(warning))
+		}
+		logf("%s", infoLine(fmt.Sprintf("Previewing %d modpack(s) from %s; install and launch are disabled", len(modpacks), opts.previewCatalog)))
+		gui := NewGUI(modpacks, root)
+		gui.previewSource = opts.previewCatalog
+		gui.previewWarnings = warnings
+		gui.launchWithCallback(new(*os.Process), root, exePath)
+		return 0
+	}
+
+	modpacks, err := loadModpacks(root)
+	if err == nil && len(modpacks) == 0 {
+		err = errors.New("no modpacks configured")
+	}
+	if err != nil && (opts.listModpacks || opts.doctor || headless) {
+		return fail(err)
+	}
+	if err != nil {
+		logf("%s", warnLine(err.Error()))
+		showStartupError("Couldn't load the modpack list", fmt.Sprintf(
+			"%s couldn't download its modpack list and has no saved copy to fall back on:\n\n%v\n\n"+
+				"Check your internet connection and start the launcher again.",
+			launcherName, err), root)
+		return 1
+	}
+
+	if opts.listModpacks {
+		if err := runListModpacks(os.Stdout, root, modpacks, opts.jsonOutput); err != nil {
+			return fail(err)
+		}
+		return 0
+	}
+
+	if opts.doctor {
+		checks := runDoctor(root, modpacks)
+		report, ok := formatDoctorReport(checks)
+		if opts.jsonOutput {
+			if err := writeJSON(os.Stdout, checks); err != nil {
+				return fail(err)
+			}
+		} else {
+			logf("%s", sectionLine("Installation Health Check"))
+			logf("%s", report)
+		}
+		if !ok {
+			return 1
+		}
+		return 0
 	}
 
 	// Set up signal handling for force-closing Prism and Minecraft on launcher exit
@@ -89,14 +231,25 @@ func main() {
 
 	go func() {
 		<-c
+		// Kill every tracked instance, including ones reattached from a previous session
+		killRegisteredProcesses(loadedProcessRegistry())
 		// Use platform-specific process management
 		forceCloseAllProcesses(prismProcess)
 		os.Exit(1)
 	}()
 
+	if headless {
+		if err := runHeadless(context.Background(), os.Stdout, root, modpacks, opts.modpack, opts.install, &prismProcess); err != nil {
+			return fail(err)
+		}
+		markLauncherLaunched(root)
+		return 0
+	}
+
 	// Launch the GUI
 	logf("Starting modern GUI interface...")
 	gui := NewGUI(modpacks, root)
 
 	gui.launchWithCallback(&prismProcess, root, exePath)
+	return 0
 }
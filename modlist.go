@@ -0,0 +1,204 @@
+package main
+
+import (
+	"archive/zip"
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+)
+
+// -------------------- Mod list --------------------
+
+// modListCacheFile caches the parsed mod list inside the instance folder
+const modListCacheFile = ".mod-list.json"
+
+// modInfo is one mod jar as described by its own metadata
+type modInfo struct {
+	Name    string `json:"name"`
+	ID      string `json:"id,omitempty"`
+	Version string `json:"version,omitempty"`
+	Loader  string `json:"loader,omitempty"`
+	File    string `json:"file"`
+}
+
+// modListCache is the mod list of one pack version. Jars counts the files it was read
+// from, so mods added or removed by hand invalidate it.
+type modListCache struct {
+	PackVersion string    `json:"packVersion"`
+	Jars        int       `json:"jars"`
+	Mods        []modInfo `json:"mods"`
+}
+
+// loadModList returns the mods installed in instDir, reading them from the cache when
+// it was built for packVersion and parsing every jar otherwise
+func loadModList(instDir, packVersion string) ([]modInfo, error) {
+	modsDir := filepath.Join(instDir, "minecraft", "mods")
+	jars, err := filepath.Glob(filepath.Join(modsDir, "*.jar"))
+	if err != nil {
+		return nil, err
+	}
+
+	cachePath := filepath.Join(instDir, modListCacheFile)
+	if packVersion != "" {
+		var cache modListCache
+		if data, err := os.ReadFile(cachePath); err == nil && json.Unmarshal(data, &cache) == nil &&
+			cache.PackVersion == packVersion && cache.Jars == len(jars) {
+			return cache.Mods, nil
+		}
+	}
+
+	mods := make([]modInfo, 0, len(jars))
+	for _, jar := range jars {
+		mod, err := readModJar(jar)
+		if err != nil {
+			debugf("Could not read mod metadata from %s: %v", filepath.Base(jar), err)
+		}
+		mods = append(mods, mod)
+	}
+	sort.Slice(mods, func(i, j int) bool {
+		return strings.ToLower(mods[i].Name) < strings.ToLower(mods[j].Name)
+	})
+
+	if packVersion != "" {
+		data, err := json.MarshalIndent(modListCache{PackVersion: packVersion, Jars: len(jars), Mods: mods}, "", "  ")
+		if err == nil {
+			err = os.WriteFile(cachePath, data, 0644)
+		}
+		if err != nil {
+			logf("%s", warnLine(fmt.Sprintf("Failed to cache mod list: %v", err)))
+		}
+	}
+	return mods, nil
+}
+
+// readModJar describes the mod in jarPath from its fabric.mod.json, quilt.mod.json or
+// mods.toml. Jars without readable metadata are still listed, by file name.
+func readModJar(jarPath string) (modInfo, error) {
+	file := filepath.Base(jarPath)
+	mod := modInfo{Name: strings.TrimSuffix(file, ".jar"), File: file}
+
+	r, err := zip.OpenReader(jarPath)
+	if err != nil {
+		return mod, err
+	}
+	defer r.Close()
+
+	files := make(map[string]*zip.File, len(r.File))
+	for _, f := range r.File {
+		files[f.Name] = f
+	}
+
+	switch {
+	case files["fabric.mod.json"] != nil:
+		var meta struct {
+			ID      string `json:"id"`
+			Name    string `json:"name"`
+			Version string `json:"version"`
+		}
+		if err := readZipJSON(files["fabric.mod.json"], &meta); err != nil {
+			return mod, err
+		}
+		mod.ID, mod.Version, mod.Loader = meta.ID, meta.Version, "fabric"
+		mod.Name = firstNonEmpty(meta.Name, meta.ID, mod.Name)
+	case files["quilt.mod.json"] != nil:
+		var meta struct {
+			QuiltLoader struct {
+				ID       string `json:"id"`
+				Version  string `json:"version"`
+				Metadata struct {
+					Name string `json:"name"`
+				} `json:"metadata"`
+			} `json:"quilt_loader"`
+		}
+		if err := readZipJSON(files["quilt.mod.json"], &meta); err != nil {
+			return mod, err
+		}
+		ql := meta.QuiltLoader
+		mod.ID, mod.Version, mod.Loader = ql.ID, ql.Version, "quilt"
+		mod.Name = firstNonEmpty(ql.Metadata.Name, ql.ID, mod.Name)
+	case files["META-INF/neoforge.mods.toml"] != nil || files["META-INF/mods.toml"] != nil:
+		loader, tomlFile := "forge", files["META-INF/mods.toml"]
+		if f := files["META-INF/neoforge.mods.toml"]; f != nil {
+			loader, tomlFile = "neoforge", f
+		}
+		data, err := readZipFile(tomlFile)
+		if err != nil {
+			return mod, err
+		}
+		var meta struct {
+			Mods []struct {
+				ModID       string `toml:"modId"`
+				Version     string `toml:"version"`
+				DisplayName string `toml:"displayName"`
+			} `toml:"mods"`
+		}
+		if err := toml.Unmarshal(data, &meta); err != nil {
+			return mod, err
+		}
+		mod.Loader = loader
+		if len(meta.Mods) > 0 {
+			first := meta.Mods[0]
+			mod.ID, mod.Version = first.ModID, first.Version
+			mod.Name = firstNonEmpty(first.DisplayName, first.ModID, mod.Name)
+		}
+		// Forge fills this placeholder in from the jar's manifest at runtime
+		if strings.Contains(mod.Version, "${file.jarVersion}") {
+			mod.Version = manifestVersion(files["META-INF/MANIFEST.MF"])
+		}
+	}
+	return mod, nil
+}
+
+// readZipFile reads all of f
+func readZipFile(f *zip.File) ([]byte, error) {
+	rc, err := f.Open()
+	if err != nil {
+		return nil, err
+	}
+	defer rc.Close()
+	return io.ReadAll(rc)
+}
+
+// readZipJSON decodes the JSON file f into v
+func readZipJSON(f *zip.File, v any) error {
+	data, err := readZipFile(f)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, v)
+}
+
+// manifestVersion returns the Implementation-Version of a jar manifest, or "" without one
+func manifestVersion(f *zip.File) string {
+	if f == nil {
+		return ""
+	}
+	data, err := readZipFile(f)
+	if err != nil {
+		return ""
+	}
+	scanner := bufio.NewScanner(strings.NewReader(string(data)))
+	for scanner.Scan() {
+		if version, ok := strings.CutPrefix(scanner.Text(), "Implementation-Version:"); ok {
+			return strings.TrimSpace(version)
+		}
+	}
+	return ""
+}
+
+// firstNonEmpty returns the first of values that isn't blank
+func firstNonEmpty(values ...string) string {
+	for _, v := range values {
+		if strings.TrimSpace(v) != "" {
+			return strings.TrimSpace(v)
+		}
+	}
+	return ""
+}
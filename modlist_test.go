@@ -0,0 +1,77 @@
+package main
+
+import (
+	"archive/zip"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// writeTestJar writes a mod jar holding files
+func writeTestJar(t *testing.T, path string, files map[string]string) {
+	t.Helper()
+	f, err := os.Create(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	zw := zip.NewWriter(f)
+	for name, content := range files {
+		w, err := zw.Create(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		w.Write([]byte(content))
+	}
+	if err := zw.Close(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// TestLoadModList tests reading Fabric and Forge metadata and reusing the cache for the same pack version
+func TestLoadModList(t *testing.T) {
+	instDir := t.TempDir()
+	modsDir := filepath.Join(instDir, "minecraft", "mods")
+	if err := os.MkdirAll(modsDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	writeTestJar(t, filepath.Join(modsDir, "sodium.jar"), map[string]string{
+		"fabric.mod.json": `{"id":"sodium","name":"Sodium","version":"0.5.8"}`,
+	})
+	writeTestJar(t, filepath.Join(modsDir, "jei.jar"), map[string]string{
+		"META-INF/mods.toml":   "modLoader=\"javafml\"\n[[mods]]\nmodId=\"jei\"\nversion=\"${file.jarVersion}\"\ndisplayName=\"Just Enough Items\"\n",
+		"META-INF/MANIFEST.MF": "Manifest-Version: 1.0\r\nImplementation-Version: 15.3.0\r\n",
+	})
+	if err := os.WriteFile(filepath.Join(modsDir, "broken.jar"), []byte("not a zip"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	mods, err := loadModList(instDir, "1.0.0")
+	if err != nil {
+		t.Fatalf("loadModList failed: %v", err)
+	}
+	want := []modInfo{
+		{Name: "broken", File: "broken.jar"},
+		{Name: "Just Enough Items", ID: "jei", Version: "15.3.0", Loader: "forge", File: "jei.jar"},
+		{Name: "Sodium", ID: "sodium", Version: "0.5.8", Loader: "fabric", File: "sodium.jar"},
+	}
+	if len(mods) != len(want) {
+		t.Fatalf("Expected %d mods, got %+v", len(want), mods)
+	}
+	for i := range want {
+		if mods[i] != want[i] {
+			t.Errorf("Expected %+v, got %+v", want[i], mods[i])
+		}
+	}
+
+	// The same pack version is served from the cache, even if a jar changed underneath
+	writeTestJar(t, filepath.Join(modsDir, "sodium.jar"), map[string]string{
+		"fabric.mod.json": `{"id":"sodium","name":"Sodium","version":"0.6.0"}`,
+	})
+	if mods, _ := loadModList(instDir, "1.0.0"); mods[2].Version != "0.5.8" {
+		t.Errorf("Expected the cached mod list, got %+v", mods[2])
+	}
+	if mods, _ := loadModList(instDir, "1.1.0"); mods[2].Version != "0.6.0" {
+		t.Errorf("Expected a new pack version to parse the jars again, got %+v", mods[2])
+	}
+}
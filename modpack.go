@@ -1,34 +1,211 @@
 package main
 
 import (
-	"context"
+	"bytes"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
 	"net/http"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
 	"strings"
 	"time"
 )
 
-func loadModpacks(root string) []Modpack {
-	remote, err := fetchRemoteModpacks(remoteModpacksURL, 30*time.Second)
+// catalogSchemaVersion is the modpacks.json format this launcher writes and understands.
+// Version 0 is the original bare JSON array; version 1 wraps it as
+// {"schemaVersion": 1, "modpacks": [...]}.
+const catalogSchemaVersion = 1
+
+// modpackCatalog is the versioned on-disk/remote catalog format
+type modpackCatalog struct {
+	SchemaVersion int       `json:"schemaVersion"`
+	Modpacks      []Modpack `json:"modpacks"`
+}
+
+// loadModpacks fetches the remote catalog, falling back to the copy saved by the
+// last successful fetch so an outage doesn't keep installed packs from launching.
+// Offline mode goes straight to the saved copy. The user's custom packs are added
+// after the catalog packs either way.
+func loadModpacks(root string) ([]Modpack, error) {
+	if offlineMode() {
+		cached, err := loadCachedModpacks(root)
+		if err != nil {
+			return nil, fmt.Errorf("offline mode needs a saved modpack catalog: %w", err)
+		}
+		logf("%s", infoLine(fmt.Sprintf("Offline mode: using the saved catalog of %d modpack(s)", len(cached))))
+		updateDefaultModpackID(cached)
+		return withCustomModpacks(root, cached), nil
+	}
+
+	normalized, err := fetchModpackCatalog(root)
 	if err != nil {
-		fail(fmt.Errorf("failed to fetch remote modpacks.json: %w", err))
+		cached, cacheErr := loadCachedModpacks(root)
+		if cacheErr != nil {
+			return nil, err
+		}
+		logf("%s", warnLine(fmt.Sprintf("%v; using the saved modpack catalog", err)))
+		updateDefaultModpackID(cached)
+		return withCustomModpacks(root, cached), nil
 	}
 
-	if len(remote) == 0 {
-		fail(errors.New("remote modpacks.json returned no modpacks"))
+	if err := saveModpackCatalog(root, normalized); err != nil {
+		logf("%s", warnLine(fmt.Sprintf("Failed to save local modpack catalog: %v", err)))
 	}
 
-	normalized := normalizeModpacks(remote)
+	logf("Loaded %d modpack(s) from %d catalog(s)", len(normalized), len(catalogSources()))
+	updateDefaultModpackID(normalized)
+	return withCustomModpacks(root, normalized), nil
+}
+
+// fetchModpackCatalog downloads every catalog source and merges them. A source that
+// can't be reached contributes the packs it had in the saved catalog instead, so an
+// outage of one add-on catalog doesn't hide its installed packs.
+func fetchModpackCatalog(root string) ([]Modpack, error) {
+	return mergeCatalogs(root, catalogSources())
+}
+
+// mergeCatalogs fetches sources in order and normalizes the combined list, so a pack
+// ID in a later source replaces the same ID from an earlier one
+func mergeCatalogs(root string, sources []string) ([]Modpack, error) {
+	var merged []Modpack
+	var firstErr error
+	reached := 0
+	var cached []Modpack
+	cacheLoaded := false
+	for _, source := range sources {
+		remote, err := fetchRemoteModpacks(source, 30*time.Second)
+		if err == nil && len(remote) == 0 {
+			err = errors.New("no modpacks returned")
+		}
+		if err != nil {
+			if firstErr == nil {
+				firstErr = fmt.Errorf("failed to fetch modpacks.json from %s: %w", source, err)
+			}
+			if !cacheLoaded {
+				cached, _ = loadCachedModpacks(root)
+				cacheLoaded = true
+			}
+			kept := 0
+			for _, mp := range cached {
+				if catalogSourceOf(mp) == source {
+					merged = append(merged, mp)
+					kept++
+				}
+			}
+			logf("%s", warnLine(fmt.Sprintf("Catalog %s unavailable (%v); keeping %d saved modpack(s) from it", source, err, kept)))
+			continue
+		}
+		reached++
+		for i := range remote {
+			remote[i].Source = source
+		}
+		merged = append(merged, remote...)
+	}
+	if reached == 0 {
+		return nil, firstErr
+	}
+
+	normalized := normalizeModpacks(merged)
 	if len(normalized) == 0 {
-		fail(errors.New("remote modpacks.json did not contain any valid modpacks"))
+		return nil, errors.New("remote modpacks.json did not contain any valid modpacks")
 	}
+	return normalized, nil
+}
 
-	logf("Loaded %d modpack(s) from remote catalog", len(normalized))
-	updateDefaultModpackID(normalized)
-	return normalized
+// catalogSources lists the official catalog followed by the user's extra catalogs,
+// in the order they are merged
+func catalogSources() []string {
+	sources := []string{remoteModpacksURL}
+	seen := map[string]bool{remoteModpacksURL: true}
+	for _, u := range settings.CatalogURLs {
+		u = strings.TrimSpace(u)
+		if u == "" || seen[u] {
+			continue
+		}
+		seen[u] = true
+		sources = append(sources, u)
+	}
+	return sources
+}
+
+// catalogSourceOf returns the catalog a pack came from; packs saved before sources
+// were recorded came from the official catalog
+func catalogSourceOf(mp Modpack) string {
+	if mp.Source == "" {
+		return remoteModpacksURL
+	}
+	return mp.Source
+}
+
+// loadCachedModpacks reads the catalog written by saveModpackCatalog
+func loadCachedModpacks(root string) ([]Modpack, error) {
+	data, err := os.ReadFile(filepath.Join(root, "modpacks.json"))
+	if err != nil {
+		return nil, err
+	}
+	mods, err := parseModpackCatalog(data)
+	if err != nil {
+		return nil, err
+	}
+	normalized := normalizeModpacks(mods)
+	if len(normalized) == 0 {
+		return nil, errors.New("saved modpacks.json did not contain any valid modpacks")
+	}
+	return normalized, nil
+}
+
+// parseModpackCatalog decodes either catalog format and migrates it to the current schema
+func parseModpackCatalog(body []byte) ([]Modpack, error) {
+	trimmed := bytes.TrimSpace(body)
+	if len(trimmed) > 0 && trimmed[0] == '[' {
+		var mods []Modpack
+		if err := json.Unmarshal(trimmed, &mods); err != nil {
+			return nil, err
+		}
+		return migrateModpacks(0, mods), nil
+	}
+
+	var catalog modpackCatalog
+	if err := json.Unmarshal(trimmed, &catalog); err != nil {
+		return nil, err
+	}
+	return migrateModpacks(catalog.SchemaVersion, catalog.Modpacks), nil
+}
+
+// migrateModpacks upgrades entries from an older catalog schema in place.
+// Each step only fills in what that version lacked; normalizeModpacks applies the remaining defaults.
+func migrateModpacks(fromVersion int, mods []Modpack) []Modpack {
+	if fromVersion > catalogSchemaVersion {
+		logf("%s", warnLine(fmt.Sprintf("Modpack catalog uses schema version %d (launcher supports %d); unknown fields will be ignored", fromVersion, catalogSchemaVersion)))
+		return mods
+	}
+
+	if fromVersion < 1 {
+		// Version 0 catalogs only marked the featured pack via the legacy "default" flag
+		for i := range mods {
+			if mods[i].Default && mods[i].Category == "" {
+				mods[i].Category = "featured"
+			}
+		}
+	}
+
+	return mods
+}
+
+// saveModpackCatalog writes the migrated catalog to the launcher home in the current schema
+func saveModpackCatalog(root string, mods []Modpack) error {
+	data, err := json.MarshalIndent(modpackCatalog{
+		SchemaVersion: catalogSchemaVersion,
+		Modpacks:      mods,
+	}, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(filepath.Join(root, "modpacks.json"), data, 0644)
 }
 
 func updateDefaultModpackID(modpacks []Modpack) {
@@ -45,16 +222,28 @@ func updateDefaultModpackID(modpacks []Modpack) {
 }
 
 func fetchRemoteModpacks(url string, timeout time.Duration) ([]Modpack, error) {
-	ctx, cancel := context.WithTimeout(context.Background(), timeout)
-	defer cancel()
+	body, err := fetchCatalogBody(url, timeout)
+	if err != nil {
+		return nil, err
+	}
 
-	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
+	mods, err := parseModpackCatalog(body)
+	if err != nil {
+		return nil, err
+	}
+
+	return normalizeModpacks(mods), nil
+}
+
+// fetchCatalogBody downloads a catalog without parsing it
+func fetchCatalogBody(url string, timeout time.Duration) ([]byte, error) {
+	req, err := http.NewRequest("GET", url, nil)
 	if err != nil {
 		return nil, err
 	}
 	req.Header.Set("User-Agent", getUserAgent("Launcher"))
 
-	resp, err := http.DefaultClient.Do(req)
+	resp, err := doWithRetry(req, httpRetryAttempts(), timeout)
 	if err != nil {
 		return nil, err
 	}
@@ -64,17 +253,102 @@ func fetchRemoteModpacks(url string, timeout time.Duration) ([]Modpack, error) {
 		return nil, fmt.Errorf("unexpected HTTP status %d", resp.StatusCode)
 	}
 
-	body, err := io.ReadAll(resp.Body)
+	return io.ReadAll(resp.Body)
+}
+
+// loadPreviewCatalog reads a catalog from a file or URL for --preview-catalog and
+// returns it normalized, along with everything a catalog maintainer should fix.
+// Nothing is saved, so the live catalog is left alone.
+func loadPreviewCatalog(source string) ([]Modpack, []string, error) {
+	var body []byte
+	var err error
+	if strings.HasPrefix(source, "http://") || strings.HasPrefix(source, "https://") {
+		body, err = fetchCatalogBody(source, 30*time.Second)
+	} else {
+		body, err = os.ReadFile(source)
+	}
 	if err != nil {
-		return nil, err
+		return nil, nil, fmt.Errorf("failed to read catalog %s: %w", source, err)
 	}
 
-	var mods []Modpack
-	if err := json.Unmarshal(body, &mods); err != nil {
-		return nil, err
+	raw, err := parseModpackCatalog(body)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to parse catalog %s: %w", source, err)
 	}
+	warnings := catalogWarnings(raw)
+	normalized := normalizeModpacks(raw)
+	if len(normalized) == 0 {
+		return nil, warnings, fmt.Errorf("catalog %s did not contain any valid modpacks", source)
+	}
+	return normalized, warnings, nil
+}
 
-	return normalizeModpacks(mods), nil
+// catalogWarnings lists problems in raw catalog entries that normalizeModpacks
+// would otherwise fix up or drop without telling anyone
+func catalogWarnings(mods []Modpack) []string {
+	var warnings []string
+	seenIDs := make(map[string]bool, len(mods))
+	seenInstances := make(map[string]string, len(mods))
+	for i, mp := range mods {
+		id := strings.TrimSpace(mp.ID)
+		label := fmt.Sprintf("Entry %d", i+1)
+		if id != "" {
+			label = fmt.Sprintf("Entry %d (%s)", i+1, id)
+		}
+
+		var missing []string
+		if id == "" {
+			missing = append(missing, "id")
+		}
+		if strings.TrimSpace(mp.PackURL) == "" {
+			missing = append(missing, "packUrl")
+		}
+		if strings.TrimSpace(mp.InstanceName) == "" {
+			missing = append(missing, "instanceName")
+		}
+		if len(missing) > 0 {
+			warnings = append(warnings, fmt.Sprintf("%s is missing %s and will be skipped", label, strings.Join(missing, ", ")))
+			continue
+		}
+
+		key := strings.ToLower(id)
+		if seenIDs[key] {
+			warnings = append(warnings, fmt.Sprintf("%s reuses an earlier id and will replace that entry", label))
+		}
+		seenIDs[key] = true
+
+		instanceKey := strings.ToLower(strings.TrimSpace(mp.InstanceName))
+		if other, ok := seenInstances[instanceKey]; ok && other != key {
+			warnings = append(warnings, fmt.Sprintf("%s uses the same instanceName as %s and will be renamed", label, other))
+		} else {
+			seenInstances[instanceKey] = key
+		}
+
+		packURL := strings.TrimSpace(mp.PackURL)
+		if !strings.HasPrefix(packURL, "https://") && !strings.HasPrefix(packURL, "http://") {
+			warnings = append(warnings, fmt.Sprintf("%s has a packUrl that is not an http(s) URL: %s", label, packURL))
+		}
+		if strings.TrimSpace(mp.DisplayName) == "" {
+			warnings = append(warnings, fmt.Sprintf("%s has no displayName; the id will be shown instead", label))
+		}
+		if mp.MinRam > 0 && mp.RecommendedRam > 0 && mp.MinRam > mp.RecommendedRam {
+			warnings = append(warnings, fmt.Sprintf("%s has minRam (%d) above recommendedRam (%d)", label, mp.MinRam, mp.RecommendedRam))
+		}
+		var invalid []string
+		for name := range mp.EnvVars {
+			if !envVarNamePattern.MatchString(name) {
+				invalid = append(invalid, fmt.Sprintf("%s has an invalid environment variable name %q", label, name))
+			}
+		}
+		for name := range mp.Headers {
+			if !headerNamePattern.MatchString(name) {
+				invalid = append(invalid, fmt.Sprintf("%s has an invalid header name %q", label, name))
+			}
+		}
+		sort.Strings(invalid)
+		warnings = append(warnings, invalid...)
+	}
+	return warnings
 }
 
 func normalizeModpacks(mods []Modpack) []Modpack {
@@ -94,6 +368,11 @@ func normalizeModpacks(mods []Modpack) []Modpack {
 			continue
 		}
 
+		// A user-chosen instance name takes precedence over the catalog's
+		if custom := customInstanceName(id); custom != "" {
+			instance = custom
+		}
+
 		display := strings.TrimSpace(raw.DisplayName)
 		if display == "" {
 			display = id
@@ -142,8 +421,17 @@ func normalizeModpacks(mods []Modpack) []Modpack {
 			MinRam:         raw.MinRam,
 			RecommendedRam: raw.RecommendedRam,
 			Changelog:      raw.Changelog,
+			EnvVars:        validEnvVars(id, raw.EnvVars),
+			SupportedOS:    normalizePlatformList(raw.SupportedOS),
+			SupportedArch:  normalizePlatformList(raw.SupportedArch),
+			Headers:        validHeaders(id, raw.Headers),
+			SizeBytes:      raw.SizeBytes,
+			Source:         strings.TrimSpace(raw.Source),
+			LocalPack:      raw.LocalPack,
 			Default:        raw.Default,
 		}
+		// The user's memory override lives in settings, like custom instance names
+		entry.OverrideMemoryMB = settings.MemoryOverrides[strings.ToLower(id)]
 
 		key := strings.ToLower(id)
 		if idx, ok := index[key]; ok {
@@ -154,5 +442,133 @@ func normalizeModpacks(mods []Modpack) []Modpack {
 		}
 	}
 
+	disambiguateInstanceNames(normalized)
 	return normalized
 }
+
+// envVarNamePattern matches names that are portable across Windows, macOS and Linux
+var envVarNamePattern = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// validEnvVars drops environment overrides whose names can't be passed to a process
+func validEnvVars(id string, vars map[string]string) map[string]string {
+	if len(vars) == 0 {
+		return nil
+	}
+	valid := make(map[string]string, len(vars))
+	for key, value := range vars {
+		if !envVarNamePattern.MatchString(key) {
+			logf("%s", warnLine(fmt.Sprintf("Modpack %s: ignoring invalid environment variable name %q", id, key)))
+			continue
+		}
+		valid[key] = value
+	}
+	return valid
+}
+
+// headerNamePattern matches valid HTTP header field names
+var headerNamePattern = regexp.MustCompile("^[!#$%&'*+.^_`|~0-9A-Za-z-]+$")
+
+// validHeaders drops custom headers whose names can't be sent over HTTP
+func validHeaders(id string, headers map[string]string) map[string]string {
+	if len(headers) == 0 {
+		return nil
+	}
+	valid := make(map[string]string, len(headers))
+	for name, value := range headers {
+		if !headerNamePattern.MatchString(name) {
+			logf("%s", warnLine(fmt.Sprintf("Modpack %s: ignoring invalid header name %q", id, name)))
+			continue
+		}
+		valid[name] = value
+	}
+	return valid
+}
+
+// normalizePlatformList lowercases platform names and drops blank entries
+func normalizePlatformList(names []string) []string {
+	var out []string
+	for _, name := range names {
+		if name = strings.ToLower(strings.TrimSpace(name)); name != "" {
+			out = append(out, name)
+		}
+	}
+	return out
+}
+
+// supportsPlatform reports whether the modpack can be installed on the given OS and architecture
+func supportsPlatform(mod Modpack, goos, goarch string) bool {
+	return platformListAllows(mod.SupportedOS, goos) && platformListAllows(mod.SupportedArch, goarch)
+}
+
+func platformListAllows(list []string, name string) bool {
+	if len(list) == 0 {
+		return true
+	}
+	for _, entry := range list {
+		if strings.EqualFold(entry, name) {
+			return true
+		}
+	}
+	return false
+}
+
+// disambiguateInstanceNames makes sure no two modpacks share an instance directory.
+// Of the modpacks sharing an InstanceName, the one with the lowest ID keeps it and the
+// rest get their ID appended, so reordering the catalog never moves a pack's folder.
+// Names are compared case-insensitively since Windows and macOS filesystems are.
+func disambiguateInstanceNames(mods []Modpack) {
+	seen := make(map[string]string, len(mods))
+	order := make([]int, len(mods))
+	for i, mp := range mods {
+		seen[strings.ToLower(mp.InstanceName)] = ""
+		order[i] = i
+	}
+	sort.Slice(order, func(a, b int) bool {
+		return strings.ToLower(mods[order[a]].ID) < strings.ToLower(mods[order[b]].ID)
+	})
+	for _, i := range order {
+		key := strings.ToLower(mods[i].InstanceName)
+		owner, taken := seen[key]
+		if !taken || owner == "" {
+			seen[key] = mods[i].ID
+			continue
+		}
+
+		renamed := mods[i].InstanceName + "-" + slugifyID(mods[i].ID)
+		for n := 2; ; n++ {
+			if _, clash := seen[strings.ToLower(renamed)]; !clash {
+				break
+			}
+			renamed = fmt.Sprintf("%s-%s-%d", mods[i].InstanceName, slugifyID(mods[i].ID), n)
+		}
+		logf("%s", warnLine(fmt.Sprintf("Modpack %s shares instance name %q with %s; using %q instead", mods[i].ID, mods[i].InstanceName, owner, renamed)))
+		mods[i].InstanceName = renamed
+		seen[strings.ToLower(renamed)] = mods[i].ID
+	}
+}
+
+// catalogTags returns every tag used in the catalog, lowercased and sorted
+func catalogTags(mods []Modpack) []string {
+	seen := make(map[string]bool)
+	var tags []string
+	for _, mp := range mods {
+		for _, tag := range mp.Tags {
+			tag = strings.ToLower(strings.TrimSpace(tag))
+			if tag == "" || seen[tag] {
+				continue
+			}
+			seen[tag] = true
+			tags = append(tags, tag)
+		}
+	}
+	sort.Strings(tags)
+	return tags
+}
+
+// sortByLastPlayed orders modpacks most recently played first.
+// Packs that were never played keep their catalog order at the end.
+func sortByLastPlayed(mods []Modpack) {
+	sort.SliceStable(mods, func(i, j int) bool {
+		return lastPlayedAt(mods[i].ID).After(lastPlayedAt(mods[j].ID))
+	})
+}
@@ -0,0 +1,304 @@
+package main
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+// TestNormalizeModpacksDuplicateInstanceNames tests that colliding instance names are disambiguated
+func TestNormalizeModpacksDuplicateInstanceNames(t *testing.T) {
+	mods := normalizeModpacks([]Modpack{
+		{ID: "alpha", PackURL: "https://example.com/a/pack.toml", InstanceName: "Shared"},
+		{ID: "beta", PackURL: "https://example.com/b/pack.toml", InstanceName: "shared"},
+		{ID: "gamma", PackURL: "https://example.com/c/pack.toml", InstanceName: "Unique"},
+	})
+
+	if len(mods) != 3 {
+		t.Fatalf("Expected 3 modpacks, got %d", len(mods))
+	}
+	if mods[0].InstanceName != "Shared" {
+		t.Errorf("Expected first entry to keep its instance name, got %q", mods[0].InstanceName)
+	}
+	if mods[1].InstanceName != "shared-beta" {
+		t.Errorf("Expected colliding entry to be renamed to %q, got %q", "shared-beta", mods[1].InstanceName)
+	}
+	if mods[2].InstanceName != "Unique" {
+		t.Errorf("Expected unique entry to be unchanged, got %q", mods[2].InstanceName)
+	}
+
+	seen := make(map[string]bool)
+	for _, mp := range mods {
+		key := strings.ToLower(mp.InstanceName)
+		if seen[key] {
+			t.Errorf("Instance name %q is used more than once", mp.InstanceName)
+		}
+		seen[key] = true
+	}
+
+	// The same catalog in another order must not move a pack to another folder
+	reordered := normalizeModpacks([]Modpack{
+		{ID: "beta", PackURL: "https://example.com/b/pack.toml", InstanceName: "shared"},
+		{ID: "alpha", PackURL: "https://example.com/a/pack.toml", InstanceName: "Shared"},
+	})
+	if len(reordered) != 2 || reordered[0].InstanceName != "shared-beta" || reordered[1].InstanceName != "Shared" {
+		t.Errorf("Expected names to follow the modpack IDs rather than catalog order, got %+v", reordered)
+	}
+}
+
+// TestNormalizeModpacksInstanceNameOverride tests that user-chosen instance names are applied
+func TestNormalizeModpacksInstanceNameOverride(t *testing.T) {
+	saved := settings
+	defer func() { settings = saved }()
+	settings.InstanceNames = map[string]string{"alpha": "My Alpha"}
+
+	mods := normalizeModpacks([]Modpack{
+		{ID: "Alpha", PackURL: "https://example.com/a/pack.toml", InstanceName: "Alpha"},
+	})
+
+	if len(mods) != 1 || mods[0].InstanceName != "My Alpha" {
+		t.Errorf("Expected instance name override to apply, got %+v", mods)
+	}
+}
+
+// TestParseModpackCatalog tests that both the legacy array and the versioned catalog formats load
+func TestParseModpackCatalog(t *testing.T) {
+	legacy := `[{"id": "alpha", "packUrl": "https://example.com/a/pack.toml", "instanceName": "Alpha", "default": true}]`
+	versioned := `{"schemaVersion": 1, "modpacks": [{"id": "alpha", "packUrl": "https://example.com/a/pack.toml", "instanceName": "Alpha", "default": true}]}`
+
+	legacyMods, err := parseModpackCatalog([]byte(legacy))
+	if err != nil {
+		t.Fatalf("Failed to parse legacy catalog: %v", err)
+	}
+	if len(legacyMods) != 1 || legacyMods[0].Category != "featured" {
+		t.Errorf("Expected legacy default pack to migrate into the featured category, got %+v", legacyMods)
+	}
+
+	versionedMods, err := parseModpackCatalog([]byte(versioned))
+	if err != nil {
+		t.Fatalf("Failed to parse versioned catalog: %v", err)
+	}
+	if len(versionedMods) != 1 || versionedMods[0].ID != "alpha" {
+		t.Errorf("Expected one modpack from versioned catalog, got %+v", versionedMods)
+	}
+}
+
+// TestSortByLastPlayed tests that recently played packs come first and unplayed packs keep their order
+func TestSortByLastPlayed(t *testing.T) {
+	saved := settings
+	defer func() { settings = saved }()
+	now := time.Now()
+	settings.LastPlayed = map[string]time.Time{
+		"alpha": now.Add(-48 * time.Hour),
+		"gamma": now.Add(-time.Hour),
+	}
+
+	mods := []Modpack{{ID: "alpha"}, {ID: "beta"}, {ID: "Gamma"}, {ID: "delta"}}
+	sortByLastPlayed(mods)
+
+	var got []string
+	for _, mp := range mods {
+		got = append(got, mp.ID)
+	}
+	if strings.Join(got, ",") != "Gamma,alpha,beta,delta" {
+		t.Errorf("Expected order Gamma,alpha,beta,delta, got %s", strings.Join(got, ","))
+	}
+}
+
+// TestModpackEnvVars tests that invalid override names are dropped and overrides win over defaults
+func TestModpackEnvVars(t *testing.T) {
+	mods := normalizeModpacks([]Modpack{{
+		ID:           "alpha",
+		PackURL:      "https://example.com/a/pack.toml",
+		InstanceName: "Alpha",
+		EnvVars: map[string]string{
+			"MESA_GL_VERSION_OVERRIDE": "4.6",
+			"JAVA_HOME":                "/custom/java",
+			"BAD NAME":                 "x",
+			"1STARTS_WITH_DIGIT":       "x",
+		},
+	}})
+	if len(mods) != 1 {
+		t.Fatalf("Expected 1 modpack, got %d", len(mods))
+	}
+	if len(mods[0].EnvVars) != 2 {
+		t.Errorf("Expected only the 2 valid env vars to be kept, got %v", mods[0].EnvVars)
+	}
+
+	env := buildQtEnvironment(t.TempDir(), "/default/jre", mods[0].EnvVars)
+	javaHome := ""
+	for _, kv := range env {
+		if strings.HasPrefix(kv, "JAVA_HOME=") {
+			javaHome = strings.TrimPrefix(kv, "JAVA_HOME=")
+		}
+	}
+	if javaHome != "/custom/java" {
+		t.Errorf("Expected the last JAVA_HOME entry to be the modpack override, got %q", javaHome)
+	}
+}
+
+// TestSupportsPlatform tests that platform restrictions in the catalog are matched case-insensitively
+func TestSupportsPlatform(t *testing.T) {
+	mods := normalizeModpacks([]Modpack{
+		{ID: "any", PackURL: "https://example.com/a/pack.toml", InstanceName: "Any"},
+		{ID: "windows", PackURL: "https://example.com/b/pack.toml", InstanceName: "Windows", SupportedOS: []string{" Windows ", ""}},
+		{ID: "arm", PackURL: "https://example.com/c/pack.toml", InstanceName: "Arm", SupportedOS: []string{"darwin", "linux"}, SupportedArch: []string{"arm64"}},
+	})
+	if len(mods) != 3 {
+		t.Fatalf("Expected 3 modpacks, got %d", len(mods))
+	}
+	if len(mods[1].SupportedOS) != 1 || mods[1].SupportedOS[0] != "windows" {
+		t.Errorf("Expected SupportedOS to be normalized to [windows], got %v", mods[1].SupportedOS)
+	}
+
+	cases := []struct {
+		mod    Modpack
+		goos   string
+		goarch string
+		want   bool
+	}{
+		{mods[0], "darwin", "arm64", true},
+		{mods[1], "windows", "amd64", true},
+		{mods[1], "darwin", "arm64", false},
+		{mods[2], "linux", "arm64", true},
+		{mods[2], "linux", "amd64", false},
+		{mods[2], "windows", "arm64", false},
+	}
+	for _, tc := range cases {
+		if got := supportsPlatform(tc.mod, tc.goos, tc.goarch); got != tc.want {
+			t.Errorf("supportsPlatform(%s, %s/%s) = %v, want %v", tc.mod.ID, tc.goos, tc.goarch, got, tc.want)
+		}
+	}
+}
+
+// TestCatalogTagsAndMatching tests tag chips are built from the catalog and filter with any/all semantics
+func TestCatalogTagsAndMatching(t *testing.T) {
+	mods := []Modpack{
+		{ID: "alpha", Tags: []string{"Tech", " magic ", ""}},
+		{ID: "beta", Tags: []string{"magic"}},
+		{ID: "gamma"},
+	}
+	if got := strings.Join(catalogTags(mods), ","); got != "magic,tech" {
+		t.Errorf("Expected tags magic,tech, got %s", got)
+	}
+
+	active := map[string]bool{"magic": true, "tech": true}
+	var any, all []string
+	for _, mp := range mods {
+		if modMatchesTags(mp, active, false) {
+			any = append(any, mp.ID)
+		}
+		if modMatchesTags(mp, active, true) {
+			all = append(all, mp.ID)
+		}
+	}
+	if strings.Join(any, ",") != "alpha,beta" {
+		t.Errorf("Expected alpha,beta to match any tag, got %v", any)
+	}
+	if strings.Join(all, ",") != "alpha" {
+		t.Errorf("Expected only alpha to match all tags, got %v", all)
+	}
+	if !modMatchesTags(mods[2], nil, true) {
+		t.Error("Expected every modpack to match when no tags are active")
+	}
+}
+
+// TestLoadCachedModpacks tests that the catalog saved after a fetch can be read back as a fallback
+func TestLoadCachedModpacks(t *testing.T) {
+	root := t.TempDir()
+	if _, err := loadCachedModpacks(root); err == nil {
+		t.Error("Expected an error when no catalog has been saved")
+	}
+
+	saved := normalizeModpacks([]Modpack{{ID: "alpha", PackURL: "https://example.com/a/pack.toml", InstanceName: "Alpha"}})
+	if err := saveModpackCatalog(root, saved); err != nil {
+		t.Fatalf("saveModpackCatalog failed: %v", err)
+	}
+	mods, err := loadCachedModpacks(root)
+	if err != nil {
+		t.Fatalf("loadCachedModpacks failed: %v", err)
+	}
+	if len(mods) != 1 || mods[0].ID != "alpha" || mods[0].InstanceName != "Alpha" {
+		t.Errorf("Expected the saved modpack back, got %+v", mods)
+	}
+}
+
+// TestCatalogWarnings tests that problems normalizeModpacks would silently fix are reported for catalog previews
+func TestCatalogWarnings(t *testing.T) {
+	warnings := catalogWarnings([]Modpack{
+		{ID: "alpha", DisplayName: "Alpha", PackURL: "https://example.com/a/pack.toml", InstanceName: "Shared"},
+		{ID: "beta", PackURL: "example.com/b/pack.toml", InstanceName: "shared", MinRam: 8192, RecommendedRam: 4096},
+		{ID: "Alpha", DisplayName: "Alpha again", PackURL: "https://example.com/c/pack.toml", InstanceName: "Shared"},
+		{DisplayName: "No ID", InstanceName: "Other"},
+	})
+
+	want := []string{
+		"Entry 2 (beta) uses the same instanceName as alpha",
+		"Entry 2 (beta) has a packUrl that is not an http(s) URL",
+		"Entry 2 (beta) has no displayName",
+		"Entry 2 (beta) has minRam (8192) above recommendedRam (4096)",
+		"Entry 3 (Alpha) reuses an earlier id",
+		"Entry 4 is missing id, packUrl and will be skipped",
+	}
+	if len(warnings) != len(want) {
+		t.Fatalf("Expected %d warnings, got %d:\n%s", len(want), len(warnings), strings.Join(warnings, "\n"))
+	}
+	for i, prefix := range want {
+		if !strings.HasPrefix(warnings[i], prefix) {
+			t.Errorf("Warning %d: expected prefix %q, got %q", i, prefix, warnings[i])
+		}
+	}
+}
+
+// TestMergeCatalogs tests that later catalogs override earlier ones and an unreachable catalog keeps its saved packs
+func TestMergeCatalogs(t *testing.T) {
+	catalogs := map[string]string{
+		"/official.json": `[{"id": "alpha", "displayName": "Alpha", "packUrl": "https://example.com/a/pack.toml", "instanceName": "Alpha"},
+			{"id": "beta", "displayName": "Beta", "packUrl": "https://example.com/b/pack.toml", "instanceName": "Beta"}]`,
+		"/private.json": `[{"id": "Beta", "displayName": "Private Beta", "packUrl": "https://private.example.com/b/pack.toml", "instanceName": "Beta"},
+			{"id": "gamma", "displayName": "Gamma", "packUrl": "https://private.example.com/c/pack.toml", "instanceName": "Gamma"}]`,
+	}
+	down := false
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, ok := catalogs[r.URL.Path]
+		if !ok || (down && r.URL.Path == "/private.json") {
+			http.NotFound(w, r)
+			return
+		}
+		w.Write([]byte(body))
+	}))
+	defer server.Close()
+
+	root := t.TempDir()
+	official, private := server.URL+"/official.json", server.URL+"/private.json"
+	mods, err := mergeCatalogs(root, []string{official, private})
+	if err != nil {
+		t.Fatalf("mergeCatalogs failed: %v", err)
+	}
+	var got []string
+	for _, mp := range mods {
+		got = append(got, mp.ID+"="+mp.DisplayName+"@"+strings.TrimPrefix(mp.Source, server.URL))
+	}
+	if want := "alpha=Alpha@/official.json,Beta=Private Beta@/private.json,gamma=Gamma@/private.json"; strings.Join(got, ",") != want {
+		t.Errorf("Expected %s, got %s", want, strings.Join(got, ","))
+	}
+
+	// With the private catalog down, its packs come from the saved copy
+	if err := saveModpackCatalog(root, mods); err != nil {
+		t.Fatalf("saveModpackCatalog failed: %v", err)
+	}
+	down = true
+	mods, err = mergeCatalogs(root, []string{official, private})
+	if err != nil {
+		t.Fatalf("mergeCatalogs failed: %v", err)
+	}
+	if len(mods) != 3 || mods[1].DisplayName != "Private Beta" || mods[2].ID != "gamma" {
+		t.Errorf("Expected the saved private packs to be kept, got %+v", mods)
+	}
+
+	if _, err := mergeCatalogs(t.TempDir(), []string{server.URL + "/missing.json"}); err == nil {
+		t.Error("Expected an error when no catalog can be reached")
+	}
+}
@@ -1,39 +1,99 @@
 package main
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"os"
 	"os/exec"
 	"path/filepath"
+	"sort"
 	"strings"
 )
 
 // -------------------- MultiMC Instance Creation --------------------
 
 func createMultiMCInstance(modpack Modpack, packInfo *PackInfo, instDir, javaExe string) error {
-	memoryMB := MemoryForModpack(modpack)
-	minMB, maxMB := memoryMB, memoryMB
+	files, err := renderInstanceFiles(modpack, packInfo, javaExe, MemoryForModpack(modpack), getLWJGLVersionForMinecraft(packInfo.Minecraft))
+	if err != nil {
+		return err
+	}
+
+	// Write all the required MultiMC files (only if they don't exist)
+	for _, f := range []struct {
+		name string
+		data []byte
+	}{
+		{"instance.cfg", files.InstanceCfg},
+		{"mmc-pack.json", files.MMCPack},
+		{"pack.json", files.PackJSON},
+	} {
+		path := filepath.Join(instDir, f.name)
+		if exists(path) {
+			continue
+		}
+		if err := os.WriteFile(path, f.data, 0644); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// instanceFiles holds the contents of a new instance's files
+type instanceFiles struct {
+	InstanceCfg []byte
+	MMCPack     []byte
+	PackJSON    []byte
+}
+
+// renderInstanceFiles builds instance.cfg, mmc-pack.json and pack.json for a new
+// instance. It only formats its inputs, so the same arguments always give the same bytes.
+func renderInstanceFiles(modpack Modpack, packInfo *PackInfo, javaExe string, memoryMB int, lwjgl LWJGLInfo) (instanceFiles, error) {
+	var files instanceFiles
 
-	// Create instance.cfg
 	instanceLines := []string{
 		"InstanceType=OneSix", // Use OneSix not Minecraft
 		"name=" + modpack.InstanceName,
 		"iconKey=default",
 		"OverrideMemory=true",
-		fmt.Sprintf("MinMemAlloc=%d", minMB),
-		fmt.Sprintf("MaxMemAlloc=%d", maxMB),
+		fmt.Sprintf("MinMemAlloc=%d", memoryMB),
+		fmt.Sprintf("MaxMemAlloc=%d", memoryMB),
 		"OverrideJava=true",
 		"JavaPath=" + filepath.ToSlash(javaExe),
 		"AutomaticJava=false",
 		"Notes=Managed by " + launcherName,
 	}
+	files.InstanceCfg = []byte(strings.Join(instanceLines, "\n") + "\n")
 
-	// Build components dynamically based on pack info
-	lwjglInfo := getLWJGLVersionForMinecraft(packInfo.Minecraft)
-	lwjglVersion := lwjglInfo.Version
-	lwjglUID := lwjglInfo.UID
-	lwjglName := lwjglInfo.Name
+	components := instanceComponentsFor(packInfo, lwjgl)
+
+	// mmc-pack.json for Prism and pack.json for MultiMC list the same components
+	var err error
+	files.MMCPack, err = json.MarshalIndent(map[string]interface{}{
+		"formatVersion": 1,
+		"components":    components,
+	}, "", "  ")
+	if err != nil {
+		return files, err
+	}
+	files.PackJSON, err = json.MarshalIndent(map[string]interface{}{
+		"formatVersion": 3,
+		"components":    components,
+	}, "", "  ")
+	return files, err
+}
+
+// instanceComponents builds the LWJGL, Minecraft and modloader components for mmc-pack.json
+func instanceComponents(packInfo *PackInfo) []interface{} {
+	return instanceComponentsFor(packInfo, getLWJGLVersionForMinecraft(packInfo.Minecraft))
+}
+
+// instanceComponentsFor builds the components for packInfo using an already resolved LWJGL version
+func instanceComponentsFor(packInfo *PackInfo, lwjgl LWJGLInfo) []interface{} {
+	lwjglVersion := lwjgl.Version
+	lwjglUID := lwjgl.UID
+	lwjglName := lwjgl.Name
 
 	components := []interface{}{
 		map[string]interface{}{
@@ -116,64 +176,269 @@ func createMultiMCInstance(modpack Modpack, packInfo *PackInfo, instDir, javaExe
 		}
 	}
 
-	components = append(components, modloaderComponent)
-
-	// Create mmc-pack.json with dynamic components
-	mmcPack := map[string]interface{}{
-		"formatVersion": 1,
-		"components":    components,
-	}
-
-	// Create pack.json for MultiMC format with matching components
-	pack := map[string]interface{}{
-		"formatVersion": 3,
-		"components":    components,
+	if modloaderComponent == nil {
+		return components
 	}
+	return append(components, modloaderComponent)
+}
 
-	// Write all the required MultiMC files (only if they don't exist)
-	instanceCfgPath := filepath.Join(instDir, "instance.cfg")
+// migrateInstanceComponents rewrites an existing instance's LWJGL, Minecraft and
+// modloader components for packInfo, keeping any other components (e.g. ones
+// added through Prism) after them
+func migrateInstanceComponents(instDir string, packInfo *PackInfo) error {
 	mmcPackPath := filepath.Join(instDir, "mmc-pack.json")
-	packJsonPath := filepath.Join(instDir, "pack.json")
+	data, err := os.ReadFile(mmcPackPath)
+	if err != nil {
+		return err
+	}
+	var existing struct {
+		Components []map[string]interface{} `json:"components"`
+	}
+	if err := json.Unmarshal(data, &existing); err != nil {
+		return fmt.Errorf("failed to parse mmc-pack.json: %w", err)
+	}
 
-	if !exists(instanceCfgPath) {
-		if err := os.WriteFile(instanceCfgPath, []byte(strings.Join(instanceLines, "\n")+"\n"), 0644); err != nil {
-			return err
+	components := instanceComponents(packInfo)
+	for _, c := range existing.Components {
+		uid, _ := c["uid"].(string)
+		if _, isLoader := modLoaderUIDs[uid]; isLoader || uid == "net.minecraft" || strings.HasPrefix(uid, "org.lwjgl") {
+			continue
 		}
+		components = append(components, c)
 	}
 
-	if !exists(mmcPackPath) {
-		mmcPackData, err := json.MarshalIndent(mmcPack, "", "  ")
+	files := []struct {
+		path          string
+		formatVersion int
+	}{
+		{mmcPackPath, 1},
+		{filepath.Join(instDir, "pack.json"), 3},
+	}
+	for _, f := range files {
+		encoded, err := json.MarshalIndent(map[string]interface{}{
+			"formatVersion": f.formatVersion,
+			"components":    components,
+		}, "", "  ")
 		if err != nil {
 			return err
 		}
-		if err := os.WriteFile(mmcPackPath, mmcPackData, 0644); err != nil {
+		if err := os.WriteFile(f.path, encoded, 0644); err != nil {
 			return err
 		}
 	}
+	return nil
+}
 
-	if !exists(packJsonPath) {
-		packData, err := json.MarshalIndent(pack, "", "  ")
-		if err != nil {
-			return err
+// validateInstanceName rejects instance names that are unsafe as a directory name
+func validateInstanceName(name string) error {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return errors.New("instance name cannot be empty")
+	}
+	if name == "." || name == ".." {
+		return fmt.Errorf("%q is not a valid instance name", name)
+	}
+	if strings.ContainsAny(name, `<>:"/\|?*`) {
+		return errors.New(`instance name cannot contain any of <>:"/\|?*`)
+	}
+	return nil
+}
+
+// renameInstanceDir moves an instance directory under instancesDir and updates the
+// name shown by Prism. A missing source directory is not an error, so packs that are
+// not installed yet can be renamed ahead of time.
+func renameInstanceDir(instancesDir, oldName, newName string) error {
+	if err := validateInstanceName(newName); err != nil {
+		return err
+	}
+	newName = strings.TrimSpace(newName)
+	if oldName == newName {
+		return nil
+	}
+
+	oldDir := filepath.Join(instancesDir, oldName)
+	newDir := filepath.Join(instancesDir, newName)
+	if !exists(oldDir) {
+		return nil
+	}
+
+	if strings.EqualFold(oldName, newName) {
+		// Case-only rename: go through a temporary name so case-insensitive filesystems cooperate
+		tmpDir := oldDir + ".rename"
+		if err := os.Rename(oldDir, tmpDir); err != nil {
+			return fmt.Errorf("failed to rename instance: %w", err)
 		}
-		if err := os.WriteFile(packJsonPath, packData, 0644); err != nil {
-			return err
+		oldDir = tmpDir
+	} else if exists(newDir) {
+		return fmt.Errorf("an instance named %q already exists", newName)
+	}
+
+	if err := os.Rename(oldDir, newDir); err != nil {
+		return fmt.Errorf("failed to rename instance: %w", err)
+	}
+
+	return updateInstanceDisplayName(newDir, newName)
+}
+
+// updateInstanceDisplayName rewrites the name= entry in instance.cfg
+func updateInstanceDisplayName(instDir, name string) error {
+	instanceCfgPath := filepath.Join(instDir, "instance.cfg")
+	if !exists(instanceCfgPath) {
+		return nil
+	}
+
+	data, err := os.ReadFile(instanceCfgPath)
+	if err != nil {
+		return err
+	}
+
+	lines := strings.Split(strings.ReplaceAll(string(data), "\r\n", "\n"), "\n")
+	var updated []string
+	for _, line := range lines {
+		if line == "" {
+			continue
 		}
+		if strings.HasPrefix(line, "name=") {
+			line = "name=" + name
+		}
+		updated = append(updated, line)
 	}
 
-	return nil
+	output := strings.Join(updated, "\n") + "\n"
+	return os.WriteFile(instanceCfgPath, []byte(output), 0644)
+}
+
+// modLoaderUIDs maps mmc-pack.json component UIDs to packwiz modloader names
+var modLoaderUIDs = map[string]string{
+	"net.minecraftforge":         "forge",
+	"net.fabricmc.fabric-loader": "fabric",
+	"org.quiltmc.quilt-loader":   "quilt",
+	"net.neoforged.neoforge":     "neoforge",
+}
+
+// readInstancePackInfo reads the Minecraft version and modloader currently
+// configured in an instance's mmc-pack.json
+func readInstancePackInfo(instDir string) (*PackInfo, error) {
+	data, err := os.ReadFile(filepath.Join(instDir, "mmc-pack.json"))
+	if err != nil {
+		return nil, err
+	}
+
+	var mmcPack struct {
+		Components []struct {
+			UID     string `json:"uid"`
+			Version string `json:"version"`
+		} `json:"components"`
+	}
+	if err := json.Unmarshal(data, &mmcPack); err != nil {
+		return nil, fmt.Errorf("failed to parse mmc-pack.json: %w", err)
+	}
+
+	info := &PackInfo{}
+	for _, c := range mmcPack.Components {
+		if c.UID == "net.minecraft" {
+			info.Minecraft = c.Version
+		} else if loader, ok := modLoaderUIDs[c.UID]; ok {
+			info.ModLoader = loader
+			info.LoaderVersion = c.Version
+		}
+	}
+
+	return info, nil
+}
+
+// readInstanceConfig returns the key=value entries of an instance's instance.cfg
+func readInstanceConfig(instDir string) (map[string]string, error) {
+	data, err := os.ReadFile(filepath.Join(instDir, "instance.cfg"))
+	if err != nil {
+		return nil, err
+	}
+	values := make(map[string]string)
+	for _, line := range strings.Split(strings.ReplaceAll(string(data), "\r\n", "\n"), "\n") {
+		if key, value, ok := strings.Cut(line, "="); ok {
+			values[key] = value
+		}
+	}
+	return values, nil
+}
+
+// setInstanceJvmArgs overrides the instance's JVM arguments and returns a function
+// that puts the previous OverrideJavaArgs/JvmArgs entries back. Only those two keys
+// are restored, so anything Prism writes to instance.cfg meanwhile is kept.
+func setInstanceJvmArgs(instDir, args string) (func() error, error) {
+	previous, err := readInstanceConfig(instDir)
+	if err != nil {
+		return nil, err
+	}
+	if err := rewriteInstanceConfig(instDir, map[string]string{"OverrideJavaArgs": "true", "JvmArgs": args}); err != nil {
+		return nil, err
+	}
+
+	return func() error {
+		restore := make(map[string]string)
+		for _, key := range []string{"OverrideJavaArgs", "JvmArgs"} {
+			if value, ok := previous[key]; ok {
+				restore[key] = value
+			} else {
+				restore[key] = "" // removed below
+			}
+		}
+		return rewriteInstanceConfig(instDir, restore)
+	}, nil
+}
+
+// rewriteInstanceConfig sets keys in instance.cfg, appending missing ones.
+// An empty value removes the key.
+func rewriteInstanceConfig(instDir string, values map[string]string) error {
+	instanceCfgPath := filepath.Join(instDir, "instance.cfg")
+	data, err := os.ReadFile(instanceCfgPath)
+	if err != nil {
+		return err
+	}
+
+	lines := strings.Split(strings.ReplaceAll(string(data), "\r\n", "\n"), "\n")
+	var updated []string
+	written := make(map[string]bool)
+	for _, line := range lines {
+		if line == "" {
+			continue
+		}
+		if key, _, ok := strings.Cut(line, "="); ok {
+			if value, set := values[key]; set {
+				if value == "" || written[key] {
+					continue
+				}
+				line = key + "=" + value
+				written[key] = true
+			}
+		}
+		updated = append(updated, line)
+	}
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		if values[key] != "" && !written[key] {
+			updated = append(updated, key+"="+values[key])
+		}
+	}
+
+	output := strings.Join(updated, "\n") + "\n"
+	return os.WriteFile(instanceCfgPath, []byte(output), 0644)
 }
 
-func installModLoaderForInstance(instDir, javaBin string, packInfo *PackInfo) error {
+func installModLoaderForInstance(ctx context.Context, instDir, javaBin string, packInfo *PackInfo) error {
 	switch packInfo.ModLoader {
 	case "forge":
-		return installForgeForInstance(instDir, javaBin, packInfo)
+		return installForgeForInstance(ctx, instDir, javaBin, packInfo)
 	case "fabric":
-		return installFabricForInstance(instDir, javaBin, packInfo)
+		return installFabricForInstance(ctx, instDir, javaBin, packInfo)
 	case "quilt":
-		return installQuiltForInstance(instDir, javaBin, packInfo)
+		return installQuiltForInstance(ctx, instDir, javaBin, packInfo)
 	case "neoforge":
-		return installNeoForgeForInstance(instDir, javaBin, packInfo)
+		return installNeoForgeForInstance(ctx, instDir, javaBin, packInfo)
 	default:
 		return fmt.Errorf("unsupported modloader: %s", packInfo.ModLoader)
 	}
@@ -226,7 +491,155 @@ func updateInstanceMemory(instDir string, memoryMB int) error {
 	return os.WriteFile(instanceCfgPath, []byte(output), 0644)
 }
 
-func installForgeForInstance(instDir, javaBin string, packInfo *PackInfo) error {
+// updateInstanceJava pins the instance to the given Java runtime via instance.cfg
+// so packs that need different Java majors never share Prism's global JavaPath.
+func updateInstanceJava(instDir, javaExe string) error {
+	instanceCfgPath := filepath.Join(instDir, "instance.cfg")
+	if !exists(instanceCfgPath) {
+		return nil
+	}
+
+	data, err := os.ReadFile(instanceCfgPath)
+	if err != nil {
+		return err
+	}
+
+	javaPath := "JavaPath=" + filepath.ToSlash(javaExe)
+	lines := strings.Split(strings.ReplaceAll(string(data), "\r\n", "\n"), "\n")
+	var updated []string
+	var hasPath, hasOverride, hasAutomatic bool
+
+	for _, line := range lines {
+		if line == "" {
+			continue
+		}
+		switch {
+		case strings.HasPrefix(line, "JavaPath="):
+			line = javaPath
+			hasPath = true
+		case strings.HasPrefix(line, "OverrideJava="):
+			line = "OverrideJava=true"
+			hasOverride = true
+		case strings.HasPrefix(line, "AutomaticJava="):
+			line = "AutomaticJava=false"
+			hasAutomatic = true
+		}
+		updated = append(updated, line)
+	}
+
+	if !hasOverride {
+		updated = append(updated, "OverrideJava=true")
+	}
+	if !hasPath {
+		updated = append(updated, javaPath)
+	}
+	if !hasAutomatic {
+		updated = append(updated, "AutomaticJava=false")
+	}
+
+	output := strings.Join(updated, "\n") + "\n"
+	return os.WriteFile(instanceCfgPath, []byte(output), 0644)
+}
+
+// updatePrismInstanceDir points Prism's InstanceDir setting at instancesDir.
+// The default location under prismDir is written as Prism's own relative
+// "instances" value so portable installs stay relocatable.
+func updatePrismInstanceDir(cfgPath, prismDir, instancesDir string) error {
+	value := filepath.ToSlash(instancesDir)
+	if filepath.Clean(instancesDir) == filepath.Join(prismDir, "instances") {
+		value = "instances"
+	}
+	entry := "InstanceDir=" + value
+
+	var lines []string
+	if data, err := os.ReadFile(cfgPath); err == nil {
+		lines = strings.Split(strings.ReplaceAll(string(data), "\r\n", "\n"), "\n")
+	} else if !os.IsNotExist(err) {
+		return err
+	}
+
+	var updated []string
+	found := false
+	for _, line := range lines {
+		if line == "" {
+			continue
+		}
+		if strings.HasPrefix(line, "InstanceDir=") {
+			if found {
+				continue
+			}
+			line = entry
+			found = true
+		}
+		updated = append(updated, line)
+	}
+	if !found {
+		updated = append(updated, entry)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(cfgPath), 0755); err != nil {
+		return err
+	}
+	output := strings.Join(updated, "\n") + "\n"
+	return os.WriteFile(cfgPath, []byte(output), 0644)
+}
+
+// moveInstances moves every entry of fromDir (instance folders plus Prism's
+// instgroups.json) into toDir and returns how many were moved. Entries are
+// renamed when possible and copied then deleted across drives. Nothing is
+// overwritten: if any entry already exists in toDir nothing is moved.
+func moveInstances(fromDir, toDir string) (int, error) {
+	entries, err := os.ReadDir(fromDir)
+	if os.IsNotExist(err) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+	for _, entry := range entries {
+		if exists(filepath.Join(toDir, entry.Name())) {
+			return 0, fmt.Errorf("%s already exists in %s", entry.Name(), toDir)
+		}
+	}
+	if err := os.MkdirAll(toDir, 0755); err != nil {
+		return 0, err
+	}
+
+	moved := 0
+	for _, entry := range entries {
+		if err := movePath(filepath.Join(fromDir, entry.Name()), filepath.Join(toDir, entry.Name())); err != nil {
+			return moved, err
+		}
+		moved++
+	}
+	return moved, nil
+}
+
+// movePath renames src to dst, copying and deleting when they are on different drives
+func movePath(src, dst string) error {
+	if err := os.Rename(src, dst); err == nil {
+		return nil
+	}
+	info, err := os.Stat(src)
+	if err != nil {
+		return err
+	}
+	if info.IsDir() {
+		err = copyDir(src, dst)
+	} else {
+		err = copyFile(src, dst)
+	}
+	if err != nil {
+		_ = os.RemoveAll(dst)
+		return fmt.Errorf("failed to move %s: %w", filepath.Base(src), err)
+	}
+	if err := os.RemoveAll(src); err != nil {
+		return fmt.Errorf("copied %s but failed to remove the original: %w", filepath.Base(src), err)
+	}
+	return nil
+}
+
+func installForgeForInstance(ctx context.Context, instDir, javaBin string, packInfo *PackInfo) error {
 	mcDir := filepath.Join(instDir, "minecraft") // Use minecraft not .minecraft
 
 	// Check for Forge installation in MultiMC/Prism instance structure
@@ -245,7 +658,7 @@ func installForgeForInstance(instDir, javaBin string, packInfo *PackInfo) error
 	installerPath := filepath.Join(utilDir, "forge-installer.jar")
 
 	logf("Downloading Forge installer...")
-	if err := downloadTo(forgeURL, installerPath, 0644); err != nil {
+	if err := downloadTo(ctx, forgeURL, installerPath, 0644); err != nil {
 		return fmt.Errorf("failed to download Forge installer: %w", err)
 	}
 
@@ -253,7 +666,7 @@ func installForgeForInstance(instDir, javaBin string, packInfo *PackInfo) error
 	logf("Installing Forge...")
 	fmt.Fprintf(out, "Running Forge installer... (this may take a few minutes)\n")
 
-	cmd := exec.Command(javaBin, "-jar", installerPath, "--installClient", "--installServer")
+	cmd := exec.CommandContext(ctx, javaBin, "-jar", installerPath, "--installClient", "--installServer")
 	cmd.Dir = mcDir
 	cmd.Env = append(os.Environ(),
 		"JAVA_HOME="+filepath.Dir(filepath.Dir(javaBin)),
@@ -276,7 +689,7 @@ func installForgeForInstance(instDir, javaBin string, packInfo *PackInfo) error
 	return nil
 }
 
-func installFabricForInstance(instDir, javaBin string, packInfo *PackInfo) error {
+func installFabricForInstance(ctx context.Context, instDir, javaBin string, packInfo *PackInfo) error {
 	mcDir := filepath.Join(instDir, "minecraft")
 
 	// Download Fabric installer
@@ -285,7 +698,7 @@ func installFabricForInstance(instDir, javaBin string, packInfo *PackInfo) error
 	installerPath := filepath.Join(utilDir, "fabric-installer.jar")
 
 	logf("Downloading Fabric installer...")
-	if err := downloadTo(fabricURL, installerPath, 0644); err != nil {
+	if err := downloadTo(ctx, fabricURL, installerPath, 0644); err != nil {
 		return fmt.Errorf("failed to download Fabric installer: %w", err)
 	}
 
@@ -293,7 +706,7 @@ func installFabricForInstance(instDir, javaBin string, packInfo *PackInfo) error
 	logf("Installing Fabric Loader...")
 	fmt.Fprintf(out, "Running Fabric installer... (this may take a few minutes)\n")
 
-	cmd := exec.Command(javaBin, "-jar", installerPath, "client", "-dir", mcDir, "-mcversion", packInfo.Minecraft)
+	cmd := exec.CommandContext(ctx, javaBin, "-jar", installerPath, "client", "-dir", mcDir, "-mcversion", packInfo.Minecraft)
 	cmd.Env = append(os.Environ(),
 		"JAVA_HOME="+filepath.Dir(filepath.Dir(javaBin)),
 		"PATH="+filepath.Dir(filepath.Dir(javaBin))+";"+os.Getenv("PATH"),
@@ -315,7 +728,7 @@ func installFabricForInstance(instDir, javaBin string, packInfo *PackInfo) error
 	return nil
 }
 
-func installQuiltForInstance(instDir, javaBin string, packInfo *PackInfo) error {
+func installQuiltForInstance(ctx context.Context, instDir, javaBin string, packInfo *PackInfo) error {
 	mcDir := filepath.Join(instDir, "minecraft")
 
 	// Download Quilt installer
@@ -324,7 +737,7 @@ func installQuiltForInstance(instDir, javaBin string, packInfo *PackInfo) error
 	installerPath := filepath.Join(utilDir, "quilt-installer.jar")
 
 	logf("Downloading Quilt installer...")
-	if err := downloadTo(quiltURL, installerPath, 0644); err != nil {
+	if err := downloadTo(ctx, quiltURL, installerPath, 0644); err != nil {
 		return fmt.Errorf("failed to download Quilt installer: %w", err)
 	}
 
@@ -332,7 +745,7 @@ func installQuiltForInstance(instDir, javaBin string, packInfo *PackInfo) error
 	logf("Installing Quilt Loader...")
 	fmt.Fprintf(out, "Running Quilt installer... (this may take a few minutes)\n")
 
-	cmd := exec.Command(javaBin, "-jar", installerPath, "install", "client", "--dir", mcDir, "--minecraft-version", packInfo.Minecraft)
+	cmd := exec.CommandContext(ctx, javaBin, "-jar", installerPath, "install", "client", "--dir", mcDir, "--minecraft-version", packInfo.Minecraft)
 	cmd.Env = append(os.Environ(),
 		"JAVA_HOME="+filepath.Dir(filepath.Dir(javaBin)),
 		"PATH="+filepath.Dir(filepath.Dir(javaBin))+";"+os.Getenv("PATH"),
@@ -354,7 +767,7 @@ func installQuiltForInstance(instDir, javaBin string, packInfo *PackInfo) error
 	return nil
 }
 
-func installNeoForgeForInstance(instDir, javaBin string, packInfo *PackInfo) error {
+func installNeoForgeForInstance(ctx context.Context, instDir, javaBin string, packInfo *PackInfo) error {
 	mcDir := filepath.Join(instDir, "minecraft")
 
 	// Download NeoForge installer
@@ -363,7 +776,7 @@ func installNeoForgeForInstance(instDir, javaBin string, packInfo *PackInfo) err
 	installerPath := filepath.Join(utilDir, "neoforge-installer.jar")
 
 	logf("Downloading NeoForge installer...")
-	if err := downloadTo(neoforgeURL, installerPath, 0644); err != nil {
+	if err := downloadTo(ctx, neoforgeURL, installerPath, 0644); err != nil {
 		return fmt.Errorf("failed to download NeoForge installer: %w", err)
 	}
 
@@ -371,7 +784,7 @@ func installNeoForgeForInstance(instDir, javaBin string, packInfo *PackInfo) err
 	logf("Installing NeoForge...")
 	fmt.Fprintf(out, "Running NeoForge installer... (this may take a few minutes)\n")
 
-	cmd := exec.Command(javaBin, "-jar", installerPath, "--install-client", "--install-server")
+	cmd := exec.CommandContext(ctx, javaBin, "-jar", installerPath, "--install-client", "--install-server")
 	cmd.Dir = mcDir
 	cmd.Env = append(os.Environ(),
 		"JAVA_HOME="+filepath.Dir(filepath.Dir(javaBin)),
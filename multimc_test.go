@@ -0,0 +1,322 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// TestUpdateInstanceJava tests that the Java override is written per instance
+func TestUpdateInstanceJava(t *testing.T) {
+	instA := filepath.Join(t.TempDir(), "PackA")
+	instB := filepath.Join(t.TempDir(), "PackB")
+
+	existing := "InstanceType=OneSix\nname=PackA\nOverrideJava=false\nJavaPath=/old/java\n"
+	if err := os.MkdirAll(instA, 0755); err != nil {
+		t.Fatalf("Failed to create instance directory: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(instA, "instance.cfg"), []byte(existing), 0644); err != nil {
+		t.Fatalf("Failed to create instance.cfg: %v", err)
+	}
+
+	// Instance without JavaPath entries should have them appended
+	if err := os.MkdirAll(instB, 0755); err != nil {
+		t.Fatalf("Failed to create instance directory: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(instB, "instance.cfg"), []byte("InstanceType=OneSix\nname=PackB\n"), 0644); err != nil {
+		t.Fatalf("Failed to create instance.cfg: %v", err)
+	}
+
+	if err := updateInstanceJava(instA, "/jre/java17/bin/java"); err != nil {
+		t.Fatalf("updateInstanceJava failed: %v", err)
+	}
+	if err := updateInstanceJava(instB, "/jre/java21/bin/java"); err != nil {
+		t.Fatalf("updateInstanceJava failed: %v", err)
+	}
+
+	checks := map[string]string{
+		instA: "JavaPath=/jre/java17/bin/java",
+		instB: "JavaPath=/jre/java21/bin/java",
+	}
+	for inst, wantPath := range checks {
+		data, err := os.ReadFile(filepath.Join(inst, "instance.cfg"))
+		if err != nil {
+			t.Fatalf("Failed to read instance.cfg: %v", err)
+		}
+		lines := strings.Split(strings.TrimSpace(string(data)), "\n")
+
+		count := 0
+		for _, line := range lines {
+			if strings.HasPrefix(line, "JavaPath=") {
+				count++
+				if line != wantPath {
+					t.Errorf("%s: expected %q, got %q", filepath.Base(inst), wantPath, line)
+				}
+			}
+		}
+		if count != 1 {
+			t.Errorf("%s: expected exactly one JavaPath line, got %d", filepath.Base(inst), count)
+		}
+		for _, want := range []string{"OverrideJava=true", "AutomaticJava=false"} {
+			if !strings.Contains(string(data), want+"\n") {
+				t.Errorf("%s: expected %q in instance.cfg, got:\n%s", filepath.Base(inst), want, data)
+			}
+		}
+	}
+}
+
+// TestUpdateInstanceJavaMissingConfig tests that a missing instance.cfg is not an error
+func TestUpdateInstanceJavaMissingConfig(t *testing.T) {
+	if err := updateInstanceJava(t.TempDir(), "/jre/bin/java"); err != nil {
+		t.Errorf("Expected no error for missing instance.cfg, got: %v", err)
+	}
+}
+
+// TestRenameInstanceDir tests moving an instance directory and updating its display name
+func TestRenameInstanceDir(t *testing.T) {
+	instancesDir := t.TempDir()
+	oldDir := filepath.Join(instancesDir, "OldName")
+	if err := os.MkdirAll(filepath.Join(oldDir, "minecraft"), 0755); err != nil {
+		t.Fatalf("Failed to create instance directory: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(oldDir, "instance.cfg"), []byte("InstanceType=OneSix\nname=OldName\n"), 0644); err != nil {
+		t.Fatalf("Failed to create instance.cfg: %v", err)
+	}
+	if err := os.MkdirAll(filepath.Join(instancesDir, "Taken"), 0755); err != nil {
+		t.Fatalf("Failed to create instance directory: %v", err)
+	}
+
+	if err := renameInstanceDir(instancesDir, "OldName", "Taken"); err == nil {
+		t.Error("Expected error when renaming onto an existing instance")
+	}
+	if err := renameInstanceDir(instancesDir, "OldName", "../escape"); err == nil {
+		t.Error("Expected error for instance name containing a path separator")
+	}
+
+	if err := renameInstanceDir(instancesDir, "OldName", "New Name"); err != nil {
+		t.Fatalf("renameInstanceDir failed: %v", err)
+	}
+	if exists(oldDir) {
+		t.Error("Expected old instance directory to be gone")
+	}
+	data, err := os.ReadFile(filepath.Join(instancesDir, "New Name", "instance.cfg"))
+	if err != nil {
+		t.Fatalf("Failed to read renamed instance.cfg: %v", err)
+	}
+	if !strings.Contains(string(data), "name=New Name\n") {
+		t.Errorf("Expected instance.cfg name to be updated, got:\n%s", data)
+	}
+}
+
+// TestUpdatePrismInstanceDir tests that InstanceDir is set once and the default location stays relative
+func TestUpdatePrismInstanceDir(t *testing.T) {
+	prismDir := t.TempDir()
+	cfgPath := filepath.Join(prismDir, "prismlauncher.cfg")
+	if err := os.WriteFile(cfgPath, []byte("Portable=true\r\nInstanceDir=old\r\nJavaDir=java\r\n"), 0644); err != nil {
+		t.Fatalf("Failed to create prismlauncher.cfg: %v", err)
+	}
+
+	custom := filepath.Join(t.TempDir(), "Instances")
+	if err := updatePrismInstanceDir(cfgPath, prismDir, custom); err != nil {
+		t.Fatalf("updatePrismInstanceDir failed: %v", err)
+	}
+	data, err := os.ReadFile(cfgPath)
+	if err != nil {
+		t.Fatalf("Failed to read prismlauncher.cfg: %v", err)
+	}
+	want := "Portable=true\nInstanceDir=" + filepath.ToSlash(custom) + "\nJavaDir=java\n"
+	if string(data) != want {
+		t.Errorf("Expected:\n%s\ngot:\n%s", want, data)
+	}
+
+	if err := updatePrismInstanceDir(cfgPath, prismDir, filepath.Join(prismDir, "instances")); err != nil {
+		t.Fatalf("updatePrismInstanceDir failed: %v", err)
+	}
+	data, _ = os.ReadFile(cfgPath)
+	if !strings.Contains(string(data), "InstanceDir=instances\n") {
+		t.Errorf("Expected default location to be written as a relative path, got:\n%s", data)
+	}
+}
+
+// TestMoveInstances tests moving instances to a new folder and refusing to overwrite
+func TestMoveInstances(t *testing.T) {
+	from := t.TempDir()
+	to := filepath.Join(t.TempDir(), "Instances")
+	for _, name := range []string{"PackA", "PackB"} {
+		if err := os.MkdirAll(filepath.Join(from, name, "minecraft"), 0755); err != nil {
+			t.Fatalf("Failed to create instance directory: %v", err)
+		}
+	}
+	if err := os.WriteFile(filepath.Join(from, "instgroups.json"), []byte("{}"), 0644); err != nil {
+		t.Fatalf("Failed to create instgroups.json: %v", err)
+	}
+
+	moved, err := moveInstances(from, to)
+	if err != nil {
+		t.Fatalf("moveInstances failed: %v", err)
+	}
+	if moved != 3 {
+		t.Errorf("Expected 3 moved entries, got %d", moved)
+	}
+	for _, name := range []string{"PackA/minecraft", "PackB", "instgroups.json"} {
+		if !exists(filepath.Join(to, name)) {
+			t.Errorf("Expected %s in the new folder", name)
+		}
+	}
+	if exists(filepath.Join(from, "PackA")) {
+		t.Error("Expected PackA to be gone from the old folder")
+	}
+
+	// A clash must leave both folders untouched
+	if err := os.MkdirAll(filepath.Join(from, "PackA"), 0755); err != nil {
+		t.Fatalf("Failed to create instance directory: %v", err)
+	}
+	if err := os.MkdirAll(filepath.Join(from, "PackC"), 0755); err != nil {
+		t.Fatalf("Failed to create instance directory: %v", err)
+	}
+	if moved, err := moveInstances(from, to); err == nil || moved != 0 {
+		t.Errorf("Expected clash to fail before moving anything, got moved=%d err=%v", moved, err)
+	}
+	if !exists(filepath.Join(from, "PackC")) {
+		t.Error("Expected PackC to stay in the old folder after a clash")
+	}
+}
+
+// TestSetInstanceJvmArgs tests that one-off JVM arguments are applied and the previous entries restored
+func TestSetInstanceJvmArgs(t *testing.T) {
+	instDir := t.TempDir()
+	cfgPath := filepath.Join(instDir, "instance.cfg")
+	if err := os.WriteFile(cfgPath, []byte("InstanceType=OneSix\nname=Pack\n"), 0644); err != nil {
+		t.Fatalf("Failed to create instance.cfg: %v", err)
+	}
+
+	restore, err := setInstanceJvmArgs(instDir, "-XX:+UseG1GC")
+	if err != nil {
+		t.Fatalf("setInstanceJvmArgs failed: %v", err)
+	}
+	cfg, err := readInstanceConfig(instDir)
+	if err != nil {
+		t.Fatalf("readInstanceConfig failed: %v", err)
+	}
+	if cfg["OverrideJavaArgs"] != "true" || cfg["JvmArgs"] != "-XX:+UseG1GC" {
+		t.Errorf("Expected JVM argument override, got %v", cfg)
+	}
+
+	// Prism records launch details while the game runs; those must survive the restore
+	data, _ := os.ReadFile(cfgPath)
+	if err := os.WriteFile(cfgPath, append(data, []byte("lastLaunchTime=123\n")...), 0644); err != nil {
+		t.Fatalf("Failed to update instance.cfg: %v", err)
+	}
+
+	if err := restore(); err != nil {
+		t.Fatalf("restore failed: %v", err)
+	}
+	data, _ = os.ReadFile(cfgPath)
+	if want := "InstanceType=OneSix\nname=Pack\nlastLaunchTime=123\n"; string(data) != want {
+		t.Errorf("Expected:\n%s\ngot:\n%s", want, data)
+	}
+}
+
+// TestRenderInstanceFiles tests the exact instance.cfg, mmc-pack.json and pack.json written for each modloader
+func TestRenderInstanceFiles(t *testing.T) {
+	lwjgl3 := LWJGLInfo{Version: "3.3.3", UID: "org.lwjgl3", Name: "LWJGL 3"}
+	lwjgl2 := LWJGLInfo{Version: "2.9.4-nightly-20150209", UID: "org.lwjgl", Name: "LWJGL 2"}
+
+	cases := []struct {
+		name       string
+		info       PackInfo
+		lwjgl      LWJGLInfo
+		loaderName string
+		loaderUID  string
+	}{
+		{"forge", PackInfo{Minecraft: "1.20.1", ModLoader: "forge", LoaderVersion: "47.2.0"}, lwjgl3, "Forge", "net.minecraftforge"},
+		{"legacy forge", PackInfo{Minecraft: "1.12.2", ModLoader: "forge", LoaderVersion: "14.23.5.2860"}, lwjgl2, "Forge", "net.minecraftforge"},
+		{"fabric", PackInfo{Minecraft: "1.21.1", ModLoader: "fabric", LoaderVersion: "0.16.5"}, lwjgl3, "Fabric Loader", "net.fabricmc.fabric-loader"},
+		{"neoforge", PackInfo{Minecraft: "1.21.1", ModLoader: "neoforge", LoaderVersion: "21.1.77"}, lwjgl3, "NeoForge", "net.neoforged.neoforge"},
+		{"quilt", PackInfo{Minecraft: "1.20.4", ModLoader: "quilt", LoaderVersion: "0.24.0"}, lwjgl3, "Quilt Loader", "org.quiltmc.quilt-loader"},
+	}
+
+	wantCfg := "InstanceType=OneSix\nname=Test Pack\niconKey=default\nOverrideMemory=true\nMinMemAlloc=6144\nMaxMemAlloc=6144\n" +
+		"OverrideJava=true\nJavaPath=/launcher/prism/java/jre17/bin/java\nAutomaticJava=false\nNotes=Managed by " + launcherName + "\n"
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			info := tc.info
+			files, err := renderInstanceFiles(Modpack{InstanceName: "Test Pack"}, &info, "/launcher/prism/java/jre17/bin/java", 6144, tc.lwjgl)
+			if err != nil {
+				t.Fatalf("renderInstanceFiles failed: %v", err)
+			}
+			if string(files.InstanceCfg) != wantCfg {
+				t.Errorf("instance.cfg:\nexpected:\n%s\ngot:\n%s", wantCfg, files.InstanceCfg)
+			}
+			for _, f := range []struct {
+				name          string
+				data          []byte
+				formatVersion int
+			}{
+				{"mmc-pack.json", files.MMCPack, 1},
+				{"pack.json", files.PackJSON, 3},
+			} {
+				want := expectedInstancePack(tc.lwjgl, info, tc.loaderName, tc.loaderUID, f.formatVersion)
+				if string(f.data) != want {
+					t.Errorf("%s:\nexpected:\n%s\ngot:\n%s", f.name, want, f.data)
+				}
+			}
+		})
+	}
+}
+
+// expectedInstancePack spells out the components file Prism expects, key order and indentation included
+func expectedInstancePack(lwjgl LWJGLInfo, info PackInfo, loaderName, loaderUID string, formatVersion int) string {
+	return fmt.Sprintf(`{
+  "components": [
+    {
+      "cachedName": "%[1]s",
+      "cachedVersion": "%[2]s",
+      "cachedVolatile": true,
+      "dependencyOnly": true,
+      "uid": "%[3]s",
+      "version": "%[2]s"
+    },
+    {
+      "cachedName": "Minecraft",
+      "cachedRequires": [
+        {
+          "suggests": "%[2]s",
+          "uid": "%[3]s"
+        }
+      ],
+      "cachedVersion": "%[4]s",
+      "important": true,
+      "uid": "net.minecraft",
+      "version": "%[4]s"
+    },
+    {
+      "cachedName": "%[5]s",
+      "cachedRequires": [
+        {
+          "equals": "%[4]s",
+          "uid": "net.minecraft"
+        }
+      ],
+      "cachedVersion": "%[6]s",
+      "uid": "%[7]s",
+      "version": "%[6]s"
+    }
+  ],
+  "formatVersion": %[8]d
+}`, lwjgl.Name, lwjgl.Version, lwjgl.UID, info.Minecraft, loaderName, info.LoaderVersion, loaderUID, formatVersion)
+}
+
+// TestRenderInstanceFilesWithoutLoader tests that a pack without a modloader gets no empty component
+func TestRenderInstanceFilesWithoutLoader(t *testing.T) {
+	files, err := renderInstanceFiles(Modpack{InstanceName: "Vanilla"}, &PackInfo{Minecraft: "1.21.1"}, "/jre/bin/java", 4096, LWJGLInfo{Version: "3.3.3", UID: "org.lwjgl3", Name: "LWJGL 3"})
+	if err != nil {
+		t.Fatalf("renderInstanceFiles failed: %v", err)
+	}
+	if strings.Contains(string(files.MMCPack), "null") {
+		t.Errorf("Expected no null component, got:\n%s", files.MMCPack)
+	}
+}
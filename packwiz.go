@@ -1,15 +1,24 @@
 package main
 
 import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
+	"net"
 	"net/http"
+	"net/http/httputil"
+	"net/url"
 	"os"
 	"path/filepath"
 	"regexp"
+	"slices"
 	"sort"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/BurntSushi/toml"
@@ -18,16 +27,14 @@ import (
 // -------------------- packwiz bootstrap URL discovery --------------------
 
 // downloadPackwizInstaller downloads the main packwiz-installer.jar using our non-GitHub API method
-func downloadPackwizInstaller(destPath string) error {
+func downloadPackwizInstaller(ctx context.Context, destPath string) error {
 	releasesURL := "https://github.com/packwiz/packwiz-installer/releases"
 
-	client := &http.Client{
-		CheckRedirect: func(req *http.Request, via []*http.Request) error {
-			return nil
-		},
+	req, err := http.NewRequestWithContext(ctx, "GET", releasesURL, nil)
+	if err != nil {
+		return err
 	}
-
-	resp, err := client.Get(releasesURL)
+	resp, err := doWithRetry(req, httpRetryAttempts(), downloadTimeout())
 	if err != nil {
 		return fmt.Errorf("failed to fetch packwiz-installer releases page: %w", err)
 	}
@@ -65,13 +72,13 @@ func downloadPackwizInstaller(destPath string) error {
 		assetURL := fmt.Sprintf("https://github.com/packwiz/packwiz-installer/releases/download/%s/%s", latestTag, assetName)
 
 		// Verify the asset exists by making a HEAD request
-		headReq, err := http.NewRequest("HEAD", assetURL, nil)
+		headReq, err := http.NewRequestWithContext(ctx, "HEAD", assetURL, nil)
 		if err != nil {
 			continue
 		}
 		headReq.Header.Set("User-Agent", getUserAgent("General"))
 
-		headResp, err := http.DefaultClient.Do(headReq)
+		headResp, err := apiClient().Do(headReq)
 		if err != nil {
 			continue
 		}
@@ -80,37 +87,36 @@ func downloadPackwizInstaller(destPath string) error {
 		if headResp.StatusCode == 200 {
 			// Download the file
 			logf("Downloading packwiz-installer.jar from: %s", assetURL)
-			return downloadTo(assetURL, destPath, 0644)
+			return downloadTo(ctx, assetURL, destPath, 0644)
 		}
 	}
 
 	return errors.New("no packwiz-installer.jar assets found")
 }
 
-func fetchPackwizBootstrapURL() (string, error) {
+func fetchPackwizBootstrapURL(ctx context.Context) (string, string, error) {
 	// Use GitHub's releases page to find the latest packwiz bootstrap without API
 	releasesURL := "https://github.com/packwiz/packwiz-installer-bootstrap/releases"
 
-	client := &http.Client{
-		CheckRedirect: func(req *http.Request, via []*http.Request) error {
-			return nil
-		},
+	req, err := http.NewRequestWithContext(ctx, "GET", releasesURL, nil)
+	if err != nil {
+		return "", "", err
 	}
-
-	resp, err := client.Get(releasesURL)
+	req.Header.Set("User-Agent", getUserAgent("General"))
+	resp, err := doWithRetry(req, httpRetryAttempts(), downloadTimeout())
 	if err != nil {
-		return "", fmt.Errorf("failed to fetch packwiz releases page: %w", err)
+		return "", "", fmt.Errorf("failed to fetch packwiz releases page: %w", err)
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode != 200 {
-		return "", fmt.Errorf("packwiz releases page returned status %d", resp.StatusCode)
+		return "", "", fmt.Errorf("packwiz releases page returned status %d", resp.StatusCode)
 	}
 
 	// Read HTML content
 	htmlBody, err := io.ReadAll(resp.Body)
 	if err != nil {
-		return "", fmt.Errorf("failed to read packwiz releases page HTML: %w", err)
+		return "", "", fmt.Errorf("failed to read packwiz releases page HTML: %w", err)
 	}
 	html := string(htmlBody)
 
@@ -120,7 +126,7 @@ func fetchPackwizBootstrapURL() (string, error) {
 	tagMatches := tagRe.FindStringSubmatch(html)
 
 	if len(tagMatches) < 2 {
-		return "", errors.New("could not find any packwiz bootstrap release tags")
+		return "", "", errors.New("could not find any packwiz bootstrap release tags")
 	}
 
 	latestTag := tagMatches[1]
@@ -142,26 +148,34 @@ func fetchPackwizBootstrapURL() (string, error) {
 		}
 		headReq.Header.Set("User-Agent", getUserAgent("General"))
 
-		headResp, err := http.DefaultClient.Do(headReq)
+		headResp, err := apiClient().Do(headReq)
 		if err != nil {
 			continue
 		}
 		headResp.Body.Close()
 
 		if headResp.StatusCode == 200 {
-			return assetURL, nil
+			return assetURL, fetchSHA256Sidecar(ctx, assetURL), nil
 		}
 	}
 
-	return "", errors.New("no packwiz bootstrap assets found")
+	return "", "", errors.New("no packwiz bootstrap assets found")
 }
 
 // -------------------- Modpack Version Checking --------------------
 
 // PackConfig represents the structure of a pack.toml file
 type PackConfig struct {
+	Name     string       `toml:"name"`
+	Author   string       `toml:"author"`
 	Version  string       `toml:"version"`
 	Versions PackVersions `toml:"versions"`
+	Index    PackIndexRef `toml:"index"`
+}
+
+// PackIndexRef represents the [index] section from pack.toml
+type PackIndexRef struct {
+	File string `toml:"file"`
 }
 
 // PackVersions represents the [versions] section from pack.toml
@@ -175,30 +189,151 @@ type PackVersions struct {
 
 // PackInfo holds the complete modpack information from pack.toml
 type PackInfo struct {
+	Name          string
+	Author        string
 	Version       string
 	Minecraft     string
 	ModLoader     string // "forge", "fabric", "quilt", "neoforge"
 	LoaderVersion string
+	IndexURL      string // absolute URL of index.toml; empty if pack.toml has no [index]
+	// Headers the modpack needs on requests for its files
+	Headers map[string]string
 }
 
-// fetchPackInfo reads the remote pack.toml and extracts all version information
-func fetchPackInfo(packURL string) (*PackInfo, error) {
-	req, err := http.NewRequest("GET", packURL, nil)
+// expandHeaderValue fills in the ${NAME} references of a modpack header value. Only
+// variables the user listed in settings.HeaderEnvVars are read: headers come from
+// catalogs anyone can publish, which must not get to ask for other secrets from the
+// user's environment. Other references are sent as written.
+func expandHeaderValue(value string) string {
+	return os.Expand(value, func(name string) string {
+		if !slices.Contains(settings.HeaderEnvVars, name) {
+			debugf("Not expanding $%s in a pack header; it isn't listed in headerEnvVars", name)
+			return "${" + name + "}"
+		}
+		return os.Getenv(name)
+	})
+}
+
+// newPackRequest builds an uncached GET for a pack file with the modpack's custom headers.
+// Header values may reference environment variables as ${NAME} so secrets can stay out
+// of the catalog, see expandHeaderValue.
+func newPackRequest(fileURL string, headers map[string]string) (*http.Request, error) {
+	req, err := http.NewRequest("GET", fileURL, nil)
 	if err != nil {
 		return nil, err
 	}
 	req.Header.Set("User-Agent", getUserAgent("General"))
 	req.Header.Set("Cache-Control", "no-cache")
 	req.Header.Set("Pragma", "no-cache")
+	for name, value := range headers {
+		req.Header.Set(name, expandHeaderValue(value))
+	}
+	return req, nil
+}
+
+// maskSecret hides all but the last few characters of a header value for logging
+func maskSecret(value string) string {
+	if len(value) <= 8 {
+		return "****"
+	}
+	return "****" + value[len(value)-4:]
+}
+
+// describeHeaders lists header names with masked values for logging
+func describeHeaders(headers map[string]string) string {
+	names := make([]string, 0, len(headers))
+	for name := range headers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	parts := make([]string, len(names))
+	for i, name := range names {
+		parts[i] = name + ": " + maskSecret(expandHeaderValue(headers[name]))
+	}
+	return strings.Join(parts, ", ")
+}
+
+// redactURL removes credentials from a URL before it is logged or shown
+func redactURL(raw string) string {
+	u, err := url.Parse(raw)
+	if err != nil || u.User == nil {
+		return raw
+	}
+	u.User = url.User("****")
+	return u.String()
+}
 
-	resp, err := http.DefaultClient.Do(req)
+// startPackProxy serves the pack's host on a loopback address and adds the modpack's
+// headers to every request, since packwiz-installer can't send custom headers itself.
+// It returns packURL rewritten to point at the proxy and a function that stops it.
+// Files packwiz resolves relative to pack.toml go through the proxy; mod downloads
+// from other hosts do not, so the headers never leave the pack's host. The proxy
+// only answers paths under a random prefix, so another local process that finds
+// the port can't use it to make requests with the headers.
+func startPackProxy(packURL string, headers map[string]string) (string, func(), error) {
+	target, err := url.Parse(packURL)
+	if err != nil {
+		return "", nil, err
+	}
+	token := make([]byte, 16)
+	if _, err := rand.Read(token); err != nil {
+		return "", nil, err
+	}
+	prefix := "/" + hex.EncodeToString(token)
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return "", nil, err
+	}
+
+	proxy := &httputil.ReverseProxy{
+		Rewrite: func(r *httputil.ProxyRequest) {
+			r.SetURL(&url.URL{Scheme: target.Scheme, Host: target.Host})
+			r.Out.Host = target.Host
+			if target.User != nil {
+				password, _ := target.User.Password()
+				r.Out.SetBasicAuth(target.User.Username(), password)
+			}
+			for name, value := range headers {
+				r.Out.Header.Set(name, expandHeaderValue(value))
+			}
+		},
+	}
+	server := &http.Server{Handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		rest, ok := strings.CutPrefix(r.URL.Path, prefix)
+		if !ok || !strings.HasPrefix(rest, "/") {
+			http.NotFound(w, r)
+			return
+		}
+		r.URL.Path = rest
+		r.URL.RawPath = ""
+		proxy.ServeHTTP(w, r)
+	})}
+	go server.Serve(listener)
+
+	local := *target
+	local.Scheme = "http"
+	local.Host = listener.Addr().String()
+	local.Path = prefix + target.Path
+	local.RawPath = ""
+	local.User = nil
+	return local.String(), func() { server.Close() }, nil
+}
+
+// fetchPackInfo reads the remote pack.toml and extracts all version information
+func fetchPackInfo(packURL string, headers map[string]string) (*PackInfo, error) {
+	req, err := newPackRequest(packURL, headers)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := apiClient().Do(req)
 	if err != nil {
 		return nil, err
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode != 200 {
-		return nil, fmt.Errorf("HTTP %d: %s", resp.StatusCode, packURL)
+		return nil, fmt.Errorf("HTTP %d: %s", resp.StatusCode, redactURL(packURL))
 	}
 
 	body, err := io.ReadAll(resp.Body)
@@ -217,8 +352,16 @@ func fetchPackInfo(packURL string) (*PackInfo, error) {
 
 	// Determine modloader and versions
 	info := &PackInfo{
+		Name:      packConfig.Name,
+		Author:    packConfig.Author,
 		Version:   packConfig.Version,
 		Minecraft: packConfig.Versions.Minecraft,
+		Headers:   headers,
+	}
+	if packConfig.Index.File != "" {
+		if indexURL, err := resolveRelativeURL(packURL, packConfig.Index.File); err == nil {
+			info.IndexURL = indexURL
+		}
 	}
 
 	// Determine which modloader is being used
@@ -241,24 +384,204 @@ func fetchPackInfo(packURL string) (*PackInfo, error) {
 	return info, nil
 }
 
+// -------------------- Pack index scanning --------------------
+
+// packIndex represents the structure of a packwiz index.toml file
+type packIndex struct {
+	Files []packIndexFile `toml:"files"`
+}
+
+// packIndexFile represents a single [[files]] entry in index.toml
+type packIndexFile struct {
+	File     string `toml:"file"`
+	Metafile bool   `toml:"metafile"`
+}
+
+// packMetafile represents the fields we use from a .pw.toml metafile
+type packMetafile struct {
+	Name     string `toml:"name"`
+	Filename string `toml:"filename"`
+	Download struct {
+		URL  string `toml:"url"`
+		Mode string `toml:"mode"`
+	} `toml:"download"`
+	Update struct {
+		CurseForge struct {
+			FileID    int `toml:"file-id"`
+			ProjectID int `toml:"project-id"`
+		} `toml:"curseforge"`
+	} `toml:"update"`
+}
+
+// resolveRelativeURL resolves ref against base the way packwiz does (relative to base's directory)
+func resolveRelativeURL(base, ref string) (string, error) {
+	baseURL, err := url.Parse(base)
+	if err != nil {
+		return "", err
+	}
+	refURL, err := url.Parse(ref)
+	if err != nil {
+		return "", err
+	}
+	return baseURL.ResolveReference(refURL).String(), nil
+}
+
+// fetchPackFile downloads a small pack file (index or metafile) bypassing caches
+func fetchPackFile(fileURL string, headers map[string]string) ([]byte, error) {
+	req, err := newPackRequest(fileURL, headers)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := apiClient().Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != 200 {
+		return nil, fmt.Errorf("HTTP %d: %s", resp.StatusCode, redactURL(fileURL))
+	}
+
+	return io.ReadAll(resp.Body)
+}
+
+// fetchPackIndex reads the remote index.toml
+func fetchPackIndex(indexURL string, headers map[string]string) (*packIndex, error) {
+	body, err := fetchPackFile(indexURL, headers)
+	if err != nil {
+		return nil, err
+	}
+
+	var index packIndex
+	if err := toml.Unmarshal(body, &index); err != nil {
+		return nil, fmt.Errorf("failed to parse index.toml: %w", err)
+	}
+	return &index, nil
+}
+
+// fetchPackMetafile reads a .pw.toml metafile listed in index.toml
+func fetchPackMetafile(indexURL, file string, headers map[string]string) (*packMetafile, error) {
+	metaURL, err := resolveRelativeURL(indexURL, file)
+	if err != nil {
+		return nil, err
+	}
+	body, err := fetchPackFile(metaURL, headers)
+	if err != nil {
+		return nil, err
+	}
+
+	var meta packMetafile
+	if err := toml.Unmarshal(body, &meta); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", file, err)
+	}
+	return &meta, nil
+}
+
+// -------------------- Pack size estimation --------------------
+
+// packSizeConcurrency is how many files are sized at once by estimatePackSize
+const packSizeConcurrency = 8
+
+// packSizeEstimate is the approximate download size of a pack's files
+type packSizeEstimate struct {
+	Bytes int64
+	// Files whose size was found
+	Files int
+	// Files whose size couldn't be found, such as CurseForge metadata-only mods
+	Unknown int
+}
+
+// estimatePackSize sums the download sizes of the files listed in the pack's index.
+// Pack files are sized with HEAD requests carrying the modpack's headers; mod downloads
+// hosted elsewhere are sized without them so secrets never leave the pack host.
+func estimatePackSize(packURL string, headers map[string]string) (packSizeEstimate, error) {
+	var estimate packSizeEstimate
+	info, err := fetchPackInfo(packURL, headers)
+	if err != nil {
+		return estimate, err
+	}
+	if info.IndexURL == "" {
+		return estimate, errors.New("pack.toml has no index to estimate the size from")
+	}
+	index, err := fetchPackIndex(info.IndexURL, headers)
+	if err != nil {
+		return estimate, err
+	}
+
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	sem := make(chan struct{}, packSizeConcurrency)
+	for _, f := range index.Files {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func() {
+			defer wg.Done()
+			defer func() { <-sem }()
+			size := packFileSize(info.IndexURL, f, headers)
+			mu.Lock()
+			defer mu.Unlock()
+			if size > 0 {
+				estimate.Bytes += size
+				estimate.Files++
+			} else {
+				estimate.Unknown++
+			}
+		}()
+	}
+	wg.Wait()
+	return estimate, nil
+}
+
+// packFileSize returns the download size of one index entry, or 0 if it is unknown
+func packFileSize(indexURL string, f packIndexFile, headers map[string]string) int64 {
+	if !f.Metafile {
+		fileURL, err := resolveRelativeURL(indexURL, f.File)
+		if err != nil {
+			return 0
+		}
+		return contentLength(fileURL, headers)
+	}
+	meta, err := fetchPackMetafile(indexURL, f.File, headers)
+	if err != nil || meta.Download.URL == "" {
+		return 0
+	}
+	return contentLength(meta.Download.URL, nil)
+}
+
+// contentLength asks the server for the size of fileURL without downloading it
+func contentLength(fileURL string, headers map[string]string) int64 {
+	req, err := newPackRequest(fileURL, headers)
+	if err != nil {
+		return 0
+	}
+	req.Method = http.MethodHead
+	resp, err := apiClient().Do(req)
+	if err != nil {
+		return 0
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusOK || resp.ContentLength < 0 {
+		return 0
+	}
+	return resp.ContentLength
+}
+
 // fetchRemotePackVersion fetches the remote pack.toml and extracts the version
-func fetchRemotePackVersion(packURL string) (string, error) {
-	req, err := http.NewRequest("GET", packURL, nil)
+func fetchRemotePackVersion(packURL string, headers map[string]string) (string, error) {
+	req, err := newPackRequest(packURL, headers)
 	if err != nil {
 		return "", err
 	}
-	req.Header.Set("User-Agent", getUserAgent("General"))
-	req.Header.Set("Cache-Control", "no-cache")
-	req.Header.Set("Pragma", "no-cache")
 
-	resp, err := http.DefaultClient.Do(req)
+	resp, err := apiClient().Do(req)
 	if err != nil {
 		return "", err
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode != 200 {
-		return "", fmt.Errorf("HTTP %d: %s", resp.StatusCode, packURL)
+		return "", fmt.Errorf("HTTP %d: %s", resp.StatusCode, redactURL(packURL))
 	}
 
 	body, err := io.ReadAll(resp.Body)
@@ -309,7 +632,7 @@ func saveLocalVersion(mp Modpack, instDir, version string) error {
 
 // checkModpackUpdate checks if there's a modpack update available
 func checkModpackUpdate(modpack Modpack, instDir string) (bool, string, string, error) {
-	remoteVersion, err := fetchRemotePackVersion(modpack.PackURL)
+	remoteVersion, err := fetchRemotePackVersion(modpack.PackURL, modpack.Headers)
 	if err != nil {
 		return false, "", "", fmt.Errorf("failed to fetch remote modpack version: %w", err)
 	}
@@ -327,8 +650,8 @@ func checkModpackUpdate(modpack Modpack, instDir string) (bool, string, string,
 		return true, "", remoteVersion, nil
 	}
 
-	// Compare versions
-	if localVersion != remoteVersion {
+	// Any difference counts as an update so a pack can also be rolled back
+	if !sameVersion(localVersion, remoteVersion) {
 		logf("%s update available: %s → %s", packName, localVersion, remoteVersion)
 		return true, localVersion, remoteVersion, nil
 	}
@@ -339,18 +662,190 @@ func checkModpackUpdate(modpack Modpack, instDir string) (bool, string, string,
 
 // -------------------- Modpack Backup & Restore --------------------
 
-// createModpackBackup creates a backup of the current modpack before updating
-func createModpackBackup(mp Modpack, mcDir string) (string, error) {
-	packName := modpackLabel(mp)
-	// Clean up old backups (keep only the 3 most recent)
-	if err := cleanupOldBackups(mp, mcDir, 3); err != nil {
-		logf("%s", warnLine(fmt.Sprintf("Failed to clean old backups: %v", err)))
+// backupMetadataFile is written into every backup directory to describe what it was taken from
+const backupMetadataFile = "backup.json"
+
+// BackupMetadata records the instance state a backup was created from
+type BackupMetadata struct {
+	ModpackID        string    `json:"modpack_id"`
+	PackVersion      string    `json:"pack_version,omitempty"`
+	MinecraftVersion string    `json:"minecraft_version,omitempty"`
+	ModLoader        string    `json:"modloader,omitempty"`
+	LoaderVersion    string    `json:"loader_version,omitempty"`
+	CreatedAt        time.Time `json:"created_at"`
+}
+
+// writeBackupMetadata stores the instance's current versions alongside a backup
+func writeBackupMetadata(mp Modpack, backupPath, instDir string) error {
+	meta := BackupMetadata{
+		ModpackID: mp.ID,
+		CreatedAt: time.Now(),
+	}
+	if version, err := getLocalPackVersion(mp, instDir); err == nil {
+		meta.PackVersion = version
+	}
+	if info, err := readInstancePackInfo(instDir); err == nil {
+		meta.MinecraftVersion = info.Minecraft
+		meta.ModLoader = info.ModLoader
+		meta.LoaderVersion = info.LoaderVersion
+	}
+
+	data, err := json.MarshalIndent(meta, "", "  ")
+	if err != nil {
+		return err
 	}
+	return os.WriteFile(filepath.Join(backupPath, backupMetadataFile), data, 0644)
+}
+
+// readBackupMetadata loads backup.json from a backup directory
+func readBackupMetadata(backupPath string) (*BackupMetadata, error) {
+	data, err := os.ReadFile(filepath.Join(backupPath, backupMetadataFile))
+	if err != nil {
+		return nil, err
+	}
+
+	var meta BackupMetadata
+	if err := json.Unmarshal(data, &meta); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", backupMetadataFile, err)
+	}
+	return &meta, nil
+}
 
-	timestamp := time.Now().Format("2006-01-02-15-04-05")
+// backupCompatibilityWarnings compares a backup's metadata with the installed instance and
+// describes any Minecraft version or modloader differences. Missing metadata on either side
+// is not treated as a mismatch.
+func backupCompatibilityWarnings(backupPath, instDir string) []string {
+	meta, err := readBackupMetadata(backupPath)
+	if err != nil {
+		return nil
+	}
+	current, err := readInstancePackInfo(instDir)
+	if err != nil {
+		return nil
+	}
+
+	var warnings []string
+	if meta.MinecraftVersion != "" && current.Minecraft != "" && meta.MinecraftVersion != current.Minecraft {
+		warnings = append(warnings, fmt.Sprintf("Backup was made for Minecraft %s but the instance is on %s", meta.MinecraftVersion, current.Minecraft))
+	}
+	if meta.ModLoader != "" && current.ModLoader != "" && meta.ModLoader != current.ModLoader {
+		warnings = append(warnings, fmt.Sprintf("Backup was made for %s but the instance uses %s", meta.ModLoader, current.ModLoader))
+	}
+	return warnings
+}
+
+// playerDataItems are the files under minecraft/ that "Reinstall (keep saves)" carries over
+var playerDataItems = []string{"saves", "options.txt", "servers.dat"}
+
+// keptDataDir is where player data waits while a modpack is reinstalled
+func keptDataDir(root string, mp Modpack) string {
+	return filepath.Join(root, "util", "kept", slugifyID(mp.ID))
+}
+
+// keepPlayerData moves worlds, options and the server list out of mcDir into keptDir
+// and returns what was moved
+func keepPlayerData(mcDir, keptDir string) ([]string, error) {
+	if err := os.MkdirAll(keptDir, 0755); err != nil {
+		return nil, fmt.Errorf("failed to create %s: %w", keptDir, err)
+	}
+	var kept []string
+	for _, name := range playerDataItems {
+		src := filepath.Join(mcDir, name)
+		if !exists(src) {
+			continue
+		}
+		dst := filepath.Join(keptDir, name)
+		if exists(dst) {
+			return kept, fmt.Errorf("%s is still waiting to be restored from an earlier reinstall in %s", name, keptDir)
+		}
+		if err := movePath(src, dst); err != nil {
+			return kept, err
+		}
+		kept = append(kept, name)
+	}
+	return kept, nil
+}
+
+// restorePlayerData moves everything in keptDir back into mcDir, replacing any copy the
+// fresh install created, and removes keptDir once it is empty
+func restorePlayerData(keptDir, mcDir string) ([]string, error) {
+	entries, err := os.ReadDir(keptDir)
+	if err != nil {
+		return nil, err
+	}
+	var restored []string
+	for _, entry := range entries {
+		dst := filepath.Join(mcDir, entry.Name())
+		if err := os.RemoveAll(dst); err != nil {
+			return restored, fmt.Errorf("failed to replace %s: %w", entry.Name(), err)
+		}
+		if err := movePath(filepath.Join(keptDir, entry.Name()), dst); err != nil {
+			return restored, err
+		}
+		restored = append(restored, entry.Name())
+	}
+	return restored, os.Remove(keptDir)
+}
+
+// backupsDirFor is where update backups of every modpack are kept. Backups still in
+// the folder earlier versions used are moved here the first time it is looked up.
+func backupsDirFor(root string) string {
+	dir := filepath.Join(root, "util", "backups")
+	migrateLegacyBackups(root, dir)
+	return dir
+}
+
+// legacyBackupsMu serializes moving backups out of their old folder
+var legacyBackupsMu sync.Mutex
+
+// migrateLegacyBackups moves backups from the util folder next to the instances folder,
+// where they were kept before, into dir. A backup whose name is already taken in dir
+// is left where it is.
+func migrateLegacyBackups(root, dir string) {
+	legacyBackupsMu.Lock()
+	defer legacyBackupsMu.Unlock()
+
+	for _, legacy := range []string{
+		filepath.Join(root, "prism", "util", "backups"),
+		filepath.Join(filepath.Dir(instancesDirFor(root)), "util", "backups"),
+	} {
+		if filepath.Clean(legacy) == filepath.Clean(dir) {
+			continue
+		}
+		entries, err := os.ReadDir(legacy)
+		if err != nil {
+			continue
+		}
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			logf("%s", warnLine(fmt.Sprintf("Failed to create %s: %v", dir, err)))
+			return
+		}
+		moved := 0
+		for _, entry := range entries {
+			target := filepath.Join(dir, entry.Name())
+			if !entry.IsDir() || exists(target) {
+				continue
+			}
+			if err := movePath(filepath.Join(legacy, entry.Name()), target); err != nil {
+				logf("%s", warnLine(fmt.Sprintf("Failed to move backup %s: %v", entry.Name(), err)))
+				continue
+			}
+			moved++
+		}
+		if moved > 0 {
+			logf("%s", infoLine(fmt.Sprintf("Moved %d backup(s) from %s to %s", moved, legacy, dir)))
+		}
+		// Only removed once nothing is left in it
+		_ = os.Remove(legacy)
+	}
+}
+
+// createModpackBackup creates a backup of the current modpack before updating
+func createModpackBackup(root string, mp Modpack, mcDir string) (string, error) {
+	packName := modpackLabel(mp)
+	timestamp := time.Now().Format(backupTimestampLayout)
 	backupName := backupPrefixFor(mp) + timestamp
-	rootDir := filepath.Dir(filepath.Dir(filepath.Dir(mcDir)))
-	backupPath := filepath.Join(rootDir, "util", "backups", backupName)
+	backupPath := filepath.Join(backupsDirFor(root), backupName)
 
 	// Create backup directory
 	if err := os.MkdirAll(backupPath, 0755); err != nil {
@@ -388,22 +883,50 @@ func createModpackBackup(mp Modpack, mcDir string) (string, error) {
 		}
 	}
 
+	// packwiz's cache of what it installed, so a restored pack isn't trusted as the newer one
+	if manifest := filepath.Join(mcDir, packwizManifestFile); exists(manifest) && len(backedUpItems) > 0 {
+		if err := copyFile(manifest, filepath.Join(backupPath, packwizManifestFile)); err != nil {
+			logf("%s", warnLine(fmt.Sprintf("Failed to backup %s: %v", packwizManifestFile, err)))
+		}
+	}
+
 	if len(backedUpItems) == 0 {
 		logf("%s", warnLine(fmt.Sprintf("No files found to backup for %s", packName)))
 		return "", nil
 	}
 
+	if err := writeBackupMetadata(mp, backupPath, filepath.Dir(mcDir)); err != nil {
+		logf("%s", warnLine(fmt.Sprintf("Failed to write backup metadata: %v", err)))
+	}
+
 	logf("%s", successLine(fmt.Sprintf("Backup created for %s: %s (items: %s)", packName, backupName, strings.Join(backedUpItems, ", "))))
+
+	// Only the newest backups are kept, counting the one just made
+	if err := cleanupOldBackups(root, mp, backupRetention()); err != nil {
+		logf("%s", warnLine(fmt.Sprintf("Failed to clean old backups: %v", err)))
+	}
 	return backupPath, nil
 }
 
-// restoreModpackBackup restores from a backup if the update fails
-func restoreModpackBackup(mp Modpack, backupPath, mcDir string) error {
+// restoreModpackBackup restores from a backup if the update fails, or when the user
+// rolls back from the modpack card. If the backup was taken
+// from a different Minecraft version or modloader than the installed instance, confirm is
+// asked whether to continue; a nil confirm refuses such restores.
+func restoreModpackBackup(mp Modpack, backupPath, mcDir string, confirm func(warnings []string) bool) error {
 	if backupPath == "" || !exists(backupPath) {
 		return errors.New("no backup available to restore")
 	}
 
 	packName := modpackLabel(mp)
+	if warnings := backupCompatibilityWarnings(backupPath, filepath.Dir(mcDir)); len(warnings) > 0 {
+		for _, w := range warnings {
+			logf("%s", warnLine(w))
+		}
+		if confirm == nil || !confirm(warnings) {
+			return errors.New("backup does not match the installed Minecraft version or modloader")
+		}
+	}
+
 	logf("%s", stepLine(fmt.Sprintf("Restoring %s from backup", packName)))
 
 	// Remove current modpack directories
@@ -450,51 +973,174 @@ func restoreModpackBackup(mp Modpack, backupPath, mcDir string) error {
 		return errors.New("nothing to restore from backup")
 	}
 
+	// packwiz's cache has to match the restored files; without one in the backup, the
+	// next sync re-hashes everything rather than trusting the newer version's cache
+	manifestDst := filepath.Join(mcDir, packwizManifestFile)
+	if manifestSrc := filepath.Join(backupPath, packwizManifestFile); exists(manifestSrc) {
+		if err := copyFile(manifestSrc, manifestDst); err != nil {
+			logf("%s", warnLine(fmt.Sprintf("Failed to restore %s: %v", packwizManifestFile, err)))
+			_ = os.Remove(manifestDst)
+		}
+	} else if err := os.Remove(manifestDst); err != nil && !os.IsNotExist(err) {
+		logf("%s", warnLine(fmt.Sprintf("Failed to remove %s: %v", packwizManifestFile, err)))
+	}
+
 	logf("%s", successLine(fmt.Sprintf("Restored %s: %s", packName, strings.Join(restoredItems, ", "))))
 	return nil
 }
 
-// cleanupOldBackups removes old backups, keeping only the most recent ones
-func cleanupOldBackups(mp Modpack, mcDir string, keepCount int) error {
-	packName := modpackLabel(mp)
-	rootDir := filepath.Dir(filepath.Dir(filepath.Dir(mcDir)))
-	backupsDir := filepath.Join(rootDir, "util", "backups")
-	if !exists(backupsDir) {
-		return nil
+// backupTimestampLayout is the creation time in backup directory names
+const backupTimestampLayout = "2006-01-02-15-04-05"
+
+// backupCreatedAt returns when a backup was made: the time in its backup.json, else
+// the time in its name, else when the directory was last modified
+func backupCreatedAt(backupPath, prefix string) time.Time {
+	if meta, err := readBackupMetadata(backupPath); err == nil && !meta.CreatedAt.IsZero() {
+		return meta.CreatedAt
+	}
+	name := strings.TrimPrefix(filepath.Base(backupPath), prefix)
+	if t, err := time.ParseInLocation(backupTimestampLayout, name, time.Local); err == nil {
+		return t
+	}
+	if info, err := os.Stat(backupPath); err == nil {
+		return info.ModTime()
 	}
+	return time.Time{}
+}
+
+// modpackBackup is one backup of a modpack as listed for rotation and restore
+type modpackBackup struct {
+	Name    string
+	Path    string
+	Created time.Time
+	// Meta is nil for backups made before backup.json was written
+	Meta *BackupMetadata
+}
 
+// listModpackBackups returns the backups of mp, newest first
+func listModpackBackups(root string, mp Modpack) ([]modpackBackup, error) {
+	backupsDir := backupsDirFor(root)
 	entries, err := os.ReadDir(backupsDir)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	var backupDirs []os.DirEntry
+	var backups []modpackBackup
 	prefix := backupPrefixFor(mp)
 	for _, entry := range entries {
-		if entry.IsDir() && strings.HasPrefix(entry.Name(), prefix) {
-			backupDirs = append(backupDirs, entry)
+		if !entry.IsDir() || !strings.HasPrefix(entry.Name(), prefix) {
+			continue
+		}
+		path := filepath.Join(backupsDir, entry.Name())
+		b := modpackBackup{Name: entry.Name(), Path: path, Created: backupCreatedAt(path, prefix)}
+		if meta, err := readBackupMetadata(path); err == nil {
+			b.Meta = meta
 		}
+		backups = append(backups, b)
 	}
+	sort.SliceStable(backups, func(i, j int) bool {
+		return backups[i].Created.After(backups[j].Created)
+	})
+	return backups, nil
+}
 
-	// Sort by name (which includes timestamp, so this sorts by time)
-	if len(backupDirs) <= keepCount {
-		return nil
+// cleanupOldBackups removes old backups, keeping only the keepCount most recent ones
+func cleanupOldBackups(root string, mp Modpack, keepCount int) error {
+	packName := modpackLabel(mp)
+	backups, err := listModpackBackups(root, mp)
+	if err != nil || len(backups) <= keepCount {
+		return err
 	}
 
-	// Remove oldest backups (keep the most recent ones)
-	sort.Slice(backupDirs, func(i, j int) bool {
-		return backupDirs[i].Name() > backupDirs[j].Name() // newer names first
-	})
-
-	toRemove := backupDirs[keepCount:]
-	for _, entry := range toRemove {
-		removePath := filepath.Join(backupsDir, entry.Name())
-		if err := os.RemoveAll(removePath); err != nil {
-			logf("%s", warnLine(fmt.Sprintf("Failed to remove old %s backup %s: %v", packName, entry.Name(), err)))
+	for _, b := range backups[keepCount:] {
+		if err := os.RemoveAll(b.Path); err != nil {
+			logf("%s", warnLine(fmt.Sprintf("Failed to remove old %s backup %s: %v", packName, b.Name, err)))
 		} else {
-			logf("%s", successLine(fmt.Sprintf("Removed old %s backup: %s", packName, entry.Name())))
+			logf("%s", successLine(fmt.Sprintf("Removed old %s backup: %s (from %s)", packName, b.Name, b.Created.Format("2006-01-02 15:04"))))
 		}
 	}
 
 	return nil
 }
+
+// -------------------- Installation verification --------------------
+
+// packwizManifestFile is where packwiz-installer caches the hashes of the files it installed
+const packwizManifestFile = "packwiz.json"
+
+// verifySkipDirs hold player data that packwiz never touches, so they aren't scanned
+var verifySkipDirs = map[string]bool{
+	"saves":         true,
+	"logs":          true,
+	"crash-reports": true,
+	"screenshots":   true,
+	"backups":       true,
+}
+
+// fileStamp is enough of a file's metadata to tell that it was rewritten
+type fileStamp struct {
+	size    int64
+	modTime time.Time
+}
+
+// snapshotPackFiles records the files under mcDir that a packwiz sync may replace
+func snapshotPackFiles(mcDir string) (map[string]fileStamp, error) {
+	files := make(map[string]fileStamp)
+	err := filepath.Walk(mcDir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(mcDir, path)
+		if err != nil {
+			return err
+		}
+		if info.IsDir() {
+			if verifySkipDirs[rel] {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if info.Mode().IsRegular() && rel != packwizManifestFile {
+			files[filepath.ToSlash(rel)] = fileStamp{size: info.Size(), modTime: info.ModTime()}
+		}
+		return nil
+	})
+	return files, err
+}
+
+// countChangedFiles returns how many files in after are new or were rewritten since before
+func countChangedFiles(before, after map[string]fileStamp) int {
+	changed := 0
+	for rel, stamp := range after {
+		if old, ok := before[rel]; !ok || old.size != stamp.size || !old.modTime.Equal(stamp.modTime) {
+			changed++
+		}
+	}
+	return changed
+}
+
+// setAsidePackwizManifest moves packwiz-installer's hash cache out of the way so the
+// next sync re-hashes every file instead of trusting it. The returned func puts the
+// cache back, for when the sync fails before writing a new one.
+func setAsidePackwizManifest(mcDir string) (func(), error) {
+	manifest := filepath.Join(mcDir, packwizManifestFile)
+	aside := manifest + ".verify"
+	if err := os.Rename(manifest, aside); err != nil {
+		if os.IsNotExist(err) {
+			return func() {}, nil
+		}
+		return nil, err
+	}
+	return func() {
+		if exists(manifest) {
+			_ = os.Remove(aside)
+			return
+		}
+		if err := os.Rename(aside, manifest); err != nil {
+			logf("%s", warnLine(fmt.Sprintf("Failed to restore %s: %v", packwizManifestFile, err)))
+		}
+	}, nil
+}
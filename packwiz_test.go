@@ -0,0 +1,534 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// writeTestMMCPack writes a minimal mmc-pack.json for the given Minecraft version and loader UID
+func writeTestMMCPack(t *testing.T, instDir, minecraft, loaderUID, loaderVersion string) {
+	t.Helper()
+	content := `{"formatVersion": 1, "components": [
+		{"uid": "net.minecraft", "version": "` + minecraft + `"},
+		{"uid": "` + loaderUID + `", "version": "` + loaderVersion + `"}
+	]}`
+	if err := os.MkdirAll(instDir, 0755); err != nil {
+		t.Fatalf("Failed to create instance directory: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(instDir, "mmc-pack.json"), []byte(content), 0644); err != nil {
+		t.Fatalf("Failed to write mmc-pack.json: %v", err)
+	}
+}
+
+// TestBackupCompatibilityWarnings tests that backup metadata is compared with the installed instance
+func TestBackupCompatibilityWarnings(t *testing.T) {
+	mp := Modpack{ID: "testpack", DisplayName: "Test Pack"}
+	instDir := filepath.Join(t.TempDir(), "TestPack")
+	backupPath := t.TempDir()
+
+	writeTestMMCPack(t, instDir, "1.20.1", "net.minecraftforge", "47.2.0")
+	if err := writeBackupMetadata(mp, backupPath, instDir); err != nil {
+		t.Fatalf("writeBackupMetadata failed: %v", err)
+	}
+
+	meta, err := readBackupMetadata(backupPath)
+	if err != nil {
+		t.Fatalf("readBackupMetadata failed: %v", err)
+	}
+	if meta.MinecraftVersion != "1.20.1" || meta.ModLoader != "forge" {
+		t.Errorf("Expected 1.20.1/forge in metadata, got %s/%s", meta.MinecraftVersion, meta.ModLoader)
+	}
+
+	if warnings := backupCompatibilityWarnings(backupPath, instDir); len(warnings) != 0 {
+		t.Errorf("Expected no warnings for matching instance, got: %v", warnings)
+	}
+
+	// Instance moved to a new Minecraft version and modloader
+	writeTestMMCPack(t, instDir, "1.21.1", "net.neoforged.neoforge", "21.1.0")
+	if warnings := backupCompatibilityWarnings(backupPath, instDir); len(warnings) != 2 {
+		t.Errorf("Expected 2 warnings for mismatched instance, got: %v", warnings)
+	}
+
+	// Restoring without confirmation must be refused
+	if err := restoreModpackBackup(mp, backupPath, filepath.Join(instDir, "minecraft"), nil); err == nil {
+		t.Error("Expected restore of mismatched backup to be refused without confirmation")
+	}
+}
+
+// TestBackupCompatibilityWarningsNoMetadata tests that older backups without backup.json are accepted
+func TestBackupCompatibilityWarningsNoMetadata(t *testing.T) {
+	instDir := filepath.Join(t.TempDir(), "TestPack")
+	writeTestMMCPack(t, instDir, "1.21.1", "net.fabricmc.fabric-loader", "0.16.0")
+
+	if warnings := backupCompatibilityWarnings(t.TempDir(), instDir); len(warnings) != 0 {
+		t.Errorf("Expected no warnings without backup metadata, got: %v", warnings)
+	}
+}
+
+// TestRestoreModpackBackup tests that restoring a backup brings back its mods and the
+// packwiz cache that matches them, and drops the newer cache when the backup has none
+func TestRestoreModpackBackup(t *testing.T) {
+	mp := Modpack{ID: "testpack", DisplayName: "Test Pack"}
+	root := t.TempDir()
+	instDir := filepath.Join(root, "instances", "TestPack")
+	mcDir := filepath.Join(instDir, "minecraft")
+	writeTestMMCPack(t, instDir, "1.20.1", "net.minecraftforge", "47.2.0")
+	write := func(rel, content string) {
+		path := filepath.Join(mcDir, filepath.FromSlash(rel))
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	read := func(rel string) string {
+		data, _ := os.ReadFile(filepath.Join(mcDir, filepath.FromSlash(rel)))
+		return string(data)
+	}
+
+	write("mods/old.jar", "old")
+	write(packwizManifestFile, `{"version": "old"}`)
+	backupPath, err := createModpackBackup(root, mp, mcDir)
+	if err != nil || backupPath == "" {
+		t.Fatalf("createModpackBackup failed: %q, %v", backupPath, err)
+	}
+
+	os.RemoveAll(filepath.Join(mcDir, "mods"))
+	write("mods/new.jar", "new")
+	write(packwizManifestFile, `{"version": "new"}`)
+	if err := restoreModpackBackup(mp, backupPath, mcDir, nil); err != nil {
+		t.Fatalf("restoreModpackBackup failed: %v", err)
+	}
+	if read("mods/old.jar") != "old" || exists(filepath.Join(mcDir, "mods", "new.jar")) {
+		t.Error("Expected the backed up mods in place of the new ones")
+	}
+	if got := read(packwizManifestFile); got != `{"version": "old"}` {
+		t.Errorf("Expected the backed up packwiz cache, got %q", got)
+	}
+
+	// Backups made before the cache was kept leave none behind to be trusted
+	os.Remove(filepath.Join(backupPath, packwizManifestFile))
+	write(packwizManifestFile, `{"version": "new"}`)
+	if err := restoreModpackBackup(mp, backupPath, mcDir, nil); err != nil {
+		t.Fatalf("restoreModpackBackup failed: %v", err)
+	}
+	if exists(filepath.Join(mcDir, packwizManifestFile)) {
+		t.Error("Expected the newer packwiz cache to be removed")
+	}
+}
+
+// TestMigrateLegacyBackups tests that backups kept under prism/util/backups by earlier
+// versions are moved into the current backups folder and listed from there
+func TestMigrateLegacyBackups(t *testing.T) {
+	saved := settings
+	defer func() { settings = saved }()
+	settings.InstancesDir = ""
+	mp := Modpack{ID: "testpack", DisplayName: "Test Pack"}
+	root := t.TempDir()
+	legacy := filepath.Join(root, "prism", "util", "backups")
+	name := backupPrefixFor(mp) + "2024-06-01-10-00-00"
+	if err := os.MkdirAll(filepath.Join(legacy, name, "mods"), 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	backups, err := listModpackBackups(root, mp)
+	if err != nil || len(backups) != 1 || backups[0].Name != name {
+		t.Fatalf("Expected the old backup to be listed, got %+v, %v", backups, err)
+	}
+	if !exists(filepath.Join(root, "util", "backups", name, "mods")) {
+		t.Error("Expected the backup to be moved into util/backups")
+	}
+	if exists(legacy) {
+		t.Error("Expected the emptied old backups folder to be removed")
+	}
+}
+
+// TestCleanupOldBackups tests that only the newest backups by backup.json timestamp survive rotation
+func TestCleanupOldBackups(t *testing.T) {
+	mp := Modpack{ID: "testpack", DisplayName: "Test Pack"}
+	root := t.TempDir()
+	backupsDir := backupsDirFor(root)
+
+	// Names sort the other way round from the recorded times, so the metadata must win
+	base := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	for i := 0; i < 5; i++ {
+		backupPath := filepath.Join(backupsDir, fmt.Sprintf("%s%d", backupPrefixFor(mp), 9-i))
+		if err := os.MkdirAll(backupPath, 0755); err != nil {
+			t.Fatal(err)
+		}
+		data, _ := json.Marshal(BackupMetadata{ModpackID: mp.ID, CreatedAt: base.Add(time.Duration(i) * time.Hour)})
+		if err := os.WriteFile(filepath.Join(backupPath, backupMetadataFile), data, 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	other := filepath.Join(backupsDir, "otherpack-backup-2020-01-01-00-00-00")
+	if err := os.MkdirAll(other, 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := cleanupOldBackups(root, mp, 3); err != nil {
+		t.Fatalf("cleanupOldBackups failed: %v", err)
+	}
+	for i := 0; i < 5; i++ {
+		name := fmt.Sprintf("%s%d", backupPrefixFor(mp), 9-i)
+		if kept := exists(filepath.Join(backupsDir, name)); kept != (i >= 2) {
+			t.Errorf("Backup %d (%s): expected kept=%v", i, name, i >= 2)
+		}
+	}
+	if !exists(other) {
+		t.Error("Expected another modpack's backup to be left alone")
+	}
+}
+
+// TestListModpackBackups tests that backups are listed newest first, including ones without backup.json
+func TestListModpackBackups(t *testing.T) {
+	mp := Modpack{ID: "testpack", DisplayName: "Test Pack"}
+	root := t.TempDir()
+	legacy := filepath.Join(backupsDirFor(root), backupPrefixFor(mp)+"2023-05-01-10-00-00")
+	recent := filepath.Join(backupsDirFor(root), backupPrefixFor(mp)+"2024-06-01-10-00-00")
+	for _, dir := range []string{legacy, recent} {
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			t.Fatal(err)
+		}
+	}
+	data, _ := json.Marshal(BackupMetadata{ModpackID: mp.ID, PackVersion: "1.4.0", MinecraftVersion: "1.20.1", CreatedAt: time.Date(2024, 6, 1, 10, 0, 0, 0, time.Local)})
+	if err := os.WriteFile(filepath.Join(recent, backupMetadataFile), data, 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	backups, err := listModpackBackups(root, mp)
+	if err != nil {
+		t.Fatalf("listModpackBackups failed: %v", err)
+	}
+	if len(backups) != 2 || backups[0].Path != recent || backups[1].Path != legacy {
+		t.Fatalf("Expected the recent backup first, got %+v", backups)
+	}
+	if backups[1].Meta != nil || backups[1].Created.Year() != 2023 {
+		t.Errorf("Expected the legacy backup to be dated from its name, got %+v", backups[1])
+	}
+	if got := backupLabel(backups[0], 5*1024*1024); got != "2024-06-01 10:00 · version 1.4.0 · Minecraft 1.20.1 · 5 MB" {
+		t.Errorf("Unexpected backup label %q", got)
+	}
+}
+
+// TestScanManualMods tests that CurseForge projects packwiz asked for by hand are found
+// in the pack index, and that other CurseForge mods are left to packwiz
+func TestScanManualMods(t *testing.T) {
+	files := map[string]string{
+		"/pack/pack.toml": `name = "Test"
+version = "1.0.0"
+[index]
+file = "index.toml"
+[versions]
+minecraft = "1.20.1"
+forge = "47.2.0"
+`,
+		"/pack/index.toml": `[[files]]
+file = "mods/excluded-mod.pw.toml"
+metafile = true
+[[files]]
+file = "mods/present-mod.pw.toml"
+metafile = true
+[[files]]
+file = "mods/regular-mod.pw.toml"
+metafile = true
+[[files]]
+file = "config/settings.cfg"
+`,
+		"/pack/mods/excluded-mod.pw.toml": `name = "Excluded Mod"
+filename = "excluded-mod-1.0.jar"
+[download]
+mode = "metadata:curseforge"
+[update.curseforge]
+file-id = 1234567
+project-id = 42
+`,
+		"/pack/mods/regular-mod.pw.toml": `name = "Regular Mod"
+filename = "regular-mod-3.0.jar"
+[download]
+mode = "metadata:curseforge"
+[update.curseforge]
+file-id = 1000000
+project-id = 44
+`,
+		"/pack/mods/present-mod.pw.toml": `name = "Present Mod"
+filename = "present-mod-2.0.jar"
+[download]
+mode = "metadata:curseforge"
+[update.curseforge]
+file-id = 7654321
+project-id = 43
+`,
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, ok := files[r.URL.Path]
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+		w.Write([]byte(body))
+	}))
+	defer server.Close()
+
+	info, err := fetchPackInfo(server.URL+"/pack/pack.toml", nil)
+	if err != nil {
+		t.Fatalf("fetchPackInfo failed: %v", err)
+	}
+	if info.IndexURL != server.URL+"/pack/index.toml" {
+		t.Errorf("Expected index URL to resolve next to pack.toml, got %q", info.IndexURL)
+	}
+
+	mcDir := t.TempDir()
+	utilDir := t.TempDir()
+	// Nothing is known to need a manual download before packwiz has reported it
+	if items := scanManualMods(info, mcDir, utilDir); len(items) != 0 {
+		t.Errorf("Expected no manual mods without any remembered projects, got %+v", items)
+	}
+
+	rememberManualMods(utilDir, []manualItem{
+		{Name: "Excluded Mod", URL: "https://www.curseforge.com/minecraft/mc-mods/excluded-mod/files/1111111"},
+		{Name: "Present Mod", URL: "https://www.curseforge.com/minecraft/mc-mods/present-mod/files/2222222"},
+	})
+	if err := os.MkdirAll(filepath.Join(mcDir, "mods"), 0755); err != nil {
+		t.Fatalf("Failed to create mods directory: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(mcDir, "mods", "present-mod-2.0.jar"), []byte("jar"), 0644); err != nil {
+		t.Fatalf("Failed to create mod file: %v", err)
+	}
+
+	items := scanManualMods(info, mcDir, utilDir)
+	if len(items) != 1 {
+		t.Fatalf("Expected 1 missing manual mod, got %+v", items)
+	}
+	if items[0].URL != "https://www.curseforge.com/minecraft/mc-mods/excluded-mod/files/1234567" {
+		t.Errorf("Expected download page for the pack's current file, got %q", items[0].URL)
+	}
+	if want := filepath.Join(mcDir, "mods", "excluded-mod-1.0.jar"); items[0].Path != want {
+		t.Errorf("Expected destination %q, got %q", want, items[0].Path)
+	}
+}
+
+// TestPackHeaders tests that custom headers reach the pack host directly and through the packwiz proxy
+func TestPackHeaders(t *testing.T) {
+	saved := settings
+	defer func() { settings = saved }()
+	t.Setenv("THEBOYS_TEST_PACK_TOKEN", "s3cret-token")
+	t.Setenv("THEBOYS_TEST_OTHER_SECRET", "not-for-catalogs")
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer s3cret-token" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		w.Write([]byte("version = \"1.2.3\"\n[versions]\nminecraft = \"1.20.1\"\nforge = \"47.2.0\"\n"))
+	}))
+	defer server.Close()
+	headers := map[string]string{"Authorization": "Bearer ${THEBOYS_TEST_PACK_TOKEN}"}
+	packURL := server.URL + "/private/pack.toml"
+
+	if _, err := fetchRemotePackVersion(packURL, nil); err == nil {
+		t.Error("Expected a request without the header to be refused")
+	}
+	// Variables the user hasn't allowed are never read
+	if _, err := fetchRemotePackVersion(packURL, headers); err == nil {
+		t.Error("Expected a variable missing from headerEnvVars not to be expanded")
+	}
+	if got := expandHeaderValue("$THEBOYS_TEST_OTHER_SECRET"); got != "${THEBOYS_TEST_OTHER_SECRET}" {
+		t.Errorf("Expected an unlisted variable to be sent as written, got %q", got)
+	}
+	settings.HeaderEnvVars = []string{"THEBOYS_TEST_PACK_TOKEN"}
+	if v, err := fetchRemotePackVersion(packURL, headers); err != nil || v != "1.2.3" {
+		t.Errorf("Expected version 1.2.3 with the header, got %q, %v", v, err)
+	}
+
+	proxyURL, stop, err := startPackProxy(packURL, headers)
+	if err != nil {
+		t.Fatalf("startPackProxy failed: %v", err)
+	}
+	defer stop()
+	if v, err := fetchRemotePackVersion(proxyURL, nil); err != nil || v != "1.2.3" {
+		t.Errorf("Expected the proxy to add the header, got %q, %v", v, err)
+	}
+	// Requests that don't know the proxy's prefix are refused
+	if u, _ := url.Parse(proxyURL); u != nil {
+		u.Path = "/private/pack.toml"
+		if _, err := fetchRemotePackVersion(u.String(), nil); err == nil {
+			t.Error("Expected the proxy to refuse a path without its prefix")
+		}
+	}
+
+	if got := describeHeaders(headers); got != "Authorization: ****oken" {
+		t.Errorf("Expected the header value to be masked, got %q", got)
+	}
+}
+
+// TestEstimatePackSize tests that index entries and metafile downloads are summed and unsized files counted
+func TestEstimatePackSize(t *testing.T) {
+	var server *httptest.Server
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/pack/pack.toml":
+			w.Write([]byte("name = \"Test\"\nversion = \"1.0.0\"\n[index]\nfile = \"index.toml\"\n[versions]\nminecraft = \"1.20.1\"\nfabric = \"0.16.0\"\n"))
+		case "/pack/index.toml":
+			w.Write([]byte("[[files]]\nfile = \"config/settings.cfg\"\n[[files]]\nfile = \"mods/hosted.pw.toml\"\nmetafile = true\n[[files]]\nfile = \"mods/curse.pw.toml\"\nmetafile = true\n"))
+		case "/pack/config/settings.cfg":
+			w.Write(make([]byte, 1000))
+		case "/pack/mods/hosted.pw.toml":
+			w.Write([]byte("name = \"Hosted\"\nfilename = \"hosted.jar\"\n[download]\nurl = \"" + server.URL + "/cdn/hosted.jar\"\n"))
+		case "/pack/mods/curse.pw.toml":
+			w.Write([]byte("name = \"Curse\"\nfilename = \"curse.jar\"\n[download]\nmode = \"metadata:curseforge\"\n"))
+		case "/cdn/hosted.jar":
+			if r.Header.Get("X-Pack-Token") != "" {
+				t.Error("Pack headers must not be sent to mod download hosts")
+			}
+			w.Header().Set("Content-Length", "5000")
+			w.Write(make([]byte, 5000))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	estimate, err := estimatePackSize(server.URL+"/pack/pack.toml", map[string]string{"X-Pack-Token": "secret"})
+	if err != nil {
+		t.Fatalf("estimatePackSize failed: %v", err)
+	}
+	if estimate.Bytes != 6000 || estimate.Files != 2 || estimate.Unknown != 1 {
+		t.Errorf("Expected 6000 bytes from 2 files with 1 unknown, got %+v", estimate)
+	}
+
+	for bytes, want := range map[int64]string{500: "1 KB", 350 * 1024 * 1024: "350 MB", 3 * 1024 * 1024 * 1024 / 2: "1.5 GB"} {
+		if got := formatSize(bytes); got != want {
+			t.Errorf("formatSize(%d) = %q, want %q", bytes, got, want)
+		}
+	}
+}
+
+// TestKeepAndRestorePlayerData tests that saves survive a reinstall and replace what the fresh install created
+func TestKeepAndRestorePlayerData(t *testing.T) {
+	mcDir := filepath.Join(t.TempDir(), "minecraft")
+	keptDir := filepath.Join(t.TempDir(), "kept", "pack")
+	if err := os.MkdirAll(filepath.Join(mcDir, "saves", "World"), 0755); err != nil {
+		t.Fatalf("Failed to create saves: %v", err)
+	}
+	for name, content := range map[string]string{"saves/World/level.dat": "world", "options.txt": "fov:90", "mods/a.jar": "mod"} {
+		if err := os.MkdirAll(filepath.Dir(filepath.Join(mcDir, name)), 0755); err != nil {
+			t.Fatalf("Failed to create directory: %v", err)
+		}
+		if err := os.WriteFile(filepath.Join(mcDir, name), []byte(content), 0644); err != nil {
+			t.Fatalf("Failed to write %s: %v", name, err)
+		}
+	}
+
+	kept, err := keepPlayerData(mcDir, keptDir)
+	if err != nil {
+		t.Fatalf("keepPlayerData failed: %v", err)
+	}
+	if len(kept) != 2 || exists(filepath.Join(mcDir, "saves")) || !exists(filepath.Join(mcDir, "mods", "a.jar")) {
+		t.Errorf("Expected only saves and options.txt to be moved aside, got %v", kept)
+	}
+
+	// The fresh install ships its own options.txt
+	if err := os.RemoveAll(mcDir); err != nil {
+		t.Fatalf("Failed to wipe instance: %v", err)
+	}
+	if err := os.MkdirAll(mcDir, 0755); err != nil {
+		t.Fatalf("Failed to recreate instance: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(mcDir, "options.txt"), []byte("fov:70"), 0644); err != nil {
+		t.Fatalf("Failed to write options.txt: %v", err)
+	}
+
+	if _, err := restorePlayerData(keptDir, mcDir); err != nil {
+		t.Fatalf("restorePlayerData failed: %v", err)
+	}
+	if data, _ := os.ReadFile(filepath.Join(mcDir, "options.txt")); string(data) != "fov:90" {
+		t.Errorf("Expected the player's options.txt to win, got %q", data)
+	}
+	if !exists(filepath.Join(mcDir, "saves", "World", "level.dat")) {
+		t.Error("Expected the world to be restored")
+	}
+	if exists(keptDir) {
+		t.Error("Expected the holding folder to be removed after restoring")
+	}
+}
+
+// TestVerifyFileTracking tests that a verify counts rewritten files and skips player data
+func TestVerifyFileTracking(t *testing.T) {
+	mcDir := t.TempDir()
+	write := func(rel, content string) {
+		path := filepath.Join(mcDir, filepath.FromSlash(rel))
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatalf("Failed to create %s: %v", filepath.Dir(path), err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatalf("Failed to write %s: %v", rel, err)
+		}
+	}
+	write("mods/a.jar", "a")
+	write("mods/b.jar", "b")
+	write("saves/world/level.dat", "world")
+	write(packwizManifestFile, "{}")
+
+	before, err := snapshotPackFiles(mcDir)
+	if err != nil {
+		t.Fatalf("snapshotPackFiles failed: %v", err)
+	}
+	if len(before) != 2 {
+		t.Errorf("Expected only the two mods to be tracked, got %v", before)
+	}
+
+	write("mods/b.jar", "repaired b")
+	write("mods/c.jar", "c")
+	write("saves/world/level.dat", "played")
+	after, err := snapshotPackFiles(mcDir)
+	if err != nil {
+		t.Fatalf("snapshotPackFiles failed: %v", err)
+	}
+	if got := countChangedFiles(before, after); got != 2 {
+		t.Errorf("Expected the rewritten and the new mod to count, got %d", got)
+	}
+}
+
+// TestSetAsidePackwizManifest tests that the packwiz cache comes back only when the sync didn't replace it
+func TestSetAsidePackwizManifest(t *testing.T) {
+	mcDir := t.TempDir()
+	manifest := filepath.Join(mcDir, packwizManifestFile)
+	if err := os.WriteFile(manifest, []byte("old"), 0644); err != nil {
+		t.Fatalf("Failed to write manifest: %v", err)
+	}
+
+	restore, err := setAsidePackwizManifest(mcDir)
+	if err != nil {
+		t.Fatalf("setAsidePackwizManifest failed: %v", err)
+	}
+	if exists(manifest) {
+		t.Fatal("Expected the manifest to be moved aside")
+	}
+	restore()
+	if data, _ := os.ReadFile(manifest); string(data) != "old" {
+		t.Errorf("Expected the old manifest back after a failed sync, got %q", data)
+	}
+
+	restore, err = setAsidePackwizManifest(mcDir)
+	if err != nil {
+		t.Fatalf("setAsidePackwizManifest failed: %v", err)
+	}
+	if err := os.WriteFile(manifest, []byte("new"), 0644); err != nil {
+		t.Fatalf("Failed to write manifest: %v", err)
+	}
+	restore()
+	if data, _ := os.ReadFile(manifest); string(data) != "new" {
+		t.Errorf("Expected the manifest written by the sync to be kept, got %q", data)
+	}
+	if exists(manifest + ".verify") {
+		t.Error("Expected the set-aside copy to be removed")
+	}
+}
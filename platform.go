@@ -132,6 +132,15 @@ func BuildPathEnv(additionalPath string) string {
 	return additionalPath + separator + os.Getenv("PATH")
 }
 
+// prismConfigFile returns the prismlauncher.cfg Prism reads: the app support
+// config on macOS, the portable copy next to Prism elsewhere
+func prismConfigFile(prismDir string) string {
+	if runtime.GOOS == "darwin" {
+		return filepath.Join(GetPrismConfigDir(), "prismlauncher.cfg")
+	}
+	return filepath.Join(prismDir, "prismlauncher.cfg")
+}
+
 // GetPrismConfigDir returns the Prism configuration directory
 func GetPrismConfigDir() string {
 	if runtime.GOOS == "windows" {
@@ -4,10 +4,13 @@
 package main
 
 import (
+	"fmt"
 	"os"
 	"os/exec"
 	"path/filepath"
 	"runtime"
+	"strings"
+	"syscall"
 )
 
 // macOS memory detection using sysctl
@@ -24,7 +27,7 @@ func totalRAMMB() int {
 }
 
 // macOS-specific directory paths
-func getLauncherHome() string {
+func defaultLauncherHome() string {
 	// macOS: ~/Library/Application Support/TheBoysLauncher
 	homeDir := os.Getenv("HOME")
 	if homeDir == "" {
@@ -88,3 +91,38 @@ func getArchitecture() string {
 func isAppBundle(path string) bool {
 	return filepath.Ext(path) == ".app"
 }
+
+// macOS: open a file or folder with Finder / the default application
+func openPath(path string) error {
+	cmd := exec.Command("open", path)
+	if err := cmd.Start(); err != nil {
+		return err
+	}
+	go cmd.Wait()
+	return nil
+}
+
+// macOS: free space available to this user on the volume holding path
+func freeDiskSpace(path string) (int64, error) {
+	var st syscall.Statfs_t
+	if err := syscall.Statfs(path, &st); err != nil {
+		return 0, err
+	}
+	return int64(st.Bavail * uint64(st.Bsize)), nil
+}
+
+// macOS: remove the com.apple.quarantine attribute Gatekeeper puts on downloaded files
+// from path and everything inside it. Reports whether anything was quarantined.
+func clearQuarantine(path string) (bool, error) {
+	list, err := exec.Command("xattr", "-lr", path).Output()
+	if err != nil {
+		return false, fmt.Errorf("failed to read extended attributes of %s: %w", path, err)
+	}
+	if !strings.Contains(string(list), quarantineAttribute) {
+		return false, nil
+	}
+	if output, err := exec.Command("xattr", "-dr", quarantineAttribute, path).CombinedOutput(); err != nil {
+		return true, fmt.Errorf("xattr -dr %s failed: %v: %s", quarantineAttribute, err, strings.TrimSpace(string(output)))
+	}
+	return true, nil
+}
@@ -10,6 +10,7 @@ import (
 	"runtime"
 	"strconv"
 	"strings"
+	"syscall"
 )
 
 // Linux memory detection using /proc/meminfo
@@ -43,7 +44,7 @@ func totalRAMMB() int {
 }
 
 // Linux-specific directory paths
-func getLauncherHome() string {
+func defaultLauncherHome() string {
 	// Linux: ~/.theboyslauncher
 	homeDir := os.Getenv("HOME")
 	if homeDir == "" {
@@ -106,3 +107,27 @@ func getArchitecture() string {
 func isAppBundle(path string) bool {
 	return false
 }
+
+// Linux: open a file or folder with the desktop's default application
+func openPath(path string) error {
+	cmd := exec.Command("xdg-open", path)
+	if err := cmd.Start(); err != nil {
+		return err
+	}
+	go cmd.Wait()
+	return nil
+}
+
+// Linux: free space available to this user on the volume holding path
+func freeDiskSpace(path string) (int64, error) {
+	var st syscall.Statfs_t
+	if err := syscall.Statfs(path, &st); err != nil {
+		return 0, err
+	}
+	return int64(st.Bavail * uint64(st.Bsize)), nil
+}
+
+// Linux: downloads are never quarantined
+func clearQuarantine(path string) (bool, error) {
+	return false, nil
+}
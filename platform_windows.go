@@ -84,7 +84,7 @@ func isInstalledMode(installPath string) bool {
 }
 
 // Windows-specific directory paths
-func getLauncherHome() string {
+func defaultLauncherHome() string {
 	// First, check the registry for custom installation path
 	installPath := readInstallationPathFromRegistry()
 
@@ -146,3 +146,32 @@ func setExecutablePermissions(path string) error {
 	// Windows doesn't have executable permissions in the same way as Unix
 	return nil
 }
+
+// Windows: open a file or folder with Explorer / the default application
+func openPath(path string) error {
+	cmd := exec.Command("rundll32", "url.dll,FileProtocolHandler", path)
+	if err := cmd.Start(); err != nil {
+		return err
+	}
+	go cmd.Wait()
+	return nil
+}
+
+// Windows: free space available to this user on the volume holding path
+func freeDiskSpace(path string) (int64, error) {
+	p, err := windows.UTF16PtrFromString(path)
+	if err != nil {
+		return 0, err
+	}
+	var free uint64
+	if err := windows.GetDiskFreeSpaceEx(p, &free, nil, nil); err != nil {
+		return 0, err
+	}
+	return int64(free), nil
+}
+
+// Windows: SmartScreen marks downloads through a zone stream, which doesn't stop
+// Prism or Java from starting
+func clearQuarantine(path string) (bool, error) {
+	return false, nil
+}
@@ -1,11 +1,14 @@
 package main
 
 import (
+	"context"
+	"encoding/json"
 	"encoding/xml"
 	"errors"
 	"fmt"
 	"io"
 	"net/http"
+	"net/url"
 	"os"
 	"path/filepath"
 	"regexp"
@@ -15,13 +18,24 @@ import (
 
 // -------------------- Prism + Instance --------------------
 
-func ensurePrism(dir string) (bool, error) {
-	if exists(GetPrismExecutablePath(dir)) {
-		return false, nil
+// ensurePrism downloads Prism Launcher into dir unless it is already there. A build
+// pinned with PrismDownloadURL or PrismVersion replaces the existing one once when
+// the pin changes; on macOS the pin only applies to a fresh install.
+func ensurePrism(ctx context.Context, dir string) (bool, error) {
+	pin, err := prismPin()
+	if err != nil {
+		return false, err
+	}
+	if exe := GetPrismExecutablePath(dir); exists(exe) && (pin == "" || runtime.GOOS == "darwin" || readPrismPin(dir) == pin) {
+		// Builds downloaded before checksums were recorded have no digest and are trusted
+		err := verifyFileChecksum(exe)
+		if err == nil {
+			return false, nil
+		}
+		logf("%s", warnLine(fmt.Sprintf("Prism Launcher looks damaged (%v); downloading it again", err)))
 	}
 
 	var url string
-	var err error
 
 	// Handle different platforms - macOS doesn't have portable builds
 	if runtime.GOOS == "darwin" {
@@ -46,13 +60,13 @@ func ensurePrism(dir string) (bool, error) {
 		os.MkdirAll(tempDir, 0755)
 		defer os.RemoveAll(tempDir)
 
-		url, err = fetchLatestPrismPortableURL()
+		url, err = prismDownloadURL()
 		if err != nil {
 			return false, err
 		}
 
 		logf("%s", stepLine(fmt.Sprintf("Downloading Prism universal build: %s", url)))
-		if err := downloadAndUnzipTo(url, tempDir); err != nil {
+		if err := downloadAndUnzipTo(withDownloadName(ctx, "Prism Launcher"), url, fetchSHA256Sidecar(ctx, url), tempDir); err != nil {
 			return false, err
 		}
 
@@ -115,6 +129,12 @@ func ensurePrism(dir string) (bool, error) {
 		}
 
 		logf("%s", successLine("Prism Launcher installed in Applications folder"))
+		if err := os.MkdirAll(dir, 0755); err == nil {
+			err = os.WriteFile(filepath.Join(dir, prismAppFile), []byte(targetAppPath+"\n"), 0644)
+		}
+		if err != nil {
+			logf("%s", warnLine(fmt.Sprintf("Failed to record the installed Prism app: %v", err)))
+		}
 
 		// Create local config directory for our customizations
 		configDir := GetPrismConfigDir()
@@ -128,17 +148,36 @@ AutomaticJavaDownload=false
 AutomaticJavaSwitch=false
 UserAskedAboutAutomaticJavaDownload=true
 `
-		_ = os.WriteFile(cfg, []byte(prismConfig), 0644)
+		if !exists(cfg) {
+			_ = os.WriteFile(cfg, []byte(prismConfig), 0644)
+		}
 	} else {
 		// Windows/Linux: download portable builds
-		url, err = fetchLatestPrismPortableURL()
+		url, err = prismDownloadURL()
 		if err != nil {
 			return false, err
 		}
 		logf("%s", stepLine(fmt.Sprintf("Downloading Prism portable build: %s", url)))
-		if err := downloadAndUnzipTo(url, dir); err != nil {
+		// The archive is extracted next to dir and swapped in, so files a newer build
+		// dropped don't linger from the one it replaces
+		staging := filepath.Clean(dir) + ".download"
+		_ = os.RemoveAll(staging)
+		defer os.RemoveAll(staging)
+		if err := downloadAndUnzipTo(withDownloadName(ctx, "Prism Launcher"), url, fetchSHA256Sidecar(ctx, url), staging); err != nil {
 			return false, err
 		}
+		if !exists(GetPrismExecutablePath(staging)) {
+			return false, fmt.Errorf("the Prism archive from %s did not contain %s; check PrismDownloadURL/PrismVersion in settings.json", url, filepath.Base(GetPrismExecutablePath(staging)))
+		}
+		if err := installPrismFiles(staging, dir); err != nil {
+			return false, fmt.Errorf("failed to install Prism Launcher: %w", err)
+		}
+		if err := writePrismPin(dir, pin); err != nil {
+			logf("%s", warnLine(fmt.Sprintf("Failed to record pinned Prism build: %v", err)))
+		}
+		if err := saveFileChecksum(GetPrismExecutablePath(dir)); err != nil {
+			logf("%s", warnLine(fmt.Sprintf("Failed to record Prism Launcher checksum: %v", err)))
+		}
 
 		// Fix Qt plugin RPATH settings on Linux to ensure plugins can find bundled libraries
 		if runtime.GOOS == "linux" {
@@ -150,7 +189,8 @@ UserAskedAboutAutomaticJavaDownload=true
 			}
 		}
 
-		// Force portable mode and disable automatic Java management
+		// Force portable mode and disable automatic Java management. An existing
+		// config is kept when Prism is downloaded again over an install.
 		cfg := filepath.Join(dir, "prismlauncher.cfg")
 		prismConfig := `Portable=true
 JavaDir=java
@@ -165,45 +205,147 @@ UserAskedAboutAutomaticJavaDownload=true
 	return true, nil
 }
 
-// updatePrismJavaPath updates the JavaPath in prismlauncher.cfg
-func updatePrismJavaPath(prismDir, javaPath string) error {
-	var cfgPath string
-	if runtime.GOOS == "darwin" {
-		// macOS: use our custom config directory
-		cfgPath = filepath.Join(GetPrismConfigDir(), "prismlauncher.cfg")
-	} else {
-		// Windows/Linux: use portable config directory
-		cfgPath = filepath.Join(prismDir, "prismlauncher.cfg")
+// prismPinFile records which pinned build was last downloaded into the Prism directory
+const prismPinFile = ".launcher-prism-pin"
+
+// prismFilesFile lists the top-level entries the last Prism archive installed
+const prismFilesFile = ".launcher-prism-files"
+
+// prismAppFile records the app bundle ensurePrism copied into /Applications on macOS,
+// so launches clear its quarantine like that of a Prism in the Prism directory
+const prismAppFile = ".launcher-prism-app"
+
+// installedPrismApp returns the app bundle recorded in prismAppFile, or ""
+func installedPrismApp(dir string) string {
+	data, err := os.ReadFile(filepath.Join(dir, prismAppFile))
+	if err != nil {
+		return ""
 	}
+	return strings.TrimSpace(string(data))
+}
 
-	// Read current config
-	content, err := os.ReadFile(cfgPath)
+// prismUserData are the entries of a portable Prism directory that hold the user's
+// runtimes, instances and accounts rather than Prism itself; they are never replaced
+var prismUserData = map[string]bool{
+	"java":              true,
+	"instances":         true,
+	"accounts.json":     true,
+	"prismlauncher.cfg": true,
+	prismPinFile:        true,
+	prismFilesFile:      true,
+	prismAppFile:        true,
+}
+
+// installPrismFiles moves a Prism build extracted into staging into dir. Each
+// top-level entry of the build replaces the one in dir, and entries an earlier build
+// installed that this one no longer has are removed, while prismUserData is kept.
+func installPrismFiles(staging, dir string) error {
+	entries, err := os.ReadDir(staging)
 	if err != nil {
-		return fmt.Errorf("failed to read prismlauncher.cfg: %w", err)
+		return err
+	}
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return err
 	}
 
-	// Parse and update JavaPath
-	lines := strings.Split(string(content), "\n")
-	var updatedLines []string
-	javaPathUpdated := false
+	fresh := make(map[string]bool, len(entries))
+	for _, entry := range entries {
+		fresh[entry.Name()] = true
+	}
+	if data, err := os.ReadFile(filepath.Join(dir, prismFilesFile)); err == nil {
+		for _, name := range strings.Fields(string(data)) {
+			if fresh[name] || prismUserData[name] || name != filepath.Base(name) {
+				continue
+			}
+			debugf("Removing %s left by the previous Prism build", name)
+			if err := os.RemoveAll(filepath.Join(dir, name)); err != nil {
+				return fmt.Errorf("failed to remove %s: %w", name, err)
+			}
+		}
+	}
 
-	for _, line := range lines {
-		if strings.HasPrefix(line, "JavaPath=") {
-			updatedLines = append(updatedLines, "JavaPath="+filepath.ToSlash(javaPath))
-			javaPathUpdated = true
-		} else {
-			updatedLines = append(updatedLines, line)
+	var installed []string
+	for _, entry := range entries {
+		name := entry.Name()
+		if prismUserData[name] {
+			continue
+		}
+		target := filepath.Join(dir, name)
+		if err := os.RemoveAll(target); err != nil {
+			return fmt.Errorf("failed to replace %s: %w", name, err)
+		}
+		if err := os.Rename(filepath.Join(staging, name), target); err != nil {
+			return fmt.Errorf("failed to move %s into place: %w", name, err)
+		}
+		installed = append(installed, name)
+	}
+	return os.WriteFile(filepath.Join(dir, prismFilesFile), []byte(strings.Join(installed, "\n")+"\n"), 0644)
+}
+
+// prismVersionPattern matches Prism release tags such as 9.4 or 10.0.0-beta1
+var prismVersionPattern = regexp.MustCompile(`^[0-9A-Za-z][0-9A-Za-z._-]*$`)
+
+// prismPin returns the pinned Prism build from settings, or "" to follow the
+// latest release. Invalid pins are reported rather than silently ignored.
+func prismPin() (string, error) {
+	if raw := strings.TrimSpace(settings.PrismDownloadURL); raw != "" {
+		if err := validatePrismDownloadURL(raw); err != nil {
+			return "", err
 		}
+		return raw, nil
+	}
+	if version := strings.TrimSpace(settings.PrismVersion); version != "" {
+		if !prismVersionPattern.MatchString(version) {
+			return "", fmt.Errorf("PrismVersion %q is not a valid Prism release tag", version)
+		}
+		return "version:" + version, nil
+	}
+	return "", nil
+}
+
+// validatePrismDownloadURL checks that a pinned Prism archive is an http(s) zip or tar.gz
+func validatePrismDownloadURL(raw string) error {
+	u, err := url.Parse(raw)
+	if err != nil || (u.Scheme != "https" && u.Scheme != "http") || u.Host == "" {
+		return fmt.Errorf("PrismDownloadURL %q is not an http(s) URL", raw)
+	}
+	lower := strings.ToLower(u.Path)
+	if !strings.HasSuffix(lower, ".zip") && !strings.HasSuffix(lower, ".tar.gz") && !strings.HasSuffix(lower, ".tgz") {
+		return fmt.Errorf("PrismDownloadURL %q must point to a .zip or .tar.gz archive", raw)
+	}
+	return nil
+}
+
+// prismDownloadURL resolves the Prism archive to download, honoring a pinned build
+func prismDownloadURL() (string, error) {
+	if raw := strings.TrimSpace(settings.PrismDownloadURL); raw != "" {
+		logf("%s", infoLine("Using Prism build pinned by PrismDownloadURL"))
+		return raw, validatePrismDownloadURL(raw)
+	}
+	if version := strings.TrimSpace(settings.PrismVersion); version != "" {
+		logf("%s", infoLine(fmt.Sprintf("Using Prism %s pinned by PrismVersion", version)))
+		return findPrismAsset(version)
 	}
+	return fetchLatestPrismPortableURL()
+}
 
-	// Add JavaPath if it wasn't present
-	if !javaPathUpdated {
-		updatedLines = append(updatedLines, "JavaPath="+filepath.ToSlash(javaPath))
+func readPrismPin(dir string) string {
+	data, err := os.ReadFile(filepath.Join(dir, prismPinFile))
+	if err != nil {
+		return ""
 	}
+	return strings.TrimSpace(string(data))
+}
 
-	// Write updated config
-	updatedContent := strings.Join(updatedLines, "\n")
-	return os.WriteFile(cfgPath, []byte(updatedContent), 0644)
+func writePrismPin(dir, pin string) error {
+	path := filepath.Join(dir, prismPinFile)
+	if pin == "" {
+		if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+		return nil
+	}
+	return os.WriteFile(path, []byte(pin+"\n"), 0644)
 }
 
 type prismRelease struct {
@@ -222,13 +364,7 @@ func fetchLatestPrismPortableURL() (string, error) {
 	// Use GitHub's releases page to find the latest Prism Launcher without API
 	releasesURL := "https://github.com/PrismLauncher/PrismLauncher/releases"
 
-	client := &http.Client{
-		CheckRedirect: func(req *http.Request, via []*http.Request) error {
-			return nil
-		},
-	}
-
-	resp, err := client.Get(releasesURL)
+	resp, err := httpGetWithRetry(releasesURL, httpRetryAttempts(), downloadTimeout())
 	if err != nil {
 		return "", fmt.Errorf("failed to fetch Prism releases page: %w", err)
 	}
@@ -254,8 +390,11 @@ func fetchLatestPrismPortableURL() (string, error) {
 		return "", errors.New("could not find any Prism Launcher release tags")
 	}
 
-	latestTag := tagMatches[1]
+	return findPrismAsset(tagMatches[1])
+}
 
+// findPrismAsset returns the download URL of this platform's build in the given Prism release
+func findPrismAsset(latestTag string) (string, error) {
 	// Build priority patterns by platform and arch
 	var patterns []string
 
@@ -307,7 +446,7 @@ func fetchLatestPrismPortableURL() (string, error) {
 		}
 		headReq.Header.Set("User-Agent", getUserAgent("General"))
 
-		headResp, err := http.DefaultClient.Do(headReq)
+		headResp, err := apiClient().Do(headReq)
 		if err != nil {
 			continue
 		}
@@ -318,7 +457,7 @@ func fetchLatestPrismPortableURL() (string, error) {
 		}
 	}
 
-	return "", errors.New("no suitable Prism portable asset found in latest release")
+	return "", fmt.Errorf("no suitable Prism portable asset found in release %s", latestTag)
 }
 
 // getCFBundleExecutable reads the Info.plist file and returns the CFBundleExecutable value
@@ -435,3 +574,94 @@ func fixMacOSExecutablePermissions(appBundlePath string) error {
 		return nil
 	})
 }
+
+// -------------------- Accounts --------------------
+
+// prismAccount is a Minecraft account configured in Prism's accounts.json
+type prismAccount struct {
+	Name   string
+	Type   string
+	Active bool
+}
+
+// readPrismAccounts lists the accounts Prism has signed in. With --dir . Prism keeps
+// accounts.json in its own folder on every platform.
+func readPrismAccounts(prismDir string) ([]prismAccount, error) {
+	data, err := os.ReadFile(filepath.Join(prismDir, "accounts.json"))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	var file struct {
+		Accounts []struct {
+			Active  bool   `json:"active"`
+			Type    string `json:"type"`
+			Profile struct {
+				Name string `json:"name"`
+			} `json:"profile"`
+		} `json:"accounts"`
+	}
+	if err := json.Unmarshal(data, &file); err != nil {
+		return nil, fmt.Errorf("failed to parse accounts.json: %w", err)
+	}
+
+	var accounts []prismAccount
+	for _, acc := range file.Accounts {
+		if acc.Profile.Name == "" {
+			continue
+		}
+		accounts = append(accounts, prismAccount{Name: acc.Profile.Name, Type: acc.Type, Active: acc.Active})
+	}
+	return accounts, nil
+}
+
+// launchAccount returns the profile name to pass to Prism with --profile: the account
+// chosen in settings if Prism still has it, otherwise "" to use Prism's active account
+func launchAccount(prismDir string) string {
+	if settings.PrismAccount == "" {
+		return ""
+	}
+	accounts, err := readPrismAccounts(prismDir)
+	if err != nil {
+		logf("%s", warnLine(fmt.Sprintf("Failed to read Prism accounts: %v", err)))
+		return ""
+	}
+	for _, acc := range accounts {
+		if strings.EqualFold(acc.Name, settings.PrismAccount) {
+			return acc.Name
+		}
+	}
+	logf("%s", warnLine(fmt.Sprintf("Minecraft account %q is no longer signed in to Prism; using Prism's active account", settings.PrismAccount)))
+	return ""
+}
+
+// activeAccountName returns the account a launch will use, or "" if Prism has none
+func activeAccountName(prismDir string) string {
+	if name := launchAccount(prismDir); name != "" {
+		return name
+	}
+	accounts, _ := readPrismAccounts(prismDir)
+	for _, acc := range accounts {
+		if acc.Active {
+			return acc.Name
+		}
+	}
+	return ""
+}
+
+// prismLaunchArgs returns the Prism arguments that launch an instance with the chosen account
+func prismLaunchArgs(prismDir, instanceName string) []string {
+	args := []string{"--dir", ".", "--launch", instanceName}
+	if account := launchAccount(prismDir); account != "" {
+		args = append(args, "--profile", account)
+	}
+	return args
+}
+
+// prismShowArgs opens Prism's window with the instance selected, without launching the game
+func prismShowArgs(instanceName string) []string {
+	return []string{"--dir", ".", "--show", instanceName}
+}
@@ -90,3 +90,160 @@ func TestGetCFBundleExecutableFileNotFound(t *testing.T) {
 		t.Error("Expected error when Info.plist doesn't exist, got nil")
 	}
 }
+
+// TestPrismLaunchArgs tests that the chosen account is passed to Prism only while it is still signed in
+func TestPrismLaunchArgs(t *testing.T) {
+	saved := settings
+	defer func() { settings = saved }()
+
+	prismDir := t.TempDir()
+	accounts := `{"formatVersion": 3, "accounts": [
+		{"type": "MSA", "active": true, "profile": {"id": "1", "name": "Steve"}},
+		{"type": "MSA", "profile": {"id": "2", "name": "Alex"}}
+	]}`
+	if err := os.WriteFile(filepath.Join(prismDir, "accounts.json"), []byte(accounts), 0644); err != nil {
+		t.Fatalf("Failed to write accounts.json: %v", err)
+	}
+
+	settings.PrismAccount = ""
+	if args := prismLaunchArgs(prismDir, "Pack"); len(args) != 4 {
+		t.Errorf("Expected no --profile without a chosen account, got %v", args)
+	}
+	if name := activeAccountName(prismDir); name != "Steve" {
+		t.Errorf("Expected Prism's active account, got %q", name)
+	}
+
+	settings.PrismAccount = "alex"
+	args := prismLaunchArgs(prismDir, "Pack")
+	if len(args) != 6 || args[4] != "--profile" || args[5] != "Alex" {
+		t.Errorf("Expected --profile Alex, got %v", args)
+	}
+
+	settings.PrismAccount = "Herobrine"
+	if args := prismLaunchArgs(prismDir, "Pack"); len(args) != 4 {
+		t.Errorf("Expected a removed account to fall back to Prism's active account, got %v", args)
+	}
+}
+
+// TestPrismPin tests that a pinned Prism URL or version is validated and recorded
+func TestPrismPin(t *testing.T) {
+	saved := settings
+	defer func() { settings = saved }()
+
+	settings.PrismDownloadURL = ""
+	settings.PrismVersion = ""
+	if pin, err := prismPin(); err != nil || pin != "" {
+		t.Errorf("Expected no pin by default, got %q, %v", pin, err)
+	}
+
+	settings.PrismVersion = "9.4"
+	if pin, err := prismPin(); err != nil || pin != "version:9.4" {
+		t.Errorf("Expected version pin, got %q, %v", pin, err)
+	}
+	settings.PrismVersion = "../9.4"
+	if _, err := prismPin(); err == nil {
+		t.Error("Expected an error for an invalid PrismVersion")
+	}
+
+	settings.PrismDownloadURL = "https://example.com/fork/PrismLauncher-Windows-Portable.zip"
+	if pin, err := prismPin(); err != nil || pin != settings.PrismDownloadURL {
+		t.Errorf("Expected PrismDownloadURL to take precedence, got %q, %v", pin, err)
+	}
+	for _, bad := range []string{"ftp://example.com/prism.zip", "https://example.com/prism.exe", "not a url"} {
+		if err := validatePrismDownloadURL(bad); err == nil {
+			t.Errorf("Expected %q to be rejected", bad)
+		}
+	}
+	if err := validatePrismDownloadURL("https://example.com/PrismLauncher-Linux.tar.gz?token=1"); err != nil {
+		t.Errorf("Expected tar.gz URL to be accepted, got %v", err)
+	}
+
+	dir := t.TempDir()
+	if err := writePrismPin(dir, "version:9.4"); err != nil {
+		t.Fatalf("writePrismPin failed: %v", err)
+	}
+	if got := readPrismPin(dir); got != "version:9.4" {
+		t.Errorf("Expected recorded pin, got %q", got)
+	}
+	if err := writePrismPin(dir, ""); err != nil || readPrismPin(dir) != "" {
+		t.Errorf("Expected clearing the pin to remove the marker, got %q, %v", readPrismPin(dir), err)
+	}
+}
+
+// TestInstallPrismFiles tests that a new Prism build replaces the old one's files
+// without touching the runtimes, instances and accounts kept next to them
+func TestInstallPrismFiles(t *testing.T) {
+	dir := t.TempDir()
+	staging := t.TempDir()
+	write := func(root, name, content string) {
+		t.Helper()
+		path := filepath.Join(root, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	write(dir, "java/jre17/bin/java", "java")
+	write(dir, "instances/pack/instance.cfg", "cfg")
+	write(dir, "accounts.json", "{}")
+	write(dir, "plugins/old.so", "old")
+	write(dir, "dropped.dll", "old")
+	write(dir, "PrismLauncher", "old")
+	write(dir, prismFilesFile, "plugins\ndropped.dll\nPrismLauncher\n")
+	write(staging, "plugins/new.so", "new")
+	write(staging, "PrismLauncher", "new")
+
+	if err := installPrismFiles(staging, dir); err != nil {
+		t.Fatalf("installPrismFiles failed: %v", err)
+	}
+	for _, name := range []string{"java/jre17/bin/java", "instances/pack/instance.cfg", "accounts.json", "plugins/new.so"} {
+		if !exists(filepath.Join(dir, filepath.FromSlash(name))) {
+			t.Errorf("Expected %s to be in place", name)
+		}
+	}
+	for _, name := range []string{"plugins/old.so", "dropped.dll"} {
+		if exists(filepath.Join(dir, filepath.FromSlash(name))) {
+			t.Errorf("Expected %s from the old build to be removed", name)
+		}
+	}
+	if data, _ := os.ReadFile(filepath.Join(dir, "PrismLauncher")); string(data) != "new" {
+		t.Errorf("Expected the new executable, got %q", data)
+	}
+	if data, _ := os.ReadFile(filepath.Join(dir, prismFilesFile)); string(data) != "PrismLauncher\nplugins\n" {
+		t.Errorf("Expected the installed entries to be recorded, got %q", data)
+	}
+}
+
+// TestLaunchQuarantineBundle tests that launches clear the quarantine of a Prism in
+// the Prism directory or the one ensurePrism installed, but not of one the user installed
+func TestLaunchQuarantineBundle(t *testing.T) {
+	prismDir := t.TempDir()
+	apps := t.TempDir()
+	local := filepath.Join(prismDir, "Prism Launcher.app", "Contents", "MacOS", "prismlauncher")
+	if got := launchQuarantineBundle(prismDir, local); got != filepath.Join(prismDir, "Prism Launcher.app") {
+		t.Errorf("Expected the local app bundle, got %q", got)
+	}
+	portable := filepath.Join(prismDir, "prismlauncher")
+	if got := launchQuarantineBundle(prismDir, portable); got != portable {
+		t.Errorf("Expected a portable executable itself, got %q", got)
+	}
+
+	installed := filepath.Join(apps, "PrismLauncher.app")
+	exe := filepath.Join(installed, "Contents", "MacOS", "prismlauncher")
+	if got := launchQuarantineBundle(prismDir, exe); got != "" {
+		t.Errorf("Expected a Prism the user installed to be left alone, got %q", got)
+	}
+	if err := os.WriteFile(filepath.Join(prismDir, prismAppFile), []byte(installed+"\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if got := launchQuarantineBundle(prismDir, exe); got != installed {
+		t.Errorf("Expected the app bundle ensurePrism installed, got %q", got)
+	}
+	other := filepath.Join(apps, "Prism Launcher.app", "Contents", "MacOS", "prismlauncher")
+	if got := launchQuarantineBundle(prismDir, other); got != "" {
+		t.Errorf("Expected another app bundle to be left alone, got %q", got)
+	}
+}
@@ -11,6 +11,7 @@ import (
 	"runtime"
 	"strconv"
 	"strings"
+	"time"
 )
 
 // Linux process management using pkill and kill
@@ -182,6 +183,11 @@ func isProcessRunning(pid int) (bool, error) {
 	// Use ps to check if the process is still running
 	cmd := exec.Command("ps", "-p", strconv.Itoa(pid), "-o", "pid=")
 	output, err := cmd.Output()
+	if exitErr, ok := err.(*exec.ExitError); ok && exitErr.ExitCode() == 1 && len(output) == 0 {
+		// ps exits with status 1 when no process matches the PID
+		debugf("Process PID %d is not running", pid)
+		return false, nil
+	}
 	if err != nil {
 		debugf("Failed to check process status for PID %d: %v", pid, err)
 		return false, fmt.Errorf("failed to check process status: %w", err)
@@ -193,53 +199,32 @@ func isProcessRunning(pid int) (bool, error) {
 	return isRunning, nil
 }
 
-// validateProcessIdentity validates that a process matches the expected executable and working directory on Linux
-func validateProcessIdentity(pid int, expectedExecutable, expectedWorkingDir string) (bool, error) {
-	// Get process information using ps
-	cmd := exec.Command("ps", "-p", strconv.Itoa(pid), "-o", "command=", "-o", "cwd=")
-	output, err := cmd.Output()
+// validateProcessIdentity reports whether pid is still the process recorded with the
+// given executable, working directory and start time, rather than a later process
+// that reused the PID, on Linux
+func validateProcessIdentity(pid int, expectedExecutable, expectedWorkingDir string, expectedStart time.Time) (bool, error) {
+	executable, workingDir, err := getProcessDetails(pid)
 	if err != nil {
-		return false, fmt.Errorf("failed to get process information: %w", err)
-	}
-
-	lines := strings.Split(strings.TrimSpace(string(output)), "\n")
-	if len(lines) < 1 {
-		return false, fmt.Errorf("process not found")
+		return false, err
 	}
-
-	// Parse ps output (format: command\ncwd)
-	parts := strings.Split(lines[0], "\n")
-	if len(parts) < 2 {
-		return false, fmt.Errorf("invalid process information format")
-	}
-
-	actualCommand := strings.TrimSpace(parts[0])
-	actualWorkingDir := strings.TrimSpace(parts[1])
-
-	// Normalize paths for comparison
-	expectedExecutable = strings.ToLower(expectedExecutable)
-	actualCommand = strings.ToLower(actualCommand)
-
-	// Check if executable names match (allowing for case differences)
-	if !strings.Contains(actualCommand, filepath.Base(expectedExecutable)) {
-		return false, nil
+	startTime, err := processStartTime(pid)
+	if err != nil {
+		debugf("Could not read the start time of PID %d: %v", pid, err)
 	}
+	identity := processIdentity{Executable: executable, WorkingDir: workingDir, StartTime: startTime}
+	return identity.matches(expectedExecutable, expectedWorkingDir, expectedStart), nil
+}
 
-	// Check working directory if provided
-	if expectedWorkingDir != "" {
-		expectedWorkingDir = strings.ToLower(expectedWorkingDir)
-		actualWorkingDir = strings.ToLower(actualWorkingDir)
-
-		// Normalize paths for comparison
-		if !strings.EqualFold(filepath.Clean(actualWorkingDir), filepath.Clean(expectedWorkingDir)) {
-			// Try to match by basename if full paths differ
-			if filepath.Base(actualWorkingDir) != filepath.Base(expectedWorkingDir) {
-				return false, nil
-			}
-		}
+// processStartTime returns when pid started, as reported by ps
+func processStartTime(pid int) (time.Time, error) {
+	cmd := exec.Command("ps", "-p", strconv.Itoa(pid), "-o", "lstart=")
+	// lstart uses the C locale's day and month names
+	cmd.Env = append(os.Environ(), "LC_ALL=C")
+	output, err := cmd.Output()
+	if err != nil {
+		return time.Time{}, fmt.Errorf("failed to get process start time: %w", err)
 	}
-
-	return true, nil
+	return parsePsStartTime(string(output))
 }
 
 // getProcessDetails retrieves detailed information about a process on Linux
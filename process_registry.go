@@ -6,6 +6,7 @@ import (
 	"os"
 	"path/filepath"
 	"runtime"
+	"strings"
 	"sync"
 	"time"
 )
@@ -174,6 +175,57 @@ func (r *PersistentProcessRecord) IsExpired(duration time.Duration) bool {
 	return time.Since(r.LastSeen) > duration
 }
 
+// processStartTolerance is how far a process's start time may be from the StartTime
+// recorded for it. The record is written just after the process starts, and some
+// platforms only report start times to the second.
+const processStartTolerance = 30 * time.Second
+
+// processIdentity is what the OS reports about a live process, enough to tell the
+// process we launched apart from a later one that was given the same PID
+type processIdentity struct {
+	Executable string
+	WorkingDir string
+	StartTime  time.Time
+}
+
+// matches reports whether id is the process recorded with the given executable,
+// working directory and start time. Details either side couldn't read are skipped.
+func (id processIdentity) matches(expectedExecutable, expectedWorkingDir string, expectedStart time.Time) bool {
+	if expectedExecutable != "" {
+		if id.Executable == "" {
+			return false
+		}
+		if filepath.IsAbs(id.Executable) && filepath.IsAbs(expectedExecutable) {
+			if !strings.EqualFold(filepath.Clean(id.Executable), filepath.Clean(expectedExecutable)) {
+				return false
+			}
+		} else if !strings.EqualFold(filepath.Base(id.Executable), filepath.Base(expectedExecutable)) {
+			// Some fallbacks only report the executable's name
+			return false
+		}
+	}
+
+	if filepath.IsAbs(expectedWorkingDir) && filepath.IsAbs(id.WorkingDir) &&
+		!strings.EqualFold(filepath.Clean(id.WorkingDir), filepath.Clean(expectedWorkingDir)) {
+		return false
+	}
+
+	// A reused PID belongs to a process that started after ours
+	if !expectedStart.IsZero() && !id.StartTime.IsZero() {
+		diff := id.StartTime.Sub(expectedStart)
+		if diff > processStartTolerance || diff < -processStartTolerance {
+			return false
+		}
+	}
+	return true
+}
+
+// parsePsStartTime parses the lstart column of ps, such as "Wed Oct 14 09:05:03 2026",
+// in local time
+func parsePsStartTime(lstart string) (time.Time, error) {
+	return time.ParseInLocation("Mon Jan 2 15:04:05 2006", strings.Join(strings.Fields(lstart), " "), time.Local)
+}
+
 // ProcessRegistry manages persistent process records
 type ProcessRegistry struct {
 	records      map[string]*PersistentProcessRecord
@@ -282,6 +334,11 @@ func (pr *ProcessRegistry) Save() error {
 	pr.mutex.RLock()
 	defer pr.mutex.RUnlock()
 
+	return pr.saveLocked()
+}
+
+// saveLocked writes the registry to disk; the caller must hold pr.mutex
+func (pr *ProcessRegistry) saveLocked() error {
 	// Create temporary file for atomic write
 	tempPath := pr.registryPath + ".tmp"
 
@@ -311,7 +368,7 @@ func (pr *ProcessRegistry) AddRecord(record *PersistentProcessRecord) error {
 	defer pr.mutex.Unlock()
 
 	pr.records[record.ID] = record
-	return pr.Save()
+	return pr.saveLocked()
 }
 
 // UpdateRecord updates an existing process record
@@ -325,7 +382,7 @@ func (pr *ProcessRegistry) UpdateRecord(id string, updateFunc func(*PersistentPr
 	}
 
 	updateFunc(record)
-	return pr.Save()
+	return pr.saveLocked()
 }
 
 // RemoveRecord removes a process record from the registry
@@ -342,7 +399,7 @@ func (pr *ProcessRegistry) RemoveRecord(id string) error {
 	pr.statusCache.Invalidate(record.PID)
 
 	delete(pr.records, id)
-	return pr.Save()
+	return pr.saveLocked()
 }
 
 // GetRecord retrieves a process record by ID
@@ -412,7 +469,7 @@ func (pr *ProcessRegistry) CleanupExpiredRecords(maxAge time.Duration) error {
 	}
 
 	if len(toRemove) > 0 {
-		return pr.Save()
+		return pr.saveLocked()
 	}
 
 	return nil
@@ -438,6 +495,17 @@ func (pr *ProcessRegistry) ValidateProcesses() error {
 			isRunning = false
 		}
 
+		// A live PID only counts if it still belongs to the process we launched
+		if isRunning {
+			valid, err := validateProcessIdentity(record.PID, record.Executable, record.WorkingDir, record.StartTime)
+			if err != nil {
+				logf("Warning: Failed to validate process %d: %v", record.PID, err)
+			} else if !valid {
+				logf("Process %d (modpack: %s) now belongs to another program", record.PID, record.ModpackName)
+				isRunning = false
+			}
+		}
+
 		if isRunning {
 			// Process is still running, update last seen time
 			record.LastSeen = now
@@ -472,7 +540,7 @@ func (pr *ProcessRegistry) ValidateProcesses() error {
 		logf("Removed old process record: %s", id)
 	}
 
-	return pr.Save()
+	return pr.saveLocked()
 }
 
 // GetRunningProcesses returns all currently running processes
@@ -541,13 +609,61 @@ func (pr *ProcessRegistry) GetProcessStatusCacheStats() (entryCount int, ttl tim
 
 // Global registry instance
 var globalRegistry *ProcessRegistry
-var registryOnce sync.Once
+var globalRegistryMu sync.Mutex
 
-// GetGlobalProcessRegistry returns the global process registry instance
+// GetGlobalProcessRegistry returns the global process registry instance.
+// A failed initialization is not cached, so a later call can try again.
 func GetGlobalProcessRegistry(rootDir string) (*ProcessRegistry, error) {
-	var err error
-	registryOnce.Do(func() {
-		globalRegistry, err = NewProcessRegistry(rootDir)
-	})
-	return globalRegistry, err
+	globalRegistryMu.Lock()
+	defer globalRegistryMu.Unlock()
+	if globalRegistry != nil {
+		return globalRegistry, nil
+	}
+	registry, err := NewProcessRegistry(rootDir)
+	if err != nil {
+		return nil, err
+	}
+	globalRegistry = registry
+	return registry, nil
+}
+
+// loadedProcessRegistry returns the global registry if it is already open, without
+// opening it or waiting for an open in progress. Used on shutdown.
+func loadedProcessRegistry() *ProcessRegistry {
+	if !globalRegistryMu.TryLock() {
+		return nil
+	}
+	defer globalRegistryMu.Unlock()
+	return globalRegistry
+}
+
+// killRegisteredProcesses kills every live process in the registry and removes its
+// record. Records whose PID now belongs to a different program are only removed.
+// Returns the number of processes killed.
+func killRegisteredProcesses(registry *ProcessRegistry) int {
+	if registry == nil {
+		return 0
+	}
+
+	killed := 0
+	for _, record := range registry.GetAllRecords() {
+		if record.Status == ProcessStatusRunning || record.Status == ProcessStatusStarting {
+			valid, err := validateProcessIdentity(record.PID, record.Executable, record.WorkingDir, record.StartTime)
+			if err != nil {
+				logf("Warning: Failed to validate process %d: %v", record.PID, err)
+			}
+			if valid {
+				if err := killProcessByPID(record.PID); err != nil {
+					logf("Warning: Failed to kill %s (PID %d): %v", record.ModpackName, record.PID, err)
+				} else {
+					logf("Force-closed %s (PID %d)", record.ModpackName, record.PID)
+					killed++
+				}
+			}
+		}
+		if err := registry.RemoveRecord(record.ID); err != nil {
+			logf("Warning: Failed to remove process record: %v", err)
+		}
+	}
+	return killed
 }
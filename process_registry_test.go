@@ -0,0 +1,61 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// TestProcessIdentityMatches tests that a reused PID is told apart from the process that was recorded
+func TestProcessIdentityMatches(t *testing.T) {
+	prism := filepath.Join(t.TempDir(), "prism", "prismlauncher")
+	started := time.Date(2026, 10, 14, 9, 5, 3, 0, time.Local)
+	recorded := started.Add(2 * time.Second)
+
+	tests := []struct {
+		name     string
+		identity processIdentity
+		want     bool
+	}{
+		{"same process", processIdentity{Executable: prism, WorkingDir: filepath.Dir(prism), StartTime: started}, true},
+		{"reused PID", processIdentity{Executable: prism, WorkingDir: filepath.Dir(prism), StartTime: started.Add(10 * time.Minute)}, false},
+		{"older process", processIdentity{Executable: prism, StartTime: started.Add(-time.Hour)}, false},
+		{"other program", processIdentity{Executable: filepath.Join(filepath.Dir(prism), "java"), StartTime: started}, false},
+		{"other working directory", processIdentity{Executable: prism, WorkingDir: t.TempDir(), StartTime: started}, false},
+		{"name only", processIdentity{Executable: "prismlauncher", StartTime: started}, true},
+		{"start time unknown", processIdentity{Executable: prism}, true},
+		{"executable unknown", processIdentity{StartTime: started}, false},
+	}
+	for _, tt := range tests {
+		if got := tt.identity.matches(prism, filepath.Dir(prism), recorded); got != tt.want {
+			t.Errorf("%s: matches() = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+
+	got, err := parsePsStartTime("Wed Oct 14  9:05:03 2026\n")
+	if err != nil || !got.Equal(started) {
+		t.Errorf("Expected ps lstart to parse as %v, got %v, %v", started, got, err)
+	}
+}
+
+// TestValidateProcessIdentity tests validating the running test process against its own record
+func TestValidateProcessIdentity(t *testing.T) {
+	pid := os.Getpid()
+	executable, workingDir, err := getProcessDetails(pid)
+	if err != nil {
+		t.Skipf("cannot read process details: %v", err)
+	}
+	started, err := processStartTime(pid)
+	if err != nil {
+		t.Skipf("cannot read process start time: %v", err)
+	}
+
+	if valid, err := validateProcessIdentity(pid, executable, workingDir, started); err != nil || !valid {
+		t.Errorf("Expected the test process to match its own record, got %v, %v", valid, err)
+	}
+	// A record from an hour ago means the PID has since been given to this process
+	if valid, err := validateProcessIdentity(pid, executable, workingDir, started.Add(-time.Hour)); err != nil || valid {
+		t.Errorf("Expected a reused PID not to match, got %v, %v", valid, err)
+	}
+}
@@ -2,7 +2,6 @@ package main
 
 import (
 	"os"
-	"path/filepath"
 	"testing"
 	"time"
 )
@@ -130,7 +129,7 @@ func TestProcessStatusCacheTTL(t *testing.T) {
 	time.Sleep(150 * time.Millisecond)
 
 	// Test cache expiration
-	isRunning3, err := cache.Get(12345)
+	_, err = cache.Get(12345)
 	if err != nil {
 		t.Errorf("Expected no error on cache refresh, got: %v", err)
 	}
@@ -11,6 +11,7 @@ import (
 	"runtime"
 	"strconv"
 	"strings"
+	"time"
 )
 
 // Windows process management using taskkill
@@ -120,8 +121,10 @@ func isProcessRunning(pid int) (bool, error) {
 	return isRunning, nil
 }
 
-// validateProcessIdentity validates that a process matches the expected executable and working directory on Windows
-func validateProcessIdentity(pid int, expectedExecutable, expectedWorkingDir string) (bool, error) {
+// validateProcessIdentity reports whether pid is still the process recorded with the
+// given executable and start time, rather than a later process that reused the PID,
+// on Windows
+func validateProcessIdentity(pid int, expectedExecutable, expectedWorkingDir string, expectedStart time.Time) (bool, error) {
 	var actualExecutable string
 	var err error
 
@@ -215,24 +218,26 @@ func validateProcessIdentity(pid int, expectedExecutable, expectedWorkingDir str
 		}
 	}
 
-	// Normalize paths for comparison
-	actualExecutable = strings.ToLower(actualExecutable)
-	expectedExecutable = strings.ToLower(expectedExecutable)
-
-	// Check if executable paths match (allowing for case differences)
-	if !strings.EqualFold(actualExecutable, expectedExecutable) {
-		// Also check basename in case full paths differ
-		actualBase := filepath.Base(actualExecutable)
-		expectedBase := filepath.Base(expectedExecutable)
-		if !strings.EqualFold(actualBase, expectedBase) {
-			return false, nil
-		}
+	startTime, err := processStartTime(pid)
+	if err != nil {
+		debugf("Could not read the start time of PID %d: %v", pid, err)
 	}
 
 	// For working directory, we can't easily get it reliably on Windows, so we'll skip this check
-	// and rely on executable matching only
+	// and rely on the executable and start time only
+	identity := processIdentity{Executable: actualExecutable, StartTime: startTime}
+	return identity.matches(expectedExecutable, "", expectedStart), nil
+}
 
-	return true, nil
+// processStartTime returns when pid started, as reported by PowerShell
+func processStartTime(pid int) (time.Time, error) {
+	cmd := exec.Command("powershell", "-NoProfile", "-Command",
+		fmt.Sprintf("(Get-Process -Id %d -ErrorAction Stop).StartTime.ToUniversalTime().ToString('o')", pid))
+	output, err := cmd.Output()
+	if err != nil {
+		return time.Time{}, fmt.Errorf("failed to get process start time: %w", err)
+	}
+	return time.Parse(time.RFC3339Nano, strings.TrimSpace(string(output)))
 }
 
 // getProcessDetails retrieves detailed information about a process on Windows
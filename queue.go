@@ -0,0 +1,94 @@
+package main
+
+import "sync"
+
+// installQueue runs modpack operations first in, first out with a bounded number at
+// once. Each started operation holds its slot until it calls the done func it was given.
+type installQueue struct {
+	mu      sync.Mutex
+	limit   func() int
+	active  int
+	pending []queuedOperation
+}
+
+type queuedOperation struct {
+	id    string
+	start func(done func())
+}
+
+// newInstallQueue creates a queue whose concurrency is read from limit each time a
+// slot frees up, so a changed setting applies to the next operation
+func newInstallQueue(limit func() int) *installQueue {
+	return &installQueue{limit: limit}
+}
+
+// Enqueue starts the operation right away if a slot is free, otherwise it waits behind
+// the operations already queued. It reports whether the operation was queued.
+func (q *installQueue) Enqueue(id string, start func(done func())) bool {
+	q.mu.Lock()
+	if len(q.pending) > 0 || q.active >= q.slots() {
+		q.pending = append(q.pending, queuedOperation{id: id, start: start})
+		q.mu.Unlock()
+		return true
+	}
+	q.active++
+	q.mu.Unlock()
+
+	start(q.doneFunc())
+	return false
+}
+
+// Cancel drops a queued operation that hasn't started yet. It reports whether one was found.
+func (q *installQueue) Cancel(id string) bool {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	for i, op := range q.pending {
+		if op.id == id {
+			q.pending = append(q.pending[:i], q.pending[i+1:]...)
+			return true
+		}
+	}
+	return false
+}
+
+// Queued reports whether an operation for id is waiting for a slot
+func (q *installQueue) Queued(id string) bool {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	for _, op := range q.pending {
+		if op.id == id {
+			return true
+		}
+	}
+	return false
+}
+
+func (q *installQueue) slots() int {
+	if n := q.limit(); n > 0 {
+		return n
+	}
+	return 1
+}
+
+// doneFunc releases a slot once, however many times it is called, and starts
+// as many queued operations as the freed slots allow
+func (q *installQueue) doneFunc() func() {
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			q.mu.Lock()
+			q.active--
+			var next []queuedOperation
+			for len(q.pending) > 0 && q.active < q.slots() {
+				next = append(next, q.pending[0])
+				q.pending = q.pending[1:]
+				q.active++
+			}
+			q.mu.Unlock()
+
+			for _, op := range next {
+				op.start(q.doneFunc())
+			}
+		})
+	}
+}
@@ -0,0 +1,95 @@
+package main
+
+import (
+	"strings"
+	"sync"
+	"testing"
+)
+
+// TestInstallQueue tests that operations start first in, first out within the limit
+func TestInstallQueue(t *testing.T) {
+	q := newInstallQueue(func() int { return 1 })
+	var mu sync.Mutex
+	var started []string
+	dones := make(map[string]func())
+	op := func(id string) func(done func()) {
+		return func(done func()) {
+			mu.Lock()
+			started = append(started, id)
+			dones[id] = done
+			mu.Unlock()
+		}
+	}
+
+	if q.Enqueue("a", op("a")) {
+		t.Error("Expected the first operation to start right away")
+	}
+	if !q.Enqueue("b", op("b")) || !q.Enqueue("c", op("c")) || !q.Enqueue("d", op("d")) {
+		t.Fatal("Expected later operations to be queued while the slot is taken")
+	}
+	if !q.Queued("c") {
+		t.Error("Expected c to be reported as queued")
+	}
+	if !q.Cancel("c") || q.Queued("c") || q.Cancel("c") {
+		t.Error("Expected c to be removed from the queue exactly once")
+	}
+
+	dones["a"]()
+	dones["a"]() // a second call must not free another slot
+	if got := strings.Join(started, ","); got != "a,b" {
+		t.Fatalf("Expected only b to start after a finished, got %s", got)
+	}
+	dones["b"]()
+	if got := strings.Join(started, ","); got != "a,b,d" {
+		t.Errorf("Expected the cancelled operation to be skipped, got %s", got)
+	}
+	if q.Cancel("d") {
+		t.Error("Expected a started operation not to be cancellable from the queue")
+	}
+}
+
+// TestInstallQueueLimit tests that raising the limit lets queued operations run side by side
+func TestInstallQueueLimit(t *testing.T) {
+	limit := 1
+	q := newInstallQueue(func() int { return limit })
+	running := 0
+	var dones []func()
+	op := func(done func()) {
+		running++
+		dones = append(dones, done)
+	}
+
+	q.Enqueue("a", op)
+	q.Enqueue("b", op)
+	q.Enqueue("c", op)
+	if running != 1 {
+		t.Fatalf("Expected 1 running operation, got %d", running)
+	}
+
+	limit = 2
+	dones[0]()
+	if running != 3 {
+		t.Errorf("Expected both queued operations to start once two slots were free, got %d started", running)
+	}
+}
+
+// TestModpackStateQueued tests that a queued card is told apart from one that is busy
+func TestModpackStateQueued(t *testing.T) {
+	state := &ModpackState{Installed: true, Queued: true}
+	if got := state.PrimaryAction(); got != ActionQueued {
+		t.Errorf("Expected ActionQueued, got %v", got)
+	}
+	if got := state.PrimaryLabel(); got != T("status.queued") {
+		t.Errorf("Expected the queued label, got %q", got)
+	}
+
+	state.Queued = false
+	state.Busy = true
+	state.CurrentAction = ActionUpdate
+	if got := state.PrimaryAction(); got != ActionUpdate {
+		t.Errorf("Expected the busy action once started, got %v", got)
+	}
+	if got := state.PrimaryLabel(); got != T("status.updating") {
+		t.Errorf("Expected the updating label, got %q", got)
+	}
+}
@@ -0,0 +1,142 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+)
+
+// -------------------- Launcher rollback --------------------
+
+// previousLauncherFile is the launcher executable an update replaced. It is kept in
+// the launcher home so a bad update can be undone with --rollback or from Settings.
+const previousLauncherFile = launcherShortName + ".prev"
+
+// previousLauncherRecordFile records which version previousLauncherFile is
+const previousLauncherRecordFile = previousLauncherFile + ".json"
+
+// previousLauncher is the content of previousLauncherRecordFile
+type previousLauncher struct {
+	// Version is the version of the kept executable
+	Version string `json:"version"`
+	// ReplacedBy is the version the update installed in its place
+	ReplacedBy string `json:"replacedBy"`
+	// Launched is set once ReplacedBy has started successfully
+	Launched bool `json:"launched"`
+}
+
+// readPreviousLauncher returns the record of the executable kept in root, and false
+// when there is none to roll back to
+func readPreviousLauncher(root string) (previousLauncher, bool) {
+	var prev previousLauncher
+	if !exists(filepath.Join(root, previousLauncherFile)) {
+		return prev, false
+	}
+	data, err := os.ReadFile(filepath.Join(root, previousLauncherRecordFile))
+	if err != nil || json.Unmarshal(data, &prev) != nil || prev.Version == "" {
+		return prev, false
+	}
+	return prev, true
+}
+
+// writePreviousLauncher saves the record of the executable kept in root
+func writePreviousLauncher(root string, prev previousLauncher) error {
+	data, err := json.MarshalIndent(prev, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(filepath.Join(root, previousLauncherRecordFile), data, 0644)
+}
+
+// keepPreviousLauncher copies exePath into root before an update replaces it with
+// tag. A copy kept by an earlier update is only given up once the version that
+// replaced it has launched successfully, so two updates in a row to broken builds
+// still leave the last one that worked.
+func keepPreviousLauncher(root, exePath, tag string) error {
+	// Left behind by a rollback on Windows, where the running executable can't be deleted
+	_ = os.Remove(exePath + ".old")
+
+	if prev, ok := readPreviousLauncher(root); ok && !prev.Launched && normalizeTag(prev.ReplacedBy) == normalizeTag(version) {
+		logf("%s", infoLine(fmt.Sprintf("Keeping %s for rollback, since %s has not launched successfully yet", prev.Version, version)))
+		prev.ReplacedBy = tag
+		return writePreviousLauncher(root, prev)
+	}
+
+	path := filepath.Join(root, previousLauncherFile)
+	tmp := path + ".tmp"
+	if err := copyFile(exePath, tmp); err != nil {
+		os.Remove(tmp)
+		return err
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		os.Remove(tmp)
+		return err
+	}
+	debugf("Kept %s as %s for rollback", version, path)
+	return writePreviousLauncher(root, previousLauncher{Version: version, ReplacedBy: tag})
+}
+
+// markLauncherLaunched records that the running version started successfully. After
+// that the next update may replace the kept executable with this one.
+func markLauncherLaunched(root string) {
+	prev, ok := readPreviousLauncher(root)
+	if !ok || prev.Launched || normalizeTag(prev.ReplacedBy) != normalizeTag(version) {
+		return
+	}
+	prev.Launched = true
+	if err := writePreviousLauncher(root, prev); err != nil {
+		logf("%s", warnLine(fmt.Sprintf("Failed to record that %s launched: %v", version, err)))
+	}
+}
+
+// rollbackLauncher puts the kept executable back in place of exePath and returns its
+// version. The replaced executable is discarded rather than kept in turn.
+func rollbackLauncher(root, exePath string) (string, error) {
+	prev, ok := readPreviousLauncher(root)
+	if !ok {
+		return "", errors.New("no previous launcher version is kept to roll back to")
+	}
+
+	updateSwapMu.Lock()
+	defer updateSwapMu.Unlock()
+
+	// The running executable can be renamed but, on Windows, not deleted
+	old := exePath + ".old"
+	_ = os.Remove(old)
+	if err := os.Rename(exePath, old); err != nil {
+		return "", fmt.Errorf("failed to move the current launcher aside: %w", err)
+	}
+	if err := movePath(filepath.Join(root, previousLauncherFile), exePath); err != nil {
+		_ = os.Rename(old, exePath)
+		return "", fmt.Errorf("failed to restore %s: %w", prev.Version, err)
+	}
+	if err := os.Chmod(exePath, 0755); err != nil {
+		logf("%s", warnLine(fmt.Sprintf("Failed to make the restored launcher executable: %v", err)))
+	}
+	if err := removeQuarantineAttribute(exePath); err != nil {
+		logf("%s", warnLine(fmt.Sprintf("Failed to remove quarantine attribute: %v", err)))
+	}
+	_ = os.Remove(filepath.Join(root, previousLauncherRecordFile))
+	_ = os.Remove(old)
+
+	logf("%s", successLine(fmt.Sprintf("Rolled back from %s to %s", version, prev.Version)))
+	return prev.Version, nil
+}
+
+// relaunchAfterRollback starts the restored launcher. Its first run skips the update
+// check, which would otherwise install the release that was just rolled back.
+func relaunchAfterRollback(exePath string) error {
+	cmd := exec.Command(exePath)
+	setRestartUpdateProcessAttributes(cmd)
+	if cmd.Env == nil {
+		cmd.Env = os.Environ()
+	}
+	cmd.Env = append(cmd.Env, envNoUpdate+"=1")
+	if err := cmd.Start(); err != nil {
+		return fmt.Errorf("failed to start the restored launcher: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,69 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// TestLauncherRollback tests that updates keep the replaced executable until its
+// successor has launched, and that rolling back puts the kept one in place
+func TestLauncherRollback(t *testing.T) {
+	savedVersion := version
+	defer func() { version = savedVersion }()
+	root := t.TempDir()
+	exePath := filepath.Join(t.TempDir(), launcherShortName)
+	install := func(v string) {
+		version = v
+		if err := os.WriteFile(exePath, []byte("launcher "+v), 0755); err != nil {
+			t.Fatal(err)
+		}
+	}
+	kept := func() string {
+		data, _ := os.ReadFile(filepath.Join(root, previousLauncherFile))
+		return string(data)
+	}
+
+	if _, err := rollbackLauncher(root, exePath); err == nil {
+		t.Error("Expected an error with nothing kept to roll back to")
+	}
+
+	install("v3.2.26")
+	if err := keepPreviousLauncher(root, exePath, "v3.2.27"); err != nil {
+		t.Fatalf("keepPreviousLauncher failed: %v", err)
+	}
+	if prev, ok := readPreviousLauncher(root); !ok || prev.Version != "v3.2.26" || kept() != "launcher v3.2.26" {
+		t.Fatalf("Expected v3.2.26 to be kept, got %+v", prev)
+	}
+
+	// v3.2.27 updates again before it ever launched, so v3.2.26 stays
+	install("v3.2.27")
+	if err := keepPreviousLauncher(root, exePath, "v3.2.28"); err != nil {
+		t.Fatal(err)
+	}
+	if prev, _ := readPreviousLauncher(root); prev.Version != "v3.2.26" || prev.ReplacedBy != "v3.2.28" {
+		t.Errorf("Expected v3.2.26 to be kept for v3.2.28, got %+v", prev)
+	}
+
+	// Once v3.2.28 has launched, its own update keeps it instead
+	install("v3.2.28")
+	markLauncherLaunched(root)
+	if err := keepPreviousLauncher(root, exePath, "v3.2.29"); err != nil {
+		t.Fatal(err)
+	}
+	if prev, _ := readPreviousLauncher(root); prev.Version != "v3.2.28" || prev.Launched || kept() != "launcher v3.2.28" {
+		t.Errorf("Expected v3.2.28 to replace the kept executable, got %+v", prev)
+	}
+
+	install("v3.2.29")
+	previous, err := rollbackLauncher(root, exePath)
+	if err != nil {
+		t.Fatalf("rollbackLauncher failed: %v", err)
+	}
+	if data, _ := os.ReadFile(exePath); previous != "v3.2.28" || string(data) != "launcher v3.2.28" {
+		t.Errorf("Expected v3.2.28 back in place, got %s (%s)", previous, data)
+	}
+	if _, ok := readPreviousLauncher(root); ok || exists(exePath+".old") {
+		t.Error("Expected nothing left to roll back to after the rollback")
+	}
+}
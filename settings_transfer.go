@@ -0,0 +1,233 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// -------------------- Settings import/export --------------------
+
+// settingsExportFormat marks a file written by exportSettings
+const settingsExportFormat = "theboyslauncher-settings"
+
+// settingsExport is the portable file written by Export settings. LauncherHome is the
+// launcher folder chosen in Settings, which settings.json itself can't hold.
+type settingsExport struct {
+	Format          string           `json:"format"`
+	SchemaVersion   int              `json:"schemaVersion"`
+	LauncherVersion string           `json:"launcherVersion"`
+	ExportedAt      time.Time        `json:"exportedAt"`
+	LauncherHome    string           `json:"launcherHome,omitempty"`
+	Settings        LauncherSettings `json:"settings"`
+}
+
+// exportSettings writes the current settings to w as a settingsExport
+func exportSettings(w io.Writer) error {
+	export := settingsExport{
+		Format:          settingsExportFormat,
+		SchemaVersion:   settingsSchemaVersion,
+		LauncherVersion: version,
+		ExportedAt:      time.Now(),
+		Settings:        settings,
+	}
+	if record, err := readLauncherHomeRecord(); err == nil {
+		export.LauncherHome = record.Path
+	}
+	playStatsMu.RLock()
+	data, err := json.MarshalIndent(export, "", "  ")
+	playStatsMu.RUnlock()
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(append(data, '\n'))
+	return err
+}
+
+// readSettingsExport parses a file written by exportSettings. A settings.json copied
+// straight out of another launcher folder is accepted too.
+func readSettingsExport(data []byte) (settingsExport, error) {
+	var export settingsExport
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return export, fmt.Errorf("not a settings file: %w", err)
+	}
+	if _, ok := fields["memoryMB"]; ok && fields["format"] == nil {
+		export.SchemaVersion = settingsSchemaVersion
+		if err := json.Unmarshal(data, &export.Settings); err != nil {
+			return export, fmt.Errorf("invalid settings.json: %w", err)
+		}
+		if export.Settings.SchemaVersion > settingsSchemaVersion {
+			export.SchemaVersion = export.Settings.SchemaVersion
+		}
+	} else if err := json.Unmarshal(data, &export); err != nil {
+		return export, fmt.Errorf("invalid settings export: %w", err)
+	} else if export.Format != settingsExportFormat {
+		return export, errors.New("not a " + launcherName + " settings export")
+	}
+	if export.SchemaVersion > settingsSchemaVersion {
+		return export, fmt.Errorf("these settings are from a newer version of %s; update this launcher first", launcherName)
+	}
+	if err := validateImportedURLs(export.Settings); err != nil {
+		return export, err
+	}
+	return export, nil
+}
+
+// validateImportedURLs checks that the catalogs, Prism download and log upload
+// addresses in imported settings are all http(s) URLs
+func validateImportedURLs(imported LauncherSettings) error {
+	check := func(what, raw string) error {
+		u, err := url.Parse(raw)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return fmt.Errorf("the imported %s %q is not an http(s) URL", what, raw)
+		}
+		return nil
+	}
+	for _, raw := range imported.CatalogURLs {
+		if err := check("catalog", raw); err != nil {
+			return err
+		}
+	}
+	if raw := strings.TrimSpace(imported.PrismDownloadURL); raw != "" {
+		if err := validatePrismDownloadURL(raw); err != nil {
+			return err
+		}
+	}
+	if raw := strings.TrimSpace(imported.LogUploadURL); raw != "" {
+		if err := check("log upload address", raw); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// importedSource is an address imported settings would start using
+type importedSource struct {
+	// Kind is "catalog", "prism" or "logUpload"
+	Kind string
+	URL  string
+}
+
+// importedSources lists the catalogs, Prism download and log upload address that
+// importing would add or change. They decide where packs and programs come from
+// and where logs go, so they are confirmed before the settings are applied.
+func importedSources(current, imported LauncherSettings) []importedSource {
+	var sources []importedSource
+	for _, raw := range imported.CatalogURLs {
+		if !containsFold(current.CatalogURLs, raw) {
+			sources = append(sources, importedSource{Kind: "catalog", URL: raw})
+		}
+	}
+	if raw := strings.TrimSpace(imported.PrismDownloadURL); raw != "" && raw != strings.TrimSpace(current.PrismDownloadURL) {
+		sources = append(sources, importedSource{Kind: "prism", URL: raw})
+	}
+	if raw := strings.TrimSpace(imported.LogUploadURL); raw != "" && raw != strings.TrimSpace(current.LogUploadURL) {
+		sources = append(sources, importedSource{Kind: "logUpload", URL: raw})
+	}
+	return sources
+}
+
+// mergeImportedSettings returns current with the preferences from imported applied.
+// What belongs to this machine, such as the instances folder and the window state,
+// is kept, and per-modpack records are merged so nothing recorded here is lost.
+// Memory values are clamped as if they had been set here.
+func mergeImportedSettings(current, imported LauncherSettings) LauncherSettings {
+	merged := current
+
+	merged.AutoRAM = imported.AutoRAM
+	if imported.MemoryMB > 0 {
+		merged.MemoryMB = clampMemoryMB(imported.MemoryMB)
+	}
+	merged.RAMHeadroomMB = max(imported.RAMHeadroomMB, 0)
+	merged.DevBuildsEnabled = imported.DevBuildsEnabled
+	merged.UpdateChannel = imported.UpdateChannel
+	merged.DebugEnabled = imported.DebugEnabled
+	merged.CacheBust = imported.CacheBust
+	merged.DisableSelfUpdate = imported.DisableSelfUpdate
+	merged.Language = imported.Language
+	merged.Prefetch = imported.Prefetch
+	merged.PrismAccount = imported.PrismAccount
+	merged.DownloadTimeoutSec = max(imported.DownloadTimeoutSec, 0)
+	merged.DownloadConcurrency = max(imported.DownloadConcurrency, 0)
+	merged.InstallConcurrency = max(imported.InstallConcurrency, 0)
+	merged.BackupRetention = max(imported.BackupRetention, 0)
+	merged.HTTPRetries = max(imported.HTTPRetries, 0)
+	merged.HTTPRetryDelayMs = max(imported.HTTPRetryDelayMs, 0)
+	merged.OfflineMode = imported.OfflineMode
+	merged.PrismDownloadURL = imported.PrismDownloadURL
+	merged.PrismVersion = imported.PrismVersion
+	merged.KeepConsoleOpen = imported.KeepConsoleOpen
+	merged.LaunchPrismGUI = imported.LaunchPrismGUI
+	merged.QtPlatform = imported.QtPlatform
+	merged.LogUploadProvider = imported.LogUploadProvider
+	merged.LogUploadURL = imported.LogUploadURL
+	merged.RedactLogs = imported.RedactLogs
+	if format := strings.ToLower(imported.LogFormat); format == "" || format == logFormatPretty || format == logFormatJSON {
+		merged.LogFormat = imported.LogFormat
+	}
+	merged.SetupComplete = current.SetupComplete || imported.SetupComplete
+
+	merged.CatalogURLs = append([]string(nil), current.CatalogURLs...)
+	for _, url := range imported.CatalogURLs {
+		if !containsFold(merged.CatalogURLs, url) {
+			merged.CatalogURLs = append(merged.CatalogURLs, url)
+		}
+	}
+	merged.FavoriteModpackIDs = append([]string(nil), current.FavoriteModpackIDs...)
+	for _, id := range imported.FavoriteModpackIDs {
+		if !containsFold(merged.FavoriteModpackIDs, id) {
+			merged.FavoriteModpackIDs = append(merged.FavoriteModpackIDs, strings.ToLower(id))
+		}
+	}
+
+	merged.MemoryOverrides = make(map[string]int, len(current.MemoryOverrides)+len(imported.MemoryOverrides))
+	for id, mb := range current.MemoryOverrides {
+		merged.MemoryOverrides[id] = mb
+	}
+	for id, mb := range imported.MemoryOverrides {
+		merged.MemoryOverrides[strings.ToLower(id)] = clampMemoryMB(mb)
+	}
+	// Renaming an instance directory that is already installed here would orphan it
+	merged.InstanceNames = make(map[string]string, len(current.InstanceNames)+len(imported.InstanceNames))
+	for id, name := range imported.InstanceNames {
+		merged.InstanceNames[strings.ToLower(id)] = name
+	}
+	for id, name := range current.InstanceNames {
+		merged.InstanceNames[id] = name
+	}
+	merged.LastPlayed = make(map[string]time.Time, len(current.LastPlayed)+len(imported.LastPlayed))
+	for id, at := range current.LastPlayed {
+		merged.LastPlayed[id] = at
+	}
+	for id, at := range imported.LastPlayed {
+		if id = strings.ToLower(id); at.After(merged.LastPlayed[id]) {
+			merged.LastPlayed[id] = at
+		}
+	}
+	// Importing the same file twice must not count its playtime twice
+	merged.PlaytimeSeconds = make(map[string]int64, len(current.PlaytimeSeconds)+len(imported.PlaytimeSeconds))
+	for id, secs := range current.PlaytimeSeconds {
+		merged.PlaytimeSeconds[id] = secs
+	}
+	for id, secs := range imported.PlaytimeSeconds {
+		if id = strings.ToLower(id); secs > merged.PlaytimeSeconds[id] {
+			merged.PlaytimeSeconds[id] = secs
+		}
+	}
+	return merged
+}
+
+// containsFold reports whether list holds s, ignoring case
+func containsFold(list []string, s string) bool {
+	for _, item := range list {
+		if strings.EqualFold(item, s) {
+			return true
+		}
+	}
+	return false
+}
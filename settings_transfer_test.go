@@ -0,0 +1,122 @@
+package main
+
+import (
+	"bytes"
+	"testing"
+	"time"
+)
+
+// TestSettingsExportRoundTrip tests that exported settings read back, that a bare
+// settings.json is accepted and that files from newer launchers are refused
+func TestSettingsExportRoundTrip(t *testing.T) {
+	saved := settings
+	defer func() { settings = saved }()
+	t.Setenv("HOME", t.TempDir())
+	settings = LauncherSettings{MemoryMB: 6144, DebugEnabled: true, FavoriteModpackIDs: []string{"skyblock"}, SchemaVersion: settingsSchemaVersion}
+
+	var buf bytes.Buffer
+	if err := exportSettings(&buf); err != nil {
+		t.Fatal(err)
+	}
+	export, err := readSettingsExport(buf.Bytes())
+	if err != nil {
+		t.Fatalf("readSettingsExport failed: %v", err)
+	}
+	if export.Settings.MemoryMB != 6144 || !export.Settings.DebugEnabled || len(export.Settings.FavoriteModpackIDs) != 1 {
+		t.Errorf("Expected the exported settings back, got %+v", export.Settings)
+	}
+
+	if export, err := readSettingsExport([]byte(`{"memoryMB": 4096, "autoRam": false, "schemaVersion": 1}`)); err != nil || export.Settings.MemoryMB != 4096 {
+		t.Errorf("Expected a bare settings.json to be read, got %+v, %v", export.Settings, err)
+	}
+	for name, data := range map[string]string{
+		"other JSON":     `{"format": "something-else"}`,
+		"newer launcher": `{"format": "theboyslauncher-settings", "schemaVersion": 99}`,
+		"not JSON":       `memoryMB=4096`,
+		"file catalog":   `{"format": "theboyslauncher-settings", "settings": {"catalogUrls": ["file:///etc/passwd"]}}`,
+		"bad prism":      `{"format": "theboyslauncher-settings", "settings": {"prismDownloadUrl": "ftp://example.com/prism.zip"}}`,
+		"bad log upload": `{"format": "theboyslauncher-settings", "settings": {"logUploadUrl": "javascript:alert(1)"}}`,
+	} {
+		if _, err := readSettingsExport([]byte(data)); err == nil {
+			t.Errorf("%s: expected an error", name)
+		}
+	}
+}
+
+// TestMergeImportedSettings tests that imported preferences replace the current
+// ones while machine-specific values are kept and per-modpack records are merged
+func TestMergeImportedSettings(t *testing.T) {
+	earlier := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	later := earlier.Add(48 * time.Hour)
+	current := LauncherSettings{
+		MemoryMB:           4096,
+		AutoRAM:            true,
+		SetupComplete:      true,
+		InstancesDir:       "/data/instances",
+		LastTab:            2,
+		FavoriteModpackIDs: []string{"skyblock"},
+		InstanceNames:      map[string]string{"skyblock": "Skyblock (old)"},
+		LastPlayed:         map[string]time.Time{"skyblock": later},
+		PlaytimeSeconds:    map[string]int64{"skyblock": 500},
+		MemoryOverrides:    map[string]int{"skyblock": 6144},
+	}
+	imported := LauncherSettings{
+		MemoryMB:           64000,
+		DebugEnabled:       true,
+		HTTPRetries:        -3,
+		LogFormat:          "xml",
+		InstancesDir:       `C:\Games\instances`,
+		FavoriteModpackIDs: []string{"SKYBLOCK", "create"},
+		InstanceNames:      map[string]string{"skyblock": "Skyblock", "create": "Create"},
+		LastPlayed:         map[string]time.Time{"skyblock": earlier, "create": earlier},
+		PlaytimeSeconds:    map[string]int64{"skyblock": 300, "create": 900},
+		MemoryOverrides:    map[string]int{"create": 1024},
+	}
+
+	merged := mergeImportedSettings(current, imported)
+	if merged.MemoryMB != 16384 || merged.AutoRAM || !merged.DebugEnabled || merged.HTTPRetries != 0 || merged.LogFormat != "" {
+		t.Errorf("Expected imported preferences, clamped and validated, got %+v", merged)
+	}
+	if merged.InstancesDir != "/data/instances" || merged.LastTab != 2 || !merged.SetupComplete {
+		t.Errorf("Expected machine-specific settings to be kept, got %+v", merged)
+	}
+	if len(merged.FavoriteModpackIDs) != 2 || merged.FavoriteModpackIDs[1] != "create" {
+		t.Errorf("Expected favorites to be merged, got %v", merged.FavoriteModpackIDs)
+	}
+	if merged.InstanceNames["skyblock"] != "Skyblock (old)" || merged.InstanceNames["create"] != "Create" {
+		t.Errorf("Expected this machine's instance names to win, got %v", merged.InstanceNames)
+	}
+	if !merged.LastPlayed["skyblock"].Equal(later) || merged.PlaytimeSeconds["skyblock"] != 500 || merged.PlaytimeSeconds["create"] != 900 {
+		t.Errorf("Expected the later play time and larger playtime, got %v %v", merged.LastPlayed, merged.PlaytimeSeconds)
+	}
+	if merged.MemoryOverrides["skyblock"] != 6144 || merged.MemoryOverrides["create"] != 2048 {
+		t.Errorf("Expected memory overrides merged and clamped, got %v", merged.MemoryOverrides)
+	}
+}
+
+// TestImportedSources tests that only catalogs and addresses the launcher doesn't
+// use yet are listed for confirmation
+func TestImportedSources(t *testing.T) {
+	current := LauncherSettings{CatalogURLs: []string{"https://example.com/catalog.json"}, LogUploadURL: "https://logs.example.com"}
+	if sources := importedSources(current, current); len(sources) != 0 {
+		t.Errorf("Expected nothing new in unchanged settings, got %+v", sources)
+	}
+	imported := LauncherSettings{
+		CatalogURLs:      []string{"https://EXAMPLE.com/catalog.json", "https://other.example.com/catalog.json"},
+		PrismDownloadURL: "https://mirror.example.com/prism.zip",
+		LogUploadURL:     "https://logs.example.com",
+	}
+	sources := importedSources(current, imported)
+	want := []importedSource{
+		{Kind: "catalog", URL: "https://other.example.com/catalog.json"},
+		{Kind: "prism", URL: "https://mirror.example.com/prism.zip"},
+	}
+	if len(sources) != len(want) {
+		t.Fatalf("Expected %+v, got %+v", want, sources)
+	}
+	for i := range want {
+		if sources[i] != want[i] {
+			t.Errorf("Expected %+v, got %+v", want[i], sources[i])
+		}
+	}
+}
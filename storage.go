@@ -0,0 +1,238 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// -------------------- Disk usage --------------------
+
+// storageItem is one folder in the launcher home and the space it takes
+type storageItem struct {
+	Name string
+	Path string
+	Size int64
+}
+
+// modpackStorage is the space one modpack takes: its instance, of which Mods is the
+// mods folder, plus the backups made before its updates
+type modpackStorage struct {
+	Modpack  Modpack
+	Instance int64
+	Mods     int64
+	Backups  []storageItem
+}
+
+// Total is the instance plus all of its backups
+func (m modpackStorage) Total() int64 {
+	total := m.Instance
+	for _, backup := range m.Backups {
+		total += backup.Size
+	}
+	return total
+}
+
+// javaStorage is a managed JRE; Unused ones can be deleted
+type javaStorage struct {
+	storageItem
+	Version string
+	Unused  bool
+}
+
+// storageReport breaks the launcher home down by modpack and shared download
+type storageReport struct {
+	Modpacks []modpackStorage
+	// Prism is Prism Launcher itself, without the instances and Java it holds
+	Prism storageItem
+	Java  []javaStorage
+	// OtherBackups are backups of modpacks no longer in the catalog
+	OtherBackups []storageItem
+	Total        int64
+}
+
+// scanStorage measures the instances of mods, their backups in util/backups and the
+// shared Prism and Java downloads. Working out which JREs are unused may look up
+// each instance's Java version online, so call it off the UI thread.
+func scanStorage(root string, mods []Modpack) storageReport {
+	var report storageReport
+	instancesDir := instancesDirFor(root)
+
+	backupsDir := backupsDirFor(root)
+	var backups []storageItem
+	if entries, err := os.ReadDir(backupsDir); err == nil {
+		for _, entry := range entries {
+			if entry.IsDir() {
+				path := filepath.Join(backupsDir, entry.Name())
+				backups = append(backups, storageItem{Name: entry.Name(), Path: path, Size: dirSize(path)})
+			}
+		}
+	}
+	claimed := make(map[string]bool)
+
+	for _, mod := range mods {
+		instDir := filepath.Join(instancesDir, mod.InstanceName)
+		usage := modpackStorage{Modpack: mod}
+		if exists(instDir) {
+			usage.Instance = dirSize(instDir)
+			usage.Mods = dirSize(filepath.Join(instDir, "minecraft", "mods"))
+		}
+		prefix := backupPrefixFor(mod)
+		for _, backup := range backups {
+			if !claimed[backup.Path] && strings.HasPrefix(backup.Name, prefix) {
+				usage.Backups = append(usage.Backups, backup)
+				claimed[backup.Path] = true
+			}
+		}
+		if usage.Total() > 0 {
+			report.Modpacks = append(report.Modpacks, usage)
+			report.Total += usage.Total()
+		}
+	}
+	sort.SliceStable(report.Modpacks, func(i, j int) bool {
+		return report.Modpacks[i].Total() > report.Modpacks[j].Total()
+	})
+	for _, backup := range backups {
+		if !claimed[backup.Path] {
+			report.OtherBackups = append(report.OtherBackups, backup)
+			report.Total += backup.Size
+		}
+	}
+
+	// Prism keeps instances and Java inside its folder; they are counted separately
+	prismDir := filepath.Join(root, "prism")
+	report.Prism = storageItem{Name: "Prism Launcher", Path: prismDir}
+	if entries, err := os.ReadDir(prismDir); err == nil {
+		for _, entry := range entries {
+			path := filepath.Join(prismDir, entry.Name())
+			if entry.Name() == "java" || filepath.Clean(path) == filepath.Clean(instancesDir) {
+				continue
+			}
+			if entry.IsDir() {
+				report.Prism.Size += dirSize(path)
+			} else if info, err := entry.Info(); err == nil {
+				report.Prism.Size += info.Size()
+			}
+		}
+	}
+	report.Total += report.Prism.Size
+
+	inUse, err := javaVersionsInUse(root)
+	if err != nil {
+		logf("%s", warnLine(fmt.Sprintf("Cannot tell which Java runtimes are unused: %v", err)))
+	}
+	javaDir := filepath.Join(prismDir, "java")
+	if entries, err := os.ReadDir(javaDir); err == nil {
+		for _, entry := range entries {
+			version, ok := strings.CutPrefix(entry.Name(), "jre")
+			if !entry.IsDir() || !ok || version == "" {
+				continue
+			}
+			path := filepath.Join(javaDir, entry.Name())
+			jre := javaStorage{
+				storageItem: storageItem{Name: "Java " + version, Path: path, Size: dirSize(path)},
+				Version:     version,
+				Unused:      inUse != nil && !inUse[version],
+			}
+			report.Java = append(report.Java, jre)
+			report.Total += jre.Size
+		}
+	}
+	return report
+}
+
+// -------------------- Free space --------------------
+
+const (
+	// javaInstallBytes and prismInstallBytes are roughly what a JRE and Prism Launcher
+	// take while being installed: the unpacked files plus the archive beside them
+	javaInstallBytes  = 400 << 20
+	prismInstallBytes = 300 << 20
+	// diskSpaceMargin is kept free on top of the estimate for logs, configs and worlds
+	diskSpaceMargin = 512 << 20
+)
+
+// diskSpaceNeed is the space an install still needs on the volume holding Path
+type diskSpaceNeed struct {
+	Path  string
+	Bytes int64
+	// Uncertain is set when part of the pack couldn't be sized, so Bytes may be low
+	Uncertain bool
+}
+
+// diskSpaceShortage is a volume with less free space than an install needs
+type diskSpaceShortage struct {
+	diskSpaceNeed
+	Free int64
+}
+
+func (s diskSpaceShortage) Error() string {
+	return fmt.Sprintf("not enough free disk space for %s: about %s is needed but only %s is free",
+		s.Path, formatSize(s.Bytes+diskSpaceMargin), formatSize(s.Free))
+}
+
+// installSpaceNeeds works out what installing modpack still needs on disk: the
+// runtimes not yet downloaded under root and, for a pack that isn't installed, its
+// files in the instances folder. Both count against one volume unless the instances
+// folder was moved out of root. knownSize may hold an earlier estimate of the pack;
+// without one the pack index is sized online.
+func installSpaceNeeds(root string, modpack Modpack, jreDir string, knownSize packSizeEstimate) []diskSpaceNeed {
+	home := diskSpaceNeed{Path: root}
+	if !exists(filepath.Join(jreDir, "bin", JavaBinName)) {
+		home.Bytes += javaInstallBytes
+	}
+	if !exists(GetPrismExecutablePath(filepath.Join(root, "prism"))) {
+		home.Bytes += prismInstallBytes
+	}
+
+	instancesDir := instancesDirFor(root)
+	pack := diskSpaceNeed{Path: instancesDir}
+	if !exists(filepath.Join(instancesDir, modpack.InstanceName, "instance.cfg")) {
+		estimate := packSizeEstimate{Bytes: modpack.SizeBytes}
+		if estimate.Bytes == 0 {
+			estimate = knownSize
+		}
+		if estimate.Bytes == 0 {
+			var err error
+			if estimate, err = estimatePackSize(modpack.PackURL, modpack.Headers); err != nil {
+				debugf("Couldn't estimate the size of %s: %v", modpackLabel(modpack), err)
+			}
+		}
+		pack.Bytes = estimate.Bytes
+		pack.Uncertain = estimate.Bytes == 0 || estimate.Unknown > 0
+	}
+
+	if rel, err := filepath.Rel(root, instancesDir); err == nil && rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		home.Bytes += pack.Bytes
+		home.Uncertain = pack.Uncertain
+		return []diskSpaceNeed{home}
+	}
+	return []diskSpaceNeed{home, pack}
+}
+
+// checkDiskSpace returns the needs that don't fit, margin included, in the space free
+// reports for their volume. Volumes whose free space can't be read are skipped.
+func checkDiskSpace(needs []diskSpaceNeed, free func(path string) (int64, error)) []diskSpaceShortage {
+	var short []diskSpaceShortage
+	for _, need := range needs {
+		if need.Bytes == 0 && !need.Uncertain {
+			continue
+		}
+		// The instances folder may not exist yet; its nearest existing parent is on the same volume
+		dir := need.Path
+		for !exists(dir) && filepath.Dir(dir) != dir {
+			dir = filepath.Dir(dir)
+		}
+		avail, err := free(dir)
+		if err != nil {
+			debugf("Cannot read free disk space for %s: %v", need.Path, err)
+			continue
+		}
+		if avail < need.Bytes+diskSpaceMargin {
+			short = append(short, diskSpaceShortage{diskSpaceNeed: need, Free: avail})
+		}
+	}
+	return short
+}
@@ -0,0 +1,101 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// writeTestFile creates path with size bytes, making its folders as needed
+func writeTestFile(t *testing.T, path string, size int) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, make([]byte, size), 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// TestScanStorage tests that space is grouped by modpack and shared download with a matching total
+func TestScanStorage(t *testing.T) {
+	saved := settings
+	defer func() { settings = saved }()
+	root := t.TempDir()
+	settings.InstancesDir = ""
+	settings.OfflineMode = true
+	javaDir := filepath.Join(root, "prism", "java")
+
+	mod := Modpack{ID: "skyblock", InstanceName: "Skyblock"}
+	instDir := filepath.Join(instancesDirFor(root), mod.InstanceName)
+	writeTestFile(t, filepath.Join(instDir, "minecraft", "mods", "a.jar"), 300)
+	writeTestFile(t, filepath.Join(instDir, "minecraft", "options.txt"), 100)
+	writeTestInstance(t, instancesDirFor(root), mod.InstanceName, filepath.Join(javaDir, "jre21", "bin", JavawBinName), "")
+	writeTestFile(t, filepath.Join(root, "util", "backups", backupPrefixFor(mod)+"2024-01-01-00-00-00", "mods", "a.jar"), 250)
+	writeTestFile(t, filepath.Join(root, "util", "backups", "oldpack-backup-2023-01-01-00-00-00", "x"), 50)
+	writeTestFile(t, filepath.Join(root, "prism", "PrismLauncher"), 1000)
+	writeTestFile(t, filepath.Join(javaDir, "jre21", "bin", JavawBinName), 400)
+	writeTestFile(t, filepath.Join(javaDir, "jre8", "bin", JavawBinName), 200)
+
+	report := scanStorage(root, []Modpack{mod, {ID: "notinstalled", InstanceName: "Missing"}})
+	if len(report.Modpacks) != 1 {
+		t.Fatalf("Expected only the installed modpack, got %+v", report.Modpacks)
+	}
+	usage := report.Modpacks[0]
+	if usage.Mods != 300 || usage.Instance < 400 || len(usage.Backups) != 1 || usage.Backups[0].Size != 250 {
+		t.Errorf("Unexpected modpack usage %+v", usage)
+	}
+	if len(report.OtherBackups) != 1 || report.OtherBackups[0].Size != 50 {
+		t.Errorf("Expected the leftover backup to be listed separately, got %+v", report.OtherBackups)
+	}
+	if report.Prism.Size != 1000 {
+		t.Errorf("Expected Prism to exclude instances and Java, got %d", report.Prism.Size)
+	}
+	unused := map[string]bool{}
+	for _, jre := range report.Java {
+		unused[jre.Version] = jre.Unused
+	}
+	if len(unused) != 2 || unused["21"] || !unused["8"] {
+		t.Errorf("Expected only Java 8 to be unused, got %v", unused)
+	}
+	if want := usage.Total() + 50 + 1000 + 400 + 200; report.Total != want {
+		t.Errorf("Expected a total of %d, got %d", want, report.Total)
+	}
+}
+
+// TestCheckDiskSpace tests that an install's needs count what isn't on disk yet and
+// that a volume short of them plus the margin is reported
+func TestCheckDiskSpace(t *testing.T) {
+	saved := settings
+	defer func() { settings = saved }()
+	root := t.TempDir()
+	settings.InstancesDir = ""
+	jreDir := filepath.Join(root, "prism", "java", "jre21")
+	mod := Modpack{ID: "skyblock", InstanceName: "Skyblock", SizeBytes: 100 << 20}
+
+	needs := installSpaceNeeds(root, mod, jreDir, packSizeEstimate{})
+	want := diskSpaceNeed{Path: root, Bytes: javaInstallBytes + prismInstallBytes + mod.SizeBytes}
+	if len(needs) != 1 || needs[0] != want {
+		t.Fatalf("Expected %+v for a fresh install, got %+v", want, needs)
+	}
+
+	// Installed runtimes aren't counted, and an instances folder elsewhere is its own volume
+	writeTestFile(t, filepath.Join(jreDir, "bin", JavaBinName), 10)
+	writeTestFile(t, GetPrismExecutablePath(filepath.Join(root, "prism")), 10)
+	settings.InstancesDir = filepath.Join(t.TempDir(), "instances")
+	mod.SizeBytes = 0
+	needs = installSpaceNeeds(root, mod, jreDir, packSizeEstimate{Bytes: 50 << 20, Unknown: 2})
+	if len(needs) != 2 || needs[0].Bytes != 0 || needs[1] != (diskSpaceNeed{Path: settings.InstancesDir, Bytes: 50 << 20, Uncertain: true}) {
+		t.Fatalf("Expected only the uncertain pack size in the instances folder, got %+v", needs)
+	}
+
+	free := map[string]int64{root: 10 << 30, filepath.Dir(settings.InstancesDir): 300 << 20}
+	short := checkDiskSpace(needs, func(path string) (int64, error) { return free[path], nil })
+	if len(short) != 1 || short[0].Path != settings.InstancesDir || short[0].Free != 300<<20 || !short[0].Uncertain {
+		t.Fatalf("Expected the instances volume to be short, got %+v", short)
+	}
+	free[filepath.Dir(settings.InstancesDir)] = 50<<20 + diskSpaceMargin
+	if short := checkDiskSpace(needs, func(path string) (int64, error) { return free[path], nil }); len(short) != 0 {
+		t.Errorf("Expected the pack to fit with the margin left, got %+v", short)
+	}
+}
@@ -1,6 +1,8 @@
 package main
 
 import (
+	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
@@ -10,14 +12,70 @@ import (
 	"regexp"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 )
 
 // -------------------- Self-update (no downgrades) --------------------
 
+// updateFlight lets concurrent callers share a single in-progress update instead of starting another
+type updateFlight struct {
+	mu   sync.Mutex
+	done chan struct{}
+	err  error
+}
+
+// do runs fn unless a run is already in progress, in which case it waits for that
+// run and returns its result. shared reports whether the result came from another caller.
+func (f *updateFlight) do(fn func() error) (shared bool, err error) {
+	f.mu.Lock()
+	if done := f.done; done != nil {
+		f.mu.Unlock()
+		<-done
+		f.mu.Lock()
+		defer f.mu.Unlock()
+		return true, f.err
+	}
+	done := make(chan struct{})
+	f.done = done
+	f.mu.Unlock()
+
+	err = fn()
+
+	f.mu.Lock()
+	f.err = err
+	f.done = nil
+	f.mu.Unlock()
+	close(done)
+	return false, err
+}
+
+// selfUpdateFlight makes overlapping selfUpdate calls (startup check and Refresh) share one run
+var selfUpdateFlight updateFlight
+
+// updateSwapMu serializes selfUpdate and forceUpdate, which both replace the launcher binary
+var updateSwapMu sync.Mutex
+
 func selfUpdate(root, exePath string, report func(string)) error {
+	if selfUpdateDisabled() {
+		logf("%s", infoLine("Self-update is disabled; skipping launcher update check"))
+		return nil
+	}
+
+	shared, err := selfUpdateFlight.do(func() error {
+		updateSwapMu.Lock()
+		defer updateSwapMu.Unlock()
+		return runSelfUpdate(root, exePath, report)
+	})
+	if shared {
+		debugf("Reused result of a launcher update check that was already in progress")
+	}
+	return err
+}
+
+// runSelfUpdate checks for a newer launcher and swaps it in; callers go through selfUpdate
+func runSelfUpdate(root, exePath string, report func(string)) error {
 	debugf("Starting self-update process")
-	_ = root
 
 	notify := func(msg string) {
 		debugf("Update notification: %s", msg)
@@ -28,10 +86,10 @@ func selfUpdate(root, exePath string, report func(string)) error {
 
 	notify("Checking for launcher updates...")
 
-	// Prefer prerelease/dev builds if the user has enabled them
-	preferDev := settings.DevBuildsEnabled
-	debugf("Update preference - Dev builds enabled: %t", preferDev)
-	tag, assetURL, err := FetchLatestAssetPreferPrerelease(UPDATE_OWNER, UPDATE_REPO, LauncherAssetName, preferDev)
+	// Follow the update channel chosen in Settings
+	channel := updateChannelSetting()
+	debugf("Update preference - channel: %s", channel)
+	tag, assetURL, err := FetchLatestAssetForChannel(UPDATE_OWNER, UPDATE_REPO, LauncherAssetName, channel)
 	if err != nil || tag == "" || assetURL == "" {
 		if err == nil {
 			err = errors.New("update metadata missing")
@@ -68,7 +126,7 @@ func selfUpdate(root, exePath string, report func(string)) error {
 
 	tmpNew := exePath + ".new"
 	debugf("Downloading update to temporary file: %s", tmpNew)
-	if err := downloadTo(assetURL, tmpNew, 0755); err != nil {
+	if err := downloadTo(context.Background(), assetURL, tmpNew, 0755); err != nil {
 		debugf("Update download failed: %v", err)
 		notify(fmt.Sprintf("Update download failed: %v", err))
 		return err
@@ -85,6 +143,10 @@ func selfUpdate(root, exePath string, report func(string)) error {
 		debugf("Quarantine attribute removed successfully")
 	}
 
+	if err := keepPreviousLauncher(root, exePath, tag); err != nil {
+		logf("%s", warnLine(fmt.Sprintf("Failed to keep %s for rollback: %v", version, err)))
+	}
+
 	notify("Update downloaded successfully")
 	logf("%s", successLine("Update downloaded successfully"))
 	notify("Preparing to restart with the new version...")
@@ -109,9 +171,15 @@ func selfUpdate(root, exePath string, report func(string)) error {
 	return nil
 }
 
-// forceUpdate forces an update to the latest version regardless of current version
-func forceUpdate(root, exePath string, preferDev bool, report func(string)) error {
-	_ = root
+// forceUpdate forces an update to the latest version on channel regardless of current version
+func forceUpdate(root, exePath string, channel updateChannel, report func(string)) error {
+	if selfUpdateDisabled() {
+		logf("%s", infoLine("Self-update is disabled; not switching launcher version"))
+		return nil
+	}
+
+	updateSwapMu.Lock()
+	defer updateSwapMu.Unlock()
 
 	notify := func(msg string) {
 		if report != nil {
@@ -121,8 +189,8 @@ func forceUpdate(root, exePath string, preferDev bool, report func(string)) erro
 
 	notify("Checking for latest launcher version...")
 
-	// Fetch the latest asset based on preference (dev or stable)
-	tag, assetURL, err := FetchLatestAssetPreferPrerelease(UPDATE_OWNER, UPDATE_REPO, LauncherAssetName, preferDev)
+	// Fetch the latest asset on the chosen channel
+	tag, assetURL, err := FetchLatestAssetForChannel(UPDATE_OWNER, UPDATE_REPO, LauncherAssetName, channel)
 	if err != nil || tag == "" || assetURL == "" {
 		if err == nil {
 			err = errors.New("update metadata missing")
@@ -131,17 +199,12 @@ func forceUpdate(root, exePath string, preferDev bool, report func(string)) erro
 		return err
 	}
 
-	channel := "stable"
-	if preferDev {
-		channel = "dev"
-	}
-
 	logf("Force updating to latest %s version: %s", channel, tag)
 	notify(fmt.Sprintf("Downloading %s version %s...", channel, tag))
 	logf("%s", stepLine("Downloading update..."))
 
 	tmpNew := exePath + ".new"
-	if err := downloadTo(assetURL, tmpNew, 0755); err != nil {
+	if err := downloadTo(context.Background(), assetURL, tmpNew, 0755); err != nil {
 		notify(fmt.Sprintf("Update download failed: %v", err))
 		return err
 	}
@@ -152,6 +215,10 @@ func forceUpdate(root, exePath string, preferDev bool, report func(string)) erro
 		// Don't fail the update, just warn the user
 	}
 
+	if err := keepPreviousLauncher(root, exePath, tag); err != nil {
+		logf("%s", warnLine(fmt.Sprintf("Failed to keep %s for rollback: %v", version, err)))
+	}
+
 	notify("Update downloaded successfully")
 	logf("%s", successLine("Update downloaded successfully"))
 	notify("Preparing to restart with the new version...")
@@ -172,21 +239,155 @@ func forceUpdate(root, exePath string, preferDev bool, report func(string)) erro
 	return nil
 }
 
-// FetchLatestAssetPreferPrerelease fetches the latest asset URL for the desired binary.
-// If preferPrerelease is true it will attempt to find a prerelease tag (containing "dev") first,
-// otherwise it falls back to the latest normal release.
-func FetchLatestAssetPreferPrerelease(owner, repo, wantName string, preferPrerelease bool) (tag, url string, err error) {
-	const maxPages = 10 // Limit pagination to avoid infinite loops
+// checkLauncherUpdate reports whether a newer launcher is published on channel,
+// without downloading or replacing anything
+func checkLauncherUpdate(channel updateChannel) (available bool, current, latest string, err error) {
+	current = version
+
+	tag, assetURL, err := FetchLatestAssetForChannel(UPDATE_OWNER, UPDATE_REPO, LauncherAssetName, channel)
+	if err == nil && (tag == "" || assetURL == "") {
+		err = errors.New("update metadata missing")
+	}
+	if err != nil {
+		return false, current, "", err
+	}
+	debugf("Update check only - Local: %s, Remote: %s", normalizeTag(version), normalizeTag(tag))
+	return compareSemver(normalizeTag(version), normalizeTag(tag)) < 0, current, tag, nil
+}
 
-	// If preferPrerelease, we only need to check the first page since dev builds are recent
+// githubAPIBase is the GitHub REST API root; tests point it at a local server
+var githubAPIBase = "https://api.github.com"
+
+// maxReleasePages bounds how many pages of releases are searched
+const maxReleasePages = 10
+
+// githubRelease is the part of a GitHub releases API entry the updater needs
+type githubRelease struct {
+	TagName    string `json:"tag_name"`
+	Draft      bool   `json:"draft"`
+	Prerelease bool   `json:"prerelease"`
+	Assets     []struct {
+		Name string `json:"name"`
+		URL  string `json:"browser_download_url"`
+	} `json:"assets"`
+}
+
+// FetchLatestAssetPreferPrerelease fetches the latest asset URL for the desired binary
+// on the Dev channel when preferPrerelease is true and the stable channel otherwise.
+// See FetchLatestAssetForChannel.
+func FetchLatestAssetPreferPrerelease(owner, repo, wantName string, preferPrerelease bool) (tag, url string, err error) {
+	channel := channelStable
 	if preferPrerelease {
-		return fetchFromPage(owner, repo, wantName, 1, preferPrerelease)
+		channel = channelDev
+	}
+	return FetchLatestAssetForChannel(owner, repo, wantName, channel)
+}
+
+// FetchLatestAssetForChannel fetches the asset URL of the highest release on channel,
+// or a steadier one, that carries wantName.
+// The releases API is paged through first; if it is unavailable (e.g. rate limited)
+// the public releases pages are scraped instead.
+func FetchLatestAssetForChannel(owner, repo, wantName string, channel updateChannel) (tag, url string, err error) {
+	tag, url, err = fetchReleaseAssetFromAPI(owner, repo, wantName, channel)
+	if err == nil {
+		return tag, url, nil
+	}
+	logf("Releases API lookup failed, falling back to releases page: %v", err)
+	return fetchReleaseAssetFromPages(owner, repo, wantName, channel)
+}
+
+// fetchReleaseAssetFromAPI walks the releases API newest first, following the Link
+// header's rel="next" until a page has a release on channel, or a steadier one, that
+// carries wantName, and returns the highest of those.
+func fetchReleaseAssetFromAPI(owner, repo, wantName string, channel updateChannel) (tag, url string, err error) {
+	pageURL := fmt.Sprintf("%s/repos/%s/%s/releases?per_page=100", githubAPIBase, owner, repo)
+
+	for page := 1; pageURL != "" && page <= maxReleasePages; page++ {
+		releases, next, err := fetchReleasesPage(pageURL)
+		if err != nil {
+			return "", "", err
+		}
+		var candidates []releaseCandidate
+		for _, release := range releases {
+			if release.Draft {
+				continue
+			}
+			for _, asset := range release.Assets {
+				if asset.Name == wantName {
+					candidates = append(candidates, releaseCandidate{Tag: release.TagName, URL: asset.URL, Prerelease: release.Prerelease})
+					break
+				}
+			}
+		}
+		if best, ok := bestRelease(candidates, channel); ok {
+			debugf("Found %s release %s on page %d for the %s channel", releaseChannel(best.Tag, best.Prerelease), best.Tag, page, channel)
+			return best.Tag, best.URL, nil
+		}
+		pageURL = next
+	}
+
+	return "", "", fmt.Errorf("no %s release of %s/%s has asset %s", channel, owner, repo, wantName)
+}
+
+// fetchReleasesPage fetches one page of the releases API and returns the next page's URL, if any
+func fetchReleasesPage(pageURL string) ([]githubRelease, string, error) {
+	req, err := http.NewRequest("GET", pageURL, nil)
+	if err != nil {
+		return nil, "", err
+	}
+	req.Header.Set("User-Agent", getUserAgent("General"))
+	req.Header.Set("Accept", "application/vnd.github+json")
+
+	resp, err := doWithRetry(req, httpRetryAttempts(), downloadTimeout())
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to fetch releases: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, "", fmt.Errorf("releases API returned status %d", resp.StatusCode)
 	}
 
-	// For stable releases, we need to check multiple pages since stable releases might be on older pages
+	var releases []githubRelease
+	if err := json.NewDecoder(resp.Body).Decode(&releases); err != nil {
+		return nil, "", fmt.Errorf("failed to parse releases: %w", err)
+	}
+	return releases, nextPageLink(resp.Header.Get("Link")), nil
+}
+
+// nextPageLink returns the rel="next" URL from a Link header, or "" on the last page
+func nextPageLink(header string) string {
+	for _, part := range strings.Split(header, ",") {
+		segments := strings.Split(part, ";")
+		if len(segments) < 2 {
+			continue
+		}
+		target := strings.TrimSpace(segments[0])
+		if !strings.HasPrefix(target, "<") || !strings.HasSuffix(target, ">") {
+			continue
+		}
+		for _, param := range segments[1:] {
+			if strings.TrimSpace(param) == `rel="next"` {
+				return target[1 : len(target)-1]
+			}
+		}
+	}
+	return ""
+}
+
+// fetchReleaseAssetFromPages scrapes the public releases pages for the asset
+func fetchReleaseAssetFromPages(owner, repo, wantName string, channel updateChannel) (tag, url string, err error) {
+	const maxPages = maxReleasePages
+
+	// Dev builds are recent, so only the first page needs checking for them
+	if channel == channelDev {
+		return fetchFromPage(owner, repo, wantName, 1, channel)
+	}
+
+	// Steadier releases might be on older pages
 	for page := 1; page <= maxPages; page++ {
-		logf("Checking page %d for stable releases...", page)
-		tag, url, err := fetchFromPage(owner, repo, wantName, page, preferPrerelease)
+		logf("Checking page %d for %s releases...", page, channel)
+		tag, url, err := fetchFromPage(owner, repo, wantName, page, channel)
 		if err != nil {
 			// If we get an error that indicates no more releases, stop pagination
 			if strings.Contains(err.Error(), "could not find any release tags") {
@@ -198,9 +399,9 @@ func FetchLatestAssetPreferPrerelease(owner, repo, wantName string, preferPrerel
 			continue
 		}
 
-		// If we found a stable release, return it
+		// If we found a release on the channel, return it
 		if tag != "" && url != "" {
-			logf("Found stable release %s on page %d", tag, page)
+			logf("Found release %s on page %d", tag, page)
 			return tag, url, nil
 		}
 
@@ -216,11 +417,12 @@ func FetchLatestAssetPreferPrerelease(owner, repo, wantName string, preferPrerel
 		}
 	}
 
-	return "", "", fmt.Errorf("no stable releases found for %s/%s after checking %d pages", owner, repo, maxPages)
+	return "", "", fmt.Errorf("no %s releases found for %s/%s after checking %d pages", channel, owner, repo, maxPages)
 }
 
-// fetchFromPage fetches releases from a specific page and returns the appropriate tag/URL
-func fetchFromPage(owner, repo, wantName string, page int, preferPrerelease bool) (tag, url string, err error) {
+// fetchFromPage fetches releases from a specific page and returns the highest one on
+// channel, or "" without an error when the page has none
+func fetchFromPage(owner, repo, wantName string, page int, channel updateChannel) (tag, url string, err error) {
 	var releasesURL string
 	if page == 1 {
 		releasesURL = fmt.Sprintf("https://github.com/%s/%s/releases", owner, repo)
@@ -228,7 +430,7 @@ func fetchFromPage(owner, repo, wantName string, page int, preferPrerelease bool
 		releasesURL = fmt.Sprintf("https://github.com/%s/%s/releases?page=%d", owner, repo, page)
 	}
 
-	resp, err := http.Get(releasesURL)
+	resp, err := httpGetWithRetry(releasesURL, httpRetryAttempts(), downloadTimeout())
 	if err != nil {
 		return "", "", fmt.Errorf("failed to fetch releases page %d: %w", page, err)
 	}
@@ -244,28 +446,7 @@ func fetchFromPage(owner, repo, wantName string, page int, preferPrerelease bool
 	}
 	html := string(body)
 
-	// If preferPrerelease, try to locate a tag that contains 'dev' (our autobump uses dev.<sha>)
-	if preferPrerelease {
-		// Match tags like v1.2.3-dev.<sha> (look for '-dev.' to reduce false positives)
-		prereleaseRe := regexp.MustCompile(fmt.Sprintf(`/%s/%s/releases/tag/([^"']*-dev[.\-][^"']*)`, regexp.QuoteMeta(owner), regexp.QuoteMeta(repo)))
-		if m := prereleaseRe.FindStringSubmatch(html); len(m) >= 2 {
-			tag = m[1]
-			assetURL := fmt.Sprintf("https://github.com/%s/%s/releases/download/%s/%s", owner, repo, tag, wantName)
-			// verify
-			headReq, _ := http.NewRequest("HEAD", assetURL, nil)
-			headReq.Header.Set("User-Agent", getUserAgent("General"))
-			headResp, err := http.DefaultClient.Do(headReq)
-			if err == nil && headResp != nil {
-				headResp.Body.Close()
-				if headResp.StatusCode == 200 {
-					return tag, assetURL, nil
-				}
-			}
-		}
-		// fall through to normal latest release
-	}
-
-	// Fallback: find release tags on the releases page
+	// Find release tags on the releases page
 	tagPattern := fmt.Sprintf(`/%s/%s/releases/tag/([^"']+)`, regexp.QuoteMeta(owner), regexp.QuoteMeta(repo))
 	tagRe := regexp.MustCompile(tagPattern)
 	tagMatches := tagRe.FindAllStringSubmatch(html, -1)
@@ -274,31 +455,19 @@ func fetchFromPage(owner, repo, wantName string, page int, preferPrerelease bool
 		return "", "", fmt.Errorf("could not find any release tags for %s/%s on page %d", owner, repo, page)
 	}
 
-	// Extract all tags
-	var allTags []string
+	// The page doesn't say which releases are marked as prereleases, so labels decide
+	var candidates []releaseCandidate
 	for _, match := range tagMatches {
 		if len(match) >= 2 {
-			allTags = append(allTags, match[1])
+			candidates = append(candidates, releaseCandidate{Tag: match[1]})
 		}
 	}
-
-	// If preferPrerelease is false, filter out dev/prerelease versions
-	if !preferPrerelease {
-		var stableTags []string
-		for _, tag := range allTags {
-			if !isPrereleaseTag(tag) {
-				stableTags = append(stableTags, tag)
-			}
-		}
-		if len(stableTags) == 0 {
-			// Return empty results but no error - let the caller decide to continue pagination
-			return "", "", nil
-		}
-		tag = stableTags[0] // Return the first stable tag found
-	} else {
-		// When preferring prerelease, return the first tag found
-		tag = allTags[0]
+	best, ok := bestRelease(candidates, channel)
+	if !ok {
+		// Return empty results but no error - let the caller decide to continue pagination
+		return "", "", nil
 	}
+	tag = best.Tag
 
 	assetURL := fmt.Sprintf("https://github.com/%s/%s/releases/download/%s/%s", owner, repo, tag, wantName)
 
@@ -309,7 +478,7 @@ func fetchFromPage(owner, repo, wantName string, page int, preferPrerelease bool
 	}
 	headReq.Header.Set("User-Agent", getUserAgent("General"))
 
-	headResp, err := http.DefaultClient.Do(headReq)
+	headResp, err := apiClient().Do(headReq)
 	if err != nil {
 		return tag, "", fmt.Errorf("failed to verify asset exists: %w", err)
 	}
@@ -335,7 +504,7 @@ func hasMorePages(owner, repo string, currentPage int) (bool, error) {
 		releasesURL = fmt.Sprintf("https://github.com/%s/%s/releases?page=%d", owner, repo, currentPage)
 	}
 
-	resp, err := http.Get(releasesURL)
+	resp, err := httpGetWithRetry(releasesURL, httpRetryAttempts(), downloadTimeout())
 	if err != nil {
 		return false, err
 	}
@@ -361,18 +530,125 @@ func hasMorePages(owner, repo string, currentPage int) (bool, error) {
 	return len(tagMatches) > 0, nil
 }
 
-// isPrereleaseTag checks if a version tag represents a prerelease/dev version
+// prereleaseIndicators are the identifiers that mark a tag as a prerelease/dev build
+var prereleaseIndicators = []string{"dev", "beta", "rc", "alpha", "pre"}
+
+// isPrereleaseTag checks if a version tag represents a prerelease/dev version.
+// Only the prerelease part (after the first "-", before any "+build") is looked at,
+// and one of its identifiers must start with a known indicator.
 func isPrereleaseTag(tag string) bool {
-	tag = strings.ToLower(tag)
-	prereleaseIndicators := []string{"-dev", "-beta", "-rc", "-alpha", "-pre"}
+	pre := strings.ToLower(parseSemver(normalizeTag(tag)).Prerelease)
+	for _, ident := range strings.FieldsFunc(pre, func(r rune) bool { return r == '.' || r == '-' }) {
+		for _, indicator := range prereleaseIndicators {
+			if strings.HasPrefix(ident, indicator) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// updateChannel is the release line launcher updates come from. A channel also takes
+// releases of any steadier channel, so Beta gets RC and stable releases too.
+type updateChannel string
+
+const (
+	channelStable updateChannel = "stable"
+	channelRC     updateChannel = "rc"
+	channelBeta   updateChannel = "beta"
+	channelDev    updateChannel = "dev"
+)
 
-	for _, indicator := range prereleaseIndicators {
-		if strings.Contains(tag, indicator) {
-			return true
+// updateChannels lists the channels from steadiest to newest
+var updateChannels = []updateChannel{channelStable, channelRC, channelBeta, channelDev}
+
+// rank orders channels from steadiest (0) to newest
+func (c updateChannel) rank() int {
+	for i, ch := range updateChannels {
+		if ch == c {
+			return i
 		}
 	}
+	return 0
+}
 
-	return false
+// parseUpdateChannel reads a channel name as stored in settings, ignoring case
+func parseUpdateChannel(s string) (updateChannel, bool) {
+	for _, ch := range updateChannels {
+		if strings.EqualFold(strings.TrimSpace(s), string(ch)) {
+			return ch, true
+		}
+	}
+	return channelStable, false
+}
+
+// releaseChannel returns the channel a release belongs to from its prerelease label:
+// rc and pre are RC, beta is Beta, and alpha, dev or an unlabelled GitHub prerelease is Dev
+func releaseChannel(tag string, prerelease bool) updateChannel {
+	channel := channelStable
+	pre := strings.ToLower(parseSemver(normalizeTag(tag)).Prerelease)
+	for _, ident := range strings.FieldsFunc(pre, func(r rune) bool { return r == '.' || r == '-' }) {
+		found := channelStable
+		switch {
+		case strings.HasPrefix(ident, "rc"), strings.HasPrefix(ident, "pre"):
+			found = channelRC
+		case strings.HasPrefix(ident, "beta"):
+			found = channelBeta
+		case strings.HasPrefix(ident, "alpha"), strings.HasPrefix(ident, "dev"):
+			found = channelDev
+		}
+		if found.rank() > channel.rank() {
+			channel = found
+		}
+	}
+	if channel == channelStable && prerelease {
+		channel = channelDev
+	}
+	return channel
+}
+
+// releaseCandidate is a published release that carries the wanted asset
+type releaseCandidate struct {
+	Tag        string
+	URL        string
+	Prerelease bool
+}
+
+// bestRelease picks the highest version on channel or a steadier one from releases
+// listed newest first. Of two releases with the same major.minor.patch the steadier
+// wins, so a final release beats its own release candidates; within one channel the
+// newer one wins, since dev builds are labelled with a commit hash that can't be ordered.
+func bestRelease(releases []releaseCandidate, channel updateChannel) (releaseCandidate, bool) {
+	var best releaseCandidate
+	found := false
+	for _, r := range releases {
+		rank := releaseChannel(r.Tag, r.Prerelease).rank()
+		if rank > channel.rank() {
+			continue
+		}
+		if found {
+			cmp := compareBaseVersion(r.Tag, best.Tag)
+			if cmp < 0 || (cmp == 0 && rank >= releaseChannel(best.Tag, best.Prerelease).rank()) {
+				continue
+			}
+		}
+		best, found = r, true
+	}
+	return best, found
+}
+
+// compareBaseVersion compares the major.minor.patch of two tags, ignoring any label
+func compareBaseVersion(a, b string) int {
+	av, bv := parseSemver(normalizeTag(a)), parseSemver(normalizeTag(b))
+	for _, pair := range [][2]int{{av.Major, bv.Major}, {av.Minor, bv.Minor}, {av.Patch, bv.Patch}} {
+		if pair[0] < pair[1] {
+			return -1
+		}
+		if pair[0] > pair[1] {
+			return 1
+		}
+	}
+	return 0
 }
 
 // replaceAndRestart replaces the current executable with the new one and launches it
@@ -429,9 +705,9 @@ func performUpdateCleanup(oldExe, newExe string) {
 }
 
 func fetchLatestAsset(owner, repo, wantName string) (tag, url string, err error) {
-	// Delegate to the prefer-prerelease fetcher so callers automatically respect the
-	// global DevBuildsEnabled setting when present.
-	return FetchLatestAssetPreferPrerelease(owner, repo, wantName, settings.DevBuildsEnabled)
+	// Delegate to the channel fetcher so callers automatically respect the update
+	// channel chosen in Settings.
+	return FetchLatestAssetForChannel(owner, repo, wantName, updateChannelSetting())
 }
 
 func normalizeTag(t string) string {
@@ -443,29 +719,52 @@ func normalizeTag(t string) string {
 	return t
 }
 
-func parseSemverInts(t string) (major, minor, patch int) {
-	// Split version to separate core version from prerelease
-	coreVersion := t
+// semVersion is a version split into its semver parts
+type semVersion struct {
+	Major, Minor, Patch int
+	Prerelease          string
+	Build               string
+	// Valid is false when the core is not one to three numeric parts
+	Valid bool
+}
+
+// parseSemver splits a normalized version like 1.2.3-rc.1+build.5. Build metadata is
+// separated first so a "-" inside it is not taken for a prerelease. Missing core parts
+// are 0 and non-numeric parts parse as 0 with Valid unset.
+func parseSemver(t string) semVersion {
+	var v semVersion
+	if i := strings.Index(t, "+"); i >= 0 {
+		t, v.Build = t[:i], t[i+1:]
+	}
+	core := t
 	if i := strings.Index(t, "-"); i >= 0 {
-		coreVersion = t[:i]
+		core, v.Prerelease = t[:i], t[i+1:]
 	}
 
-	parts := strings.Split(coreVersion, ".")
-	get := func(i int) int {
-		if i >= len(parts) || parts[i] == "" {
-			return 0
+	parts := strings.Split(core, ".")
+	v.Valid = core != "" && len(parts) <= 3
+	nums := make([]int, 3)
+	for i, part := range parts {
+		n, err := strconv.Atoi(part)
+		if err != nil || n < 0 {
+			v.Valid = false
+			n = 0
+		}
+		if i < len(nums) {
+			nums[i] = n
 		}
-		n, _ := strconv.Atoi(parts[i])
-		return n
 	}
-	return get(0), get(1), get(2)
+	v.Major, v.Minor, v.Patch = nums[0], nums[1], nums[2]
+	return v
+}
+
+func parseSemverInts(t string) (major, minor, patch int) {
+	v := parseSemver(t)
+	return v.Major, v.Minor, v.Patch
 }
 
 func getPrerelease(t string) string {
-	if i := strings.Index(t, "-"); i >= 0 {
-		return t[i+1:]
-	}
-	return ""
+	return parseSemver(t).Prerelease
 }
 
 func comparePrerelease(a, b string) int {
@@ -531,12 +830,9 @@ func comparePrerelease(a, b string) int {
 			// a is alphanumeric, b is numeric -> a is higher
 			return 1
 		} else {
-			// Both alphanumeric, compare lexically
-			if aPart < bPart {
-				return -1
-			}
-			if aPart > bPart {
-				return 1
+			// Both alphanumeric, compare lexically ignoring case so -RC.1 and -rc.1 match
+			if c := strings.Compare(strings.ToLower(aPart), strings.ToLower(bPart)); c != 0 {
+				return c
 			}
 		}
 	}
@@ -544,32 +840,28 @@ func comparePrerelease(a, b string) int {
 	return 0 // prereleases are equal
 }
 
+// compareSemver orders two normalized versions by semver precedence: core version,
+// then prerelease (a stable release beats its prereleases). Build metadata is ignored.
 func compareSemver(a, b string) int {
-	// Compare core version (major.minor.patch)
-	amaj, amin, apat := parseSemverInts(a)
-	bmaj, bmin, bpat := parseSemverInts(b)
-
-	if amaj != bmaj {
-		if amaj < bmaj {
-			return -1
-		}
-		return 1
-	}
-	if amin != bmin {
-		if amin < bmin {
-			return -1
-		}
-		return 1
-	}
-	if apat != bpat {
-		if apat < bpat {
-			return -1
+	av, bv := parseSemver(a), parseSemver(b)
+	for _, pair := range [][2]int{{av.Major, bv.Major}, {av.Minor, bv.Minor}, {av.Patch, bv.Patch}} {
+		if pair[0] != pair[1] {
+			if pair[0] < pair[1] {
+				return -1
+			}
+			return 1
 		}
-		return 1
 	}
+	return comparePrerelease(av.Prerelease, bv.Prerelease)
+}
 
-	// Core versions are equal, compare prerelease
-	aPre := getPrerelease(a)
-	bPre := getPrerelease(b)
-	return comparePrerelease(aPre, bPre)
+// sameVersion reports whether two version strings name the same release. Valid
+// semver versions are compared by precedence, so "v1.2.0" and "1.2.0+build.7"
+// match; anything else must be identical.
+func sameVersion(a, b string) bool {
+	a, b = normalizeTag(a), normalizeTag(b)
+	if parseSemver(a).Valid && parseSemver(b).Valid {
+		return compareSemver(a, b) == 0
+	}
+	return a == b
 }
@@ -2,8 +2,13 @@ package main
 
 import (
 	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"runtime"
 	"strings"
+	"sync/atomic"
 	"testing"
+	"time"
 )
 
 func TestFetchLatestAssetPreferPrerelease(t *testing.T) {
@@ -193,7 +198,7 @@ func TestForceUpdate(t *testing.T) {
 
 	root := "/test/root"
 	exePath := "/test/path/TheBoysLauncher"
-	preferDev := true
+	channel := channelDev
 	report := func(msg string) {
 		// Mock report function
 		t.Logf("Report: %s", msg)
@@ -201,7 +206,7 @@ func TestForceUpdate(t *testing.T) {
 
 	// This would normally make HTTP requests and perform updates, but we're just testing the function signature
 	// In a complete test suite, you would mock the HTTP responses and file operations
-	err := forceUpdate(root, exePath, preferDev, report)
+	err := forceUpdate(root, exePath, channel, report)
 
 	// We expect this to fail since we're not mocking the HTTP requests and file operations
 	// The important thing is that the function exists and has the correct signature
@@ -253,11 +258,11 @@ func TestForceUpdateErrorHandling(t *testing.T) {
 	t.Run("EmptyExePath", func(t *testing.T) {
 		root := "/test/root"
 		exePath := ""
-		preferDev := false
+		channel := channelStable
 		report := func(msg string) {}
 
 		// This should fail when trying to download to an empty path
-		err := forceUpdate(root, exePath, preferDev, report)
+		err := forceUpdate(root, exePath, channel, report)
 		if err == nil {
 			t.Error("Expected forceUpdate to fail with empty exePath")
 		}
@@ -267,11 +272,11 @@ func TestForceUpdateErrorHandling(t *testing.T) {
 	t.Run("InvalidRootPath", func(t *testing.T) {
 		root := "/invalid/root/path/that/does/not/exist"
 		exePath := "/test/path/TheBoysLauncher"
-		preferDev := false
+		channel := channelStable
 		report := func(msg string) {}
 
 		// This should fail when trying to operate on invalid paths
-		err := forceUpdate(root, exePath, preferDev, report)
+		err := forceUpdate(root, exePath, channel, report)
 		if err == nil {
 			t.Error("Expected forceUpdate to fail with invalid root path")
 		}
@@ -288,10 +293,10 @@ func TestForceUpdateCallbackHandling(t *testing.T) {
 
 	root := "/test/root"
 	exePath := "/test/path/TheBoysLauncher"
-	preferDev := true
+	channel := channelDev
 
 	// This will fail but should still call the report function
-	_ = forceUpdate(root, exePath, preferDev, report)
+	_ = forceUpdate(root, exePath, channel, report)
 
 	// Check that some messages were reported
 	if len(reportedMessages) == 0 {
@@ -659,3 +664,201 @@ func TestVersionFilteringEdgeCases(t *testing.T) {
 		})
 	}
 }
+
+// TestUpdateFlightSharesInProgressRun tests that overlapping update triggers share one run
+func TestUpdateFlightSharesInProgressRun(t *testing.T) {
+	var flight updateFlight
+	var runs int32
+	started := make(chan struct{})
+	release := make(chan struct{})
+
+	firstDone := make(chan error, 1)
+	go func() {
+		_, err := flight.do(func() error {
+			atomic.AddInt32(&runs, 1)
+			close(started)
+			<-release
+			return fmt.Errorf("first run result")
+		})
+		firstDone <- err
+	}()
+	<-started
+
+	secondDone := make(chan bool, 1)
+	go func() {
+		shared, err := flight.do(func() error {
+			atomic.AddInt32(&runs, 1)
+			return nil
+		})
+		if err == nil || err.Error() != "first run result" {
+			t.Errorf("Expected joined caller to get the first run's result, got %v", err)
+		}
+		secondDone <- shared
+	}()
+
+	// Give the second caller time to join before the first run finishes
+	time.Sleep(50 * time.Millisecond)
+	close(release)
+
+	<-firstDone
+	if shared := <-secondDone; !shared {
+		t.Error("Expected second caller to share the in-progress run")
+	}
+	if n := atomic.LoadInt32(&runs); n != 1 {
+		t.Errorf("Expected exactly one run, got %d", n)
+	}
+
+	// Once finished, the next trigger starts a fresh run
+	if shared, err := flight.do(func() error { return nil }); shared || err != nil {
+		t.Errorf("Expected a new run after the previous one finished, got shared=%t err=%v", shared, err)
+	}
+}
+
+// TestFetchReleaseAssetFromAPIPaginates tests that a stable release buried under prerelease pages is found
+func TestFetchReleaseAssetFromAPIPaginates(t *testing.T) {
+	var server *httptest.Server
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		asset := `"assets": [{"name": "launcher.exe", "browser_download_url": "https://example.com/%s/launcher.exe"}]`
+		switch r.URL.Query().Get("page") {
+		case "":
+			w.Header().Set("Link", fmt.Sprintf(`<%s/repos/o/r/releases?per_page=100&page=2>; rel="next", <%s/repos/o/r/releases?per_page=100&page=3>; rel="last"`, server.URL, server.URL))
+			fmt.Fprintf(w, `[{"tag_name": "v3.3.0-dev.abc", "prerelease": true, %s}, {"tag_name": "v3.2.99", "draft": true, %s}]`,
+				fmt.Sprintf(asset, "v3.3.0-dev.abc"), fmt.Sprintf(asset, "v3.2.99"))
+		case "2":
+			w.Header().Set("Link", fmt.Sprintf(`<%s/repos/o/r/releases?per_page=100&page=3>; rel="next"`, server.URL))
+			fmt.Fprintf(w, `[{"tag_name": "v3.2.30-rc.1", "prerelease": false, %s}, {"tag_name": "v3.2.29", "assets": []}]`, fmt.Sprintf(asset, "v3.2.30-rc.1"))
+		case "3":
+			fmt.Fprintf(w, `[{"tag_name": "v3.2.28", %s}]`, fmt.Sprintf(asset, "v3.2.28"))
+		}
+	}))
+	defer server.Close()
+
+	saved := githubAPIBase
+	githubAPIBase = server.URL
+	defer func() { githubAPIBase = saved }()
+
+	tag, url, err := fetchReleaseAssetFromAPI("o", "r", "launcher.exe", channelStable)
+	if err != nil {
+		t.Fatalf("fetchReleaseAssetFromAPI failed: %v", err)
+	}
+	if tag != "v3.2.28" || url != "https://example.com/v3.2.28/launcher.exe" {
+		t.Errorf("Expected stable v3.2.28 from page 3, got %s (%s)", tag, url)
+	}
+
+	tag, _, err = fetchReleaseAssetFromAPI("o", "r", "launcher.exe", channelDev)
+	if err != nil || tag != "v3.3.0-dev.abc" {
+		t.Errorf("Expected newest prerelease v3.3.0-dev.abc, got %s (%v)", tag, err)
+	}
+
+	if _, _, err := fetchReleaseAssetFromAPI("o", "r", "missing.bin", channelStable); err == nil {
+		t.Error("Expected error when no release has the asset")
+	}
+}
+
+func TestNextPageLink(t *testing.T) {
+	header := `<https://api.github.com/x?page=2>; rel="next", <https://api.github.com/x?page=5>; rel="last"`
+	if got := nextPageLink(header); got != "https://api.github.com/x?page=2" {
+		t.Errorf("nextPageLink = %q", got)
+	}
+	if got := nextPageLink(`<https://api.github.com/x?page=1>; rel="prev"`); got != "" {
+		t.Errorf("Expected no next link on the last page, got %q", got)
+	}
+}
+
+// TestCheckLauncherUpdate tests that an update check only reports the newest release
+// on the chosen channel against the running version
+func TestCheckLauncherUpdate(t *testing.T) {
+	var downloads int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !strings.Contains(r.URL.Path, "/releases") {
+			atomic.AddInt32(&downloads, 1)
+			return
+		}
+		asset := fmt.Sprintf(`"assets": [{"name": %q, "browser_download_url": "https://example.com/launcher"}]`, LauncherAssetName)
+		fmt.Fprintf(w, `[{"tag_name": "v3.3.0-dev.abc", "prerelease": true, %s}, {"tag_name": "v3.2.28", %s}]`, asset, asset)
+	}))
+	defer server.Close()
+
+	savedBase, savedVersion := githubAPIBase, version
+	githubAPIBase = server.URL
+	defer func() { githubAPIBase, version = savedBase, savedVersion }()
+
+	tests := []struct {
+		version   string
+		channel   updateChannel
+		latest    string
+		available bool
+	}{
+		{"v3.2.27", channelStable, "v3.2.28", true},
+		{"v3.2.28", channelStable, "v3.2.28", false},
+		{"v3.2.29", channelStable, "v3.2.28", false},
+		{"v3.2.28", channelDev, "v3.3.0-dev.abc", true},
+	}
+	for _, tt := range tests {
+		version = tt.version
+		available, current, latest, err := checkLauncherUpdate(tt.channel)
+		if err != nil {
+			t.Fatalf("checkLauncherUpdate failed: %v", err)
+		}
+		if available != tt.available || current != tt.version || latest != tt.latest {
+			t.Errorf("%s (%s): expected %s available=%t, got %s available=%t (current %s)",
+				tt.version, tt.channel, tt.latest, tt.available, latest, available, current)
+		}
+	}
+	if downloads != 0 {
+		t.Errorf("Expected nothing to be downloaded, got %d request(s)", downloads)
+	}
+}
+
+// TestBestReleaseForChannel tests that each channel gets the highest release carrying
+// its own label or a steadier one, and that a final release beats its candidates
+func TestBestReleaseForChannel(t *testing.T) {
+	releases := []releaseCandidate{
+		{Tag: "v3.4.0-dev.1a2b3c", Prerelease: true},
+		{Tag: "v3.4.0-nightly.7", Prerelease: true},
+		{Tag: "v3.3.0-beta.2", Prerelease: true},
+		{Tag: "v3.2.30", Prerelease: false},
+		{Tag: "v3.2.30-rc.2", Prerelease: true},
+		{Tag: "v3.2.29"},
+	}
+	tests := map[updateChannel]string{
+		channelStable: "v3.2.30",
+		channelRC:     "v3.2.30",
+		channelBeta:   "v3.3.0-beta.2",
+		channelDev:    "v3.4.0-dev.1a2b3c",
+	}
+	for channel, want := range tests {
+		if got, ok := bestRelease(releases, channel); !ok || got.Tag != want {
+			t.Errorf("%s: expected %s, got %s", channel, want, got.Tag)
+		}
+	}
+
+	// Without its final release, RC gets the candidate and Stable the older release
+	if got, _ := bestRelease(releases[4:], channelRC); got.Tag != "v3.2.30-rc.2" {
+		t.Errorf("Expected v3.2.30-rc.2 on the RC channel, got %s", got.Tag)
+	}
+	if got, _ := bestRelease(releases[4:], channelStable); got.Tag != "v3.2.29" {
+		t.Errorf("Expected v3.2.29 on the stable channel, got %s", got.Tag)
+	}
+	if _, ok := bestRelease(releases[:3], channelStable); ok {
+		t.Error("Expected no stable release among prereleases")
+	}
+
+	for _, tt := range []struct {
+		stored string
+		dev    bool
+		want   updateChannel
+	}{
+		{"", false, channelStable},
+		{"", true, channelDev},
+		{"Beta", false, channelBeta},
+		{"nightly", true, channelDev},
+	} {
+		saved := settings
+		settings.UpdateChannel, settings.DevBuildsEnabled = tt.stored, tt.dev
+		if got := updateChannelSetting(); got != tt.want {
+			t.Errorf("Channel %q with dev builds %t: expected %s, got %s", tt.stored, tt.dev, tt.want, got)
+		}
+		settings = saved
+	}
+}
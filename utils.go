@@ -2,13 +2,18 @@ package main
 
 import (
 	"bufio"
+	"bytes"
+	"encoding/json"
 	"flag"
 	"fmt"
 	"io"
+	"io/fs"
 	"os"
 	"path/filepath"
 	"runtime"
+	"sort"
 	"strings"
+	"sync"
 	"time"
 )
 
@@ -16,19 +21,37 @@ import (
 var (
 	out       io.Writer = os.Stdout
 	activeLog *os.File
+	// quietConsole keeps log lines off stdout (they still reach latest.log) so --json output stays parseable
+	quietConsole bool
+	// jsonLogs writes latest.log and crash.log as JSON lines; the console stays readable
+	jsonLogs bool
+	// jsonLog turns what is written to latest.log into JSON lines when jsonLogs is set
+	jsonLog = &jsonLogWriter{}
+)
+
+// Values of the logFormat setting and the --log-format flag
+const (
+	logFormatPretty = "pretty"
+	logFormatJSON   = "json"
 )
 
 type logTeeWriter struct{}
 
 func (logTeeWriter) Write(p []byte) (int, error) {
 	// Write to console; ignore errors
-	if len(p) > 0 {
+	if len(p) > 0 && !quietConsole {
 		if _, err := os.Stdout.Write(p); err != nil {
 			// fall back to Print in case Write fails
 			fmt.Print(string(p))
 		}
 	}
 
+	if activeLog != nil && len(p) > 0 && jsonLogs {
+		if err := jsonLog.Write(activeLog, p); err != nil {
+			fmt.Printf("Warning: Failed to write to log file: %v\n", err)
+		}
+		return len(p), nil
+	}
 	if activeLog != nil && len(p) > 0 {
 		if _, err := activeLog.Write(p); err != nil {
 			fmt.Printf("Warning: Failed to write to log file: %v\n", err)
@@ -42,10 +65,114 @@ func (logTeeWriter) Write(p []byte) (int, error) {
 	return len(p), nil
 }
 
+// logEntry is one line of latest.log in the JSON format
+type logEntry struct {
+	Timestamp time.Time `json:"timestamp"`
+	Level     string    `json:"level"`
+	Stage     string    `json:"stage,omitempty"`
+	Message   string    `json:"message"`
+}
+
+// jsonLogWriter collects log output into lines and writes each as a logEntry. Output
+// from subprocesses arrives in arbitrary chunks, so an unfinished line is held until
+// the rest of it is written or the log is closed.
+type jsonLogWriter struct {
+	mu      sync.Mutex
+	pending []byte
+	stage   string
+}
+
+// Write encodes every line completed by p to w. Progress that redraws itself with \r
+// counts each redraw as a line.
+func (j *jsonLogWriter) Write(w io.Writer, p []byte) error {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	j.pending = append(j.pending, p...)
+	for {
+		i := bytes.IndexAny(j.pending, "\r\n")
+		if i < 0 {
+			return nil
+		}
+		line := string(j.pending[:i])
+		j.pending = j.pending[i+1:]
+		if err := j.writeLine(w, line); err != nil {
+			return err
+		}
+	}
+}
+
+// Flush writes out a last line that never got its newline
+func (j *jsonLogWriter) Flush(w io.Writer) error {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	line := string(j.pending)
+	j.pending = nil
+	return j.writeLine(w, line)
+}
+
+// SetStage records the install stage the following lines belong to; "" clears it
+func (j *jsonLogWriter) SetStage(stage string) {
+	j.mu.Lock()
+	j.stage = stage
+	j.mu.Unlock()
+}
+
+func (j *jsonLogWriter) writeLine(w io.Writer, line string) error {
+	if strings.TrimSpace(line) == "" {
+		return nil
+	}
+	level, message := logLevelOf(line)
+	data, err := json.Marshal(logEntry{Timestamp: time.Now(), Level: level, Stage: j.stage, Message: message})
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(append(data, '\n'))
+	return err
+}
+
+// logLevelOf works out the level of a log line from the marker stepLine, successLine,
+// warnLine, infoLine or debugf put in front of it, and returns the line without it.
+// Lines without a marker, such as subprocess output, are info.
+func logLevelOf(line string) (level, message string) {
+	trimmed := strings.TrimSpace(line)
+	for _, marker := range []struct{ prefix, level string }{
+		{"DEBUG: ", "debug"},
+		{"● ", "step"},
+		{"✓ ", "success"},
+		{"⚠ ", "warn"},
+		{"ℹ ", "info"},
+	} {
+		if rest, ok := strings.CutPrefix(trimmed, marker.prefix); ok {
+			level, message = marker.level, strings.TrimSpace(rest)
+			break
+		}
+	}
+	if level == "" {
+		level, message = "info", trimmed
+	}
+	if level == "warn" && strings.HasPrefix(message, "Error:") {
+		level = "error"
+	}
+	return level, message
+}
+
 type launcherOptions struct {
 	cleanupAfterUpdate bool
 	cleanupOldExe      string
 	cleanupNewExe      string
+	noUpdate           bool
+	offline            bool
+	doctor             bool
+	previewCatalog     string
+	listModpacks       bool
+	printSettings      bool
+	jsonOutput         bool
+	modpack            string
+	install            bool
+	noGUI              bool
+	logFormat          string
+	checkUpdate        bool
+	rollback           bool
 }
 
 func parseOptions() launcherOptions {
@@ -53,6 +180,19 @@ func parseOptions() launcherOptions {
 	flag.BoolVar(&opts.cleanupAfterUpdate, "cleanup-after-update", false, "internal use only")
 	flag.StringVar(&opts.cleanupOldExe, "cleanup-old-exe", "", "internal use only")
 	flag.StringVar(&opts.cleanupNewExe, "cleanup-new-exe", "", "internal use only")
+	flag.BoolVar(&opts.noUpdate, "no-update", false, "never check for or install launcher updates")
+	flag.BoolVar(&opts.offline, "offline", false, "launch installed modpacks without any network requests")
+	flag.BoolVar(&opts.doctor, "doctor", false, "check the launcher installation and print a health report")
+	flag.BoolVar(&opts.listModpacks, "list-modpacks", false, "print every modpack with its install state and versions")
+	flag.BoolVar(&opts.printSettings, "print-settings", false, "print the current launcher settings")
+	flag.BoolVar(&opts.checkUpdate, "check-update", false, "report whether a launcher update is available without installing it; exits with status 10 if one is")
+	flag.BoolVar(&opts.rollback, "rollback", false, "replace the launcher with the version the last update replaced, then restart it")
+	flag.BoolVar(&opts.jsonOutput, "json", false, "print --list-modpacks, --print-settings, --doctor and --check-update output as JSON")
+	flag.StringVar(&opts.previewCatalog, "preview-catalog", "", "show a modpacks.json file or URL in the GUI without installing or launching anything")
+	flag.StringVar(&opts.modpack, "modpack", "", "modpack ID for --install or --no-gui")
+	flag.BoolVar(&opts.install, "install", false, "install or update --modpack without launching it, then exit")
+	flag.BoolVar(&opts.noGUI, "no-gui", false, "install, update and launch --modpack from the command line, printing progress")
+	flag.StringVar(&opts.logFormat, "log-format", "", "write latest.log as \"pretty\" text (the default) or \"json\" lines; overrides the logFormat setting")
 	flag.Parse()
 	return opts
 }
@@ -98,16 +238,17 @@ func roundToNearestGB(mb int) int {
 	return (mb + 512) / 1024
 }
 
-// getLauncherHome is now implemented in platform-specific files
-// This function is handled by platform_windows.go and platform_darwin.go
+// getLauncherHome is implemented in home.go on top of the platform-specific
+// defaultLauncherHome in platform_windows.go, platform_linux.go and platform_darwin.go
 
 // -------------------- Helpers --------------------
 
-func fail(err error) {
+// fail reports err on stderr and in the log and returns the exit code for it
+func fail(err error) int {
 	msg := fmt.Sprintf("Error: %v", err)
 	fmt.Fprintln(os.Stderr, msg)
 	logf("%s", warnLine(msg))
-	os.Exit(1)
+	return 1
 }
 
 func pause() {
@@ -173,6 +314,9 @@ func setupLogging(root string) func() {
 	// Return cleanup function that flushes and closes
 	return func() {
 		if activeLog != nil {
+			if jsonLogs {
+				_ = jsonLog.Flush(activeLog)
+			}
 			_ = activeLog.Sync()
 			activeLog.Close()
 			activeLog = nil
@@ -181,6 +325,89 @@ func setupLogging(root string) func() {
 	}
 }
 
+// consoleLogFiles lists the log files the console can show: latest.log first, then
+// previous.log, then any other rotated logs newest first
+func consoleLogFiles(logDir string) []string {
+	files := []string{"latest.log"}
+	if exists(filepath.Join(logDir, "previous.log")) {
+		files = append(files, "previous.log")
+	}
+
+	entries, err := os.ReadDir(logDir)
+	if err != nil {
+		return files
+	}
+	type rotated struct {
+		name    string
+		modTime time.Time
+	}
+	var others []rotated
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || name == "latest.log" || name == "previous.log" || !strings.HasSuffix(name, ".log") {
+			continue
+		}
+		info, err := entry.Info()
+		if err != nil {
+			continue
+		}
+		others = append(others, rotated{name, info.ModTime()})
+	}
+	sort.Slice(others, func(i, j int) bool { return others[i].modTime.After(others[j].modTime) })
+	for _, r := range others {
+		files = append(files, r.name)
+	}
+	return files
+}
+
+// -------------------- Launcher Home Checks --------------------
+
+// checkHomeWritable creates and deletes a probe file in root and in the data
+// folders that already exist below it, so a read-only or locked launcher home
+// is reported at startup instead of deep inside an install.
+func checkHomeWritable(root string) error {
+	if err := os.MkdirAll(root, 0755); err != nil {
+		return fmt.Errorf("cannot create %s: %w", root, err)
+	}
+
+	dirs := []string{root}
+	for _, sub := range []string{"logs", "prism", "util", filepath.Join("util", "backups")} {
+		if dir := filepath.Join(root, sub); exists(dir) {
+			dirs = append(dirs, dir)
+		}
+	}
+
+	for _, dir := range dirs {
+		if err := probeWritable(dir); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// probeWritable creates and deletes a temporary file in dir
+func probeWritable(dir string) error {
+	probe, err := os.CreateTemp(dir, ".write-test-*")
+	if err != nil {
+		return fmt.Errorf("cannot write to %s: %w", dir, err)
+	}
+	name := probe.Name()
+	probe.Close()
+	if err := os.Remove(name); err != nil {
+		return fmt.Errorf("cannot delete files in %s: %w", dir, err)
+	}
+	return nil
+}
+
+// isSubPath reports whether path is strictly inside parent
+func isSubPath(parent, path string) bool {
+	rel, err := filepath.Rel(parent, path)
+	if err != nil {
+		return false
+	}
+	return rel != "." && rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator))
+}
+
 // -------------------- Emergency Crash Logging --------------------
 
 func setupEmergencyCrashLogger(root string) {
@@ -205,9 +432,14 @@ func setupEmergencyCrashLogger(root string) {
 
 			// Write to crash file
 			if file, err := os.OpenFile(crashLogPath, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644); err == nil {
-				file.WriteString(crashMsg)
-				file.WriteString(stackTrace)
-				file.WriteString("\n=== END CRASH ===\n")
+				if jsonLogs {
+					data, _ := json.Marshal(logEntry{Timestamp: time.Now(), Level: "panic", Message: fmt.Sprintf("%v\n%s", r, stackTrace)})
+					file.Write(append(data, '\n'))
+				} else {
+					file.WriteString(crashMsg)
+					file.WriteString(stackTrace)
+					file.WriteString("\n=== END CRASH ===\n")
+				}
 				file.Close()
 				fmt.Printf("\nCrash details written to: %s\n", crashLogPath)
 			}
@@ -319,6 +551,21 @@ func copyDir(src, dst string) error {
 	return nil
 }
 
+// dirSize returns the total size of the files under path, skipping any it can't read
+func dirSize(path string) int64 {
+	var size int64
+	filepath.WalkDir(path, func(_ string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return nil
+		}
+		if info, err := d.Info(); err == nil {
+			size += info.Size()
+		}
+		return nil
+	})
+	return size
+}
+
 func flattenOneLevel(path string) error {
 	entries, err := os.ReadDir(path)
 	if err != nil {
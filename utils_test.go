@@ -0,0 +1,56 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+// TestJSONLogWriter tests that log output written in chunks becomes one JSON entry per
+// line with the level taken from the line's marker
+func TestJSONLogWriter(t *testing.T) {
+	var buf bytes.Buffer
+	j := &jsonLogWriter{}
+
+	j.SetStage("Ensuring Java runtime")
+	for _, chunk := range []string{
+		stepLine("Installing Temurin") + " JRE 21",
+		"\n" + successLine("Java installed") + "\n\n",
+		"packwiz output\n" + warnLine("Error: boom") + "\r\n",
+	} {
+		if err := j.Write(&buf, []byte(chunk)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	j.SetStage("")
+	j.Write(&buf, []byte("DEBUG: no newline"))
+	if err := j.Flush(&buf); err != nil {
+		t.Fatal(err)
+	}
+
+	want := []logEntry{
+		{Level: "step", Stage: "Ensuring Java runtime", Message: "Installing Temurin JRE 21"},
+		{Level: "success", Stage: "Ensuring Java runtime", Message: "Java installed"},
+		{Level: "info", Stage: "Ensuring Java runtime", Message: "packwiz output"},
+		{Level: "error", Stage: "Ensuring Java runtime", Message: "Error: boom"},
+		{Level: "debug", Message: "no newline"},
+	}
+	lines := strings.Split(strings.TrimSuffix(buf.String(), "\n"), "\n")
+	if len(lines) != len(want) {
+		t.Fatalf("Expected %d JSON lines, got %d:\n%s", len(want), len(lines), buf.String())
+	}
+	for i, line := range lines {
+		var got logEntry
+		if err := json.Unmarshal([]byte(line), &got); err != nil {
+			t.Fatalf("Line %d is not JSON: %v (%s)", i+1, err, line)
+		}
+		if got.Timestamp.IsZero() {
+			t.Errorf("Line %d has no timestamp", i+1)
+		}
+		got.Timestamp = want[i].Timestamp
+		if got != want[i] {
+			t.Errorf("Line %d: expected %+v, got %+v", i+1, want[i], got)
+		}
+	}
+}
@@ -336,3 +336,63 @@ func TestIsPrereleaseTagPosition(t *testing.T) {
 		})
 	}
 }
+
+func TestCompareSemverBuildMetadataAndPrereleaseTypes(t *testing.T) {
+	tests := []struct {
+		a        string
+		b        string
+		expected int
+	}{
+		// Build metadata is ignored for precedence
+		{"3.2.25+build.456", "3.2.25", 0},
+		{"3.2.25+build.456", "3.2.26", -1},
+		{"3.2.25+build-1", "3.2.25", 0}, // dash inside build metadata is not a prerelease
+		{"3.2.25-rc.2+build.9", "3.2.25-rc.2", 0},
+
+		// Mixed prerelease types order lexically: alpha < beta < dev < pre < rc
+		{"3.2.25-dev.5c0625a", "3.2.25-rc.2", -1},
+		{"3.2.25-rc.2", "3.2.25-beta.7", 1},
+		{"3.2.25-rc.2", "3.2.25", -1},
+		{"3.2.25-RC.1", "3.2.25-rc.2", -1},
+		{"3.2.25-rc.10", "3.2.25-rc.2", 1},
+	}
+
+	for _, test := range tests {
+		result := compareSemver(test.a, test.b)
+		if result != test.expected {
+			t.Errorf("compareSemver(%q, %q) = %d, want %d", test.a, test.b, result, test.expected)
+		}
+	}
+}
+
+func TestParseSemver(t *testing.T) {
+	v := parseSemver("3.2.25-rc.2+build.456")
+	if !v.Valid || v.Major != 3 || v.Minor != 2 || v.Patch != 25 || v.Prerelease != "rc.2" || v.Build != "build.456" {
+		t.Errorf("parseSemver returned %+v", v)
+	}
+	for _, invalid := range []string{"", "invalid", "1.2.x", "1.2.3.4"} {
+		if parseSemver(invalid).Valid {
+			t.Errorf("Expected %q to be invalid", invalid)
+		}
+	}
+}
+
+func TestSameVersion(t *testing.T) {
+	tests := []struct {
+		a, b     string
+		expected bool
+	}{
+		{"v1.2.0", "1.2.0", true},
+		{"1.2.0+build.7", "1.2.0", true},
+		{"1.2", "1.2.0", true},
+		{"1.2.0", "1.2.1", false},
+		{"1.2.0-rc.1", "1.2.0", false},
+		{"Season 3", "Season 3", true},
+		{"Season 3", "Season 4", false},
+	}
+	for _, test := range tests {
+		if got := sameVersion(test.a, test.b); got != test.expected {
+			t.Errorf("sameVersion(%q, %q) = %v, want %v", test.a, test.b, got, test.expected)
+		}
+	}
+}
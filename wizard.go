@@ -0,0 +1,258 @@
+package main
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/layout"
+	"fyne.io/fyne/v2/theme"
+	"fyne.io/fyne/v2/widget"
+)
+
+const (
+	wizardRAMAuto       = "Auto RAM (recommended)"
+	wizardRAMManual     = "Manual"
+	wizardChannelStable = "Stable"
+	wizardChannelRC     = "Release candidates"
+	wizardChannelBeta   = "Beta"
+	wizardChannelDev    = "Dev (pre-release)"
+	wizardNoModpack     = "Skip for now"
+)
+
+// showSetupWizard walks new users through the main launcher choices once.
+// Choices are only applied on Finish; "Use defaults" keeps the current settings.
+func (g *GUI) showSetupWizard() {
+	totalMB := totalRAMMB()
+	autoMB := clampMemoryMB(DefaultAutoMemoryMB())
+
+	// Memory step
+	manualSlider := widget.NewSlider(2, 16)
+	manualSlider.Step = 1
+	manualSlider.SetValue(float64(clampMemoryMB(settings.MemoryMB) / 1024))
+	manualLabel := widget.NewLabel("")
+	manualSlider.OnChanged = func(v float64) {
+		manualLabel.SetText(fmt.Sprintf("Manual RAM: %.0f GB", v))
+	}
+	manualSlider.OnChanged(manualSlider.Value)
+
+	ramRadio := widget.NewRadioGroup([]string{wizardRAMAuto, wizardRAMManual}, func(choice string) {
+		if choice == wizardRAMManual {
+			manualSlider.Show()
+			manualLabel.Show()
+		} else {
+			manualSlider.Hide()
+			manualLabel.Hide()
+		}
+	})
+	ramRadio.Required = true
+	if settings.AutoRAM {
+		ramRadio.SetSelected(wizardRAMAuto)
+	} else {
+		ramRadio.SetSelected(wizardRAMManual)
+	}
+
+	detected := "Detected system RAM: unknown"
+	if totalMB > 0 {
+		detected = fmt.Sprintf("Detected system RAM: %d GB", roundToNearestGB(totalMB))
+	}
+	memoryStep := container.NewVBox(
+		widget.NewLabel("How much memory should Minecraft use?"),
+		widget.NewLabel(detected),
+		widget.NewLabel(fmt.Sprintf("Auto RAM will use about %d GB and adjusts per modpack.", autoMB/1024)),
+		ramRadio,
+		manualLabel,
+		manualSlider,
+	)
+
+	// Channel step
+	// wizardChannels matches the radio choices to updateChannels
+	wizardChannels := []string{wizardChannelStable, wizardChannelRC, wizardChannelBeta, wizardChannelDev}
+	channelRadio := widget.NewRadioGroup(wizardChannels, nil)
+	channelRadio.Required = true
+	channelRadio.SetSelected(wizardChannels[updateChannelSetting().rank()])
+	channelNote := widget.NewLabel("Stable builds are tested releases. Release candidates and betas preview the next release, and dev builds get new features first but may contain bugs.")
+	channelNote.Wrapping = fyne.TextWrapWord
+	if selfUpdateDisabled() {
+		channelRadio.Disable()
+		channelNote.SetText("Launcher updates are disabled on this computer, so the update channel can't be changed.")
+	}
+	channelStep := container.NewVBox(
+		widget.NewLabel("Which launcher updates would you like to receive?"),
+		channelRadio,
+		channelNote,
+	)
+
+	// Data directory step; a new folder is handed to changeLauncherHome on Finish
+	homeChoice := ""
+	dataPath := widget.NewLabel(g.root)
+	dataPath.Wrapping = fyne.TextWrapBreak
+	dataNote := widget.NewLabel("Prism Launcher, Java runtimes, modpack instances, backups and logs are stored here.")
+	dataNote.Wrapping = fyne.TextWrapWord
+	dataChangeBtn := widget.NewButtonWithIcon("Choose folder", theme.FolderOpenIcon(), func() {
+		dialog.ShowFolderOpen(func(uri fyne.ListableURI, err error) {
+			if err != nil {
+				dialog.ShowError(err, g.window)
+				return
+			}
+			if uri == nil {
+				return
+			}
+			homeChoice = filepath.Clean(uri.Path())
+			if homeChoice == filepath.Clean(g.root) {
+				homeChoice = ""
+			}
+			if homeChoice == "" {
+				dataPath.SetText(g.root)
+			} else {
+				dataPath.SetText(homeChoice)
+			}
+		}, g.window)
+	})
+	if launcherHomeFromEnv() != "" {
+		dataChangeBtn.Disable()
+		dataNote.SetText(dataNote.Text + " The THEBOYS_HOME environment variable picks this folder.")
+	}
+	dataStep := container.NewVBox(
+		widget.NewLabel("Launcher data directory"),
+		widget.NewCard("", "", dataPath),
+		container.NewHBox(dataChangeBtn),
+		dataNote,
+	)
+
+	// First modpack step
+	modpackNames := []string{wizardNoModpack}
+	for _, mod := range g.modpacks {
+		modpackNames = append(modpackNames, mod.DisplayName)
+	}
+	modpackSelect := widget.NewSelect(modpackNames, nil)
+	modpackSelect.SetSelected(wizardNoModpack)
+	modpackStep := container.NewVBox(
+		widget.NewLabel("Install a modpack now? You can always install more from the Browse tab."),
+		modpackSelect,
+	)
+
+	steps := []struct {
+		title   string
+		content fyne.CanvasObject
+	}{
+		{"Memory", memoryStep},
+		{"Updates", channelStep},
+		{"Data Directory", dataStep},
+		{"First Modpack", modpackStep},
+	}
+
+	titleLabel := widget.NewLabelWithStyle("", fyne.TextAlignCenter, fyne.TextStyle{Bold: true})
+	stepLabel := widget.NewLabel("")
+	body := container.NewStack()
+
+	backBtn := widget.NewButtonWithIcon("Back", theme.NavigateBackIcon(), nil)
+	nextBtn := widget.NewButtonWithIcon("Next", theme.NavigateNextIcon(), nil)
+	nextBtn.Importance = widget.HighImportance
+	skipBtn := widget.NewButton("Use defaults", nil)
+
+	current := 0
+	showStep := func(i int) {
+		current = i
+		titleLabel.SetText(fmt.Sprintf("Welcome to %s", launcherName))
+		stepLabel.SetText(fmt.Sprintf("Step %d of %d: %s", i+1, len(steps), steps[i].title))
+		body.Objects = []fyne.CanvasObject{steps[i].content}
+		body.Refresh()
+		if i == 0 {
+			backBtn.Disable()
+		} else {
+			backBtn.Enable()
+		}
+		if i == len(steps)-1 {
+			nextBtn.SetText("Finish")
+			nextBtn.SetIcon(theme.ConfirmIcon())
+		} else {
+			nextBtn.SetText("Next")
+			nextBtn.SetIcon(theme.NavigateNextIcon())
+		}
+	}
+
+	content := container.NewVBox(
+		container.NewPadded(titleLabel),
+		stepLabel,
+		widget.NewSeparator(),
+		container.NewPadded(body),
+		widget.NewSeparator(),
+		container.NewPadded(container.NewHBox(skipBtn, layout.NewSpacer(), backBtn, nextBtn)),
+	)
+
+	pop := widget.NewModalPopUp(container.NewPadded(content), g.window.Canvas())
+
+	finish := func(apply bool) {
+		pop.Hide()
+
+		channelChanged := false
+		var firstModpack *Modpack
+		if apply {
+			settings.AutoRAM = ramRadio.Selected != wizardRAMManual
+			if settings.AutoRAM {
+				settings.MemoryMB = autoMB
+			} else {
+				settings.MemoryMB = clampMemoryMB(int(manualSlider.Value) * 1024)
+			}
+
+			for i, name := range wizardChannels {
+				if name == channelRadio.Selected && updateChannels[i] != updateChannelSetting() {
+					setUpdateChannel(updateChannels[i])
+					channelChanged = true
+				}
+			}
+
+			for i := range g.modpacks {
+				if g.modpacks[i].DisplayName == modpackSelect.Selected {
+					firstModpack = &g.modpacks[i]
+					break
+				}
+			}
+		}
+
+		settings.SetupComplete = true
+		if err := saveSettings(g.root); err != nil {
+			logf("%s", warnLine(fmt.Sprintf("Failed to save setup choices: %v", err)))
+		}
+		logf("%s", successLine("First-run setup complete"))
+		g.updateMemorySummaryLabel()
+
+		if channelChanged {
+			g.startUpdateCheck()
+		}
+		if apply && homeChoice != "" {
+			// The launcher restarts in the new folder, which is where the modpack belongs
+			if firstModpack != nil {
+				logf("%s", infoLine(fmt.Sprintf("Install %s once the launcher has started in %s", firstModpack.DisplayName, homeChoice)))
+				firstModpack = nil
+			}
+			g.changeLauncherHome(homeChoice)
+		}
+		if firstModpack != nil && !g.isModpackInstalled(*firstModpack) {
+			g.runModpackOperation(*firstModpack, ActionInstall)
+		}
+	}
+
+	backBtn.OnTapped = func() {
+		if current > 0 {
+			showStep(current - 1)
+		}
+	}
+	nextBtn.OnTapped = func() {
+		if current < len(steps)-1 {
+			showStep(current + 1)
+			return
+		}
+		finish(true)
+	}
+	skipBtn.OnTapped = func() {
+		finish(false)
+	}
+
+	showStep(0)
+	pop.Resize(fyne.NewSize(560, 420))
+	pop.Show()
+}